@@ -58,6 +58,14 @@ func TestAdminEndpointsAuthAndBasicBehavior(t *testing.T) {
 		t.Fatalf("ledger expected 200, got %d", w.Code)
 	}
 
+	req = httptest.NewRequest(http.MethodGet, "/api/rake", nil)
+	req.Header = adminHeader.Clone()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("rake expected 200, got %d", w.Code)
+	}
+
 	agentID, _, _ := createTestAgent(t, st, "AdminTopupAgent")
 	topupBody := map[string]any{"agent_id": agentID, "amount_cc": 100}
 	topupBytes, _ := json.Marshal(topupBody)
@@ -40,6 +40,23 @@ func main() {
 		log.Fatal().Err(err).Msg("db ping failed")
 	}
 
+	report, err := st.RecoverInterruptedTables(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("recover interrupted tables failed")
+	}
+	if report.Tables > 0 || report.AbortedMatches > 0 || report.AbortedTournaments > 0 || report.RequeuedLeagueMatches > 0 || report.ClosedInvites > 0 {
+		log.Warn().
+			Int("tables", report.Tables).
+			Int("voided_hands", report.VoidedHands).
+			Int64("refunded_cc", report.RefundedCC).
+			Int64("cashed_out_cc", report.CashedOutCC).
+			Int("aborted_matches", report.AbortedMatches).
+			Int("aborted_tournaments", report.AbortedTournaments).
+			Int("requeued_league_matches", report.RequeuedLeagueMatches).
+			Int("closed_invites", report.ClosedInvites).
+			Msg("recovered interrupted tables")
+	}
+
 	led := ledger.New(st)
 	if err := st.EnsureDefaultRooms(context.Background()); err != nil {
 		log.Fatal().Err(err).Msg("ensure default rooms failed")
@@ -48,6 +65,7 @@ func main() {
 		log.Fatal().Err(err).Msg("ensure provider rates failed")
 	}
 	agentCoord := agentgateway.NewCoordinator(st, led)
+	agentCoord.SetEquityIterations(cfg.EquityIterations)
 	pushCfg, err := spectatorpush.ConfigFromServer(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("load spectator push config failed")
@@ -84,8 +84,8 @@ func TestPublicEndpoints(t *testing.T) {
 	req = httptest.NewRequest(http.MethodGet, "/api/public/leaderboard?room_id=bad", nil)
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-	if w.Code != http.StatusBadRequest {
-		t.Fatalf("leaderboard invalid room expected 400, got %d", w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("leaderboard unknown room expected 404, got %d", w.Code)
 	}
 
 	req = httptest.NewRequest(http.MethodGet, "/api/public/leaderboard?sort=bad", nil)
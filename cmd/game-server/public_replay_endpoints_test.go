@@ -25,7 +25,7 @@ func TestPublicReplayEndpoints(t *testing.T) {
 		t.Fatalf("create table: %v", err)
 	}
 	agentID, _, _ := createTestAgent(t, st, "ReplayBot")
-	handID, err := st.CreateHand(t.Context(), tableID)
+	handID, err := st.CreateHand(t.Context(), tableID, 100)
 	if err != nil {
 		t.Fatalf("create hand: %v", err)
 	}
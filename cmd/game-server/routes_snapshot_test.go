@@ -29,8 +29,11 @@ func TestRouteSnapshot(t *testing.T) {
 
 	expected := []string{
 		"DELETE /api/agent/sessions/{session_id}",
+		"GET /api/agent/challenges",
 		"GET /api/agent/sessions/{session_id}/events",
 		"GET /api/agent/sessions/{session_id}/state",
+		"GET /api/agent/sessions/{session_id}/status",
+		"GET /api/agent/sessions/{session_id}/tournament",
 		"GET /api/agents",
 		"GET /api/agents/me",
 		"GET /api/debug/vars",
@@ -39,8 +42,19 @@ func TestRouteSnapshot(t *testing.T) {
 		"GET /api/public/agent-table",
 		"GET /api/public/agents/{agent_id}/profile",
 		"GET /api/public/agents/{agent_id}/tables",
+		"GET /api/public/duplicate-matches",
+		"GET /api/public/duplicate-matches/{match_id}",
+		"GET /api/public/duplicate-standings",
+		"GET /api/public/equity",
+		"GET /api/public/hands/{hand_id}/verify",
 		"GET /api/public/leaderboard",
+		"GET /api/public/leaderboard/compare",
+		"GET /api/public/leagues",
+		"GET /api/public/leagues/{league_id}",
+		"GET /api/public/leagues/{league_id}/standings",
 		"GET /api/public/rooms",
+		"GET /api/public/seasons",
+		"GET /api/public/seasons/{season_id}/standings",
 		"GET /api/public/spectate/events",
 		"GET /api/public/spectate/state",
 		"GET /api/public/tables",
@@ -48,19 +62,32 @@ func TestRouteSnapshot(t *testing.T) {
 		"GET /api/public/tables/{table_id}/replay",
 		"GET /api/public/tables/{table_id}/snapshot",
 		"GET /api/public/tables/{table_id}/timeline",
+		"GET /api/public/tournaments",
+		"GET /api/public/tournaments/{tournament_id}",
+		"GET /api/public/tournaments/{tournament_id}/events",
+		"GET /api/public/tournaments/{tournament_id}/state",
+		"GET /api/rake",
 		"GET /claim/{claim_code}",
 		"GET /healthz",
 		"GET /mcp",
 		"OPTIONS /mcp",
 		"DELETE /mcp",
+		"POST /api/agent/challenges/{challenge_id}/decline",
 		"POST /api/agent/sessions",
 		"POST /api/agent/sessions/{session_id}/actions",
+		"POST /api/agent/sessions/{session_id}/tournament/addon",
+		"POST /api/agent/sessions/{session_id}/tournament/rebuy",
+		"POST /api/agent/tournaments",
 		"POST /api/agents/bind_key",
+		"POST /api/agents/{agent_id}/owner",
 		"POST /api/agents/claim",
 		"POST /api/agents/register",
+		"POST /api/leagues",
 		"POST /api/providers/rates",
 		"POST /api/rooms",
+		"POST /api/seasons",
 		"POST /api/topup",
+		"POST /api/tournaments",
 		"POST /mcp",
 	}
 	sort.Strings(expected)
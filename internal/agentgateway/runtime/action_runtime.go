@@ -2,10 +2,15 @@ package runtime
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"strings"
+	"time"
 
 	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -44,6 +49,14 @@ func (c *Coordinator) SubmitAction(ctx context.Context, sessionID string, req Ac
 	rt := sess.runtime
 	c.mu.Unlock()
 
+	// Runs after rt.mu is released: closing the table takes c.mu.
+	closeAfter := false
+	defer func() {
+		c.releaseDeparted(ctx, rt)
+		if closeAfter {
+			c.finishTable(ctx, rt)
+		}
+	}()
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
 	if rt.status == tableStatusClosing {
@@ -88,6 +101,9 @@ func (c *Coordinator) SubmitAction(ctx context.Context, sessionID string, req Ac
 	if req.Amount != nil {
 		action.Amount = *req.Amount
 	}
+	// The street and whether the action raised the bet feed the HUD stats
+	// read back from the replay stream.
+	street, betBefore := rt.engine.State.Street, rt.engine.State.CurrentBet
 	done, applyErr := rt.engine.ApplyAction(ctx, action)
 	if applyErr != nil {
 		reason := mapApplyError(applyErr)
@@ -112,6 +128,8 @@ func (c *Coordinator) SubmitAction(ctx context.Context, sessionID string, req Ac
 		"seat_id":     actor,
 		"action":      req.Action,
 		"amount_cc":   req.Amount,
+		"street":      string(street),
+		"raise":       rt.engine.State.CurrentBet > betBefore,
 		"thought_log": req.ThoughtLog,
 	})
 	if req.ThoughtLog != "" {
@@ -121,43 +139,12 @@ func (c *Coordinator) SubmitAction(ctx context.Context, sessionID string, req Ac
 			"thought_log": req.ThoughtLog,
 		})
 	}
-	prevStreet := rt.engine.State.Street
 	if done {
-		if handDone, winner := rt.handleRoundEnd(ctx); handDone {
-			pot := rt.engine.State.Pot
-			_ = c.store.EndHandWithSummary(ctx, rt.engine.State.HandID, winner, &pot, string(rt.engine.State.Street))
-			c.appendReplayEvent(ctx, rt, "showdown", "", map[string]any{
-				"hand_id":  rt.engine.State.HandID,
-				"showdown": buildShowdownPayload(rt),
-			})
-			c.appendReplayEvent(ctx, rt, "hand_settled", winner, map[string]any{
-				"hand_id": rt.engine.State.HandID,
-				"winner":  winner,
-				"pot_cc":  pot,
-				"street":  string(rt.engine.State.Street),
-			})
-			if rt.status == tableStatusActive {
-				if err := rt.startNextHand(ctx); err == nil {
-					rt.turnID = nextTurnID()
-					rt.handSeq = 0
-					c.appendReplayEvent(ctx, rt, "hand_started", "", map[string]any{
-						"hand_id": rt.engine.State.HandID,
-						"street":  string(rt.engine.State.Street),
-					})
-					c.appendReplayEvent(ctx, rt, "state_snapshot", "", c.buildReplayState(rt))
-				}
-			}
-		} else if prevStreet != rt.engine.State.Street {
-			rt.turnID = nextTurnID()
-			c.appendReplayEvent(ctx, rt, "street_advanced", "", map[string]any{
-				"hand_id": rt.engine.State.HandID,
-				"street":  string(rt.engine.State.Street),
-			})
-		}
+		closeAfter = c.advanceHandLocked(ctx, rt)
 	} else {
 		rt.turnID = nextTurnID()
 	}
-	c.appendReplayEvent(ctx, rt, "state_snapshot", "", c.buildReplayState(rt))
+	c.appendReplayState(ctx, rt)
 	res := ActionResponse{Accepted: true, RequestID: req.RequestID}
 	_, err = c.saveActionResult(ctx, sessionID, req, res)
 	if err != nil {
@@ -194,29 +181,201 @@ func mapApplyError(err error) string {
 	}
 }
 
-func (rt *tableRuntime) handleRoundEnd(ctx context.Context) (bool, string) {
+// advanceHandLocked moves the table on after a betting round is over: it
+// deals the next street or settles the hand and starts the next one. It
+// reports true when the next hand cannot be dealt because fewer than two
+// seated players can cover the big blind, or because a duplicate leg has
+// played all its hands; the caller must then finish the table once rt.mu is
+// released. On a tournament table the players the hand busted are
+// eliminated and, in a multi-table tournament, the director rebalances the
+// tables. Caller must hold rt.mu.
+func (c *Coordinator) advanceHandLocked(ctx context.Context, rt *tableRuntime) bool {
+	prevStreet := rt.engine.State.Street
+	if !rt.handleRoundEnd() {
+		if prevStreet != rt.engine.State.Street {
+			rt.turnID = nextTurnID()
+			c.appendReplayEvent(ctx, rt, "street_advanced", "", map[string]any{
+				"hand_id": rt.engine.State.HandID,
+				"street":  string(rt.engine.State.Street),
+			})
+		}
+		return false
+	}
+	_, settleErr := c.settleHandLocked(ctx, rt, func(winner string) []replayEvent {
+		return []replayEvent{
+			{eventType: "showdown", payload: map[string]any{
+				"hand_id":  rt.engine.State.HandID,
+				"showdown": buildShowdownPayload(rt),
+			}},
+			{eventType: "hand_settled", actorAgentID: winner, payload: handSettledPayload(rt, winner)},
+		}
+	})
+	if rt.tournament != nil && settleErr == nil {
+		c.eliminateBustedLocked(ctx, rt)
+	}
+	if rt.director != nil && settleErr == nil {
+		c.directHandEndLocked(ctx, rt)
+	}
+	if rt.status != tableStatusActive {
+		return false
+	}
+	if rt.legOver() {
+		rt.status = tableStatusClosing
+		rt.closeReason = closeReasonLegComplete
+		rt.turnDeadline = time.Time{}
+		rt.turnSeat = -1
+		return true
+	}
+	if rt.leagueMatchOver() {
+		rt.status = tableStatusClosing
+		rt.closeReason = closeReasonLeagueMatchComplete
+		rt.turnDeadline = time.Time{}
+		rt.turnSeat = -1
+		return true
+	}
+	levelChanged := rt.advanceBlindLevel(time.Now())
+	if err := rt.startNextHand(ctx); err != nil {
+		if errors.Is(err, game.ErrNotEnoughPlayers) {
+			rt.status = tableStatusClosing
+			rt.closeReason = closeReasonNotEnoughPlayers
+			rt.turnDeadline = time.Time{}
+			rt.turnSeat = -1
+			return true
+		}
+		return false
+	}
+	rt.turnID = nextTurnID()
+	rt.handSeq = 0
+	c.appendReplayEvent(ctx, rt, "hand_started", "", handStartedPayload(rt))
+	if levelChanged {
+		c.emitBlindLevelChanged(ctx, rt)
+	}
+	c.appendReplayState(ctx, rt)
+	// A tournament blind can leave nobody with a decision to make: run the
+	// board out and deal the next hand.
+	if (rt.tournament != nil || rt.director != nil) && rt.needsNoActionLocked() {
+		return c.advanceHandLocked(ctx, rt)
+	}
+	return false
+}
+
+// settleHandLocked pays out the current hand. The replay events returned by
+// describe are written in the same transaction as the pot credits and the
+// hand summary, so a hand is either fully settled and replayable or not
+// settled at all. When the settlement cannot be committed the hand is voided
+// and every contribution goes back to the stacks it came from. Caller must
+// hold rt.mu.
+func (c *Coordinator) settleHandLocked(ctx context.Context, rt *tableRuntime, describe func(winner string) []replayEvent) (string, error) {
+	staged := 0
+	winner, err := rt.engine.SettleWith(ctx, func(winner string) []store.TableReplayEvent {
+		events := describe(winner)
+		out := make([]store.TableReplayEvent, 0, len(events))
+		for _, ev := range events {
+			if rec, ok := stageReplayEvent(rt, len(out), ev); ok {
+				out = append(out, rec)
+			}
+		}
+		staged = len(out)
+		return out
+	})
+	if err != nil {
+		log.Error().Err(err).Str("table_id", rt.id).Str("hand_id", rt.engine.State.HandID).Msg("settle hand failed")
+		c.voidHandLocked(ctx, rt, err)
+		return "", err
+	}
+	c.commitReplayEvents(ctx, rt, staged)
+	return winner, nil
+}
+
+// voidHandLocked refunds a hand whose settlement failed. Stacks are reloaded
+// from the store when the next hand is dealt. Caller must hold rt.mu.
+func (c *Coordinator) voidHandLocked(ctx context.Context, rt *tableRuntime, cause error) {
+	handID := rt.engine.State.HandID
+	refunded, err := c.store.VoidHand(ctx, rt.id, handID)
+	if err != nil {
+		log.Error().Err(err).Str("table_id", rt.id).Str("hand_id", handID).Msg("void hand failed")
+		return
+	}
+	payload := map[string]any{
+		"hand_id":     handID,
+		"refunded_cc": refunded,
+		"reason":      cause.Error(),
+	}
+	if rt.match == nil {
+		payload["seed"] = hex.EncodeToString(rt.engine.State.DeckSeed)
+	}
+	c.appendReplayEvent(ctx, rt, "hand_voided", "", payload)
+}
+
+// handStartedPayload commits to the deck: seed_hash is published before any
+// card is dealt and the seed itself only once the hand is over. Duplicate
+// legs replay the same decks, so their seeds are withheld until the match
+// has ended and its seed schedule is published.
+func handStartedPayload(rt *tableRuntime) map[string]any {
+	st := rt.engine.State
+	seedSource := "random"
+	if rt.engine.DeckSeedSchedule != "" {
+		seedSource = "schedule"
+	}
+	payload := map[string]any{
+		"hand_id":     st.HandID,
+		"hand_number": st.HandNumber,
+		"street":      string(st.Street),
+		"seed_hash":   game.SeedHash(st.DeckSeed),
+		"seed_source": seedSource,
+		"deal_order":  st.DealOrder,
+		"variant":     st.GameVariant().Name(),
+		"ante_cc":     st.Ante,
+	}
+	if rt.match != nil {
+		payload["match_id"] = rt.match.matchID
+		payload["leg"] = rt.match.leg
+	}
+	if id := rt.tournamentID(); id != "" {
+		payload["tournament_id"] = id
+	}
+	return payload
+}
+
+func handSettledPayload(rt *tableRuntime, winner string) map[string]any {
+	st := rt.engine.State
+	payload := map[string]any{
+		"hand_id":     st.HandID,
+		"winner":      winner,
+		"pot_cc":      st.Pot,
+		"street":      string(st.Street),
+		"pots":        st.PotResults,
+		"rake_cc":     st.RakeCC,
+		"board":       game.CardStrings(st.Community),
+		"hand_number": st.HandNumber,
+	}
+	if rt.match == nil {
+		payload["seed"] = hex.EncodeToString(st.DeckSeed)
+	}
+	return payload
+}
+
+// handleRoundEnd reports whether the hand is over and ready to settle;
+// otherwise it deals the next street.
+func (rt *tableRuntime) handleRoundEnd() bool {
 	st := rt.engine.State
-	if st.Players[0].Folded || st.Players[1].Folded {
-		winner, _ := rt.engine.Settle(ctx)
-		return true, winner
+	if st.ActivePlayers() <= 1 {
+		return true
 	}
-	if st.Players[0].AllIn || st.Players[1].AllIn {
+	if st.ActionablePlayers() <= 1 {
 		rt.engine.FastForwardToShowdown()
-		winner, _ := rt.engine.Settle(ctx)
-		return true, winner
+		return true
 	}
 	if st.Street == game.StreetRiver {
-		winner, _ := rt.engine.Settle(ctx)
-		return true, winner
+		return true
 	}
 	rt.engine.NextStreet()
-	return false, ""
+	return false
 }
 
+// startNextHand deals the next hand at the current blind level.
 func (rt *tableRuntime) startNextHand(ctx context.Context) error {
-	players := [2]*game.Player{
-		{ID: rt.players[0].agent.ID, Name: rt.players[0].agent.Name, Seat: 0},
-		{ID: rt.players[1].agent.ID, Name: rt.players[1].agent.Name, Seat: 1},
-	}
-	return rt.engine.StartHand(ctx, players[0], players[1], rt.room.SmallBlindCC, rt.room.BigBlindCC)
+	level := rt.currentBlindLevel()
+	rt.engine.State.Ante = level.Ante
+	return rt.engine.StartHand(ctx, rt.enginePlayers(), level.SmallBlind, level.BigBlind)
 }
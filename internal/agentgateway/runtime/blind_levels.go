@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+)
+
+// roomBlindSchedule is the room's blind schedule: its own blinds and ante as
+// the first level, followed by the stored levels.
+func roomBlindSchedule(room *store.Room, levels []store.BlindLevel) game.BlindSchedule {
+	out := game.BlindSchedule{
+		Levels: []game.BlindLevel{{SmallBlind: room.SmallBlindCC, BigBlind: room.BigBlindCC, Ante: room.AnteCC}},
+		Hands:  room.BlindLevelHands,
+		Every:  time.Duration(room.BlindLevelMinutes) * time.Minute,
+	}
+	for _, l := range levels {
+		out.Levels = append(out.Levels, game.BlindLevel{SmallBlind: l.SmallBlindCC, BigBlind: l.BigBlindCC, Ante: l.AnteCC})
+	}
+	return out
+}
+
+// currentBlindLevel is the level the next hand is dealt at. Caller must hold
+// rt.mu.
+func (rt *tableRuntime) currentBlindLevel() game.BlindLevel {
+	if len(rt.blinds.Levels) == 0 {
+		return game.BlindLevel{SmallBlind: rt.room.SmallBlindCC, BigBlind: rt.room.BigBlindCC, Ante: rt.room.AnteCC}
+	}
+	return rt.blinds.Levels[rt.blindLevel]
+}
+
+// advanceBlindLevel moves the table to the level its schedule has reached
+// by now and reports whether the level changed. Caller must hold rt.mu.
+func (rt *tableRuntime) advanceBlindLevel(now time.Time) bool {
+	level := rt.blinds.Level(rt.engine.State.HandNumber, now.Sub(rt.blindsStartedAt))
+	if level == rt.blindLevel {
+		return false
+	}
+	rt.blindLevel = level
+	return true
+}
+
+// emitBlindLevelChanged announces the current blind level to the replay, the
+// seated agents and spectators. Caller must hold rt.mu.
+func (c *Coordinator) emitBlindLevelChanged(ctx context.Context, rt *tableRuntime) {
+	level := rt.currentBlindLevel()
+	payload := map[string]any{
+		"table_id":       rt.id,
+		"hand_id":        rt.engine.State.HandID,
+		"level":          rt.blindLevel,
+		"small_blind_cc": level.SmallBlind,
+		"big_blind_cc":   level.BigBlind,
+		"ante_cc":        level.Ante,
+	}
+	c.appendReplayEvent(ctx, rt, "blind_level_changed", "", payload)
+	for _, p := range rt.players {
+		if p == nil || p.buffer == nil {
+			continue
+		}
+		p.buffer.Append("blind_level_changed", p.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("blind_level_changed", rt.id, payload)
+	}
+}
@@ -11,9 +11,20 @@ func (c *Coordinator) StartJanitor(ctx context.Context, interval time.Duration)
 	}
 	expiryTicker := time.NewTicker(interval)
 	sweepTicker := time.NewTicker(coordinatorSweepInterval)
+	tournamentTicker := time.NewTicker(tournamentSweepInterval)
+	leagueTicker := time.NewTicker(leagueSweepInterval)
+	challengeTicker := time.NewTicker(challengeSweepInterval)
+	rankedTicker := time.NewTicker(rankedSweepInterval)
+	seasonTicker := time.NewTicker(seasonSweepInterval)
+	c.startLeaderboardRefresher(ctx)
 	go func() {
 		defer expiryTicker.Stop()
 		defer sweepTicker.Stop()
+		defer tournamentTicker.Stop()
+		defer leagueTicker.Stop()
+		defer challengeTicker.Stop()
+		defer rankedTicker.Stop()
+		defer seasonTicker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
@@ -22,6 +33,16 @@ func (c *Coordinator) StartJanitor(ctx context.Context, interval time.Duration)
 				_ = c.expireSessions(ctx, now)
 			case now := <-sweepTicker.C:
 				c.sweepTableTransitions(ctx, now)
+			case now := <-tournamentTicker.C:
+				c.startDueTournaments(ctx, now)
+			case <-leagueTicker.C:
+				c.sweepLeagues(ctx)
+			case now := <-challengeTicker.C:
+				c.sweepChallenges(ctx, now)
+			case <-rankedTicker.C:
+				c.sweepRanked(ctx)
+			case now := <-seasonTicker.C:
+				c.closeDueSeasons(ctx, now)
 			}
 		}
 	}()
@@ -2,7 +2,12 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"time"
+
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
 )
 
 func (c *Coordinator) beginReconnectGrace(ctx context.Context, rt *tableRuntime, forfeiterSeat int, reason string) {
@@ -22,7 +27,7 @@ func (c *Coordinator) beginReconnectGrace(ctx context.Context, rt *tableRuntime,
 		rt.mu.Unlock()
 		return
 	}
-	if forfeiterSeat < 0 || forfeiterSeat > 1 {
+	if !rt.validSeat(forfeiterSeat) {
 		forfeiterSeat = rt.engine.State.CurrentActor
 	}
 	rt.status = tableStatusClosing
@@ -75,52 +80,106 @@ func (c *Coordinator) closeTableWithForfeit(ctx context.Context, rt *tableRuntim
 		return
 	}
 
-	var sessionsToClose []string
-	var tableID string
 	var winnerID string
-	var pot int64
 
 	rt.mu.Lock()
 	if rt.status == tableStatusClosed {
 		rt.mu.Unlock()
 		return
 	}
-	if forfeiterSeat < 0 || forfeiterSeat > 1 {
-		if rt.disconnectedSeat >= 0 && rt.disconnectedSeat <= 1 {
+	if !rt.validSeat(forfeiterSeat) {
+		if rt.validSeat(rt.disconnectedSeat) {
 			forfeiterSeat = rt.disconnectedSeat
 		} else {
 			forfeiterSeat = rt.engine.State.CurrentActor
 		}
 	}
-	winnerSeat := 1 - forfeiterSeat
-	forfeiter := rt.players[forfeiterSeat]
-	winner := rt.players[winnerSeat]
-	if rt.engine.State.Players[forfeiterSeat] != nil {
-		rt.engine.State.Players[forfeiterSeat].Folded = true
+	c.recordPlaceLocked(ctx, rt, forfeiterSeat)
+	// A multi-table tournament table keeps running for its director to
+	// close or refill.
+	if rt.seatedCount() > 2 || rt.director != nil {
+		left, closeAfter := c.standUpLocked(ctx, rt, forfeiterSeat, reason)
+		rt.mu.Unlock()
+		c.releaseSessions(ctx, []*sessionState{left})
+		c.releaseDeparted(ctx, rt)
+		if closeAfter {
+			c.finishTable(ctx, rt)
+		}
+		return
 	}
-	winnerID, _ = rt.engine.Settle(ctx)
-	if winnerID == "" && winner != nil {
-		winnerID = winner.agent.ID
+	forfeiter := rt.players[forfeiterSeat]
+	var winner *sessionState
+	for seat, p := range rt.players {
+		if p != nil && seat != forfeiterSeat {
+			winner = p
+		}
 	}
-	pot = rt.engine.State.Pot
-	tableID = rt.id
-
 	forfeiterAgentID := ""
 	if forfeiter != nil {
 		forfeiterAgentID = forfeiter.agent.ID
 	}
-	c.appendReplayEvent(ctx, rt, "opponent_forfeited", winnerID, map[string]any{
+	if rt.league != nil {
+		rt.league.forfeitAgentID = forfeiterAgentID
+	}
+	rt.engine.Forfeit(forfeiterSeat)
+	_, _ = c.settleHandLocked(ctx, rt, func(settled string) []replayEvent {
+		winnerID = settled
+		if winnerID == "" && winner != nil {
+			winnerID = winner.agent.ID
+		}
+		return []replayEvent{
+			{eventType: "hand_settled", actorAgentID: winnerID, payload: handSettledPayload(rt, winnerID)},
+		}
+	})
+	if winnerID == "" && winner != nil {
+		winnerID = winner.agent.ID
+	}
+
+	forfeitPayload := map[string]any{
 		"table_id":           rt.id,
 		"forfeiter_agent_id": forfeiterAgentID,
 		"winner_agent_id":    winnerID,
 		"reason":             reason,
-	})
-	c.appendReplayEvent(ctx, rt, "hand_settled", winnerID, map[string]any{
-		"hand_id": rt.engine.State.HandID,
-		"winner":  winnerID,
-		"pot_cc":  pot,
-		"street":  string(rt.engine.State.Street),
-	})
+	}
+	c.appendReplayEvent(ctx, rt, "opponent_forfeited", winnerID, forfeitPayload)
+	for _, p := range rt.players {
+		if p == nil || p.buffer == nil {
+			continue
+		}
+		p.buffer.Append("opponent_forfeited", p.session.ID, forfeitPayload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("opponent_forfeited", rt.id, forfeitPayload)
+	}
+	seated := c.closeTableLocked(ctx, rt, reason)
+	rt.mu.Unlock()
+
+	c.releaseClosedTable(ctx, rt, seated)
+}
+
+// closeTable closes a table outside of a forfeit, e.g. when too few seated
+// players can cover the big blind to deal another hand.
+func (c *Coordinator) closeTable(ctx context.Context, rt *tableRuntime, reason string) {
+	if rt == nil {
+		return
+	}
+	rt.mu.Lock()
+	if rt.status == tableStatusClosed {
+		rt.mu.Unlock()
+		return
+	}
+	seated := c.closeTableLocked(ctx, rt, reason)
+	rt.mu.Unlock()
+	c.releaseClosedTable(ctx, rt, seated)
+}
+
+// closeTableLocked marks the table closed and notifies and closes every
+// player and the public stream. A tournament table settles its standings
+// first. It returns the sessions that were seated. Caller must hold rt.mu.
+func (c *Coordinator) closeTableLocked(ctx context.Context, rt *tableRuntime, reason string) []*sessionState {
+	if rt.tournament != nil {
+		c.completeSitAndGoLocked(ctx, rt)
+	}
 	c.appendReplayEvent(ctx, rt, "table_closed", "", map[string]any{"reason": reason})
 
 	rt.status = tableStatusClosed
@@ -131,6 +190,7 @@ func (c *Coordinator) closeTableWithForfeit(ctx context.Context, rt *tableRuntim
 	rt.turnSeat = -1
 	rt.replayClosed = true
 
+	seated := make([]*sessionState, 0, len(rt.players))
 	for _, p := range rt.players {
 		if p == nil {
 			continue
@@ -138,57 +198,154 @@ func (c *Coordinator) closeTableWithForfeit(ctx context.Context, rt *tableRuntim
 		p.session.Status = "closed"
 		p.disconnected = false
 		p.disconnectedReason = ""
-		sessionsToClose = append(sessionsToClose, p.session.ID)
+		seated = append(seated, p)
 		if p.buffer != nil {
-			p.buffer.Append("opponent_forfeited", p.session.ID, map[string]any{
-				"table_id":           rt.id,
-				"forfeiter_agent_id": forfeiterAgentID,
-				"winner_agent_id":    winnerID,
-				"reason":             reason,
-			})
 			p.buffer.Append("table_closed", p.session.ID, map[string]any{"table_id": rt.id, "reason": reason})
 			p.buffer.Append("session_closed", p.session.ID, map[string]any{"reason": reason})
 			p.buffer.Close()
 		}
 	}
 	if rt.publicBuffer != nil {
-		rt.publicBuffer.Append("opponent_forfeited", rt.id, map[string]any{
-			"table_id":           rt.id,
-			"forfeiter_agent_id": forfeiterAgentID,
-			"winner_agent_id":    winnerID,
-			"reason":             reason,
-		})
 		rt.publicBuffer.Append("table_closed", rt.id, map[string]any{"table_id": rt.id, "reason": reason})
 		rt.publicBuffer.Close()
 	}
-	rt.mu.Unlock()
+	return seated
+}
 
-	_ = c.store.EndHandWithSummary(ctx, rt.engine.State.HandID, winnerID, &pot, string(rt.engine.State.Street))
-	_ = c.store.MarkTableStatusByID(ctx, tableID, tableStatusClosed)
-	_ = c.store.CloseAgentSessionsByTableID(ctx, tableID)
+// releaseClosedTable persists a closed table and drops it and its sessions
+// from the coordinator. Must be called without holding rt.mu.
+func (c *Coordinator) releaseClosedTable(ctx context.Context, rt *tableRuntime, seated []*sessionState) {
+	_ = c.store.MarkTableStatusByID(ctx, rt.id, tableStatusClosed)
+	_ = c.store.CloseAgentSessionsByTableID(ctx, rt.id)
 
 	c.mu.Lock()
 	observer := c.tableObserver
-	delete(c.tables, tableID)
-	for _, p := range rt.players {
+	delete(c.tables, rt.id)
+	c.mu.Unlock()
+	if rt.tournament != nil {
+		c.finishSitAndGo(ctx, rt)
+		c.releaseDeparted(ctx, rt)
+	}
+	c.releaseSessions(ctx, seated)
+	if rt.director != nil {
+		c.directorTableClosed(ctx, rt)
+	}
+	if rt.match != nil {
+		c.closeDuplicateMatch(ctx, rt)
+	}
+	if rt.league != nil {
+		c.finishLeagueMatch(ctx, rt)
+	}
+	c.rateTable(ctx, rt)
+	if rt.inviteCode != "" {
+		if err := c.store.ClosePrivateTable(ctx, rt.inviteCode); err != nil {
+			log.Error().Err(err).Str("invite_code", rt.inviteCode).Msg("close private table failed")
+		}
+	}
+	if observer != nil {
+		observer.OnTableClosed(rt.id)
+	}
+}
+
+// releaseSessions drops closed sessions from the coordinator, closes them in
+// the store and cashes out their table stacks. Must be called without holding
+// rt.mu.
+func (c *Coordinator) releaseSessions(ctx context.Context, sessions []*sessionState) {
+	c.mu.Lock()
+	for _, p := range sessions {
 		if p == nil {
 			continue
 		}
-		delete(c.sessions, p.session.ID)
-		if p.agent != nil {
+		if c.sessions[p.session.ID] == p {
+			delete(c.sessions, p.session.ID)
+		}
+		if p.agent != nil && c.byAgent[p.agent.ID] == p {
 			delete(c.byAgent, p.agent.ID)
 		}
 	}
 	c.mu.Unlock()
-	if observer != nil {
-		observer.OnTableClosed(tableID)
+	for _, p := range sessions {
+		if p == nil {
+			continue
+		}
+		_ = c.store.CloseAgentSession(ctx, p.session.ID)
+		c.cashOut(ctx, p)
 	}
+}
 
-	for _, sessionID := range sessionsToClose {
-		_ = c.store.CloseAgentSession(ctx, sessionID)
+// cashOut returns the remaining table stack of sess to its account.
+func (c *Coordinator) cashOut(ctx context.Context, sess *sessionState) {
+	if sess.session.TableID == "" || sess.agent == nil {
+		return
+	}
+	if _, err := c.ledger.CashOut(ctx, sess.session.TableID, sess.agent.ID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		log.Error().
+			Err(err).
+			Str("table_id", sess.session.TableID).
+			Str("agent_id", sess.agent.ID).
+			Msg("cash out table stack failed")
 	}
 }
 
+// standUpLocked removes the player at seat from a table that keeps running
+// with the remaining players. The player is folded out of the current hand
+// and their session is closed. It returns the removed session and whether
+// the table must be closed because the next hand cannot be dealt. Caller
+// must hold rt.mu.
+func (c *Coordinator) standUpLocked(ctx context.Context, rt *tableRuntime, seat int, reason string) (*sessionState, bool) {
+	left := rt.players[seat]
+	rt.players[seat] = nil
+	wasActor := rt.engine.State.CurrentActor == seat
+	roundOver := rt.engine.Forfeit(seat)
+
+	payload := map[string]any{
+		"table_id": rt.id,
+		"agent_id": left.agent.ID,
+		"seat_id":  seat,
+		"reason":   reason,
+	}
+	c.appendReplayEvent(ctx, rt, "player_left", left.agent.ID, payload)
+	for _, p := range rt.players {
+		if p == nil || p.buffer == nil {
+			continue
+		}
+		p.buffer.Append("player_left", p.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("player_left", rt.id, payload)
+	}
+	left.session.Status = "closed"
+	left.disconnected = false
+	left.disconnectedReason = ""
+	left.runtime = nil
+	if left.buffer != nil {
+		left.buffer.Append("session_closed", left.session.ID, map[string]any{"reason": reason})
+		left.buffer.Close()
+	}
+
+	rt.status = tableStatusActive
+	rt.closeReason = ""
+	rt.reconnectDeadline = time.Time{}
+	rt.disconnectedSeat = -1
+	closeAfter := false
+	if roundOver {
+		closeAfter = c.advanceHandLocked(ctx, rt)
+	} else if wasActor {
+		rt.turnID = nextTurnID()
+	}
+	c.appendReplayState(ctx, rt)
+	if closeAfter {
+		return left, true
+	}
+	_ = c.store.MarkTableStatusByID(ctx, rt.id, tableStatusActive)
+	for _, p := range rt.players {
+		c.emitStateSnapshot(p)
+	}
+	c.emitTurnStarted(rt)
+	c.emitPublicSnapshot(rt)
+	return left, false
+}
+
 func (c *Coordinator) sweepTableTransitions(ctx context.Context, now time.Time) {
 	c.mu.Lock()
 	tables := make([]*tableRuntime, 0, len(c.tables))
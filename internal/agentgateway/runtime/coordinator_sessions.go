@@ -31,9 +31,36 @@ func (c *Coordinator) CreateSession(ctx context.Context, req CreateSessionReques
 	}
 	c.mu.Unlock()
 
-	room, code := c.selectRoom(ctx, agent.ID, req)
-	if room == nil {
-		return nil, errors.New(code)
+	if strings.EqualFold(req.JoinMode, "league") {
+		return c.joinLeague(ctx, agent, req)
+	}
+	if strings.EqualFold(req.JoinMode, "ranked") {
+		return c.joinRanked(ctx, agent, req)
+	}
+
+	var inv *inviteJoin
+	var room *store.Room
+	var buyin int64
+	if mode := strings.ToLower(req.JoinMode); mode == "challenge" || mode == "private" {
+		if inv, err = c.resolveInvite(ctx, agent, req); err != nil {
+			return nil, err
+		}
+		room, buyin = inv.room, inv.buyin
+	} else {
+		var code string
+		if room, buyin, code = c.selectRoom(ctx, agent.ID, req); room == nil {
+			return nil, errors.New(code)
+		}
+	}
+	// Accepting a challenge claims it before the challenger is seated, so
+	// it cannot also expire or be declined.
+	if inv != nil && inv.challenge != nil && !inv.create {
+		if err := c.store.RespondChallenge(ctx, inv.challenge.ID, agent.ID, store.ChallengeAccepted); err != nil {
+			if errors.Is(err, store.ErrChallengeClosed) {
+				return nil, errChallengeClosed
+			}
+			return nil, err
+		}
 	}
 
 	now := time.Now()
@@ -47,92 +74,185 @@ func (c *Coordinator) CreateSession(ctx context.Context, req CreateSessionReques
 	}
 
 	c.mu.Lock()
-	waiter := c.waiting[room.ID]
-	if waiter == nil {
-		ss := &sessionState{session: sess, agent: agent, buffer: NewEventBuffer(500)}
-		c.waiting[room.ID] = ss
-		c.sessions[sess.ID] = ss
-		c.byAgent[agent.ID] = ss
-		ss.buffer.Append("session_joined", sess.ID, map[string]any{
+	joiner := &sessionState{session: sess, agent: agent, buyinCC: buyin, buffer: NewEventBuffer(500)}
+	if inv != nil && inv.challenge != nil {
+		joiner.challengeID = inv.challenge.ID
+	} else if inv != nil {
+		joiner.inviteCode = inv.private.InviteCode
+	}
+	if rt := c.openSeatLocked(room.ID, joiner); rt != nil {
+		c.sessions[sess.ID] = joiner
+		c.byAgent[agent.ID] = joiner
+		c.mu.Unlock()
+		return c.joinRunningTable(ctx, rt, joiner)
+	}
+
+	maxSeats, minPlayers := roomSeating(room)
+	key := joiner.waitKey()
+	queue := c.waiting[key]
+	if joiner.challengeID != "" {
+		// A challenge is played heads-up whatever the room's table size,
+		// and an accepted one whose challenger is gone has nobody to play.
+		maxSeats, minPlayers = 2, 2
+		if inv.challenge.ChallengerAgentID != agent.ID && len(queue) == 0 {
+			c.mu.Unlock()
+			return nil, errChallengeClosed
+		}
+	}
+	if len(queue)+1 < minPlayers {
+		c.waiting[key] = append(queue, joiner)
+		c.sessions[sess.ID] = joiner
+		c.byAgent[agent.ID] = joiner
+		joined := map[string]any{
 			"table_id": "",
 			"room_id":  room.ID,
 			"seat_id":  nil,
-		})
+		}
+		if joiner.challengeID != "" {
+			joined["challenge_id"] = joiner.challengeID
+		}
+		if joiner.inviteCode != "" {
+			joined["invite_code"] = joiner.inviteCode
+		}
+		joiner.buffer.Append("session_joined", sess.ID, joined)
 		c.mu.Unlock()
 		if err := c.store.CreateAgentSession(ctx, sess); err != nil {
 			return nil, err
 		}
-		return &CreateSessionResponse{
-			SessionID: sess.ID,
-			RoomID:    room.ID,
-			StreamURL: "/api/agent/sessions/" + sess.ID + "/events",
-			ExpiresAt: sess.ExpiresAt,
-		}, nil
+		if inv != nil && inv.create {
+			if err := c.openInvite(ctx, inv, joiner); err != nil {
+				_ = c.CloseSessionWithReason(ctx, sess.ID, "invite_failed")
+				return nil, err
+			}
+		}
+		return c.responseForSession(joiner), nil
 	}
 
-	delete(c.waiting, room.ID)
-	second := &sessionState{session: sess, agent: agent, buffer: NewEventBuffer(500)}
-	c.sessions[sess.ID] = second
-	c.byAgent[agent.ID] = second
+	waiters := queue[:min(len(queue), maxSeats-1)]
+	if rest := queue[len(waiters):]; len(rest) > 0 {
+		c.waiting[key] = append([]*sessionState{}, rest...)
+	} else {
+		delete(c.waiting, key)
+	}
+	c.sessions[sess.ID] = joiner
+	c.byAgent[agent.ID] = joiner
 	tableID := store.NewID()
-	seat0 := 0
-	seat1 := 1
-	waiter.session.TableID = tableID
-	waiter.session.SeatID = &seat0
-	waiter.session.Status = "active"
-	waiter.disconnected = false
-	waiter.disconnectedReason = ""
-	waiter.seat = seat0
-	second.session.TableID = tableID
-	second.session.SeatID = &seat1
-	second.session.Status = "active"
-	second.seat = seat1
+	seated := append(append([]*sessionState{}, waiters...), joiner)
+	assignments := make([]store.SeatAssignment, 0, len(waiters))
+	for seat, ss := range seated {
+		ss.session.TableID = tableID
+		ss.session.SeatID = &seat
+		ss.session.Status = "active"
+		ss.disconnected = false
+		ss.disconnectedReason = ""
+		ss.seat = seat
+		if ss != joiner {
+			assignments = append(assignments, store.SeatAssignment{
+				SessionID: ss.session.ID,
+				AgentID:   ss.agent.ID,
+				Seat:      seat,
+				BuyinCC:   ss.buyinCC,
+			})
+		}
+	}
+	var leg *duplicateLeg
+	var match store.DuplicateMatch
+	var sng *sitAndGo
+	var tournament store.Tournament
+	switch {
+	case room.MatchFormat == store.MatchFormatDuplicate && len(seated) == 2:
+		leg, match, err = newDuplicateMatch(room, tableID, seated)
+	case room.MatchFormat == store.MatchFormatSitAndGo:
+		sng, tournament = newSitAndGo(room, seated)
+	}
 	c.mu.Unlock()
 
-	if err := c.store.CreateMatchedTableAndSessions(ctx, tableID, room.ID, room.SmallBlindCC, room.BigBlindCC, waiter.session.ID, second.session, seat0, seat1); err != nil {
+	if err == nil && leg != nil {
+		err = c.store.CreateDuplicateMatchAndSessions(ctx, match, room.ID, room.SmallBlindCC, room.BigBlindCC, assignments[0], joiner.session, joiner.seat)
+	} else if err == nil && sng != nil {
+		err = c.store.CreateSitAndGo(ctx, tournament, tableID, room.SmallBlindCC, room.BigBlindCC, assignments, joiner.session, joiner.seat)
+	} else if err == nil {
+		err = c.store.CreateMatchedTableAndSessions(ctx, tableID, room.ID, room.SmallBlindCC, room.BigBlindCC, assignments, joiner.session, joiner.seat, joiner.buyinCC)
+	}
+	if err != nil {
 		log.Error().
 			Err(err).
 			Str("table_id", tableID).
 			Str("room_id", room.ID).
-			Str("waiter_session_id", waiter.session.ID).
-			Str("second_session_id", second.session.ID).
-			Str("waiter_agent_id", waiter.agent.ID).
-			Str("second_agent_id", second.agent.ID).
+			Int("waiter_count", len(waiters)).
+			Str("joiner_session_id", joiner.session.ID).
+			Str("joiner_agent_id", joiner.agent.ID).
 			Msg("create matched table and sessions failed")
 		c.mu.Lock()
-		delete(c.sessions, second.session.ID)
-		delete(c.byAgent, second.agent.ID)
-		waiter.session.TableID = ""
-		waiter.session.SeatID = nil
-		waiter.session.Status = "waiting"
-		waiter.seat = 0
-		waiter.runtime = nil
-		c.waiting[room.ID] = waiter
+		delete(c.sessions, joiner.session.ID)
+		delete(c.byAgent, joiner.agent.ID)
+		for _, waiter := range waiters {
+			waiter.session.TableID = ""
+			waiter.session.SeatID = nil
+			waiter.session.Status = "waiting"
+			waiter.seat = 0
+			waiter.runtime = nil
+		}
+		c.waiting[key] = append(append([]*sessionState{}, waiters...), c.waiting[key]...)
 		c.mu.Unlock()
 		return nil, err
 	}
 
-	rt, err := c.startTableRuntime(ctx, tableID, room, waiter, second)
+	rt, err := c.startTableRuntime(ctx, tableID, room, seated, leg, sng, nil)
 	if err != nil {
 		log.Error().
 			Err(err).
 			Str("table_id", tableID).
 			Str("room_id", room.ID).
-			Str("waiter_session_id", waiter.session.ID).
-			Str("second_session_id", second.session.ID).
-			Str("waiter_agent_id", waiter.agent.ID).
-			Str("second_agent_id", second.agent.ID).
+			Int("waiter_count", len(waiters)).
+			Str("joiner_session_id", joiner.session.ID).
+			Str("joiner_agent_id", joiner.agent.ID).
 			Msg("start table runtime failed")
+		c.mu.Lock()
+		for _, ss := range seated {
+			ss.session.Status = "closed"
+			if ss.buffer != nil {
+				ss.buffer.Append("session_closed", ss.session.ID, map[string]any{"reason": closeReasonTableStartFailed})
+				ss.buffer.Close()
+			}
+		}
+		c.mu.Unlock()
+		// The first hand may have been recorded with some antes and blinds
+		// posted before dealing failed; voiding it returns them to the
+		// stacks. Releasing the sessions then cashes out the escrowed
+		// buy-ins, and entry fees are refunded by aborting the sit-and-go.
+		if _, err := c.store.VoidOpenHands(ctx, tableID); err != nil {
+			log.Error().Err(err).Str("table_id", tableID).Msg("void open hands failed")
+		}
+		c.releaseSessions(ctx, seated)
+		_ = c.store.MarkTableStatusByID(ctx, tableID, tableStatusClosed)
+		switch {
+		case leg != nil:
+			c.recordDuplicateMatch(ctx, leg.matchID, store.DuplicateMatchAborted)
+		case sng != nil:
+			if err := c.tournaments.AbortTournament(ctx, sng.tournamentID); err != nil {
+				log.Error().Err(err).Str("tournament_id", sng.tournamentID).Msg("abort sit-and-go failed")
+			}
+		}
 		return nil, err
 	}
+	rt.challengeID, rt.inviteCode = joiner.challengeID, joiner.inviteCode
+	if rt.inviteCode != "" {
+		if err := c.store.SetPrivateTableTable(ctx, rt.inviteCode, tableID); err != nil {
+			log.Error().Err(err).Str("invite_code", rt.inviteCode).Str("table_id", tableID).Msg("set private table failed")
+		}
+	}
 	c.mu.Lock()
-	waiter.runtime = rt
-	second.runtime = rt
+	for _, ss := range seated {
+		ss.runtime = rt
+	}
 	c.tables[tableID] = rt
-	c.emitSessionJoined(waiter)
-	c.emitSessionJoined(second)
-	c.emitStateSnapshot(waiter)
-	c.emitStateSnapshot(second)
+	for _, ss := range seated {
+		c.emitSessionJoined(ss)
+	}
+	for _, ss := range seated {
+		c.emitStateSnapshot(ss)
+	}
 	c.emitTurnStarted(rt)
 	c.emitPublicSnapshot(rt)
 	observer := c.tableObserver
@@ -146,14 +266,50 @@ func (c *Coordinator) CreateSession(ctx context.Context, req CreateSessionReques
 		observer.OnTableStarted(tableMeta, publicBuffer)
 	}
 
-	return &CreateSessionResponse{
-		SessionID: second.session.ID,
-		TableID:   tableID,
-		RoomID:    room.ID,
-		SeatID:    second.session.SeatID,
-		StreamURL: "/api/agent/sessions/" + second.session.ID + "/events",
-		ExpiresAt: second.session.ExpiresAt,
-	}, nil
+	return c.responseForSession(joiner), nil
+}
+
+// joinRunningTable persists a session seated on an already running table
+// and escrows its buy-in. The player is dealt in from the next hand.
+func (c *Coordinator) joinRunningTable(ctx context.Context, rt *tableRuntime, sess *sessionState) (*CreateSessionResponse, error) {
+	if err := c.store.CreateSeatedAgentSession(ctx, sess.session, sess.buyinCC); err != nil {
+		c.mu.Lock()
+		delete(c.sessions, sess.session.ID)
+		delete(c.byAgent, sess.agent.ID)
+		c.mu.Unlock()
+		rt.mu.Lock()
+		if rt.validSeat(sess.seat) && rt.players[sess.seat] == sess {
+			rt.players[sess.seat] = nil
+		}
+		rt.mu.Unlock()
+		return nil, err
+	}
+
+	rt.mu.Lock()
+	sess.pendingBuyin = false
+	payload := map[string]any{
+		"table_id":   rt.id,
+		"agent_id":   sess.agent.ID,
+		"agent_name": sess.agent.Name,
+		"seat_id":    sess.seat,
+		"buyin_cc":   sess.buyinCC,
+	}
+	c.appendReplayEvent(ctx, rt, "player_joined", sess.agent.ID, payload)
+	for _, p := range rt.players {
+		if p == nil || p == sess || p.buffer == nil {
+			continue
+		}
+		p.buffer.Append("player_joined", p.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("player_joined", rt.id, payload)
+	}
+	c.emitSessionJoined(sess)
+	c.emitStateSnapshot(sess)
+	c.emitPublicSnapshot(rt)
+	rt.mu.Unlock()
+
+	return c.responseForSession(sess), nil
 }
 
 func (c *Coordinator) CloseSession(ctx context.Context, sessionID string) error {
@@ -168,6 +324,11 @@ func (c *Coordinator) CloseSessionWithReason(ctx context.Context, sessionID, rea
 		return c.store.CloseAgentSession(ctx, sessionID)
 	}
 
+	if sess.runtime == nil && sess.director != nil && sess.session.Status != "closed" {
+		c.mu.Unlock()
+		return c.withdrawEntrant(ctx, sess, reason)
+	}
+
 	if sess.runtime != nil {
 		rt := sess.runtime
 		sess.disconnected = true
@@ -185,11 +346,30 @@ func (c *Coordinator) CloseSessionWithReason(ctx context.Context, sessionID, rea
 	if sess.agent != nil {
 		delete(c.byAgent, sess.agent.ID)
 	}
-	if wait := c.waiting[sess.session.RoomID]; wait == sess {
-		delete(c.waiting, sess.session.RoomID)
+	if queue := removeWaiting(c.waiting[sess.waitKey()], sess); len(queue) > 0 {
+		c.waiting[sess.waitKey()] = queue
+	} else {
+		delete(c.waiting, sess.waitKey())
+	}
+	if sess.leagueID != "" {
+		if queue := removeWaiting(c.leagueWaiting[sess.leagueID], sess); len(queue) > 0 {
+			c.leagueWaiting[sess.leagueID] = queue
+		} else {
+			delete(c.leagueWaiting, sess.leagueID)
+		}
+	}
+	if sess.ranked != nil {
+		if queue := removeWaiting(c.rankedWaiting[sess.session.RoomID], sess); len(queue) > 0 {
+			c.rankedWaiting[sess.session.RoomID] = queue
+		} else {
+			delete(c.rankedWaiting, sess.session.RoomID)
+		}
 	}
 	sess.session.Status = "closed"
 	c.mu.Unlock()
+	if sess.challengeID != "" || sess.inviteCode != "" {
+		c.leaveInvite(ctx, sess)
+	}
 	return c.store.CloseAgentSession(ctx, sessionID)
 }
 func (c *Coordinator) FindTableByAgent(agentID string) (string, string, bool) {
@@ -212,38 +392,97 @@ func (c *Coordinator) FindOpenSessionByAgent(agentID string) (*CreateSessionResp
 	return c.responseForSessionLocked(sess), true
 }
 
+func (c *Coordinator) responseForSession(sess *sessionState) *CreateSessionResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.responseForSessionLocked(sess)
+}
+
 func (c *Coordinator) responseForSessionLocked(sess *sessionState) *CreateSessionResponse {
 	if sess == nil {
 		return nil
 	}
 	res := &CreateSessionResponse{
-		SessionID: sess.session.ID,
-		TableID:   sess.session.TableID,
-		RoomID:    sess.session.RoomID,
-		SeatID:    sess.session.SeatID,
-		StreamURL: "/api/agent/sessions/" + sess.session.ID + "/events",
-		ExpiresAt: sess.session.ExpiresAt,
+		SessionID:   sess.session.ID,
+		TableID:     sess.session.TableID,
+		RoomID:      sess.session.RoomID,
+		SeatID:      sess.session.SeatID,
+		BuyinCC:     sess.buyinCC,
+		StreamURL:   "/api/agent/sessions/" + sess.session.ID + "/events",
+		ExpiresAt:   sess.session.ExpiresAt,
+		ChallengeID: sess.challengeID,
+		InviteCode:  sess.inviteCode,
 	}
 	return res
 }
-func (c *Coordinator) startTableRuntime(ctx context.Context, tableID string, room *store.Room, p0, p1 *sessionState) (*tableRuntime, error) {
+
+// deckSeedSchedule is the seed schedule a new table deals from, or "" for
+// random decks. Both legs of a duplicate match share the match's schedule;
+// every table of a room with a schedule gets one of its own.
+func deckSeedSchedule(tableID string, room *store.Room, leg *duplicateLeg) string {
+	if leg != nil {
+		return leg.schedule
+	}
+	if room.DeckSeedSchedule == "" {
+		return ""
+	}
+	return game.TableSeedSchedule(room.DeckSeedSchedule, tableID)
+}
+
+// startTableRuntime deals the first hand of a new table. leg is set when the
+// table plays a leg of a duplicate match and overrides the room's deck seed
+// schedule with the match's. sng is set when the table plays a sit-and-go,
+// which takes no rake and deals in every stack with chips left, and d when
+// it is one of the tables of a multi-table tournament, which plays the same
+// way at the tournament's blind level.
+func (c *Coordinator) startTableRuntime(ctx context.Context, tableID string, room *store.Room, seated []*sessionState, leg *duplicateLeg, sng *sitAndGo, d *tournamentDirector) (*tableRuntime, error) {
 	engine := game.NewEngine(c.store, c.ledger, tableID, room.SmallBlindCC, room.BigBlindCC)
+	engine.State.Rake = game.RakeConfig{
+		BasisPoints:  int64(room.RakeBps),
+		CapCC:        room.RakeCapCC,
+		NoFlopNoDrop: room.RakeNoFlopNoDrop,
+	}
+	engine.State.Variant, _ = game.VariantByName(room.Variant)
+	engine.State.BigBlindAnte = room.BigBlindAnte
+	engine.State.Straddle = room.Straddle
+	engine.State.Betting = game.BettingStructure(room.BettingStructure)
+	engine.State.RaiseCap = room.RaiseCap
+	engine.DeckSeedSchedule = deckSeedSchedule(tableID, room, leg)
+	if sng != nil || d != nil {
+		engine.State.Rake = game.RakeConfig{}
+		engine.State.PlayShortStacks = true
+	}
+	levels, err := c.store.ListRoomBlindLevels(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	maxSeats, _ := roomSeating(room)
 	rt := &tableRuntime{
 		id:               tableID,
 		room:             room,
+		match:            leg,
+		tournament:       sng,
+		director:         d,
 		engine:           engine,
-		players:          [2]*sessionState{p0, p1},
+		players:          make([]*sessionState, maxSeats),
 		turnID:           nextTurnID(),
 		publicBuffer:     NewEventBuffer(500),
 		status:           tableStatusActive,
 		disconnectedSeat: -1,
 		turnSeat:         -1,
+		blinds:           roomBlindSchedule(room, levels),
+		blindsStartedAt:  time.Now(),
 	}
-	players := [2]*game.Player{
-		{ID: p0.agent.ID, Name: p0.agent.Name, Seat: 0},
-		{ID: p1.agent.ID, Name: p1.agent.Name, Seat: 1},
+	if d != nil {
+		d.mu.Lock()
+		rt.blindsStartedAt = d.startedAt
+		d.mu.Unlock()
+		rt.advanceBlindLevel(time.Now())
 	}
-	if err := rt.engine.StartHand(ctx, players[0], players[1], room.SmallBlindCC, room.BigBlindCC); err != nil {
+	for _, ss := range seated {
+		rt.players[ss.seat] = ss
+	}
+	if err := rt.startNextHand(ctx); err != nil {
 		return nil, err
 	}
 	rt.turnID = nextTurnID()
@@ -253,10 +492,11 @@ func (c *Coordinator) startTableRuntime(ctx context.Context, tableID string, roo
 	return rt, nil
 }
 
-func (c *Coordinator) selectRoom(ctx context.Context, agentID string, join CreateSessionRequest) (*store.Room, string) {
+// selectRoom picks the room to join and the buy-in the agent brings to it.
+func (c *Coordinator) selectRoom(ctx context.Context, agentID string, join CreateSessionRequest) (*store.Room, int64, string) {
 	balance, err := c.store.GetAccountBalance(ctx, agentID)
 	if err != nil {
-		return nil, "invalid_action"
+		return nil, 0, "invalid_action"
 	}
 	mode := strings.ToLower(join.JoinMode)
 	if mode == "" {
@@ -264,29 +504,40 @@ func (c *Coordinator) selectRoom(ctx context.Context, agentID string, join Creat
 	}
 	if mode == "select" {
 		room, err := c.store.GetRoom(ctx, join.RoomID)
-		if err != nil || room.Status != "active" {
-			return nil, "room_not_found"
+		// Multi-table rooms are entered by registering for one of their
+		// scheduled tournaments, league rooms through a league.
+		if err != nil || room.Status != "active" || room.MatchFormat == store.MatchFormatMultiTable || room.MatchFormat == store.MatchFormatLeague {
+			return nil, 0, "room_not_found"
 		}
-		if balance < room.MinBuyinCC {
-			return nil, "insufficient_buyin"
+		buyin, code := resolveBuyin(room, balance, join.BuyinCC)
+		if code != "" {
+			return nil, 0, code
 		}
-		return room, ""
+		return room, buyin, ""
 	}
 	rooms, err := c.store.ListRooms(ctx)
 	if err != nil {
-		return nil, "no_available_room"
+		return nil, 0, "no_available_room"
 	}
 	eligible := make([]store.Room, 0, len(rooms))
+	buyins := make([]int64, 0, len(rooms))
 	for _, room := range rooms {
-		if balance >= room.MinBuyinCC {
+		// Tournaments and leagues are entered on purpose, never at random.
+		switch room.MatchFormat {
+		case store.MatchFormatSitAndGo, store.MatchFormatMultiTable, store.MatchFormatLeague:
+			continue
+		}
+		if buyin, code := resolveBuyin(&room, balance, join.BuyinCC); code == "" {
 			eligible = append(eligible, room)
+			buyins = append(buyins, buyin)
 		}
 	}
 	if len(eligible) == 0 {
-		return nil, "no_available_room"
+		return nil, 0, "no_available_room"
 	}
-	pick := eligible[rand.Intn(len(eligible))]
-	return &pick, ""
+	i := rand.Intn(len(eligible))
+	pick := eligible[i]
+	return &pick, buyins[i], ""
 }
 func (c *Coordinator) emitSessionJoined(sess *sessionState) {
 	if sess == nil || sess.buffer == nil {
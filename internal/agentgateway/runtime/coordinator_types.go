@@ -1,7 +1,9 @@
 package runtime
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"silicon-casino/internal/game"
@@ -16,6 +18,9 @@ const (
 	tableStatusClosed        = "closed"
 	defaultReconnectGrace    = 30 * time.Second
 	coordinatorSweepInterval = 500 * time.Millisecond
+
+	closeReasonNotEnoughPlayers = "not_enough_players"
+	closeReasonTableStartFailed = "table_start_failed"
 )
 
 var reconnectGracePeriod = defaultReconnectGrace
@@ -25,39 +30,111 @@ type sessionState struct {
 	agent              *store.Agent
 	runtime            *tableRuntime
 	seat               int
+	buyinCC            int64
+	pendingBuyin       bool
 	buffer             *EventBuffer
 	disconnected       bool
 	disconnectedReason string
+	// director is the multi-table tournament the session is entered in; it
+	// stays set while the session waits for a seat or changes tables.
+	director *tournamentDirector
+	// leagueID is the league a "league" session waits in to be paired.
+	leagueID string
+	// challengeID and inviteCode are the challenge or private table a
+	// session was opened for. Such sessions wait and play apart from the
+	// room's public tables.
+	challengeID string
+	inviteCode  string
+	// ranked is set for a "ranked" session, which queues in its room's
+	// matchmaking apart from the room's public tables.
+	ranked *rankedEntry
+}
+
+// waitKey is the c.waiting queue the session waits in: its challenge or
+// invite code, otherwise its room.
+func (ss *sessionState) waitKey() string {
+	switch {
+	case ss.challengeID != "":
+		return ss.challengeID
+	case ss.inviteCode != "":
+		return ss.inviteCode
+	}
+	return ss.session.RoomID
+}
+
+// tableLedger is the ledger tables deal and cash out with, *ledger.Ledger
+// outside of tests.
+type tableLedger interface {
+	game.Ledger
+	CashOut(ctx context.Context, tableID, agentID string) (int64, error)
 }
 
 type Coordinator struct {
 	store  *store.Store
-	ledger *ledger.Ledger
+	ledger tableLedger
+	// tournaments is where tournaments are recorded, store itself outside
+	// of tests.
+	tournaments tournamentStore
 
-	mu            sync.Mutex
-	waiting       map[string]*sessionState
-	sessions      map[string]*sessionState
-	byAgent       map[string]*sessionState
-	tables        map[string]*tableRuntime
+	mu sync.Mutex
+	// waiting holds the sessions waiting for a table, by room or, for
+	// challenges and private tables, by sessionState.waitKey.
+	waiting   map[string][]*sessionState
+	sessions  map[string]*sessionState
+	byAgent   map[string]*sessionState
+	tables    map[string]*tableRuntime
+	directors map[string]*tournamentDirector
+	// leagueWaiting holds the sessions waiting for their next league
+	// match, by league.
+	leagueWaiting map[string][]*sessionState
+	// rankedWaiting holds the sessions queued for ranked matchmaking, by
+	// room, and rankedWait the running average of how long the agents
+	// paired in each room had queued.
+	rankedWaiting map[string][]*sessionState
+	rankedWait    map[string]time.Duration
 	tableObserver TableLifecycleObserver
+
+	// ratingMu serializes rating updates, which read the ratings they
+	// start from before writing the new ones.
+	ratingMu sync.Mutex
+
+	// equityIterations is the Monte Carlo budget of the equity annotations
+	// written to replay snapshots; 0 turns them off.
+	equityIterations atomic.Int64
 }
 
 func NewCoordinator(st *store.Store, led *ledger.Ledger) *Coordinator {
-	return &Coordinator{
-		store:    st,
-		ledger:   led,
-		waiting:  map[string]*sessionState{},
-		sessions: map[string]*sessionState{},
-		byAgent:  map[string]*sessionState{},
-		tables:   map[string]*tableRuntime{},
+	c := &Coordinator{
+		store:         st,
+		tournaments:   st,
+		waiting:       map[string][]*sessionState{},
+		sessions:      map[string]*sessionState{},
+		byAgent:       map[string]*sessionState{},
+		tables:        map[string]*tableRuntime{},
+		directors:     map[string]*tournamentDirector{},
+		leagueWaiting: map[string][]*sessionState{},
+		rankedWaiting: map[string][]*sessionState{},
+		rankedWait:    map[string]time.Duration{},
+	}
+	if led != nil {
+		c.ledger = led
 	}
+	c.equityIterations.Store(game.DefaultEquityIterations)
+	return c
 }
 
 type tableRuntime struct {
 	id                  string
 	room                *store.Room
+	match               *duplicateLeg
+	tournament          *sitAndGo
+	director            *tournamentDirector
+	league              *leagueMatch
+	challengeID         string
+	inviteCode          string
+	ranked              bool
 	engine              *game.Engine
-	players             [2]*sessionState
+	players             []*sessionState
 	turnID              string
 	globalSeq           int64
 	handSeq             int32
@@ -71,7 +148,17 @@ type tableRuntime struct {
 	disconnectedSeat    int
 	turnDeadline        time.Time
 	turnSeat            int
-	mu                  sync.Mutex
+	// equity is the replay equity annotation of the current street.
+	equity *replayEquityJob
+	// blinds is the room's blind schedule, blindLevel the level in effect
+	// and blindsStartedAt when the table dealt its first hand.
+	blinds          game.BlindSchedule
+	blindLevel      int
+	blindsStartedAt time.Time
+	// eliminated are the tournament players taken off the table, whose
+	// sessions are still to be released once rt.mu is no longer held.
+	eliminated []*sessionState
+	mu         sync.Mutex
 }
 
 func nextTurnID() string {
@@ -0,0 +1,231 @@
+package runtime
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	closeReasonLegComplete   = "duplicate_leg_complete"
+	closeReasonMatchComplete = "duplicate_match_complete"
+	closeReasonLegFailed     = "duplicate_leg_failed"
+)
+
+// duplicateLeg is the part of a duplicate match a table runtime plays. Both
+// legs share a seed schedule, so hand N of leg 2 is dealt from the same deck
+// as hand N of leg 1, with every agent in the other agent's seat.
+type duplicateLeg struct {
+	matchID     string
+	leg         int
+	handsPerLeg int
+	schedule    string
+	// nextTableID is the table of leg 2; empty on the final leg.
+	nextTableID string
+}
+
+// newDuplicateMatch pairs the two seated sessions of a duplicate room. Both
+// agents buy in for the smaller of their buy-ins so the legs mirror exactly.
+// Caller must hold c.mu.
+func newDuplicateMatch(room *store.Room, leg1TableID string, seated []*sessionState) (*duplicateLeg, store.DuplicateMatch, error) {
+	seed, err := game.NewSeed()
+	if err != nil {
+		return nil, store.DuplicateMatch{}, err
+	}
+	buyin := min(seated[0].buyinCC, seated[1].buyinCC)
+	for _, ss := range seated {
+		ss.buyinCC = buyin
+	}
+	m := store.DuplicateMatch{
+		ID:           store.NewID(),
+		RoomID:       room.ID,
+		AgentAID:     seated[0].agent.ID,
+		AgentBID:     seated[1].agent.ID,
+		Leg1TableID:  leg1TableID,
+		Leg2TableID:  store.NewID(),
+		SeedSchedule: hex.EncodeToString(seed),
+		HandsPerLeg:  max(room.DuplicateHands, 1),
+		BuyinCC:      buyin,
+	}
+	leg := &duplicateLeg{
+		matchID:     m.ID,
+		leg:         1,
+		handsPerLeg: m.HandsPerLeg,
+		schedule:    m.SeedSchedule,
+		nextTableID: m.Leg2TableID,
+	}
+	return leg, m, nil
+}
+
+// legOver reports whether the hand that just settled was the last one of the
+// leg. Caller must hold rt.mu.
+func (rt *tableRuntime) legOver() bool {
+	return rt.match != nil && rt.engine.State.HandNumber >= rt.match.handsPerLeg
+}
+
+// finishTable ends a table that will not deal another hand. A duplicate leg
+// hands its players over to the next leg instead of closing their sessions;
+// a sit-and-go is over once a single player is left, and a league match
+// once its hands are played or a player has busted.
+// Must be called without holding rt.mu.
+func (c *Coordinator) finishTable(ctx context.Context, rt *tableRuntime) {
+	if rt.match != nil {
+		c.endDuplicateLeg(ctx, rt)
+		return
+	}
+	if rt.league != nil {
+		c.closeTable(ctx, rt, closeReasonLeagueMatchComplete)
+		return
+	}
+	if rt.tournament != nil {
+		c.closeTable(ctx, rt, closeReasonTournamentComplete)
+		return
+	}
+	if rt.director != nil {
+		c.finishDirectorTable(ctx, rt)
+		return
+	}
+	c.closeTable(ctx, rt, closeReasonNotEnoughPlayers)
+}
+
+// endDuplicateLeg closes a finished leg. After leg 1 the sessions are moved
+// to the leg 2 table with their seats swapped; after leg 2, or when leg 2
+// cannot be started, the table is closed and the match is recorded by
+// releaseClosedTable. Must be called without holding rt.mu.
+func (c *Coordinator) endDuplicateLeg(ctx context.Context, rt *tableRuntime) {
+	leg := rt.match
+	rt.mu.Lock()
+	if rt.status == tableStatusClosed {
+		rt.mu.Unlock()
+		return
+	}
+	reason := closeReasonMatchComplete
+	var next []*sessionState
+	if leg.nextTableID != "" {
+		next = make([]*sessionState, len(rt.players))
+		seats := make([]store.SeatAssignment, 0, len(rt.players))
+		for seat, p := range rt.players {
+			if p == nil {
+				continue
+			}
+			swapped := len(rt.players) - 1 - seat
+			next[swapped] = p
+			seats = append(seats, store.SeatAssignment{SessionID: p.session.ID, AgentID: p.agent.ID, Seat: swapped})
+		}
+		reason = closeReasonLegComplete
+		if err := c.store.StartDuplicateLeg2(ctx, leg.matchID, seats); err != nil {
+			log.Error().Err(err).Str("match_id", leg.matchID).Str("table_id", rt.id).Msg("start duplicate leg 2 failed")
+			reason = closeReasonLegFailed
+			next = nil
+		}
+	}
+	if next == nil {
+		seated := c.closeTableLocked(ctx, rt, reason)
+		rt.mu.Unlock()
+		c.releaseClosedTable(ctx, rt, seated)
+		return
+	}
+
+	c.appendReplayEvent(ctx, rt, "table_closed", "", map[string]any{"reason": reason, "next_table_id": leg.nextTableID})
+	rt.status = tableStatusClosed
+	rt.closeReason = reason
+	rt.turnDeadline = time.Time{}
+	rt.turnSeat = -1
+	rt.replayClosed = true
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("table_closed", rt.id, map[string]any{"table_id": rt.id, "reason": reason, "next_table_id": leg.nextTableID})
+		rt.publicBuffer.Close()
+	}
+	rt.mu.Unlock()
+
+	c.mu.Lock()
+	seated := make([]*sessionState, 0, len(next))
+	for seat, p := range next {
+		if p == nil {
+			continue
+		}
+		p.seat = seat
+		p.session.TableID = leg.nextTableID
+		p.session.SeatID = &seat
+		seated = append(seated, p)
+	}
+	c.mu.Unlock()
+
+	leg2 := &duplicateLeg{matchID: leg.matchID, leg: 2, handsPerLeg: leg.handsPerLeg, schedule: leg.schedule}
+	rt2, err := c.startTableRuntime(ctx, leg.nextTableID, rt.room, seated, leg2, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Str("match_id", leg.matchID).Str("table_id", leg.nextTableID).Msg("start duplicate leg 2 runtime failed")
+		for _, p := range seated {
+			p.session.Status = "closed"
+			p.runtime = nil
+			if p.buffer != nil {
+				p.buffer.Append("session_closed", p.session.ID, map[string]any{"reason": closeReasonLegFailed})
+				p.buffer.Close()
+			}
+		}
+		c.mu.Lock()
+		delete(c.tables, rt.id)
+		observer := c.tableObserver
+		c.mu.Unlock()
+		c.releaseSessions(ctx, seated)
+		c.recordDuplicateMatch(ctx, leg.matchID, store.DuplicateMatchAborted)
+		if observer != nil {
+			observer.OnTableClosed(rt.id)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.tables, rt.id)
+	c.tables[rt2.id] = rt2
+	for _, p := range seated {
+		p.runtime = rt2
+		if p.buffer != nil {
+			p.buffer.Append("duplicate_leg_started", p.session.ID, map[string]any{
+				"match_id": leg.matchID,
+				"leg":      leg2.leg,
+				"table_id": rt2.id,
+				"seat_id":  p.seat,
+			})
+		}
+		c.emitSessionJoined(p)
+		c.emitStateSnapshot(p)
+	}
+	c.emitTurnStarted(rt2)
+	c.emitPublicSnapshot(rt2)
+	observer := c.tableObserver
+	c.mu.Unlock()
+	if observer != nil {
+		observer.OnTableClosed(rt.id)
+		observer.OnTableStarted(TableMeta{TableID: rt2.id, RoomID: rt.room.ID}, rt2.publicBuffer)
+	}
+}
+
+// closeDuplicateMatch records the match of a duplicate table that has just
+// been closed: completed when the final leg ran to the end, aborted
+// otherwise. Must be called without holding rt.mu.
+func (c *Coordinator) closeDuplicateMatch(ctx context.Context, rt *tableRuntime) {
+	rt.mu.Lock()
+	reason := rt.closeReason
+	rt.mu.Unlock()
+	status := store.DuplicateMatchAborted
+	if reason == closeReasonMatchComplete {
+		status = store.DuplicateMatchCompleted
+	}
+	c.recordDuplicateMatch(ctx, rt.match.matchID, status)
+	if status == store.DuplicateMatchCompleted {
+		c.rateDuplicateMatch(ctx, rt.match.matchID)
+	}
+}
+
+func (c *Coordinator) recordDuplicateMatch(ctx context.Context, matchID, status string) {
+	if err := c.store.CloseDuplicateMatch(ctx, matchID, status); err != nil && !errors.Is(err, store.ErrDuplicateMatchClosed) {
+		log.Error().Err(err).Str("match_id", matchID).Str("status", status).Msg("close duplicate match failed")
+	}
+}
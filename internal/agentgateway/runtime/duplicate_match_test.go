@@ -0,0 +1,160 @@
+package runtime
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"testing"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/store"
+	"silicon-casino/internal/testutil"
+)
+
+func TestDuplicateMatchReplaysDecksWithSwappedSeats(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+	roomID, err := st.CreateRoomWithConfig(ctx, store.Room{
+		Name:           "Duplicate",
+		MinBuyinCC:     1000,
+		MaxBuyinCC:     5000,
+		SmallBlindCC:   50,
+		BigBlindCC:     100,
+		MatchFormat:    store.MatchFormatDuplicate,
+		DuplicateHands: 1,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	coord := NewCoordinator(st, ledger.New(st))
+	agents := make([]string, 2)
+	var res *CreateSessionResponse
+	for i := range agents {
+		key := fmt.Sprintf("key-%d", i)
+		agents[i], err = st.CreateAgent(ctx, fmt.Sprintf("bot-%d", i), key, "claim-"+key)
+		if err != nil {
+			t.Fatalf("create agent %d: %v", i, err)
+		}
+		if err := st.EnsureAccount(ctx, agents[i], 100000); err != nil {
+			t.Fatalf("ensure account %d: %v", i, err)
+		}
+		res, err = coord.CreateSession(ctx, CreateSessionRequest{AgentID: agents[i], APIKey: key, JoinMode: "select", RoomID: roomID})
+		if err != nil {
+			t.Fatalf("create session %d: %v", i, err)
+		}
+	}
+	match, err := st.GetDuplicateMatchByTable(ctx, res.TableID)
+	if err != nil {
+		t.Fatalf("get match: %v", err)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, agents[0]); bal != 90000 {
+		t.Fatalf("expected both legs to be escrowed up front, balance=%d", bal)
+	}
+
+	// foldHand folds the first hand of the current leg and returns the hole
+	// cards each agent was dealt.
+	foldHand := func() map[string][]game.Card {
+		t.Helper()
+		coord.mu.Lock()
+		rt := coord.byAgent[agents[0]].runtime
+		coord.mu.Unlock()
+		rt.mu.Lock()
+		hole := map[string][]game.Card{}
+		for _, p := range rt.engine.State.Players {
+			if p != nil {
+				hole[p.ID] = slices.Clone(p.Hole)
+			}
+		}
+		actor := rt.players[rt.engine.State.CurrentActor]
+		turnID := rt.turnID
+		rt.mu.Unlock()
+		if _, err := coord.SubmitAction(ctx, actor.session.ID, ActionRequest{RequestID: "fold-" + turnID, TurnID: turnID, Action: "fold"}); err != nil {
+			t.Fatalf("fold: %v", err)
+		}
+		return hole
+	}
+
+	leg1 := foldHand()
+	coord.mu.Lock()
+	sess := coord.byAgent[agents[0]]
+	tableID, seat := sess.session.TableID, sess.seat
+	coord.mu.Unlock()
+	if tableID != match.Leg2TableID || seat != 1 {
+		t.Fatalf("expected agent A at seat 1 of the leg 2 table, got table=%s seat=%d", tableID, seat)
+	}
+	leg2 := foldHand()
+	if !slices.Equal(leg1[agents[0]], leg2[agents[1]]) || !slices.Equal(leg1[agents[1]], leg2[agents[0]]) {
+		t.Fatalf("expected leg 2 to deal each agent the other's leg 1 cards: %v vs %v", leg1, leg2)
+	}
+
+	done, err := st.GetDuplicateMatch(ctx, match.ID)
+	if err != nil || done.Status != store.DuplicateMatchCompleted {
+		t.Fatalf("expected the match to complete, got %+v err=%v", done, err)
+	}
+	results, err := st.ListDuplicateMatchResults(ctx, []string{match.ID})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("expected two result rows, got %+v err=%v", results, err)
+	}
+	var total int64
+	for _, r := range results {
+		if r.HandsPlayed != 2 || r.NetCC != r.Leg1NetCC+r.Leg2NetCC {
+			t.Fatalf("unexpected result row: %+v", r)
+		}
+		total += r.NetCC
+	}
+	if total != 0 {
+		t.Fatalf("expected an unraked match to be zero-sum, got %d", total)
+	}
+	for _, agentID := range agents {
+		bal, _ := st.GetAccountBalance(ctx, agentID)
+		for _, r := range results {
+			if r.AgentID == agentID && bal != 100000+r.NetCC {
+				t.Fatalf("expected %s to end with %d, got %d", agentID, 100000+r.NetCC, bal)
+			}
+		}
+	}
+}
+
+func TestScheduleRoomTablesDoNotShareDecks(t *testing.T) {
+	room := &store.Room{ID: "room-bench", DeckSeedSchedule: "bench-1"}
+	rts := make([]*tableRuntime, 2)
+	for i, id := range []string{"table-a", "table-b"} {
+		engine := game.NewEngine(nil, nil, id, 50, 100)
+		engine.DeckSeedSchedule = deckSeedSchedule(id, room, nil)
+		rts[i] = &tableRuntime{id: id, room: room, engine: engine}
+	}
+	a, b := rts[0], rts[1]
+
+	// Every seed table a reveals must deal none of table b's hands.
+	upcoming := map[string]bool{}
+	for n := 1; n <= 20; n++ {
+		upcoming[fmt.Sprint(game.DeckOrder(game.ScheduledSeed(b.engine.DeckSeedSchedule, n)))] = true
+	}
+	for n := 1; n <= 20; n++ {
+		st := a.engine.State
+		st.HandNumber = n
+		st.DeckSeed = game.ScheduledSeed(a.engine.DeckSeedSchedule, n)
+		revealed, _ := handSettledPayload(a, "")["seed"].(string)
+		seed, err := hex.DecodeString(revealed)
+		if err != nil || !slices.Equal(seed, st.DeckSeed) {
+			t.Fatalf("hand %d: expected the seed revealed, got %q", n, revealed)
+		}
+		if upcoming[fmt.Sprint(game.DeckOrder(seed))] {
+			t.Fatalf("hand %d of table a reveals a deck of table b", n)
+		}
+	}
+
+	// Re-dealing a table from the room's schedule reproduces its decks.
+	if again := deckSeedSchedule(a.id, room, nil); again != a.engine.DeckSeedSchedule {
+		t.Fatalf("expected a stable schedule for table a, got %q and %q", again, a.engine.DeckSeedSchedule)
+	}
+
+	// Both legs of a duplicate match still share the match's schedule.
+	leg := &duplicateLeg{schedule: "match-1"}
+	if deckSeedSchedule("leg-1", room, leg) != deckSeedSchedule("leg-2", room, leg) {
+		t.Fatalf("expected duplicate legs to deal the same decks")
+	}
+}
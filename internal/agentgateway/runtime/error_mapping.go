@@ -11,12 +11,22 @@ func MapSessionCreateError(err error) (int, string) {
 		return http.StatusUnauthorized, "invalid_api_key"
 	case "room_not_found":
 		return http.StatusNotFound, "room_not_found"
-	case "insufficient_buyin":
+	case "tournament_not_found", "league_not_found", "challenge_not_found", "opponent_not_found", "invite_not_found":
+		return http.StatusNotFound, err.Error()
+	case "not_league_participant", "not_invited":
+		return http.StatusForbidden, err.Error()
+	case "invalid_opponent", "too_many_invitees":
+		return http.StatusBadRequest, err.Error()
+	case "insufficient_buyin", "insufficient_balance":
 		return http.StatusBadRequest, "insufficient_buyin"
+	case "invalid_buyin":
+		return http.StatusBadRequest, "invalid_buyin"
 	case "no_available_room":
 		return http.StatusBadRequest, "no_available_room"
 	case "agent_already_in_session":
 		return http.StatusConflict, "agent_already_in_session"
+	case "tournament_closed", "tournament_full", "already_registered", "league_closed", "challenge_closed", "invite_closed":
+		return http.StatusConflict, err.Error()
 	case "invalid_action":
 		return http.StatusBadRequest, "invalid_action"
 	default:
@@ -15,6 +15,7 @@ func (c *Coordinator) emitStateSnapshot(sess *sessionState) {
 	state.TableStatus = rt.status
 	state.ReconnectDeadlineTS = rt.reconnectDeadline.UnixMilli()
 	state.CloseReason = rt.closeReason
+	state.TournamentID = rt.tournamentID()
 	sess.buffer.Append("state_snapshot", sess.session.ID, state)
 }
 
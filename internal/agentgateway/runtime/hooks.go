@@ -15,3 +15,9 @@ func (c *Coordinator) SetTableLifecycleObserver(obs TableLifecycleObserver) {
 	defer c.mu.Unlock()
 	c.tableObserver = obs
 }
+
+// SetEquityIterations sets how many boards are sampled for the equity of a
+// preflop or flop replay snapshot; n <= 0 disables equity annotations.
+func (c *Coordinator) SetEquityIterations(n int) {
+	c.equityIterations.Store(int64(max(n, 0)))
+}
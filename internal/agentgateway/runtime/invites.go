@@ -0,0 +1,293 @@
+package runtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	challengeTTL                 = 5 * time.Minute
+	challengeSweepInterval       = 5 * time.Second
+	closeReasonChallengeDeclined = "challenge_declined"
+	closeReasonChallengeExpired  = "challenge_expired"
+	maxPrivateInvitees           = 64
+)
+
+var (
+	errChallengeNotFound = errors.New("challenge_not_found")
+	errChallengeClosed   = errors.New("challenge_closed")
+	errOpponentNotFound  = errors.New("opponent_not_found")
+	errInvalidOpponent   = errors.New("invalid_opponent")
+	errInviteNotFound    = errors.New("invite_not_found")
+	errInviteClosed      = errors.New("invite_closed")
+	errNotInvited        = errors.New("not_invited")
+	errTooManyInvitees   = errors.New("too_many_invitees")
+)
+
+// inviteJoin is a "challenge" or "private" join resolved to its room and
+// buy-in. Exactly one of challenge and private is set; create is true when
+// the join issues the challenge or opens the private table, which is only
+// stored once the session is.
+type inviteJoin struct {
+	room      *store.Room
+	buyin     int64
+	challenge *store.Challenge
+	private   *store.PrivateTable
+	create    bool
+}
+
+// resolveInvite validates a "challenge" or "private" join. A challenge is
+// issued to an existing agent other than the challenger and accepted only
+// by that agent while it is pending; a private table is joined only by the
+// agents it was opened for.
+func (c *Coordinator) resolveInvite(ctx context.Context, agent *store.Agent, req CreateSessionRequest) (*inviteJoin, error) {
+	inv := &inviteJoin{}
+	var err error
+	if strings.EqualFold(req.JoinMode, "challenge") {
+		if req.ChallengeID != "" {
+			ch, getErr := c.store.GetChallenge(ctx, req.ChallengeID)
+			if getErr != nil {
+				if errors.Is(getErr, store.ErrNotFound) {
+					return nil, errChallengeNotFound
+				}
+				return nil, getErr
+			}
+			if ch.OpponentAgentID != agent.ID {
+				return nil, errChallengeNotFound
+			}
+			if ch.Status != store.ChallengePending || !time.Now().Before(ch.ExpiresAt) {
+				return nil, errChallengeClosed
+			}
+			inv.challenge = ch
+			inv.room, err = c.store.GetRoom(ctx, ch.RoomID)
+		} else {
+			if req.OpponentAgentID == "" || req.OpponentAgentID == agent.ID {
+				return nil, errInvalidOpponent
+			}
+			if _, err := c.store.GetAgentByID(ctx, req.OpponentAgentID); err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					return nil, errOpponentNotFound
+				}
+				return nil, err
+			}
+			if inv.room, err = c.inviteRoom(ctx, req.RoomID); err != nil {
+				return nil, err
+			}
+			inv.challenge = &store.Challenge{
+				ID:                store.NewID(),
+				RoomID:            inv.room.ID,
+				ChallengerAgentID: agent.ID,
+				OpponentAgentID:   req.OpponentAgentID,
+				Status:            store.ChallengePending,
+				ExpiresAt:         time.Now().Add(challengeTTL),
+			}
+			inv.create = true
+		}
+	} else {
+		if req.InviteCode != "" {
+			p, getErr := c.store.GetPrivateTable(ctx, strings.ToUpper(req.InviteCode))
+			if getErr != nil {
+				if errors.Is(getErr, store.ErrNotFound) {
+					return nil, errInviteNotFound
+				}
+				return nil, getErr
+			}
+			if p.Status != store.PrivateTableOpen {
+				return nil, errInviteClosed
+			}
+			if !p.Invited(agent.ID) {
+				return nil, errNotInvited
+			}
+			inv.private = p
+			inv.room, err = c.store.GetRoom(ctx, p.RoomID)
+		} else {
+			invited, listErr := c.inviteList(ctx, agent.ID, req.InviteAgentIDs)
+			if listErr != nil {
+				return nil, listErr
+			}
+			if inv.room, err = c.inviteRoom(ctx, req.RoomID); err != nil {
+				return nil, err
+			}
+			inv.private = &store.PrivateTable{
+				InviteCode:      newInviteCode(),
+				RoomID:          inv.room.ID,
+				CreatorAgentID:  agent.ID,
+				InvitedAgentIDs: invited,
+				Status:          store.PrivateTableOpen,
+			}
+			inv.create = true
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.store.GetAccountBalance(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	buyin, code := resolveBuyin(inv.room, balance, req.BuyinCC)
+	if code != "" {
+		return nil, errors.New(code)
+	}
+	inv.buyin = buyin
+	return inv, nil
+}
+
+// inviteRoom returns the room a challenge or private table is opened in.
+// Tournament and league rooms seat their players themselves.
+func (c *Coordinator) inviteRoom(ctx context.Context, roomID string) (*store.Room, error) {
+	room, err := c.store.GetRoom(ctx, roomID)
+	if err != nil || room.Status != "active" {
+		return nil, errors.New("room_not_found")
+	}
+	switch room.MatchFormat {
+	case "", store.MatchFormatStandard, store.MatchFormatDuplicate:
+		return room, nil
+	}
+	return nil, errors.New("room_not_found")
+}
+
+// inviteList dedupes the agents invited to a private table, leaving out its
+// creator, and checks that they exist. The count is checked first so an
+// oversized list costs no agent lookups.
+func (c *Coordinator) inviteList(ctx context.Context, creatorID string, agentIDs []string) ([]string, error) {
+	// One more than the limit leaves room for the creator listing themself.
+	if len(agentIDs) > maxPrivateInvitees+1 {
+		return nil, errTooManyInvitees
+	}
+	seen := map[string]bool{creatorID: true}
+	out := make([]string, 0, len(agentIDs))
+	for _, id := range agentIDs {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	if len(out) > maxPrivateInvitees {
+		return nil, errTooManyInvitees
+	}
+	for _, id := range out {
+		if _, err := c.store.GetAgentByID(ctx, id); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, errOpponentNotFound
+			}
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// newInviteCode returns a random 10-character code such as "K3QZ7M2XPA".
+func newInviteCode() string {
+	var b [10]byte
+	_, _ = rand.Read(b[:])
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b[:])[:10]
+}
+
+// openInvite stores the challenge or private table a waiting session was
+// just opened for and tells the challenged agent, when it has a session.
+func (c *Coordinator) openInvite(ctx context.Context, inv *inviteJoin, challenger *sessionState) error {
+	if inv.private != nil {
+		return c.store.CreatePrivateTable(ctx, *inv.private)
+	}
+	if err := c.store.CreateChallenge(ctx, *inv.challenge); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if opp := c.byAgent[inv.challenge.OpponentAgentID]; opp != nil && opp.buffer != nil && opp.session.Status != "closed" {
+		opp.buffer.Append("challenge_received", opp.session.ID, map[string]any{
+			"challenge_id":        inv.challenge.ID,
+			"room_id":             inv.challenge.RoomID,
+			"challenger_agent_id": challenger.agent.ID,
+			"challenger_name":     challenger.agent.Name,
+			"expires_at":          inv.challenge.ExpiresAt,
+		})
+	}
+	return nil
+}
+
+// leaveInvite runs after a waiting session of a challenge or private table
+// has closed. The challenge is withdrawn if it was still pending, and a
+// private table that never started is closed once nobody waits at it.
+func (c *Coordinator) leaveInvite(ctx context.Context, sess *sessionState) {
+	if sess.challengeID != "" {
+		if _, err := c.store.CancelChallenge(ctx, sess.challengeID); err != nil {
+			log.Error().Err(err).Str("challenge_id", sess.challengeID).Msg("cancel challenge failed")
+		}
+		return
+	}
+	if sess.inviteCode == "" {
+		return
+	}
+	c.mu.Lock()
+	open := len(c.waiting[sess.inviteCode]) > 0
+	for _, rt := range c.tables {
+		open = open || rt.inviteCode == sess.inviteCode
+	}
+	c.mu.Unlock()
+	if !open {
+		if err := c.store.ClosePrivateTable(ctx, sess.inviteCode); err != nil {
+			log.Error().Err(err).Str("invite_code", sess.inviteCode).Msg("close private table failed")
+		}
+	}
+}
+
+// ListChallenges returns the pending challenges the agent has sent or
+// received.
+func (c *Coordinator) ListChallenges(ctx context.Context, agentID string) ([]store.Challenge, error) {
+	return c.store.ListPendingChallenges(ctx, agentID)
+}
+
+// DeclineChallenge turns down a challenge sent to agentID and closes the
+// challenger's waiting session.
+func (c *Coordinator) DeclineChallenge(ctx context.Context, agentID, challengeID string) error {
+	if err := c.store.RespondChallenge(ctx, challengeID, agentID, store.ChallengeDeclined); err != nil {
+		if errors.Is(err, store.ErrChallengeClosed) {
+			if ch, getErr := c.store.GetChallenge(ctx, challengeID); getErr != nil || ch.OpponentAgentID != agentID {
+				return errChallengeNotFound
+			}
+			return errChallengeClosed
+		}
+		return err
+	}
+	c.closeChallengeWaiters(ctx, challengeID, closeReasonChallengeDeclined)
+	return nil
+}
+
+// sweepChallenges expires the challenges nobody answered in time.
+func (c *Coordinator) sweepChallenges(ctx context.Context, now time.Time) {
+	ids, err := c.store.ExpireChallenges(ctx, now)
+	if err != nil {
+		log.Error().Err(err).Msg("expire challenges failed")
+		return
+	}
+	for _, id := range ids {
+		c.closeChallengeWaiters(ctx, id, closeReasonChallengeExpired)
+	}
+}
+
+func (c *Coordinator) closeChallengeWaiters(ctx context.Context, challengeID, reason string) {
+	c.mu.Lock()
+	waiting := append([]*sessionState{}, c.waiting[challengeID]...)
+	c.mu.Unlock()
+	for _, w := range waiting {
+		_ = c.CloseSessionWithReason(ctx, w.session.ID, reason)
+	}
+}
+
+func IsChallengeNotFound(err error) bool {
+	return errors.Is(err, errChallengeNotFound)
+}
+
+func IsChallengeClosed(err error) bool {
+	return errors.Is(err, errChallengeClosed)
+}
@@ -0,0 +1,114 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/store"
+	"silicon-casino/internal/testutil"
+)
+
+func TestChallengeAndPrivateTableSeatOnlyInvitedAgents(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+	roomID, err := st.CreateRoomWithConfig(ctx, store.Room{
+		Name:         "Ring",
+		MinBuyinCC:   1000,
+		SmallBlindCC: 50,
+		BigBlindCC:   100,
+		MaxSeats:     6,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	coord := NewCoordinator(st, ledger.New(st))
+
+	agents := make([]string, 5)
+	for i := range agents {
+		key := fmt.Sprintf("key-%d", i)
+		if agents[i], err = st.CreateAgent(ctx, fmt.Sprintf("bot-%d", i), key, "claim-"+key); err != nil {
+			t.Fatalf("create agent %d: %v", i, err)
+		}
+		if err := st.EnsureAccount(ctx, agents[i], 100000); err != nil {
+			t.Fatalf("ensure account %d: %v", i, err)
+		}
+	}
+	join := func(i int, req CreateSessionRequest) (*CreateSessionResponse, error) {
+		req.AgentID, req.APIKey, req.RoomID = agents[i], fmt.Sprintf("key-%d", i), roomID
+		return coord.CreateSession(ctx, req)
+	}
+
+	challenge, err := join(0, CreateSessionRequest{JoinMode: "challenge", OpponentAgentID: agents[1]})
+	if err != nil || challenge.ChallengeID == "" || challenge.TableID != "" {
+		t.Fatalf("expected a waiting challenge, got %+v %v", challenge, err)
+	}
+	stranger, err := join(2, CreateSessionRequest{JoinMode: "select"})
+	if err != nil || stranger.TableID != "" {
+		t.Fatalf("expected the stranger to wait for a public table, got %+v %v", stranger, err)
+	}
+	if _, err := join(3, CreateSessionRequest{JoinMode: "challenge", ChallengeID: challenge.ChallengeID}); !errors.Is(err, errChallengeNotFound) {
+		t.Fatalf("expected challenge_not_found for another agent, got %v", err)
+	}
+	pending, err := coord.ListChallenges(ctx, agents[1])
+	if err != nil || len(pending) != 1 || pending[0].ChallengerAgentID != agents[0] {
+		t.Fatalf("expected one incoming challenge, got %+v %v", pending, err)
+	}
+	accepted, err := join(1, CreateSessionRequest{JoinMode: "challenge", ChallengeID: challenge.ChallengeID})
+	if err != nil || accepted.TableID == "" {
+		t.Fatalf("expected the challenge to start a table, got %+v %v", accepted, err)
+	}
+	if err := coord.DeclineChallenge(ctx, agents[1], challenge.ChallengeID); !errors.Is(err, errChallengeClosed) {
+		t.Fatalf("expected an accepted challenge to be closed, got %v", err)
+	}
+	coord.mu.Lock()
+	rt := coord.tables[accepted.TableID]
+	coord.mu.Unlock()
+	rt.mu.Lock()
+	seated := rt.seatedCount()
+	rt.mu.Unlock()
+	if seated != 2 {
+		t.Fatalf("expected a heads-up challenge table, got %d seated", seated)
+	}
+
+	private, err := join(3, CreateSessionRequest{JoinMode: "private", InviteAgentIDs: []string{agents[4]}})
+	if err != nil || private.InviteCode == "" || private.TableID != "" {
+		t.Fatalf("expected a waiting private table, got %+v %v", private, err)
+	}
+	if err := coord.CloseSession(ctx, stranger.SessionID); err != nil {
+		t.Fatalf("close stranger: %v", err)
+	}
+	if _, err := join(2, CreateSessionRequest{JoinMode: "private", InviteCode: private.InviteCode}); !errors.Is(err, errNotInvited) {
+		t.Fatalf("expected not_invited, got %v", err)
+	}
+	guest, err := join(4, CreateSessionRequest{JoinMode: "private", InviteCode: private.InviteCode})
+	if err != nil || guest.TableID == "" || guest.InviteCode != private.InviteCode {
+		t.Fatalf("expected the guest to start the private table, got %+v %v", guest, err)
+	}
+	if public, err := join(2, CreateSessionRequest{JoinMode: "select"}); err != nil || public.TableID != "" {
+		t.Fatalf("expected public joiners to stay off private tables, got %+v %v", public, err)
+	}
+}
+
+func TestInviteListRejectsTooManyBeforeLookups(t *testing.T) {
+	// The coordinator has no store, so any agent lookup would panic.
+	c := NewCoordinator(nil, nil)
+	ids := make([]string, 0, maxPrivateInvitees+2)
+	for i := 0; i <= maxPrivateInvitees; i++ {
+		ids = append(ids, fmt.Sprintf("agent-%d", i))
+	}
+	if _, err := c.inviteList(context.Background(), "creator", ids); !errors.Is(err, errTooManyInvitees) {
+		t.Fatalf("expected too_many_invitees, got %v", err)
+	}
+	ids = append(ids, "agent-0")
+	if _, err := c.inviteList(context.Background(), "creator", ids); !errors.Is(err, errTooManyInvitees) {
+		t.Fatalf("expected too_many_invitees for an oversized raw list, got %v", err)
+	}
+	if status, code := MapSessionCreateError(errTooManyInvitees); status != http.StatusBadRequest || code != "too_many_invitees" {
+		t.Fatalf("expected 400 too_many_invitees, got %d %s", status, code)
+	}
+}
@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// leaderboardRefreshInterval is how stale the public leaderboard may get.
+	leaderboardRefreshInterval = time.Minute
+	// leaderboardRefreshTimeout bounds one rebuild of the rollups so a slow
+	// run is abandoned before the next one is due.
+	leaderboardRefreshTimeout = 50 * time.Second
+)
+
+// startLeaderboardRefresher rebuilds the leaderboard rollups now and then
+// every leaderboardRefreshInterval until ctx is done. The rebuild scans the
+// settled hands, so it runs on its own goroutine rather than the janitor's,
+// which enforces turn deadlines.
+func (c *Coordinator) startLeaderboardRefresher(ctx context.Context) {
+	ticker := time.NewTicker(leaderboardRefreshInterval)
+	go func() {
+		defer ticker.Stop()
+		c.refreshLeaderboard(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				c.refreshLeaderboard(ctx, now)
+			}
+		}
+	}()
+}
+
+// refreshLeaderboard rebuilds the leaderboard rollups the public
+// leaderboard pages through.
+func (c *Coordinator) refreshLeaderboard(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, leaderboardRefreshTimeout)
+	defer cancel()
+	if err := c.store.RefreshLeaderboard(ctx, now); err != nil {
+		log.Error().Err(err).Msg("refresh leaderboard failed")
+	}
+}
@@ -0,0 +1,382 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	closeReasonLeagueMatchComplete = "league_match_complete"
+	closeReasonLeagueMatchFailed   = "league_match_failed"
+	closeReasonLeagueCompleted     = "league_completed"
+	leagueSweepInterval            = 5 * time.Second
+)
+
+var (
+	errLeagueNotFound       = errors.New("league_not_found")
+	errLeagueClosed         = errors.New("league_closed")
+	errNotLeagueParticipant = errors.New("not_league_participant")
+)
+
+// leagueMatch is the league fixture a heads-up table plays: hands hands
+// between the two agents of one pairing.
+type leagueMatch struct {
+	leagueID string
+	matchID  string
+	round    int
+	hands    int
+	// forfeitAgentID left the match before its last hand.
+	forfeitAgentID string
+}
+
+// leagueMatchOver reports whether the hand that just settled was the last
+// one of a league match. Caller must hold rt.mu.
+func (rt *tableRuntime) leagueMatchOver() bool {
+	return rt.league != nil && rt.engine.State.HandNumber >= rt.league.hands
+}
+
+// joinLeague opens a session that waits in a league until the agent's next
+// match can be played, which is as soon as its opponent is waiting too.
+// The session closes with the match; the agent joins again for the next.
+func (c *Coordinator) joinLeague(ctx context.Context, agent *store.Agent, req CreateSessionRequest) (*CreateSessionResponse, error) {
+	l, err := c.store.GetLeague(ctx, req.LeagueID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, errLeagueNotFound
+		}
+		return nil, err
+	}
+	if l.Status != store.LeagueRunning {
+		return nil, errLeagueClosed
+	}
+	standings, err := c.store.ListLeagueStandings(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	participant := false
+	for _, s := range standings {
+		participant = participant || s.AgentID == agent.ID
+	}
+	if !participant {
+		return nil, errNotLeagueParticipant
+	}
+	room, err := c.store.GetRoom(ctx, l.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.store.GetAccountBalance(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	buyin, code := resolveBuyin(room, balance, req.BuyinCC)
+	if code != "" {
+		return nil, errors.New(code)
+	}
+
+	sess := store.AgentSession{
+		ID:        store.NewID(),
+		AgentID:   agent.ID,
+		RoomID:    room.ID,
+		JoinMode:  "league",
+		Status:    "waiting",
+		ExpiresAt: time.Now().Add(sessionTTL),
+	}
+	joiner := &sessionState{session: sess, agent: agent, buyinCC: buyin, buffer: NewEventBuffer(500), leagueID: l.ID}
+	c.mu.Lock()
+	if old := c.byAgent[agent.ID]; old != nil && old.session.Status != "closed" {
+		c.mu.Unlock()
+		return nil, errors.New("agent_already_in_session")
+	}
+	c.sessions[sess.ID] = joiner
+	c.byAgent[agent.ID] = joiner
+	c.mu.Unlock()
+	if err := c.store.CreateAgentSession(ctx, sess); err != nil {
+		c.mu.Lock()
+		delete(c.sessions, sess.ID)
+		delete(c.byAgent, agent.ID)
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if joiner.session.Status != "closed" {
+		c.leagueWaiting[l.ID] = append(c.leagueWaiting[l.ID], joiner)
+		joiner.buffer.Append("session_joined", sess.ID, map[string]any{
+			"table_id":  "",
+			"room_id":   room.ID,
+			"seat_id":   nil,
+			"league_id": l.ID,
+		})
+	}
+	c.mu.Unlock()
+	c.startLeagueMatches(ctx, l)
+	return c.responseForSession(joiner), nil
+}
+
+// sweepLeagues advances every running league; see advanceLeague.
+func (c *Coordinator) sweepLeagues(ctx context.Context) {
+	ids, err := c.store.ListRunningLeagueIDs(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("list running leagues failed")
+		return
+	}
+	for _, id := range ids {
+		c.advanceLeague(ctx, id)
+	}
+}
+
+// advanceLeague pairs the next round of a league once every match of its
+// current round is over, completes the league after its last round, and
+// seats the waiting agents of the round's pending matches.
+func (c *Coordinator) advanceLeague(ctx context.Context, leagueID string) {
+	l, err := c.store.GetLeague(ctx, leagueID)
+	if err != nil || l.Status != store.LeagueRunning {
+		return
+	}
+	open := 0
+	if l.CurrentRound > 0 {
+		if open, err = c.store.CountOpenLeagueMatches(ctx, l.ID, l.CurrentRound); err != nil {
+			log.Error().Err(err).Str("league_id", l.ID).Msg("count open league matches failed")
+			return
+		}
+	}
+	if open == 0 {
+		if l.CurrentRound >= l.Rounds {
+			c.completeLeague(ctx, l)
+			return
+		}
+		pairings, err := c.pairLeagueRound(ctx, l)
+		if err != nil {
+			log.Error().Err(err).Str("league_id", l.ID).Int("round", l.CurrentRound+1).Msg("pair league round failed")
+			return
+		}
+		if err := c.store.StartLeagueRound(ctx, l.ID, l.CurrentRound+1, pairings); err != nil && !errors.Is(err, store.ErrLeagueRoundStarted) {
+			log.Error().Err(err).Str("league_id", l.ID).Int("round", l.CurrentRound+1).Msg("start league round failed")
+			return
+		}
+		if l, err = c.store.GetLeague(ctx, leagueID); err != nil {
+			return
+		}
+	}
+	c.startLeagueMatches(ctx, l)
+}
+
+// pairLeagueRound pairs the round after l.CurrentRound. A round robin
+// follows its fixed schedule in seed order; a Swiss round pairs the league
+// table top down, avoiding rematches and repeated byes.
+func (c *Coordinator) pairLeagueRound(ctx context.Context, l *store.League) ([][2]string, error) {
+	standings, err := c.store.ListLeagueStandings(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	if l.Format == store.LeagueRoundRobin {
+		sort.Slice(standings, func(i, j int) bool { return standings[i].Seed < standings[j].Seed })
+		players := make([]string, 0, len(standings))
+		for _, s := range standings {
+			players = append(players, s.AgentID)
+		}
+		return game.RoundRobinRound(players, l.CurrentRound+1), nil
+	}
+	matches, err := c.store.ListLeagueMatches(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	met := map[string]map[string]bool{}
+	hadBye := map[string]bool{}
+	for _, m := range matches {
+		if m.AgentBID == "" {
+			hadBye[m.AgentAID] = true
+			continue
+		}
+		for _, pair := range [][2]string{{m.AgentAID, m.AgentBID}, {m.AgentBID, m.AgentAID}} {
+			if met[pair[0]] == nil {
+				met[pair[0]] = map[string]bool{}
+			}
+			met[pair[0]][pair[1]] = true
+		}
+	}
+	ranked := make([]string, 0, len(standings))
+	for _, s := range standings {
+		ranked = append(ranked, s.AgentID)
+	}
+	return game.SwissRound(ranked, met, hadBye), nil
+}
+
+// completeLeague ends a league whose last round is over and closes the
+// sessions still waiting in it.
+func (c *Coordinator) completeLeague(ctx context.Context, l *store.League) {
+	if err := c.store.FinishLeague(ctx, l.ID, store.LeagueCompleted); err != nil {
+		log.Error().Err(err).Str("league_id", l.ID).Msg("finish league failed")
+		return
+	}
+	c.mu.Lock()
+	waiting := c.leagueWaiting[l.ID]
+	delete(c.leagueWaiting, l.ID)
+	c.mu.Unlock()
+	for _, p := range waiting {
+		_ = c.CloseSessionWithReason(ctx, p.session.ID, closeReasonLeagueCompleted)
+	}
+}
+
+// startLeagueMatches seats every pending match of the league's current
+// round whose two agents are both waiting in the league.
+func (c *Coordinator) startLeagueMatches(ctx context.Context, l *store.League) {
+	if l.CurrentRound == 0 {
+		return
+	}
+	matches, err := c.store.ListLeagueMatches(ctx, l.ID)
+	if err != nil {
+		log.Error().Err(err).Str("league_id", l.ID).Msg("list league matches failed")
+		return
+	}
+	room, err := c.store.GetRoom(ctx, l.RoomID)
+	if err != nil {
+		log.Error().Err(err).Str("league_id", l.ID).Str("room_id", l.RoomID).Msg("get league room failed")
+		return
+	}
+	type ready struct {
+		match  store.LeagueMatch
+		seated []*sessionState
+	}
+	var start []ready
+	c.mu.Lock()
+	for _, m := range matches {
+		if m.Round != l.CurrentRound || m.Status != store.LeagueMatchPending {
+			continue
+		}
+		queue := c.leagueWaiting[l.ID]
+		a, b := waitingAgent(queue, m.AgentAID), waitingAgent(queue, m.AgentBID)
+		if a == nil || b == nil {
+			continue
+		}
+		c.leagueWaiting[l.ID] = removeWaiting(removeWaiting(queue, a), b)
+		start = append(start, ready{match: m, seated: []*sessionState{a, b}})
+	}
+	if len(c.leagueWaiting[l.ID]) == 0 {
+		delete(c.leagueWaiting, l.ID)
+	}
+	c.mu.Unlock()
+	for _, r := range start {
+		c.startLeagueMatch(ctx, l, room, r.match, r.seated)
+	}
+}
+
+func waitingAgent(queue []*sessionState, agentID string) *sessionState {
+	for _, p := range queue {
+		if p.agent.ID == agentID && p.session.Status == "waiting" {
+			return p
+		}
+	}
+	return nil
+}
+
+// startLeagueMatch plays match between the two waiting sessions of seated
+// on a new table. Both agents buy in for the smaller of their buy-ins. When
+// the table cannot be opened the sessions are closed, and the match stays
+// pending for the next time both agents join.
+func (c *Coordinator) startLeagueMatch(ctx context.Context, l *store.League, room *store.Room, m store.LeagueMatch, seated []*sessionState) {
+	tableID := store.NewID()
+	buyin := min(seated[0].buyinCC, seated[1].buyinCC)
+	c.mu.Lock()
+	assignments := make([]store.SeatAssignment, 0, len(seated))
+	for seat, ss := range seated {
+		ss.buyinCC = buyin
+		ss.seat = seat
+		ss.session.TableID = tableID
+		ss.session.SeatID = &seat
+		ss.session.Status = "active"
+		assignments = append(assignments, store.SeatAssignment{
+			SessionID: ss.session.ID,
+			AgentID:   ss.agent.ID,
+			Seat:      seat,
+			BuyinCC:   buyin,
+		})
+	}
+	c.mu.Unlock()
+
+	if err := c.store.StartLeagueMatch(ctx, m.ID, tableID, room.ID, room.SmallBlindCC, room.BigBlindCC, assignments); err != nil {
+		log.Error().Err(err).Str("league_id", l.ID).Str("match_id", m.ID).Msg("start league match failed")
+		c.mu.Lock()
+		for _, ss := range seated {
+			ss.session.TableID = ""
+			ss.session.SeatID = nil
+			ss.session.Status = "waiting"
+			ss.seat = 0
+		}
+		c.mu.Unlock()
+		for _, ss := range seated {
+			_ = c.CloseSessionWithReason(ctx, ss.session.ID, closeReasonLeagueMatchFailed)
+		}
+		return
+	}
+
+	rt, err := c.startTableRuntime(ctx, tableID, room, seated, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Str("league_id", l.ID).Str("match_id", m.ID).Str("table_id", tableID).Msg("start league match runtime failed")
+		for _, ss := range seated {
+			ss.session.Status = "closed"
+			if ss.buffer != nil {
+				ss.buffer.Append("session_closed", ss.session.ID, map[string]any{"reason": closeReasonLeagueMatchFailed})
+				ss.buffer.Close()
+			}
+		}
+		c.releaseSessions(ctx, seated)
+		_ = c.store.MarkTableStatusByID(ctx, tableID, tableStatusClosed)
+		if err := c.store.ResetLeagueMatch(ctx, m.ID); err != nil {
+			log.Error().Err(err).Str("match_id", m.ID).Msg("reset league match failed")
+		}
+		return
+	}
+	rt.league = &leagueMatch{leagueID: l.ID, matchID: m.ID, round: m.Round, hands: l.HandsPerMatch}
+
+	c.mu.Lock()
+	c.tables[tableID] = rt
+	for _, ss := range seated {
+		ss.runtime = rt
+	}
+	for _, ss := range seated {
+		opponent := seated[1-ss.seat]
+		if ss.buffer != nil {
+			ss.buffer.Append("league_match_started", ss.session.ID, map[string]any{
+				"league_id":         l.ID,
+				"match_id":          m.ID,
+				"round":             m.Round,
+				"hands":             l.HandsPerMatch,
+				"table_id":          tableID,
+				"seat_id":           ss.seat,
+				"opponent_agent_id": opponent.agent.ID,
+			})
+		}
+		c.emitSessionJoined(ss)
+	}
+	for _, ss := range seated {
+		c.emitStateSnapshot(ss)
+	}
+	c.emitTurnStarted(rt)
+	c.emitPublicSnapshot(rt)
+	observer := c.tableObserver
+	c.mu.Unlock()
+	if observer != nil {
+		observer.OnTableStarted(TableMeta{TableID: tableID, RoomID: room.ID}, rt.publicBuffer)
+	}
+}
+
+// finishLeagueMatch records the result of the league match of a table that
+// has just been closed and moves its league on. Must be called without
+// holding rt.mu.
+func (c *Coordinator) finishLeagueMatch(ctx context.Context, rt *tableRuntime) {
+	rt.mu.Lock()
+	forfeit := rt.league.forfeitAgentID
+	rt.mu.Unlock()
+	if err := c.store.FinishLeagueMatch(ctx, rt.league.matchID, forfeit); err != nil && !errors.Is(err, store.ErrLeagueMatchClosed) {
+		log.Error().Err(err).Str("league_id", rt.league.leagueID).Str("match_id", rt.league.matchID).Msg("finish league match failed")
+	}
+	c.advanceLeague(ctx, rt.league.leagueID)
+}
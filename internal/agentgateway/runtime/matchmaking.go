@@ -0,0 +1,405 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	closeReasonRankedMatchFailed = "ranked_match_failed"
+	rankedSweepInterval          = time.Second
+	// A ranked pairing is accepted right away within rankedBaseGap rating
+	// points, widening by rankedGapPerSecond for every second the longer
+	// waiting agent has queued, and open to any rating after rankedOpenAfter.
+	rankedBaseGap      = 50.0
+	rankedGapPerSecond = 5.0
+	rankedOpenAfter    = 2 * time.Minute
+	// Agents that shared a table within rankedRecentWindow are only paired
+	// again once one of them has queued for rankedRematchAfter.
+	rankedRecentWindow  = time.Hour
+	rankedRematchAfter  = time.Minute
+	rankedWaitSmoothing = 0.2
+)
+
+// rankedEntry is what a "ranked" session is paired by, looked up once when
+// it joins the queue.
+type rankedEntry struct {
+	rating   float64
+	ownerID  string
+	recent   map[string]bool
+	queuedAt time.Time
+}
+
+// joinRanked opens a session that queues in a room's ranked matchmaking
+// until an opponent of similar rating is waiting too, then plays heads-up
+// against it at a table of its own.
+func (c *Coordinator) joinRanked(ctx context.Context, agent *store.Agent, req CreateSessionRequest) (*CreateSessionResponse, error) {
+	room, err := c.store.GetRoom(ctx, req.RoomID)
+	if err != nil || room.Status != "active" {
+		return nil, errors.New("room_not_found")
+	}
+	// Duplicate, tournament and league rooms seat their players
+	// themselves.
+	if room.MatchFormat != "" && room.MatchFormat != store.MatchFormatStandard {
+		return nil, errors.New("room_not_found")
+	}
+	balance, err := c.store.GetAccountBalance(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	buyin, code := resolveBuyin(room, balance, req.BuyinCC)
+	if code != "" {
+		return nil, errors.New(code)
+	}
+	entry, err := c.newRankedEntry(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := store.AgentSession{
+		ID:        store.NewID(),
+		AgentID:   agent.ID,
+		RoomID:    room.ID,
+		JoinMode:  "ranked",
+		Status:    "waiting",
+		ExpiresAt: time.Now().Add(sessionTTL),
+	}
+	joiner := &sessionState{session: sess, agent: agent, buyinCC: buyin, buffer: NewEventBuffer(500), ranked: entry}
+	c.mu.Lock()
+	if old := c.byAgent[agent.ID]; old != nil && old.session.Status != "closed" {
+		c.mu.Unlock()
+		return nil, errors.New("agent_already_in_session")
+	}
+	c.sessions[sess.ID] = joiner
+	c.byAgent[agent.ID] = joiner
+	c.mu.Unlock()
+	if err := c.store.CreateAgentSession(ctx, sess); err != nil {
+		c.mu.Lock()
+		delete(c.sessions, sess.ID)
+		delete(c.byAgent, agent.ID)
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if joiner.session.Status != "closed" {
+		entry.queuedAt = time.Now()
+		c.rankedWaiting[room.ID] = append(c.rankedWaiting[room.ID], joiner)
+		joiner.buffer.Append("session_joined", sess.ID, map[string]any{
+			"table_id": "",
+			"room_id":  room.ID,
+			"seat_id":  nil,
+			"ranked":   true,
+			"rating":   entry.rating,
+		})
+	}
+	c.mu.Unlock()
+	c.matchRanked(ctx, room)
+	return c.responseForSession(joiner), nil
+}
+
+func (c *Coordinator) newRankedEntry(ctx context.Context, agentID string) (*rankedEntry, error) {
+	rating, err := c.matchRating(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := c.store.GetAgentOwner(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.store.ListRecentOpponents(ctx, agentID, time.Now().Add(-rankedRecentWindow))
+	if err != nil {
+		return nil, err
+	}
+	recent := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		recent[id] = true
+	}
+	return &rankedEntry{rating: rating, ownerID: owner, recent: recent}, nil
+}
+
+// matchRating is the rating ranked matchmaking pairs an agent by: its
+// Glicko-2 rating, game.DefaultRating before it has been rated.
+func (c *Coordinator) matchRating(ctx context.Context, agentID string) (float64, error) {
+	r, err := c.store.GetAgentRating(ctx, agentID)
+	if errors.Is(err, store.ErrNotFound) {
+		return game.DefaultRating, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return r.Rating, nil
+}
+
+// rankedGap is the widest rating difference a pairing is accepted at once
+// the longer waiting agent has queued for waited.
+func rankedGap(waited time.Duration) float64 {
+	if waited >= rankedOpenAfter {
+		return math.Inf(1)
+	}
+	return rankedBaseGap + rankedGapPerSecond*waited.Seconds()
+}
+
+// rankedCompatible reports whether a may be paired with b, where a has
+// queued at least as long as b. Agents of the same owner never are.
+func rankedCompatible(a, b *sessionState, now time.Time) bool {
+	if a.agent.ID == b.agent.ID {
+		return false
+	}
+	if a.ranked.ownerID != "" && a.ranked.ownerID == b.ranked.ownerID {
+		return false
+	}
+	waited := now.Sub(a.ranked.queuedAt)
+	if (a.ranked.recent[b.agent.ID] || b.ranked.recent[a.agent.ID]) && waited < rankedRematchAfter {
+		return false
+	}
+	return math.Abs(a.ranked.rating-b.ranked.rating) <= rankedGap(waited)
+}
+
+// pairRanked pairs the queue longest waiting first, each agent with the
+// closest rated agent it is compatible with.
+func pairRanked(queue []*sessionState, now time.Time) [][2]*sessionState {
+	order := append([]*sessionState{}, queue...)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].ranked.queuedAt.Before(order[j].ranked.queuedAt)
+	})
+	paired := make(map[*sessionState]bool, len(order))
+	var pairs [][2]*sessionState
+	for i, a := range order {
+		if paired[a] {
+			continue
+		}
+		var best *sessionState
+		bestGap := math.Inf(1)
+		for _, b := range order[i+1:] {
+			if paired[b] || !rankedCompatible(a, b, now) {
+				continue
+			}
+			if gap := math.Abs(a.ranked.rating - b.ranked.rating); best == nil || gap < bestGap {
+				best, bestGap = b, gap
+			}
+		}
+		if best != nil {
+			paired[a], paired[best] = true, true
+			pairs = append(pairs, [2]*sessionState{a, best})
+		}
+	}
+	return pairs
+}
+
+// sweepRanked pairs every room's ranked queue again, as the accepted rating
+// gaps have widened since it last was.
+func (c *Coordinator) sweepRanked(ctx context.Context) {
+	c.mu.Lock()
+	roomIDs := make([]string, 0, len(c.rankedWaiting))
+	for id := range c.rankedWaiting {
+		roomIDs = append(roomIDs, id)
+	}
+	c.mu.Unlock()
+	for _, id := range roomIDs {
+		room, err := c.store.GetRoom(ctx, id)
+		if err != nil {
+			log.Error().Err(err).Str("room_id", id).Msg("get ranked room failed")
+			continue
+		}
+		c.matchRanked(ctx, room)
+	}
+}
+
+// matchRanked takes the pairs it can make off the room's ranked queue and
+// seats each at a table of its own.
+func (c *Coordinator) matchRanked(ctx context.Context, room *store.Room) {
+	now := time.Now()
+	c.mu.Lock()
+	queue := c.rankedWaiting[room.ID]
+	pairs := pairRanked(queue, now)
+	for _, p := range pairs {
+		queue = removeWaiting(removeWaiting(queue, p[0]), p[1])
+		for _, ss := range p {
+			c.recordRankedWaitLocked(room.ID, now.Sub(ss.ranked.queuedAt))
+		}
+	}
+	if len(queue) > 0 {
+		c.rankedWaiting[room.ID] = queue
+	} else {
+		delete(c.rankedWaiting, room.ID)
+	}
+	c.mu.Unlock()
+	for _, p := range pairs {
+		c.startRankedMatch(ctx, room, p[:])
+	}
+}
+
+// recordRankedWaitLocked folds how long a paired agent queued into the
+// room's running average wait. Caller must hold c.mu.
+func (c *Coordinator) recordRankedWaitLocked(roomID string, waited time.Duration) {
+	if avg, ok := c.rankedWait[roomID]; ok {
+		waited = avg + time.Duration(rankedWaitSmoothing*float64(waited-avg))
+	}
+	c.rankedWait[roomID] = waited
+}
+
+// startRankedMatch seats the two waiting sessions of seated at a new table.
+// When the table cannot be opened both sessions are closed.
+func (c *Coordinator) startRankedMatch(ctx context.Context, room *store.Room, seated []*sessionState) {
+	tableID := store.NewID()
+	c.mu.Lock()
+	assignments := make([]store.SeatAssignment, 0, len(seated))
+	for seat, ss := range seated {
+		ss.seat = seat
+		ss.session.TableID = tableID
+		ss.session.SeatID = &seat
+		ss.session.Status = "active"
+		assignments = append(assignments, store.SeatAssignment{
+			SessionID: ss.session.ID,
+			AgentID:   ss.agent.ID,
+			Seat:      seat,
+			BuyinCC:   ss.buyinCC,
+		})
+	}
+	c.mu.Unlock()
+
+	if err := c.store.StartMatchedTable(ctx, tableID, room.ID, room.SmallBlindCC, room.BigBlindCC, assignments); err != nil {
+		log.Error().Err(err).Str("room_id", room.ID).Str("table_id", tableID).Msg("start ranked match failed")
+		c.mu.Lock()
+		for _, ss := range seated {
+			ss.session.TableID = ""
+			ss.session.SeatID = nil
+			ss.session.Status = "waiting"
+			ss.seat = 0
+		}
+		c.mu.Unlock()
+		for _, ss := range seated {
+			_ = c.CloseSessionWithReason(ctx, ss.session.ID, closeReasonRankedMatchFailed)
+		}
+		return
+	}
+
+	rt, err := c.startTableRuntime(ctx, tableID, room, seated, nil, nil, nil)
+	if err != nil {
+		log.Error().Err(err).Str("room_id", room.ID).Str("table_id", tableID).Msg("start ranked match runtime failed")
+		for _, ss := range seated {
+			ss.session.Status = "closed"
+			if ss.buffer != nil {
+				ss.buffer.Append("session_closed", ss.session.ID, map[string]any{"reason": closeReasonRankedMatchFailed})
+				ss.buffer.Close()
+			}
+		}
+		c.releaseSessions(ctx, seated)
+		_ = c.store.MarkTableStatusByID(ctx, tableID, tableStatusClosed)
+		return
+	}
+	rt.ranked = true
+
+	c.mu.Lock()
+	c.tables[tableID] = rt
+	for _, ss := range seated {
+		ss.runtime = rt
+	}
+	for _, ss := range seated {
+		opponent := seated[1-ss.seat]
+		if ss.buffer != nil {
+			ss.buffer.Append("ranked_match_started", ss.session.ID, map[string]any{
+				"table_id":          tableID,
+				"seat_id":           ss.seat,
+				"rating":            ss.ranked.rating,
+				"opponent_agent_id": opponent.agent.ID,
+				"opponent_rating":   opponent.ranked.rating,
+			})
+		}
+		c.emitSessionJoined(ss)
+	}
+	for _, ss := range seated {
+		c.emitStateSnapshot(ss)
+	}
+	c.emitTurnStarted(rt)
+	c.emitPublicSnapshot(rt)
+	observer := c.tableObserver
+	c.mu.Unlock()
+	if observer != nil {
+		observer.OnTableStarted(TableMeta{TableID: tableID, RoomID: room.ID}, rt.publicBuffer)
+	}
+}
+
+// GetSessionStatus reports whether a session is still queued, and where,
+// or already seated at a table.
+func (c *Coordinator) GetSessionStatus(sessionID string) (SessionStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sess := c.sessions[sessionID]
+	if sess == nil {
+		return SessionStatus{}, errSessionNotFound
+	}
+	out := SessionStatus{
+		SessionID: sessionID,
+		Status:    sess.session.Status,
+		JoinMode:  sess.session.JoinMode,
+		RoomID:    sess.session.RoomID,
+		TableID:   sess.session.TableID,
+		SeatID:    sess.session.SeatID,
+	}
+	if sess.runtime != nil || sess.session.Status != "waiting" {
+		return out, nil
+	}
+	switch {
+	case sess.ranked != nil:
+		out.Queue = c.rankedQueueStatusLocked(sess, time.Now())
+	case sess.leagueID != "":
+		out.Queue = queueStatus(c.leagueWaiting[sess.leagueID], sess)
+	case sess.director == nil:
+		out.Queue = queueStatus(c.waiting[sess.waitKey()], sess)
+	}
+	return out, nil
+}
+
+func queueStatus(queue []*sessionState, sess *sessionState) *QueueStatus {
+	for i, w := range queue {
+		if w == sess {
+			return &QueueStatus{Position: i + 1, QueueSize: len(queue)}
+		}
+	}
+	return nil
+}
+
+// rankedQueueStatusLocked places sess in its room's ranked queue, longest
+// waiting first, and estimates its remaining wait from how long the agents
+// paired there lately had queued. Caller must hold c.mu.
+func (c *Coordinator) rankedQueueStatusLocked(sess *sessionState, now time.Time) *QueueStatus {
+	queue := c.rankedWaiting[sess.session.RoomID]
+	found := false
+	position := 1
+	for _, w := range queue {
+		if w == sess {
+			found = true
+		} else if w.ranked.queuedAt.Before(sess.ranked.queuedAt) {
+			position++
+		}
+	}
+	if !found {
+		return nil
+	}
+	waited := now.Sub(sess.ranked.queuedAt)
+	rating := sess.ranked.rating
+	out := &QueueStatus{
+		Position:  position,
+		QueueSize: len(queue),
+		WaitedMS:  waited.Milliseconds(),
+		Rating:    &rating,
+	}
+	if gap := rankedGap(waited); !math.IsInf(gap, 1) {
+		out.RatingGap = &gap
+	}
+	if avg, ok := c.rankedWait[sess.session.RoomID]; ok {
+		eta := max(avg-waited, 0).Milliseconds()
+		out.EstimatedWaitMS = &eta
+	}
+	return out
+}
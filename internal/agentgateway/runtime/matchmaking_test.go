@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"silicon-casino/internal/store"
+)
+
+func rankedWaiter(agentID, ownerID string, rating float64, queuedAt time.Time, recent ...string) *sessionState {
+	entry := &rankedEntry{rating: rating, ownerID: ownerID, recent: map[string]bool{}, queuedAt: queuedAt}
+	for _, id := range recent {
+		entry.recent[id] = true
+	}
+	return &sessionState{agent: &store.Agent{ID: agentID}, ranked: entry}
+}
+
+func TestPairRankedPrefersClosestRating(t *testing.T) {
+	now := time.Now()
+	a := rankedWaiter("a", "", 1500, now.Add(-3*time.Second))
+	b := rankedWaiter("b", "", 1540, now.Add(-2*time.Second))
+	c := rankedWaiter("c", "", 1510, now.Add(-time.Second))
+	pairs := pairRanked([]*sessionState{b, c, a}, now)
+	if len(pairs) != 1 || pairs[0][0] != a || pairs[0][1] != c {
+		t.Fatalf("expected a paired with c, got %v", pairs)
+	}
+}
+
+func TestPairRankedWidensGapWithWait(t *testing.T) {
+	now := time.Now()
+	a := rankedWaiter("a", "", 1500, now)
+	b := rankedWaiter("b", "", 1700, now)
+	if pairs := pairRanked([]*sessionState{a, b}, now); len(pairs) != 0 {
+		t.Fatalf("expected no pairing across a 200 point gap at once, got %d", len(pairs))
+	}
+	if pairs := pairRanked([]*sessionState{a, b}, now.Add(30*time.Second)); len(pairs) != 1 {
+		t.Fatalf("expected a pairing after 30s, got %d", len(pairs))
+	}
+	b.ranked.rating = 10000
+	if pairs := pairRanked([]*sessionState{a, b}, now.Add(rankedOpenAfter)); len(pairs) != 1 {
+		t.Fatalf("expected any gap to pair after %s, got %d", rankedOpenAfter, len(pairs))
+	}
+}
+
+func TestPairRankedAvoidsRematchesAndSameOwner(t *testing.T) {
+	now := time.Now()
+	a := rankedWaiter("a", "", 0, now, "b")
+	b := rankedWaiter("b", "", 0, now)
+	if pairs := pairRanked([]*sessionState{a, b}, now); len(pairs) != 0 {
+		t.Fatalf("expected recent opponents to stay apart, got %d", len(pairs))
+	}
+	if pairs := pairRanked([]*sessionState{a, b}, now.Add(rankedRematchAfter)); len(pairs) != 1 {
+		t.Fatalf("expected a rematch after %s, got %d", rankedRematchAfter, len(pairs))
+	}
+
+	c := rankedWaiter("c", "owner-1", 0, now)
+	d := rankedWaiter("d", "owner-1", 0, now)
+	if pairs := pairRanked([]*sessionState{c, d}, now.Add(time.Hour)); len(pairs) != 0 {
+		t.Fatalf("expected agents of one owner never to pair, got %d", len(pairs))
+	}
+}
@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/store"
+	"silicon-casino/internal/testutil"
+)
+
+func TestMultiwayTableStartsAtMinPlayersAndSeatsLateJoiner(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+	roomID, err := st.CreateRoomWithConfig(ctx, store.Room{
+		Name:         "Ring",
+		MinBuyinCC:   1000,
+		SmallBlindCC: 50,
+		BigBlindCC:   100,
+		MaxSeats:     4,
+		MinPlayers:   3,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	coord := NewCoordinator(st, ledger.New(st))
+
+	join := func(i int) *CreateSessionResponse {
+		t.Helper()
+		key := fmt.Sprintf("key-%d", i)
+		agentID, err := st.CreateAgent(ctx, fmt.Sprintf("bot-%d", i), key, "claim-"+key)
+		if err != nil {
+			t.Fatalf("create agent %d: %v", i, err)
+		}
+		if err := st.EnsureAccount(ctx, agentID, 100000); err != nil {
+			t.Fatalf("ensure account %d: %v", i, err)
+		}
+		res, err := coord.CreateSession(ctx, CreateSessionRequest{AgentID: agentID, APIKey: key, JoinMode: "select", RoomID: roomID})
+		if err != nil {
+			t.Fatalf("create session %d: %v", i, err)
+		}
+		return res
+	}
+
+	for i := 0; i < 2; i++ {
+		if res := join(i); res.TableID != "" {
+			t.Fatalf("expected agent %d to wait, got table %s", i, res.TableID)
+		}
+	}
+	third := join(2)
+	if third.TableID == "" || third.SeatID == nil || *third.SeatID != 2 {
+		t.Fatalf("expected third agent to start the table at seat 2, got %+v", third)
+	}
+
+	coord.mu.Lock()
+	rt := coord.tables[third.TableID]
+	coord.mu.Unlock()
+	rt.mu.Lock()
+	if got := rt.seatedCount(); got != 3 {
+		t.Fatalf("expected 3 seated players, got %d", got)
+	}
+	if got := rt.engine.State.ActivePlayers(); got != 3 {
+		t.Fatalf("expected 3 players dealt in, got %d", got)
+	}
+	rt.mu.Unlock()
+
+	late := join(3)
+	if late.TableID != third.TableID || late.SeatID == nil || *late.SeatID != 3 {
+		t.Fatalf("expected late joiner at seat 3 of the running table, got %+v", late)
+	}
+	state, err := coord.GetState(late.SessionID)
+	if err != nil {
+		t.Fatalf("get late joiner state: %v", err)
+	}
+	if len(state.MyHoleCards) != 0 || len(state.LegalActions) != 0 {
+		t.Fatalf("late joiner must sit out the current hand, got %+v", state)
+	}
+
+	prevGrace := reconnectGracePeriod
+	reconnectGracePeriod = 10 * time.Millisecond
+	defer func() { reconnectGracePeriod = prevGrace }()
+	if err := coord.CloseSession(ctx, late.SessionID); err != nil {
+		t.Fatalf("close late joiner: %v", err)
+	}
+	coord.sweepTableTransitions(ctx, time.Now().Add(time.Second))
+
+	rt.mu.Lock()
+	status, seated := rt.status, rt.seatedCount()
+	rt.mu.Unlock()
+	if status != tableStatusActive || seated != 3 {
+		t.Fatalf("expected table to keep running with 3 players, got status=%s seated=%d", status, seated)
+	}
+	lateSess, err := st.GetAgentSession(ctx, late.SessionID)
+	if err != nil {
+		t.Fatalf("get late joiner session: %v", err)
+	}
+	if bal, err := st.GetAccountBalance(ctx, lateSess.AgentID); err != nil || bal != 100000 {
+		t.Fatalf("expected the late joiner's untouched buy-in to be cashed out, balance=%d err=%v", bal, err)
+	}
+}
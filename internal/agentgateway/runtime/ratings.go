@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"context"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+// rateTable rates the agents of a closed cash game table by the chips each
+// won or lost over its hands. Tables that are part of a duplicate match or
+// a tournament are rated with it instead.
+func (c *Coordinator) rateTable(ctx context.Context, rt *tableRuntime) {
+	if rt.match != nil || rt.tournament != nil || rt.director != nil {
+		return
+	}
+	nets, err := c.store.ListTableNets(ctx, []string{rt.id})
+	if err != nil {
+		log.Error().Err(err).Str("table_id", rt.id).Msg("load table nets failed")
+		return
+	}
+	results := make(map[string]float64, len(nets))
+	for agentID, net := range nets {
+		results[agentID] = float64(net)
+	}
+	c.applyRatings(ctx, store.RatingSourceTable, rt.id, results)
+}
+
+// rateDuplicateMatch rates the two agents of a completed duplicate match by
+// their net over both legs.
+func (c *Coordinator) rateDuplicateMatch(ctx context.Context, matchID string) {
+	m, err := c.store.GetDuplicateMatch(ctx, matchID)
+	if err != nil {
+		log.Error().Err(err).Str("match_id", matchID).Msg("load duplicate match failed")
+		return
+	}
+	tableIDs := []string{m.Leg1TableID}
+	if m.Leg2TableID != "" {
+		tableIDs = append(tableIDs, m.Leg2TableID)
+	}
+	nets, err := c.store.ListTableNets(ctx, tableIDs)
+	if err != nil {
+		log.Error().Err(err).Str("match_id", matchID).Msg("load duplicate match nets failed")
+		return
+	}
+	c.applyRatings(ctx, store.RatingSourceDuplicateMatch, matchID, map[string]float64{
+		m.AgentAID: float64(nets[m.AgentAID]),
+		m.AgentBID: float64(nets[m.AgentBID]),
+	})
+}
+
+// rateTournament rates the entrants of a finished tournament by their
+// finishing places.
+func (c *Coordinator) rateTournament(ctx context.Context, tournamentID string, standings []store.TournamentResult) {
+	results := make(map[string]float64, len(standings))
+	for _, r := range standings {
+		results[r.AgentID] = -float64(r.Place)
+	}
+	c.applyRatings(ctx, store.RatingSourceTournament, tournamentID, results)
+}
+
+// applyRatings rates results as one rating period of source and stores the
+// new ratings. Updates are serialized so that two periods finishing at
+// once do not both start from the same ratings.
+func (c *Coordinator) applyRatings(ctx context.Context, sourceType, sourceID string, results map[string]float64) {
+	if len(results) < 2 || c.store == nil {
+		return
+	}
+	c.ratingMu.Lock()
+	defer c.ratingMu.Unlock()
+
+	agentIDs := make([]string, 0, len(results))
+	for agentID := range results {
+		agentIDs = append(agentIDs, agentID)
+	}
+	current, err := c.store.ListAgentRatings(ctx, agentIDs)
+	if err != nil {
+		log.Error().Err(err).Str("source_type", sourceType).Str("source_id", sourceID).Msg("load ratings failed")
+		return
+	}
+	before := make(map[string]game.Rating, len(current))
+	for agentID, r := range current {
+		before[agentID] = game.Rating{Rating: r.Rating, Deviation: r.Deviation, Volatility: r.Volatility}
+	}
+	changes := make([]store.RatingChange, 0, len(results))
+	for agentID, r := range game.RateField(before, results) {
+		prev, ok := before[agentID]
+		if !ok {
+			prev = game.NewRating()
+		}
+		changes = append(changes, store.RatingChange{
+			AgentID:      agentID,
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			Opponents:    len(results) - 1,
+			RatingBefore: prev.Rating,
+			Rating:       r.Rating,
+			Deviation:    r.Deviation,
+			Volatility:   r.Volatility,
+		})
+	}
+	if err := c.store.RecordRatings(ctx, changes); err != nil {
+		log.Error().Err(err).Str("source_type", sourceType).Str("source_id", sourceID).Msg("record ratings failed")
+	}
+}
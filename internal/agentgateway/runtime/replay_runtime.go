@@ -3,9 +3,12 @@ package runtime
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"time"
 
+	"silicon-casino/internal/game"
 	"silicon-casino/internal/game/viewmodel"
+	"silicon-casino/internal/store"
 
 	"github.com/rs/zerolog/log"
 )
@@ -33,44 +36,78 @@ func (c *Coordinator) initReplayRuntime(ctx context.Context, rt *tableRuntime) {
 		"table_id": rt.id,
 		"room_id":  rt.room.ID,
 	})
-	c.appendReplayEvent(ctx, rt, "hand_started", "", map[string]any{
-		"hand_id": rt.engine.State.HandID,
-		"street":  string(rt.engine.State.Street),
-	})
-	c.appendReplayEvent(ctx, rt, "state_snapshot", "", c.buildReplayState(rt))
+	c.appendReplayEvent(ctx, rt, "hand_started", "", handStartedPayload(rt))
+	c.appendReplayState(ctx, rt)
 }
 
 func (c *Coordinator) appendReplayEvent(ctx context.Context, rt *tableRuntime, eventType, actorAgentID string, payload map[string]any) {
 	if c == nil || c.store == nil || rt == nil {
 		return
 	}
+	ev, ok := stageReplayEvent(rt, 0, replayEvent{eventType: eventType, actorAgentID: actorAgentID, payload: payload})
+	if !ok {
+		return
+	}
+	if err := c.store.InsertTableReplayEvent(
+		ctx,
+		rt.id,
+		ev.HandID,
+		ev.GlobalSeq,
+		ev.HandSeq,
+		ev.EventType,
+		ev.ActorAgentID,
+		ev.Payload,
+		ev.SchemaVer,
+	); err != nil {
+		log.Error().Err(err).Str("table_id", rt.id).Int64("global_seq", ev.GlobalSeq).Str("event_type", eventType).Msg("insert replay event failed")
+		return
+	}
+	c.commitReplayEvents(ctx, rt, 1)
+}
+
+// replayEvent is a replay row that has not been sequenced yet.
+type replayEvent struct {
+	eventType    string
+	actorAgentID string
+	payload      map[string]any
+}
+
+// stageReplayEvent sequences ev as the offset-th event after the last stored
+// one without storing it. The caller writes the row and then advances the
+// table's sequence with commitReplayEvents.
+func stageReplayEvent(rt *tableRuntime, offset int, ev replayEvent) (store.TableReplayEvent, bool) {
+	payload := ev.payload
 	if payload == nil {
 		payload = map[string]any{}
 	}
 	payload["server_ts"] = time.Now().UnixMilli()
 	raw, err := json.Marshal(payload)
 	if err != nil {
-		log.Error().Err(err).Str("table_id", rt.id).Str("event_type", eventType).Msg("marshal replay payload failed")
-		return
+		log.Error().Err(err).Str("table_id", rt.id).Str("event_type", ev.eventType).Msg("marshal replay payload failed")
+		return store.TableReplayEvent{}, false
 	}
-	rt.globalSeq++
-	hs := rt.handSeq
-	if err := c.store.InsertTableReplayEvent(
-		ctx,
-		rt.id,
-		rt.engine.State.HandID,
-		rt.globalSeq,
-		&hs,
-		eventType,
-		actorAgentID,
-		raw,
-		replaySchemaVersion,
-	); err != nil {
-		log.Error().Err(err).Str("table_id", rt.id).Int64("global_seq", rt.globalSeq).Str("event_type", eventType).Msg("insert replay event failed")
+	hs := rt.handSeq + int32(offset)
+	return store.TableReplayEvent{
+		TableID:      rt.id,
+		HandID:       rt.engine.State.HandID,
+		GlobalSeq:    rt.globalSeq + int64(offset) + 1,
+		HandSeq:      &hs,
+		EventType:    ev.eventType,
+		ActorAgentID: ev.actorAgentID,
+		Payload:      raw,
+		SchemaVer:    replaySchemaVersion,
+	}, true
+}
+
+// commitReplayEvents advances the table's replay sequence past n stored
+// events and writes a state snapshot when one is due.
+func (c *Coordinator) commitReplayEvents(ctx context.Context, rt *tableRuntime, n int) {
+	if n <= 0 {
 		return
 	}
-	rt.handSeq++
-	rt.eventsSinceSnapshot++
+	rt.globalSeq += int64(n)
+	rt.handSeq += int32(n)
+	rt.eventsSinceSnapshot += n
 	if rt.snapshotInterval > 0 && rt.eventsSinceSnapshot >= int(rt.snapshotInterval) {
 		stateRaw, err := json.Marshal(c.buildReplayState(rt))
 		if err != nil {
@@ -98,9 +135,10 @@ func (c *Coordinator) buildReplayState(rt *tableRuntime) map[string]any {
 			"agent_name": player.agent.Name,
 		})
 	}
-	return map[string]any{
+	out := map[string]any{
 		"table_id":              rt.id,
 		"hand_id":               rt.engine.State.HandID,
+		"variant":               rt.engine.State.GameVariant().Name(),
 		"turn_id":               rt.turnID,
 		"table_status":          rt.status,
 		"close_reason":          rt.closeReason,
@@ -112,6 +150,115 @@ func (c *Coordinator) buildReplayState(rt *tableRuntime) map[string]any {
 		"stacks":                state.Seats,
 		"seat_map":              seatMap,
 	}
+	if job := c.replayEquityLocked(rt); job != nil && job.equity != nil {
+		out["equity"] = job.equity
+	}
+	return out
+}
+
+// replayEquityJob is the replay equity annotation of one street: the all-in
+// equity of the players left in the hand when the street was dealt. It is
+// computed off rt.mu; until it is done, the state_snapshot events of the
+// street are written without it and their seqs are kept to add it later.
+// Guarded by rt.mu.
+type replayEquityJob struct {
+	key    string
+	done   bool
+	equity map[string]any
+	seqs   []int64
+}
+
+// replayEquityLocked returns the equity job of the current street, starting
+// it when the street has none yet, or nil when no equity is shown. Caller
+// must hold rt.mu.
+func (c *Coordinator) replayEquityLocked(rt *tableRuntime) *replayEquityJob {
+	st := rt.engine.State
+	iterations := int(c.equityIterations.Load())
+	if iterations == 0 || st.HandID == "" || st.Settled {
+		return nil
+	}
+	key := st.HandID + ":" + string(st.Street)
+	if rt.equity != nil && rt.equity.key == key {
+		return rt.equity
+	}
+	job := &replayEquityJob{key: key}
+	rt.equity = job
+	holes := make([][]game.Card, 0, len(st.Players))
+	players := make([]game.Player, 0, len(st.Players))
+	for _, p := range st.Players {
+		if p == nil || p.Folded || len(p.Hole) == 0 {
+			continue
+		}
+		holes = append(holes, slices.Clone(p.Hole))
+		players = append(players, game.Player{ID: p.ID, Seat: p.Seat})
+	}
+	if len(holes) < 2 {
+		job.done = true
+		return job
+	}
+	board := slices.Clone(st.Community)
+	variant, street, handID := st.GameVariant(), st.Street, st.HandID
+	go func() {
+		res, err := game.CalculateEquity(variant, holes, board, nil, iterations, nil)
+		var equity map[string]any
+		if err != nil {
+			log.Error().Err(err).Str("table_id", rt.id).Str("hand_id", handID).Msg("calculate replay equity failed")
+		} else {
+			seats := make([]map[string]any, 0, len(players))
+			for i, p := range players {
+				seats = append(seats, map[string]any{
+					"seat_id":  p.Seat,
+					"agent_id": p.ID,
+					"win":      res.Players[i].Win,
+					"tie":      res.Players[i].Tie,
+					"equity":   res.Players[i].Equity,
+				})
+			}
+			equity = map[string]any{
+				"street": string(street),
+				"exact":  res.Exact,
+				"boards": res.Boards,
+				"seats":  seats,
+			}
+		}
+		rt.mu.Lock()
+		job.done, job.equity = true, equity
+		seqs := job.seqs
+		job.seqs = nil
+		rt.mu.Unlock()
+		c.setReplayEquity(rt.id, seqs, equity)
+	}()
+	return job
+}
+
+// setReplayEquity adds equity to the state_snapshot events at seqs, which
+// were written before it was computed.
+func (c *Coordinator) setReplayEquity(tableID string, seqs []int64, equity map[string]any) {
+	if equity == nil || len(seqs) == 0 || c.store == nil {
+		return
+	}
+	raw, err := json.Marshal(equity)
+	if err != nil {
+		log.Error().Err(err).Str("table_id", tableID).Msg("marshal replay equity failed")
+		return
+	}
+	for _, seq := range seqs {
+		if err := c.store.SetTableReplayEventEquity(context.Background(), tableID, seq, raw); err != nil {
+			log.Error().Err(err).Str("table_id", tableID).Int64("global_seq", seq).Msg("set replay equity failed")
+		}
+	}
+}
+
+// appendReplayState writes a state_snapshot replay event. While the equity
+// of the street is still being computed the event is written without it
+// and annotated once it is done. Caller must hold rt.mu.
+func (c *Coordinator) appendReplayState(ctx context.Context, rt *tableRuntime) {
+	job := c.replayEquityLocked(rt)
+	seq := rt.globalSeq
+	c.appendReplayState(ctx, rt)
+	if job != nil && !job.done && rt.globalSeq > seq {
+		job.seqs = append(job.seqs, seq+1)
+	}
 }
 
 func buildShowdownPayload(rt *tableRuntime) []map[string]any {
@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"silicon-casino/internal/game"
+)
+
+func TestReplayEquityComputedOffTableLock(t *testing.T) {
+	c := NewCoordinator(nil, nil)
+	c.SetEquityIterations(200)
+	engine := game.NewEngine(nil, nil, "table-eq", 50, 100)
+	st := engine.State
+	st.HandID = "hand-eq"
+	st.Street = game.StreetPreFlop
+	st.RoundBets = make([]int64, 2)
+	st.Players = []*game.Player{
+		{ID: "a1", Seat: 0, Stack: 1000, Hole: []game.Card{{Rank: game.Ace, Suit: game.Spades}, {Rank: game.Ace, Suit: game.Hearts}}},
+		{ID: "a2", Seat: 1, Stack: 1000, Hole: []game.Card{{Rank: game.King, Suit: game.Spades}, {Rank: game.King, Suit: game.Hearts}}},
+	}
+	rt := &tableRuntime{id: "table-eq", engine: engine, players: make([]*sessionState, 2)}
+
+	// While rt.mu is held the equity cannot be finished, so the snapshot
+	// goes out without it instead of waiting for the simulation.
+	rt.mu.Lock()
+	job := c.replayEquityLocked(rt)
+	if job == nil || job.done {
+		rt.mu.Unlock()
+		t.Fatalf("expected a pending equity job, got %+v", job)
+	}
+	if _, ok := c.buildReplayState(rt)["equity"]; ok {
+		rt.mu.Unlock()
+		t.Fatalf("expected no equity before the job is done")
+	}
+	if again := c.replayEquityLocked(rt); again != job {
+		rt.mu.Unlock()
+		t.Fatalf("expected the street's job to be reused")
+	}
+	rt.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rt.mu.Lock()
+		done := job.done
+		rt.mu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("equity job did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rt.mu.Lock()
+	equity, ok := c.buildReplayState(rt)["equity"].(map[string]any)
+	rt.mu.Unlock()
+	if !ok || equity["street"] != string(game.StreetPreFlop) {
+		t.Fatalf("expected the finished equity in the snapshot, got %v", equity)
+	}
+	if seats, _ := equity["seats"].([]map[string]any); len(seats) != 2 || seats[0]["equity"].(float64) <= seats[1]["equity"].(float64) {
+		t.Fatalf("expected aces ahead of kings, got %v", equity["seats"])
+	}
+
+	st.Street = game.StreetFlop
+	rt.mu.Lock()
+	next := c.replayEquityLocked(rt)
+	rt.mu.Unlock()
+	if next == job {
+		t.Fatalf("expected a new job for the next street")
+	}
+}
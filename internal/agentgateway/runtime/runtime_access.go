@@ -22,3 +22,11 @@ func (c *Coordinator) Store() *store.Store {
 func IsSessionNotFound(err error) bool {
 	return errors.Is(err, errSessionNotFound)
 }
+
+func IsTournamentNotFound(err error) bool {
+	return errors.Is(err, errTournamentNotFound)
+}
+
+func IsRebuyUnavailable(err error) bool {
+	return errors.Is(err, errRebuyUnavailable)
+}
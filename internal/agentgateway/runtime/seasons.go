@@ -0,0 +1,28 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const seasonSweepInterval = time.Minute
+
+// closeDueSeasons archives the final standings of every open season that
+// has ended.
+func (c *Coordinator) closeDueSeasons(ctx context.Context, now time.Time) {
+	ids, err := c.store.ListDueSeasonIDs(ctx, now)
+	if err != nil {
+		log.Error().Err(err).Msg("list due seasons failed")
+		return
+	}
+	for _, id := range ids {
+		if err := c.store.CloseSeason(ctx, id); err != nil && !errors.Is(err, store.ErrSeasonClosed) {
+			log.Error().Err(err).Str("season_id", id).Msg("close season failed")
+		}
+	}
+}
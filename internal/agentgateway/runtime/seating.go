@@ -0,0 +1,140 @@
+package runtime
+
+import (
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+)
+
+// roomSeating returns the table size and the number of players needed to
+// start a table in room, falling back to heads-up for unset values.
+func roomSeating(room *store.Room) (maxSeats, minPlayers int) {
+	maxSeats, minPlayers = store.DefaultMaxSeats, store.DefaultMinPlayers
+	if room == nil {
+		return maxSeats, minPlayers
+	}
+	if room.MaxSeats >= 2 && room.MaxSeats <= store.MaxTableSeats {
+		maxSeats = room.MaxSeats
+	}
+	if room.MinPlayers >= 2 && room.MinPlayers <= maxSeats {
+		minPlayers = room.MinPlayers
+	}
+	return maxSeats, minPlayers
+}
+
+func (rt *tableRuntime) seatedCount() int {
+	count := 0
+	for _, p := range rt.players {
+		if p != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// freeSeat returns the lowest empty seat, or -1 when the table is full.
+func (rt *tableRuntime) freeSeat() int {
+	for i, p := range rt.players {
+		if p == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+func (rt *tableRuntime) validSeat(seat int) bool {
+	return seat >= 0 && seat < len(rt.players) && rt.players[seat] != nil
+}
+
+// enginePlayers builds the per-seat player list dealt into the next hand.
+func (rt *tableRuntime) enginePlayers() []*game.Player {
+	players := make([]*game.Player, len(rt.players))
+	for i, p := range rt.players {
+		if p == nil || p.agent == nil || p.pendingBuyin {
+			continue
+		}
+		players[i] = &game.Player{ID: p.agent.ID, Name: p.agent.Name, Seat: i}
+	}
+	return players
+}
+
+// openSeatLocked reserves a free seat for sess on an active table in the
+// room. The seat is not dealt in until its buy-in has been escrowed. Public
+// joiners only take seats at public tables and invited ones only at the
+// private table they were invited to; ranked tables stay heads-up. Caller
+// must hold c.mu.
+func (c *Coordinator) openSeatLocked(roomID string, sess *sessionState) *tableRuntime {
+	for _, rt := range c.tables {
+		if rt.room == nil || rt.room.ID != roomID || rt.challengeID != sess.challengeID || rt.inviteCode != sess.inviteCode || rt.ranked {
+			continue
+		}
+		rt.mu.Lock()
+		seat := -1
+		// Duplicate legs keep the pair they were created for and
+		// tournaments the entrants they started with.
+		if rt.status == tableStatusActive && rt.match == nil && rt.tournament == nil && rt.director == nil {
+			seat = rt.freeSeat()
+		}
+		if seat >= 0 {
+			rt.players[seat] = sess
+			sess.runtime = rt
+			sess.seat = seat
+			sess.session.TableID = rt.id
+			sess.session.SeatID = &seat
+			sess.session.Status = "active"
+			sess.pendingBuyin = true
+		}
+		rt.mu.Unlock()
+		if seat >= 0 {
+			return rt
+		}
+	}
+	return nil
+}
+
+func removeWaiting(queue []*sessionState, sess *sessionState) []*sessionState {
+	out := queue[:0]
+	for _, w := range queue {
+		if w != sess {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// resolveBuyin returns the amount an agent with balance brings to a table in
+// room. Without a requested amount the agent buys in for as much as the room
+// allows. A duplicate match escrows the buy-in once per leg, so only half the
+// balance is available to it. A sit-and-go costs exactly its entry fee. It
+// returns an error code when the buy-in cannot be honored.
+func resolveBuyin(room *store.Room, balance int64, requested *int64) (int64, string) {
+	if room.MatchFormat == store.MatchFormatSitAndGo {
+		if requested != nil && *requested != room.EntryFeeCC {
+			return 0, "invalid_buyin"
+		}
+		if balance < room.EntryFeeCC {
+			return 0, "insufficient_buyin"
+		}
+		return room.EntryFeeCC, ""
+	}
+	if room.MatchFormat == store.MatchFormatDuplicate {
+		balance /= 2
+	}
+	maxBuyin := room.MaxBuyinCC
+	if maxBuyin < room.MinBuyinCC {
+		maxBuyin = room.MinBuyinCC
+	}
+	if requested == nil {
+		if balance < room.MinBuyinCC {
+			return 0, "insufficient_buyin"
+		}
+		return min(balance, maxBuyin), ""
+	}
+	amount := *requested
+	if amount < room.MinBuyinCC || amount > maxBuyin || amount < room.BigBlindCC {
+		return 0, "invalid_buyin"
+	}
+	if amount > balance {
+		return 0, "insufficient_buyin"
+	}
+	return amount, ""
+}
@@ -0,0 +1,33 @@
+package runtime
+
+import (
+	"testing"
+
+	"silicon-casino/internal/store"
+)
+
+func TestResolveBuyin(t *testing.T) {
+	room := &store.Room{MinBuyinCC: 1000, MaxBuyinCC: 10000, BigBlindCC: 100}
+	amount := func(v int64) *int64 { return &v }
+	cases := []struct {
+		name      string
+		balance   int64
+		requested *int64
+		want      int64
+		code      string
+	}{
+		{name: "default takes room max", balance: 50000, want: 10000},
+		{name: "default capped by balance", balance: 4000, want: 4000},
+		{name: "default below min", balance: 999, code: "insufficient_buyin"},
+		{name: "requested within limits", balance: 50000, requested: amount(2500), want: 2500},
+		{name: "requested below min", balance: 50000, requested: amount(500), code: "invalid_buyin"},
+		{name: "requested above max", balance: 50000, requested: amount(20000), code: "invalid_buyin"},
+		{name: "requested above balance", balance: 3000, requested: amount(5000), code: "insufficient_buyin"},
+	}
+	for _, tc := range cases {
+		got, code := resolveBuyin(room, tc.balance, tc.requested)
+		if got != tc.want || code != tc.code {
+			t.Fatalf("%s: got (%d, %q), want (%d, %q)", tc.name, got, code, tc.want, tc.code)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	closeReasonTournamentComplete = "tournament_complete"
+	closeReasonEliminated         = "eliminated"
+)
+
+var errTournamentNotFound = errors.New("tournament_not_found")
+
+// sitAndGo is the tournament a table runtime plays. Stacks are tournament
+// chips: a player whose stack reaches zero is eliminated and the last player
+// left wins. places holds the finishing place of every entrant that is out.
+type sitAndGo struct {
+	tournamentID string
+	players      int
+	prizePoolCC  int64
+	// payouts is the CC paid for each place, first place first.
+	payouts []int64
+	places  map[string]int
+	// results are the final standings, set when the table closes.
+	results []store.TournamentResult
+}
+
+// newSitAndGo starts the tournament of a full sit-and-go table. Every
+// entrant pays the room's entry fee. Caller must hold c.mu.
+func newSitAndGo(room *store.Room, seated []*sessionState) (*sitAndGo, store.Tournament) {
+	bps := game.SitAndGoPayouts(len(seated))
+	t := store.Tournament{
+		ID:            store.NewID(),
+		RoomID:        room.ID,
+		MaxPlayers:    len(seated),
+		EntryFeeCC:    room.EntryFeeCC,
+		StartingChips: room.StartingChips,
+		PrizePoolCC:   room.EntryFeeCC * int64(len(seated)),
+		PayoutBps:     bps,
+	}
+	for _, ss := range seated {
+		ss.buyinCC = room.EntryFeeCC
+	}
+	sng := &sitAndGo{
+		tournamentID: t.ID,
+		players:      len(seated),
+		prizePoolCC:  t.PrizePoolCC,
+		payouts:      game.SplitPrizePool(t.PrizePoolCC, bps),
+		places:       map[string]int{},
+	}
+	return sng, t
+}
+
+func (s *sitAndGo) payout(place int) int64 {
+	if place < 1 || place > len(s.payouts) {
+		return 0
+	}
+	return s.payouts[place-1]
+}
+
+// tournamentID is the tournament the table plays, if any. Caller must hold
+// rt.mu.
+func (rt *tableRuntime) tournamentID() string {
+	switch {
+	case rt.tournament != nil:
+		return rt.tournament.tournamentID
+	case rt.director != nil:
+		return rt.director.id
+	default:
+		return ""
+	}
+}
+
+// eliminateBustedLocked takes every player left without chips by the hand
+// that just settled off the table. Players busted in the same hand finish in
+// the order of the stacks they started it with, the smaller stack last.
+// Caller must hold rt.mu.
+func (c *Coordinator) eliminateBustedLocked(ctx context.Context, rt *tableRuntime) {
+	st := rt.engine.State
+	var seats []int
+	for seat, p := range rt.players {
+		if p == nil || seat >= len(st.Players) || st.Players[seat] == nil {
+			continue
+		}
+		if st.Players[seat].Stack == 0 {
+			seats = append(seats, seat)
+		}
+	}
+	sort.SliceStable(seats, func(i, j int) bool {
+		return st.TotalContrib[seats[i]] < st.TotalContrib[seats[j]]
+	})
+	for _, seat := range seats {
+		p := rt.players[seat]
+		place := rt.seatedCount()
+		rt.tournament.places[p.agent.ID] = place
+		if err := c.tournaments.EliminateTournamentEntry(ctx, rt.tournament.tournamentID, p.agent.ID, place); err != nil {
+			log.Error().Err(err).Str("tournament_id", rt.tournament.tournamentID).Str("agent_id", p.agent.ID).Msg("record tournament elimination failed")
+		}
+		c.removeEliminatedLocked(ctx, rt, seat, map[string]any{
+			"place":     place,
+			"payout_cc": rt.tournament.payout(place),
+		})
+	}
+}
+
+// removeEliminatedLocked takes the player at seat, who is out of the
+// tournament, off the table and closes their session. payload carries the
+// finishing place and is announced as player_eliminated once the table and
+// player fields are added. Caller must hold rt.mu.
+func (c *Coordinator) removeEliminatedLocked(ctx context.Context, rt *tableRuntime, seat int, payload map[string]any) {
+	p := rt.players[seat]
+	rt.players[seat] = nil
+	payload["tournament_id"] = rt.tournamentID()
+	payload["table_id"] = rt.id
+	payload["hand_id"] = rt.engine.State.HandID
+	payload["agent_id"] = p.agent.ID
+	payload["seat_id"] = seat
+	c.appendReplayEvent(ctx, rt, "player_eliminated", p.agent.ID, payload)
+	for _, other := range rt.players {
+		if other == nil || other.buffer == nil {
+			continue
+		}
+		other.buffer.Append("player_eliminated", other.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("player_eliminated", rt.id, payload)
+	}
+	p.session.Status = "closed"
+	p.disconnected = false
+	p.disconnectedReason = ""
+	p.runtime = nil
+	if p.buffer != nil {
+		p.buffer.Append("player_eliminated", p.session.ID, payload)
+		p.buffer.Append("session_closed", p.session.ID, map[string]any{"reason": closeReasonEliminated})
+		p.buffer.Close()
+	}
+	rt.eliminated = append(rt.eliminated, p)
+}
+
+// recordPlaceLocked places the player at seat, who is leaving a tournament
+// table before losing their chips, behind every player still seated. Caller
+// must hold rt.mu.
+func (c *Coordinator) recordPlaceLocked(ctx context.Context, rt *tableRuntime, seat int) {
+	p := rt.players[seat]
+	if p == nil {
+		return
+	}
+	if rt.director != nil {
+		c.withdrawSeatedLocked(ctx, rt, p)
+		return
+	}
+	if rt.tournament == nil {
+		return
+	}
+	place := rt.seatedCount()
+	rt.tournament.places[p.agent.ID] = place
+	if err := c.tournaments.EliminateTournamentEntry(ctx, rt.tournament.tournamentID, p.agent.ID, place); err != nil {
+		log.Error().Err(err).Str("tournament_id", rt.tournament.tournamentID).Str("agent_id", p.agent.ID).Msg("record tournament elimination failed")
+	}
+}
+
+// releaseDeparted releases the sessions eliminated since the last call and,
+// at a multi-table tournament table, seats the players its director moved.
+// Must be called without holding rt.mu.
+func (c *Coordinator) releaseDeparted(ctx context.Context, rt *tableRuntime) {
+	rt.mu.Lock()
+	eliminated := rt.eliminated
+	rt.eliminated = nil
+	d := rt.director
+	rt.mu.Unlock()
+	if len(eliminated) > 0 {
+		c.releaseSessions(ctx, eliminated)
+	}
+	if d != nil {
+		c.seatPending(ctx, d)
+	}
+}
+
+// completeSitAndGoLocked fixes the final standings when a tournament table
+// closes: the players still seated are placed ahead of everyone already out,
+// by their stacks. The standings are announced and kept for
+// releaseClosedTable to pay out. Caller must hold rt.mu.
+func (c *Coordinator) completeSitAndGoLocked(ctx context.Context, rt *tableRuntime) {
+	sng := rt.tournament
+	if sng.results != nil {
+		return
+	}
+	stack := func(seat int) int64 {
+		if seat < len(rt.engine.State.Players) && rt.engine.State.Players[seat] != nil {
+			return rt.engine.State.Players[seat].Stack
+		}
+		return 0
+	}
+	var remaining []int
+	for seat, p := range rt.players {
+		if p != nil && sng.places[p.agent.ID] == 0 {
+			remaining = append(remaining, seat)
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return stack(remaining[i]) > stack(remaining[j])
+	})
+	for i, seat := range remaining {
+		sng.places[rt.players[seat].agent.ID] = i + 1
+	}
+	sng.results = make([]store.TournamentResult, 0, len(sng.places))
+	for agentID, place := range sng.places {
+		sng.results = append(sng.results, store.TournamentResult{AgentID: agentID, Place: place, PayoutCC: sng.payout(place)})
+	}
+	sort.Slice(sng.results, func(i, j int) bool { return sng.results[i].Place < sng.results[j].Place })
+	standings := make([]map[string]any, 0, len(sng.results))
+	for _, r := range sng.results {
+		standings = append(standings, map[string]any{"agent_id": r.AgentID, "place": r.Place, "payout_cc": r.PayoutCC})
+	}
+	payload := map[string]any{
+		"tournament_id": sng.tournamentID,
+		"table_id":      rt.id,
+		"prize_pool_cc": sng.prizePoolCC,
+		"standings":     standings,
+	}
+	c.appendReplayEvent(ctx, rt, "tournament_completed", "", payload)
+	for _, p := range rt.players {
+		if p == nil || p.buffer == nil {
+			continue
+		}
+		p.buffer.Append("tournament_completed", p.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("tournament_completed", rt.id, payload)
+	}
+}
+
+// finishSitAndGo pays out the standings of a closed tournament table. Must
+// be called without holding rt.mu.
+func (c *Coordinator) finishSitAndGo(ctx context.Context, rt *tableRuntime) {
+	rt.mu.Lock()
+	tournamentID := rt.tournament.tournamentID
+	results := rt.tournament.results
+	rt.mu.Unlock()
+	err := c.tournaments.FinishTournament(ctx, tournamentID, results)
+	if err == nil {
+		c.rateTournament(ctx, tournamentID, results)
+	} else if !errors.Is(err, store.ErrTournamentClosed) {
+		log.Error().Err(err).Str("tournament_id", tournamentID).Str("table_id", rt.id).Msg("finish tournament failed")
+	}
+}
+
+// needsNoActionLocked reports whether the hand just dealt has no decision
+// left to make because the blinds put all but at most one player all-in
+// and that player already covers every bet. Caller must hold rt.mu.
+func (rt *tableRuntime) needsNoActionLocked() bool {
+	st := rt.engine.State
+	switch st.ActionablePlayers() {
+	case 0:
+		return true
+	case 1:
+		actor := st.CurrentActor
+		for seat, p := range st.Players {
+			if p != nil && !p.Folded && seat != actor && st.RoundBets[seat] > st.RoundBets[actor] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// RegisterTournament enters the agent into a scheduled multi-table
+// tournament when TournamentID is set, and otherwise into the next
+// sit-and-go of a room. A sit-and-go entry fee is charged when the table
+// fills and the tournament starts.
+func (c *Coordinator) RegisterTournament(ctx context.Context, req RegisterTournamentRequest) (*CreateSessionResponse, error) {
+	if req.TournamentID != "" {
+		agent, err := authenticateAgent(ctx, c.store, req.AgentID, req.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		return c.registerMultiTable(ctx, agent, req)
+	}
+	room, err := c.store.GetRoom(ctx, req.RoomID)
+	if err != nil || room.Status != "active" || room.MatchFormat != store.MatchFormatSitAndGo {
+		return nil, errTournamentNotFound
+	}
+	return c.CreateSession(ctx, CreateSessionRequest{
+		AgentID:  req.AgentID,
+		APIKey:   req.APIKey,
+		JoinMode: "select",
+		RoomID:   room.ID,
+	})
+}
+
+// GetTournamentStatus reports the tournament a session is registered for or
+// playing in.
+func (c *Coordinator) GetTournamentStatus(ctx context.Context, sessionID string) (TournamentStatus, error) {
+	c.mu.Lock()
+	sess := c.sessions[sessionID]
+	if sess == nil {
+		c.mu.Unlock()
+		return TournamentStatus{}, errSessionNotFound
+	}
+	rt := sess.runtime
+	if d := sess.director; d != nil {
+		agentID, seat := sess.agent.ID, sess.seat
+		c.mu.Unlock()
+		return c.multiTableStatus(sessionID, agentID, d, rt, seat), nil
+	}
+	if rt == nil {
+		roomID := sess.session.RoomID
+		registered := len(c.waiting[roomID])
+		c.mu.Unlock()
+		room, err := c.store.GetRoom(ctx, roomID)
+		if err != nil || room.MatchFormat != store.MatchFormatSitAndGo {
+			return TournamentStatus{}, errTournamentNotFound
+		}
+		maxSeats, _ := roomSeating(room)
+		return TournamentStatus{
+			SessionID:     sessionID,
+			Status:        "registering",
+			RoomID:        room.ID,
+			Registered:    registered,
+			MaxPlayers:    maxSeats,
+			EntryFeeCC:    room.EntryFeeCC,
+			StartingChips: room.StartingChips,
+		}, nil
+	}
+	c.mu.Unlock()
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.tournament == nil {
+		return TournamentStatus{}, errTournamentNotFound
+	}
+	level := rt.currentBlindLevel()
+	out := TournamentStatus{
+		SessionID:     sessionID,
+		Status:        store.TournamentRunning,
+		RoomID:        rt.room.ID,
+		TournamentID:  rt.tournament.tournamentID,
+		TableID:       rt.id,
+		Registered:    rt.tournament.players,
+		MaxPlayers:    rt.tournament.players,
+		PlayersLeft:   rt.seatedCount(),
+		EntryFeeCC:    rt.room.EntryFeeCC,
+		StartingChips: rt.room.StartingChips,
+		PrizePoolCC:   rt.tournament.prizePoolCC,
+		PayoutsCC:     rt.tournament.payouts,
+		BlindLevel:    rt.blindLevel,
+		SmallBlind:    level.SmallBlind,
+		BigBlind:      level.BigBlind,
+		Ante:          level.Ante,
+	}
+	if sess.seat < len(rt.engine.State.Players) && rt.engine.State.Players[sess.seat] != nil {
+		out.StackChips = rt.engine.State.Players[sess.seat].Stack
+	}
+	if rt.status == tableStatusClosed {
+		out.Status = store.TournamentCompleted
+	}
+	return out, nil
+}
@@ -0,0 +1,191 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/store"
+	"silicon-casino/internal/testutil"
+)
+
+// newSitAndGoTable seats one entrant per stack at a sit-and-go table of
+// room, as if its tournament had just started.
+func newSitAndGoTable(room *store.Room, stacks ...int64) *tableRuntime {
+	maxSeats, _ := roomSeating(room)
+	engine := game.NewEngine(nil, nil, "table-sng", room.SmallBlindCC, room.BigBlindCC)
+	engine.State.Players = make([]*game.Player, maxSeats)
+	engine.State.TotalContrib = make([]int64, maxSeats)
+	rt := &tableRuntime{
+		id:               "table-sng",
+		room:             room,
+		engine:           engine,
+		players:          make([]*sessionState, maxSeats),
+		status:           tableStatusActive,
+		disconnectedSeat: -1,
+		turnSeat:         -1,
+	}
+	seated := make([]*sessionState, 0, len(stacks))
+	for seat, stack := range stacks {
+		agentID := fmt.Sprintf("sng-%d", seat)
+		ss := &sessionState{
+			session: store.AgentSession{ID: "sess-" + agentID, AgentID: agentID, RoomID: room.ID, TableID: rt.id, Status: "active"},
+			agent:   &store.Agent{ID: agentID, Name: agentID},
+			buffer:  NewEventBuffer(100),
+			runtime: rt,
+			seat:    seat,
+		}
+		rt.players[seat] = ss
+		engine.State.Players[seat] = &game.Player{ID: agentID, Name: agentID, Stack: stack, Seat: seat}
+		seated = append(seated, ss)
+	}
+	rt.tournament, _ = newSitAndGo(room, seated)
+	return rt
+}
+
+func TestSitAndGoPlaysToCompletion(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTournamentStore()
+	c := NewCoordinator(nil, nil)
+	c.tournaments = fake
+	room := &store.Room{ID: "room-sng", SmallBlindCC: 50, BigBlindCC: 100, MaxSeats: 3, MatchFormat: store.MatchFormatSitAndGo, EntryFeeCC: 1000, StartingChips: 1500}
+	rt := newSitAndGoTable(room, 1500, 1500, 1500)
+	st := rt.engine.State
+
+	// The first hand busts seat 2, the second seat 1: each finishes in the
+	// place of the players seated when it went out.
+	st.Players[0].Stack, st.Players[1].Stack, st.Players[2].Stack = 3000, 1500, 0
+	rt.mu.Lock()
+	c.eliminateBustedLocked(ctx, rt)
+	rt.mu.Unlock()
+	st.Players[0].Stack, st.Players[1].Stack = 4500, 0
+	rt.mu.Lock()
+	c.eliminateBustedLocked(ctx, rt)
+	rt.mu.Unlock()
+
+	if got := fake.eliminated; !reflect.DeepEqual(got, map[string]int{"sng-2": 3, "sng-1": 2}) {
+		t.Fatalf("expected sng-2 third and sng-1 second, got %v", got)
+	}
+	if rt.seatedCount() != 1 || len(rt.eliminated) != 2 {
+		t.Fatalf("expected one player left and two eliminated, got %d seated and %d eliminated", rt.seatedCount(), len(rt.eliminated))
+	}
+	// Each eliminated player is told its own place and its session closes.
+	for _, p := range rt.eliminated {
+		events := p.buffer.ReplayAfter("")
+		n := len(events)
+		if n < 2 || events[n-2].Event != "player_eliminated" || events[n-1].Event != "session_closed" {
+			t.Fatalf("%s: expected its elimination to close the session, got %+v", p.agent.ID, events)
+		}
+		if out := events[n-2].Data.(map[string]any); out["agent_id"] != p.agent.ID || out["place"] != fake.eliminated[p.agent.ID] {
+			t.Fatalf("%s: expected its own place announced, got %v", p.agent.ID, out)
+		}
+	}
+
+	// With one player left no hand can be dealt and the table closes.
+	survivor := rt.players[0]
+	rt.mu.Lock()
+	c.closeTableLocked(ctx, rt, closeReasonNotEnoughPlayers)
+	rt.mu.Unlock()
+	c.finishSitAndGo(ctx, rt)
+
+	// Three entrants split the 3000 CC pool 65/35.
+	want := []store.TournamentResult{
+		{AgentID: "sng-0", Place: 1, PayoutCC: 1950},
+		{AgentID: "sng-1", Place: 2, PayoutCC: 1050},
+		{AgentID: "sng-2", Place: 3},
+	}
+	results, finished := fake.finished[rt.tournament.tournamentID]
+	if !finished || !reflect.DeepEqual(results, want) {
+		t.Fatalf("expected the tournament finished with %+v, got finished=%v %+v", want, finished, results)
+	}
+	var completed map[string]any
+	for _, ev := range survivor.buffer.ReplayAfter("") {
+		if ev.Event == "tournament_completed" {
+			completed = ev.Data.(map[string]any)
+		}
+	}
+	if completed == nil || completed["prize_pool_cc"] != int64(3000) {
+		t.Fatalf("expected the survivor told of the 3000 CC tournament's completion, got %v", completed)
+	}
+
+	// The standings are fixed once: finishing again leaves them as paid.
+	c.finishSitAndGo(ctx, rt)
+	if got := fake.finished[rt.tournament.tournamentID]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the standings to stay %+v, got %+v", want, got)
+	}
+}
+
+// abortRecorder records the tournaments aborted through it.
+type abortRecorder struct {
+	tournamentStore
+	aborted []string
+}
+
+func (r *abortRecorder) AbortTournament(ctx context.Context, tournamentID string) error {
+	r.aborted = append(r.aborted, tournamentID)
+	return r.tournamentStore.AbortTournament(ctx, tournamentID)
+}
+
+func TestSitAndGoStartFailureRefundsEntryFees(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+	roomID, err := st.CreateRoomWithConfig(ctx, store.Room{
+		Name:          "SNG",
+		SmallBlindCC:  50,
+		BigBlindCC:    100,
+		MaxSeats:      2,
+		MatchFormat:   store.MatchFormatSitAndGo,
+		EntryFeeCC:    1000,
+		StartingChips: 1500,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	a1, err := st.CreateAgent(ctx, "bot-a", "key-a", "claim-key-a")
+	if err != nil {
+		t.Fatalf("create a1: %v", err)
+	}
+	a2, err := st.CreateAgent(ctx, "bot-b", "key-b", "claim-key-b")
+	if err != nil {
+		t.Fatalf("create a2: %v", err)
+	}
+	for _, id := range []string{a1, a2} {
+		if err := st.EnsureAccount(ctx, id, 100000); err != nil {
+			t.Fatalf("ensure account %s: %v", id, err)
+		}
+	}
+	coord := NewCoordinator(st, ledger.New(st))
+	coord.ledger = &failingBlindLedger{Ledger: ledger.New(st)}
+	rec := &abortRecorder{tournamentStore: st}
+	coord.tournaments = rec
+
+	if _, err := coord.RegisterTournament(ctx, RegisterTournamentRequest{AgentID: a1, APIKey: "key-a", RoomID: roomID}); err != nil {
+		t.Fatalf("register a1: %v", err)
+	}
+	if _, err := coord.RegisterTournament(ctx, RegisterTournamentRequest{AgentID: a2, APIKey: "key-b", RoomID: roomID}); err == nil {
+		t.Fatalf("expected the sit-and-go to fail to start")
+	}
+	if len(rec.aborted) != 1 {
+		t.Fatalf("expected the tournament aborted once, got %v", rec.aborted)
+	}
+	tour, err := st.GetTournament(ctx, rec.aborted[0])
+	if err != nil {
+		t.Fatalf("get tournament: %v", err)
+	}
+	if tour.Status != store.TournamentAborted {
+		t.Fatalf("expected the tournament aborted, got %s", tour.Status)
+	}
+	for _, id := range []string{a1, a2} {
+		balance, err := st.GetAccountBalance(ctx, id)
+		if err != nil {
+			t.Fatalf("balance %s: %v", id, err)
+		}
+		if balance != 100000 {
+			t.Fatalf("expected %s refunded the entry fee to 100000, got %d", id, balance)
+		}
+	}
+}
@@ -18,5 +18,6 @@ func (c *Coordinator) GetState(sessionID string) (viewmodel.AgentStateView, erro
 	state.TableStatus = rt.status
 	state.ReconnectDeadlineTS = rt.reconnectDeadline.UnixMilli()
 	state.CloseReason = rt.closeReason
+	state.TournamentID = rt.tournamentID()
 	return state, nil
 }
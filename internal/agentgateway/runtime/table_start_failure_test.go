@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/testutil"
+)
+
+// failingBlindLedger fails every DebitBlind after the first, so the first
+// hand of a table is left with one blind posted.
+type failingBlindLedger struct {
+	*ledger.Ledger
+	blinds int
+}
+
+func (l *failingBlindLedger) DebitBlind(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error) {
+	l.blinds++
+	if l.blinds > 1 {
+		return 0, errors.New("debit blind failed")
+	}
+	return l.Ledger.DebitBlind(ctx, tableID, agentID, handID, amount)
+}
+
+func TestTableStartFailureReturnsPostedBlinds(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+	if err := st.EnsureDefaultRooms(ctx); err != nil {
+		t.Fatalf("ensure rooms: %v", err)
+	}
+	a1, err := st.CreateAgent(ctx, "bot-a", "key-a", "claim-key-a")
+	if err != nil {
+		t.Fatalf("create a1: %v", err)
+	}
+	a2, err := st.CreateAgent(ctx, "bot-b", "key-b", "claim-key-b")
+	if err != nil {
+		t.Fatalf("create a2: %v", err)
+	}
+	for _, id := range []string{a1, a2} {
+		if err := st.EnsureAccount(ctx, id, 100000); err != nil {
+			t.Fatalf("ensure account %s: %v", id, err)
+		}
+	}
+	coord := NewCoordinator(st, ledger.New(st))
+	stub := &failingBlindLedger{Ledger: ledger.New(st)}
+	coord.ledger = stub
+
+	if _, err := coord.CreateSession(ctx, CreateSessionRequest{AgentID: a1, APIKey: "key-a", JoinMode: "random"}); err != nil {
+		t.Fatalf("create session 1: %v", err)
+	}
+	if _, err := coord.CreateSession(ctx, CreateSessionRequest{AgentID: a2, APIKey: "key-b", JoinMode: "random"}); err == nil {
+		t.Fatalf("expected the table start to fail")
+	}
+	if stub.blinds != 2 {
+		t.Fatalf("expected the second blind to fail, got %d blind debits", stub.blinds)
+	}
+
+	coord.mu.Lock()
+	sessions, tables := len(coord.sessions), len(coord.tables)
+	coord.mu.Unlock()
+	if sessions != 0 || tables != 0 {
+		t.Fatalf("expected no sessions or tables left, got %d sessions and %d tables", sessions, tables)
+	}
+	for _, id := range []string{a1, a2} {
+		balance, err := st.GetAccountBalance(ctx, id)
+		if err != nil {
+			t.Fatalf("balance %s: %v", id, err)
+		}
+		if balance != 100000 {
+			t.Fatalf("expected %s to be made whole at 100000, got %d", id, balance)
+		}
+	}
+}
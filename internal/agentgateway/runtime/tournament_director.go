@@ -0,0 +1,1433 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	closeReasonTableBroken         = "table_broken"
+	closeReasonTournamentCancelled = "tournament_cancelled"
+	tournamentSweepInterval        = 5 * time.Second
+)
+
+var (
+	errTournamentClosed = errors.New("tournament_closed")
+	errRebuyUnavailable = errors.New("rebuy_not_available")
+)
+
+// tournamentStore is the part of the store tournament directors and
+// sit-and-go tables record registrations, seats, eliminations and results
+// in.
+type tournamentStore interface {
+	GetTournament(ctx context.Context, tournamentID string) (*store.Tournament, error)
+	ListDueTournamentIDs(ctx context.Context, now time.Time) ([]string, error)
+	RegisterTournamentEntry(ctx context.Context, tournamentID string, sess store.AgentSession, autoRebuy bool) error
+	UnregisterTournamentEntry(ctx context.Context, tournamentID, agentID string) error
+	StartTournament(ctx context.Context, tournamentID string) error
+	AbortTournament(ctx context.Context, tournamentID string) error
+	SetTournamentPayouts(ctx context.Context, tournamentID string, bps []int) error
+	OpenTournamentTable(ctx context.Context, tournamentID, tableID, roomID string, sb, bb int64, seats []store.SeatAssignment, fromTableIDs map[string]string) error
+	SeatTournamentEntry(ctx context.Context, tournamentID, tableID string, seat store.SeatAssignment, fromTableID string) (int64, error)
+	BuyTournamentChips(ctx context.Context, tournamentID, tableID, agentID string, addon bool) (int64, error)
+	EliminateTournamentEntry(ctx context.Context, tournamentID, agentID string, place int) error
+	FinishTournament(ctx context.Context, tournamentID string, results []store.TournamentResult) error
+}
+
+// tournamentDirector runs a multi-table tournament over any number of table
+// runtimes. Every table plays the room's blind schedule from the moment the
+// tournament starts. Between hands the director eliminates busted players,
+// moves players off tables that are too full and breaks tables the field no
+// longer needs, until one table, the final table, is left.
+//
+// Players taken off a table wait in pending until seatPending finds them a
+// seat. A player's session keeps the table it left in TableID, because that
+// table still holds its stack until it is seated again.
+type tournamentDirector struct {
+	id     string
+	cfg    store.Tournament
+	room   *store.Room
+	blinds game.BlindSchedule
+	// buffer is the tournament's public event stream.
+	buffer *EventBuffer
+
+	// mu guards the fields below. It may be taken while holding c.mu or
+	// rt.mu, but neither may be acquired while holding it.
+	mu        sync.Mutex
+	status    string
+	startedAt time.Time
+	// entrants are the sessions still in the tournament, by agent.
+	entrants  map[string]*sessionState
+	autoRebuy map[string]bool
+	tables    map[string]*tableRuntime
+	// seated counts the players at each table, including seats promised
+	// to pending players.
+	seated   map[string]int
+	breaking map[string]bool
+	pending  []*sessionState
+	// buys are the rebuys, or add-ons when true, queued by agent until the
+	// player's current hand ends.
+	buys   map[string]bool
+	rebuys map[string]int
+	addons map[string]bool
+	places map[string]int
+	// entries counts every registration and remaining the entrants without
+	// a place yet.
+	entries        int
+	remaining      int
+	prizePoolCC    int64
+	payoutBps      []int
+	lateRegClosed  bool
+	announcedLevel int
+	finalTable     bool
+	results        []store.TournamentResult
+	// completion announces the final standings once the tournament is won.
+	completion map[string]any
+	finished   bool
+}
+
+func newTournamentDirector(t store.Tournament, room *store.Room, levels []store.BlindLevel) *tournamentDirector {
+	return &tournamentDirector{
+		id:          t.ID,
+		cfg:         t,
+		room:        room,
+		blinds:      roomBlindSchedule(room, levels),
+		buffer:      NewEventBuffer(500),
+		status:      t.Status,
+		entrants:    map[string]*sessionState{},
+		autoRebuy:   map[string]bool{},
+		tables:      map[string]*tableRuntime{},
+		seated:      map[string]int{},
+		breaking:    map[string]bool{},
+		buys:        map[string]bool{},
+		rebuys:      map[string]int{},
+		addons:      map[string]bool{},
+		places:      map[string]int{},
+		prizePoolCC: t.PrizePoolCC,
+	}
+}
+
+func (d *tournamentDirector) publish(event string, payload map[string]any) {
+	d.buffer.Append(event, d.id, payload)
+}
+
+// levelLocked is the blind level the tournament has reached by now. Caller
+// must hold d.mu.
+func (d *tournamentDirector) levelLocked(now time.Time) int {
+	if d.status != store.TournamentRunning {
+		return 0
+	}
+	return d.blinds.Level(0, now.Sub(d.startedAt))
+}
+
+// lateRegOpenLocked reports whether agents can still register: before the
+// start and for the first LateRegLevels blind levels. Caller must hold d.mu.
+func (d *tournamentDirector) lateRegOpenLocked(now time.Time) bool {
+	switch d.status {
+	case store.TournamentScheduled:
+		return true
+	case store.TournamentRunning:
+		return !d.lateRegClosed && d.levelLocked(now) < d.cfg.LateRegLevels
+	default:
+		return false
+	}
+}
+
+// rebuyOpenLocked reports whether agentID may rebuy: during the first
+// RebuyLevels blind levels, up to MaxRebuys times. Caller must hold d.mu.
+func (d *tournamentDirector) rebuyOpenLocked(now time.Time, agentID string) bool {
+	return d.status == store.TournamentRunning &&
+		d.levelLocked(now) < d.cfg.RebuyLevels &&
+		d.rebuys[agentID] < d.cfg.MaxRebuys
+}
+
+// addonOpenLocked reports whether agentID may take the add-on, which is
+// sold once during the last rebuy level. Caller must hold d.mu.
+func (d *tournamentDirector) addonOpenLocked(now time.Time, agentID string) bool {
+	return d.status == store.TournamentRunning &&
+		d.cfg.AddonChips > 0 &&
+		!d.addons[agentID] &&
+		d.levelLocked(now) == d.cfg.RebuyLevels-1
+}
+
+// placeLocked takes agentID out of the tournament behind every entrant
+// still in it and returns its place. Caller must hold d.mu.
+func (d *tournamentDirector) placeLocked(agentID string) int {
+	place := d.remaining
+	d.remaining--
+	d.places[agentID] = place
+	delete(d.entrants, agentID)
+	delete(d.buys, agentID)
+	return place
+}
+
+// liveTablesLocked returns the tables that are not being broken, in a
+// stable order. Caller must hold d.mu.
+func (d *tournamentDirector) liveTablesLocked() []string {
+	ids := make([]string, 0, len(d.tables))
+	for id := range d.tables {
+		if !d.breaking[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// planMovesLocked decides, when a hand at tableID has ended, how many of
+// its players move to other tables. The table is broken up, and every
+// player moves, when the field fits on one table less and it is the
+// smallest. Otherwise one player moves when the table has two more players
+// than the smallest other table, or when it is the largest table and the
+// only player waiting for a seat cannot get one. Caller must hold d.mu.
+func (d *tournamentDirector) planMovesLocked(tableID string, maxSeats int) (int, bool) {
+	n := d.seated[tableID]
+	if d.breaking[tableID] {
+		return n, true
+	}
+	live := d.liveTablesLocked()
+	if len(live) < 2 {
+		if len(d.pending) == 1 && n >= 3 && n >= maxSeats {
+			return 1, false
+		}
+		return 0, false
+	}
+	smallest, largest := live[0], live[0]
+	free := 0
+	for _, id := range live {
+		if d.seated[id] < d.seated[smallest] {
+			smallest = id
+		}
+		if d.seated[id] > d.seated[largest] {
+			largest = id
+		}
+		free += max(maxSeats-d.seated[id], 0)
+	}
+	needed := (d.remaining + maxSeats - 1) / maxSeats
+	if len(live) > needed && smallest == tableID {
+		d.breaking[tableID] = true
+		return n, true
+	}
+	for _, id := range live {
+		if id != tableID && n-d.seated[id] >= 2 {
+			return 1, false
+		}
+	}
+	if len(d.pending) == 1 && free == 0 && n >= 3 && largest == tableID {
+		return 1, false
+	}
+	return 0, false
+}
+
+// openTableLocked returns the table with the fewest players that still has
+// a free seat, or nil when every table is full. Caller must hold d.mu.
+func (d *tournamentDirector) openTableLocked(maxSeats int) *tableRuntime {
+	var best string
+	for _, id := range d.liveTablesLocked() {
+		if d.seated[id] >= maxSeats {
+			continue
+		}
+		if best == "" || d.seated[id] < d.seated[best] {
+			best = id
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	return d.tables[best]
+}
+
+// directorFor returns the director of a multi-table tournament, loading a
+// scheduled tournament the coordinator has not seen yet.
+func (c *Coordinator) directorFor(ctx context.Context, tournamentID string) (*tournamentDirector, error) {
+	c.mu.Lock()
+	d := c.directors[tournamentID]
+	c.mu.Unlock()
+	if d != nil {
+		return d, nil
+	}
+	t, err := c.tournaments.GetTournament(ctx, tournamentID)
+	if err != nil || !t.MultiTable() {
+		return nil, errTournamentNotFound
+	}
+	if t.Status != store.TournamentScheduled {
+		// Running tournaments always have a director; a tournament the
+		// coordinator lost track of was aborted on startup.
+		return nil, errTournamentClosed
+	}
+	room, err := c.store.GetRoom(ctx, t.RoomID)
+	if err != nil {
+		return nil, errTournamentNotFound
+	}
+	levels, err := c.store.ListRoomBlindLevels(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	d = newTournamentDirector(*t, room, levels)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing := c.directors[tournamentID]; existing != nil {
+		return existing, nil
+	}
+	c.directors[tournamentID] = d
+	return d, nil
+}
+
+// registerMultiTable enters agent into a scheduled multi-table tournament,
+// or a running one whose late registration is open. The entry fee is
+// charged right away; a late registrant is seated as soon as a seat is
+// free.
+func (c *Coordinator) registerMultiTable(ctx context.Context, agent *store.Agent, req RegisterTournamentRequest) (*CreateSessionResponse, error) {
+	d, err := c.directorFor(ctx, req.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	d.mu.Lock()
+	open := d.lateRegOpenLocked(now)
+	d.mu.Unlock()
+	if !open {
+		return nil, errTournamentClosed
+	}
+	c.mu.Lock()
+	if old := c.byAgent[agent.ID]; old != nil && old.session.Status != "closed" {
+		c.mu.Unlock()
+		return nil, errors.New("agent_already_in_session")
+	}
+	c.mu.Unlock()
+
+	expires := now
+	if d.cfg.StartsAt != nil && d.cfg.StartsAt.After(now) {
+		expires = *d.cfg.StartsAt
+	}
+	sess := store.AgentSession{
+		ID:        store.NewID(),
+		AgentID:   agent.ID,
+		RoomID:    d.room.ID,
+		JoinMode:  "select",
+		Status:    "waiting",
+		ExpiresAt: expires.Add(sessionTTL),
+	}
+	if err := c.tournaments.RegisterTournamentEntry(ctx, d.id, sess, req.AutoRebuy); err != nil {
+		return nil, err
+	}
+	joiner := &sessionState{session: sess, agent: agent, buyinCC: d.cfg.EntryFeeCC, buffer: NewEventBuffer(500), director: d}
+	c.mu.Lock()
+	c.sessions[sess.ID] = joiner
+	c.byAgent[agent.ID] = joiner
+	joiner.buffer.Append("session_joined", sess.ID, map[string]any{
+		"table_id":      "",
+		"room_id":       d.room.ID,
+		"seat_id":       nil,
+		"tournament_id": d.id,
+	})
+	c.mu.Unlock()
+
+	d.mu.Lock()
+	d.entrants[agent.ID] = joiner
+	d.autoRebuy[agent.ID] = req.AutoRebuy
+	d.entries++
+	d.remaining++
+	d.prizePoolCC += d.cfg.EntryFeeCC
+	late := d.status == store.TournamentRunning
+	if late {
+		d.pending = append(d.pending, joiner)
+	}
+	payload := map[string]any{
+		"tournament_id": d.id,
+		"agent_id":      agent.ID,
+		"agent_name":    agent.Name,
+		"entries":       d.entries,
+		"prize_pool_cc": d.prizePoolCC,
+		"late":          late,
+	}
+	d.mu.Unlock()
+	d.publish("player_registered", payload)
+	if late {
+		c.seatPending(ctx, d)
+	}
+	return &CreateSessionResponse{
+		SessionID: sess.ID,
+		RoomID:    d.room.ID,
+		BuyinCC:   d.cfg.EntryFeeCC,
+		StreamURL: "/api/agent/sessions/" + sess.ID + "/events",
+		ExpiresAt: sess.ExpiresAt,
+	}, nil
+}
+
+// withdrawEntrant closes the session of an entrant that is not seated at a
+// table. Before the start the registration is cancelled and the entry fee
+// refunded; afterwards the entrant is out of the tournament.
+func (c *Coordinator) withdrawEntrant(ctx context.Context, sess *sessionState, reason string) error {
+	d := sess.director
+	agentID := sess.agent.ID
+	d.mu.Lock()
+	status := d.status
+	_, entered := d.entrants[agentID]
+	d.mu.Unlock()
+
+	running := status == store.TournamentRunning
+	if entered && status == store.TournamentScheduled {
+		err := c.tournaments.UnregisterTournamentEntry(ctx, d.id, agentID)
+		switch {
+		case err == nil:
+			d.mu.Lock()
+			delete(d.entrants, agentID)
+			delete(d.autoRebuy, agentID)
+			d.entries--
+			d.remaining--
+			d.prizePoolCC -= d.cfg.EntryFeeCC
+			payload := map[string]any{
+				"tournament_id": d.id,
+				"agent_id":      agentID,
+				"entries":       d.entries,
+				"prize_pool_cc": d.prizePoolCC,
+			}
+			d.mu.Unlock()
+			d.publish("player_unregistered", payload)
+		case errors.Is(err, store.ErrTournamentClosed):
+			// The tournament started meanwhile.
+			running = true
+		case !errors.Is(err, store.ErrNotFound):
+			return err
+		}
+	}
+	if entered && running {
+		d.mu.Lock()
+		for i, p := range d.pending {
+			if p == sess {
+				d.pending = append(d.pending[:i], d.pending[i+1:]...)
+				break
+			}
+		}
+		place := 0
+		if _, ok := d.entrants[agentID]; ok {
+			place = d.placeLocked(agentID)
+		}
+		d.mu.Unlock()
+		if place > 0 {
+			if err := c.tournaments.EliminateTournamentEntry(ctx, d.id, agentID, place); err != nil {
+				log.Error().Err(err).Str("tournament_id", d.id).Str("agent_id", agentID).Msg("record tournament elimination failed")
+			}
+			d.publish("player_eliminated", map[string]any{
+				"tournament_id": d.id,
+				"agent_id":      agentID,
+				"place":         place,
+				"reason":        reason,
+			})
+		}
+	}
+
+	c.mu.Lock()
+	if sess.buffer != nil {
+		sess.buffer.Append("session_closed", sess.session.ID, map[string]any{"reason": reason})
+		sess.buffer.Close()
+	}
+	sess.session.Status = "closed"
+	c.mu.Unlock()
+	c.releaseSessions(ctx, []*sessionState{sess})
+	if running {
+		c.seatPending(ctx, d)
+	}
+	return nil
+}
+
+// startDueTournaments starts the scheduled tournaments whose start time has
+// come.
+func (c *Coordinator) startDueTournaments(ctx context.Context, now time.Time) {
+	ids, err := c.tournaments.ListDueTournamentIDs(ctx, now)
+	if err != nil {
+		log.Error().Err(err).Msg("list due tournaments failed")
+		return
+	}
+	for _, id := range ids {
+		d, err := c.directorFor(ctx, id)
+		if err != nil {
+			log.Error().Err(err).Str("tournament_id", id).Msg("load due tournament failed")
+			continue
+		}
+		c.startTournament(ctx, d, now)
+	}
+}
+
+// startTournament seats the registered entrants at random over as few
+// tables as hold them, as evenly as possible. A tournament short of its
+// minimum field is cancelled and every entry refunded.
+func (c *Coordinator) startTournament(ctx context.Context, d *tournamentDirector, now time.Time) {
+	d.mu.Lock()
+	if d.status != store.TournamentScheduled {
+		d.mu.Unlock()
+		return
+	}
+	entrants := make([]*sessionState, 0, len(d.entrants))
+	for _, ss := range d.entrants {
+		entrants = append(entrants, ss)
+	}
+	if len(entrants) < max(d.cfg.MinPlayers, 2) {
+		d.status = store.TournamentAborted
+		d.mu.Unlock()
+		c.cancelTournament(ctx, d, entrants)
+		return
+	}
+	d.status = store.TournamentRunning
+	d.startedAt = now
+	d.mu.Unlock()
+	if err := c.tournaments.StartTournament(ctx, d.id); err != nil {
+		log.Error().Err(err).Str("tournament_id", d.id).Msg("start tournament failed")
+		d.mu.Lock()
+		d.status = store.TournamentAborted
+		d.mu.Unlock()
+		c.cancelTournament(ctx, d, entrants)
+		return
+	}
+
+	rand.Shuffle(len(entrants), func(i, j int) { entrants[i], entrants[j] = entrants[j], entrants[i] })
+	maxSeats, _ := roomSeating(d.room)
+	tables := (len(entrants) + maxSeats - 1) / maxSeats
+	groups := make([][]*sessionState, tables)
+	for i, ss := range entrants {
+		groups[i%tables] = append(groups[i%tables], ss)
+	}
+	d.mu.Lock()
+	d.finalTable = tables == 1
+	payload := map[string]any{
+		"tournament_id": d.id,
+		"entries":       d.entries,
+		"tables":        tables,
+		"prize_pool_cc": d.prizePoolCC,
+		"started_at":    now,
+	}
+	d.mu.Unlock()
+	d.publish("tournament_started", payload)
+	for _, group := range groups {
+		c.openDirectorTable(ctx, d, group)
+	}
+	if d.cfg.LateRegLevels == 0 {
+		c.closeLateRegistration(ctx, d)
+	}
+	c.seatPending(ctx, d)
+}
+
+// cancelTournament refunds a tournament that did not start and closes the
+// sessions of its entrants.
+func (c *Coordinator) cancelTournament(ctx context.Context, d *tournamentDirector, entrants []*sessionState) {
+	if err := c.tournaments.AbortTournament(ctx, d.id); err != nil && !errors.Is(err, store.ErrTournamentClosed) {
+		log.Error().Err(err).Str("tournament_id", d.id).Msg("cancel tournament failed")
+	}
+	payload := map[string]any{"tournament_id": d.id, "reason": closeReasonTournamentCancelled}
+	c.mu.Lock()
+	for _, ss := range entrants {
+		ss.session.Status = "closed"
+		if ss.buffer != nil {
+			ss.buffer.Append("tournament_cancelled", ss.session.ID, payload)
+			ss.buffer.Append("session_closed", ss.session.ID, map[string]any{"reason": closeReasonTournamentCancelled})
+			ss.buffer.Close()
+		}
+	}
+	delete(c.directors, d.id)
+	c.mu.Unlock()
+	c.releaseSessions(ctx, entrants)
+	d.publish("tournament_cancelled", payload)
+	d.buffer.Close()
+}
+
+// openDirectorTable opens a new table for group and deals its first hand.
+// Players moved from another table bring their stacks along. It reports
+// false, after putting the players back into pending, when the table could
+// not be opened.
+func (c *Coordinator) openDirectorTable(ctx context.Context, d *tournamentDirector, group []*sessionState) bool {
+	tableID := store.NewID()
+	c.mu.Lock()
+	seated := make([]*sessionState, 0, len(group))
+	for _, ss := range group {
+		if ss.session.Status != "closed" {
+			seated = append(seated, ss)
+		}
+	}
+	if len(seated) < 2 {
+		c.mu.Unlock()
+		d.mu.Lock()
+		d.pending = append(d.pending, seated...)
+		d.mu.Unlock()
+		return false
+	}
+	from := map[string]string{}
+	seats := make([]store.SeatAssignment, 0, len(seated))
+	for seat, ss := range seated {
+		if ss.session.TableID != "" {
+			from[ss.agent.ID] = ss.session.TableID
+		}
+		seats = append(seats, store.SeatAssignment{
+			SessionID: ss.session.ID,
+			AgentID:   ss.agent.ID,
+			Seat:      seat,
+			BuyinCC:   d.cfg.StartingChips,
+		})
+	}
+	c.mu.Unlock()
+
+	unseat := func() bool {
+		d.mu.Lock()
+		d.pending = append(d.pending, seated...)
+		d.mu.Unlock()
+		return false
+	}
+	if err := c.tournaments.OpenTournamentTable(ctx, d.id, tableID, d.room.ID, d.room.SmallBlindCC, d.room.BigBlindCC, seats, from); err != nil {
+		log.Error().Err(err).Str("tournament_id", d.id).Str("table_id", tableID).Msg("open tournament table failed")
+		return unseat()
+	}
+	c.mu.Lock()
+	for seat, ss := range seated {
+		ss.session.TableID = tableID
+		ss.session.SeatID = &seat
+		ss.session.Status = "active"
+		ss.disconnected = false
+		ss.disconnectedReason = ""
+		ss.seat = seat
+	}
+	c.mu.Unlock()
+	rt, err := c.startTableRuntime(ctx, tableID, d.room, seated, nil, nil, d)
+	if err != nil {
+		// The stacks are on the new table now, so that is where the
+		// players will be moved from.
+		log.Error().Err(err).Str("tournament_id", d.id).Str("table_id", tableID).Msg("start tournament table failed")
+		return unseat()
+	}
+	d.mu.Lock()
+	d.tables[tableID] = rt
+	d.seated[tableID] = len(seated)
+	d.mu.Unlock()
+
+	c.mu.Lock()
+	agentIDs := make([]string, 0, len(seated))
+	for _, ss := range seated {
+		ss.runtime = rt
+		agentIDs = append(agentIDs, ss.agent.ID)
+	}
+	c.tables[tableID] = rt
+	for _, ss := range seated {
+		if prev := from[ss.agent.ID]; prev != "" && ss.buffer != nil {
+			ss.buffer.Append("table_changed", ss.session.ID, map[string]any{
+				"tournament_id": d.id,
+				"from_table_id": prev,
+				"table_id":      tableID,
+				"seat_id":       ss.seat,
+			})
+		}
+		c.emitSessionJoined(ss)
+	}
+	for _, ss := range seated {
+		c.emitStateSnapshot(ss)
+	}
+	c.emitTurnStarted(rt)
+	c.emitPublicSnapshot(rt)
+	observer := c.tableObserver
+	publicBuffer := rt.publicBuffer
+	c.mu.Unlock()
+	if observer != nil && publicBuffer != nil {
+		observer.OnTableStarted(TableMeta{TableID: tableID, RoomID: d.room.ID}, publicBuffer)
+	}
+	d.publish("table_opened", map[string]any{
+		"tournament_id": d.id,
+		"table_id":      tableID,
+		"agent_ids":     agentIDs,
+	})
+	return true
+}
+
+// seatPending seats the players waiting for a seat, each at the table with
+// the fewest players that has room. When every table is full and at least
+// two players wait, they get a table of their own. The tournament is
+// completed once a single entrant is left. Must be called without holding
+// rt.mu or c.mu.
+func (c *Coordinator) seatPending(ctx context.Context, d *tournamentDirector) {
+	maxSeats, _ := roomSeating(d.room)
+	for {
+		d.mu.Lock()
+		if d.status != store.TournamentRunning {
+			d.mu.Unlock()
+			return
+		}
+		if d.remaining <= 1 {
+			// The winner's table completes the tournament when it closes.
+			done := len(d.tables) == 0
+			d.mu.Unlock()
+			if done {
+				c.completeTournament(ctx, d)
+			}
+			return
+		}
+		if len(d.pending) == 0 {
+			d.mu.Unlock()
+			return
+		}
+		if rt := d.openTableLocked(maxSeats); rt != nil {
+			p := d.pending[0]
+			d.pending = d.pending[1:]
+			d.seated[rt.id]++
+			d.mu.Unlock()
+			if !c.seatEntrant(ctx, d, rt, p) {
+				d.mu.Lock()
+				d.seated[rt.id]--
+				d.pending = append(d.pending, p)
+				d.mu.Unlock()
+				return
+			}
+			continue
+		}
+		if len(d.pending) < 2 {
+			d.mu.Unlock()
+			return
+		}
+		n := min(len(d.pending), maxSeats)
+		group := append([]*sessionState{}, d.pending[:n]...)
+		d.pending = d.pending[n:]
+		d.mu.Unlock()
+		if !c.openDirectorTable(ctx, d, group) {
+			return
+		}
+	}
+}
+
+// seatEntrant seats p at rt, which deals it in from the next hand. It
+// reports false when rt has no free seat or the seat cannot be persisted.
+func (c *Coordinator) seatEntrant(ctx context.Context, d *tournamentDirector, rt *tableRuntime, p *sessionState) bool {
+	c.mu.Lock()
+	rt.mu.Lock()
+	seat := -1
+	if rt.status != tableStatusClosed {
+		seat = rt.freeSeat()
+	}
+	if seat < 0 {
+		rt.mu.Unlock()
+		c.mu.Unlock()
+		return false
+	}
+	rt.players[seat] = p
+	p.pendingBuyin = true
+	fromTableID := p.session.TableID
+	rt.mu.Unlock()
+	c.mu.Unlock()
+
+	stack, err := c.tournaments.SeatTournamentEntry(ctx, d.id, rt.id, store.SeatAssignment{
+		SessionID: p.session.ID,
+		AgentID:   p.agent.ID,
+		Seat:      seat,
+		BuyinCC:   d.cfg.StartingChips,
+	}, fromTableID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if err != nil {
+		log.Error().Err(err).Str("tournament_id", d.id).Str("table_id", rt.id).Str("agent_id", p.agent.ID).Msg("seat tournament entrant failed")
+		if rt.players[seat] == p {
+			rt.players[seat] = nil
+		}
+		return false
+	}
+	if rt.players[seat] != p {
+		// The table broke up while the seat was persisted: the stack is
+		// here now, so the player is moved on from this table.
+		p.session.TableID = rt.id
+		d.mu.Lock()
+		d.pending = append(d.pending, p)
+		d.mu.Unlock()
+		return true
+	}
+	p.pendingBuyin = false
+	p.runtime = rt
+	p.seat = seat
+	p.session.TableID = rt.id
+	p.session.SeatID = &seat
+	p.session.Status = "active"
+	p.disconnected = false
+	p.disconnectedReason = ""
+	payload := map[string]any{
+		"tournament_id": d.id,
+		"table_id":      rt.id,
+		"agent_id":      p.agent.ID,
+		"agent_name":    p.agent.Name,
+		"seat_id":       seat,
+		"stack":         stack,
+	}
+	if fromTableID != "" {
+		payload["from_table_id"] = fromTableID
+	}
+	c.appendReplayEvent(ctx, rt, "player_joined", p.agent.ID, payload)
+	for _, other := range rt.players {
+		if other == nil || other == p || other.buffer == nil {
+			continue
+		}
+		other.buffer.Append("player_joined", other.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append("player_joined", rt.id, payload)
+	}
+	if fromTableID != "" && p.buffer != nil {
+		p.buffer.Append("table_changed", p.session.ID, map[string]any{
+			"tournament_id": d.id,
+			"from_table_id": fromTableID,
+			"table_id":      rt.id,
+			"seat_id":       seat,
+		})
+	}
+	c.emitSessionJoined(p)
+	c.emitStateSnapshot(p)
+	c.emitPublicSnapshot(rt)
+	d.publish("player_seated", payload)
+	return true
+}
+
+// directHandEndLocked runs the director between two hands at rt: queued
+// rebuys and add-ons are sold, busted players rebuy automatically when they
+// asked to and can, or are eliminated, and players are moved off the table
+// to balance the field. Caller must hold rt.mu.
+func (c *Coordinator) directHandEndLocked(ctx context.Context, rt *tableRuntime) {
+	d := rt.director
+	st := rt.engine.State
+	now := time.Now()
+	stack := func(seat int) int64 {
+		if seat < len(st.Players) && st.Players[seat] != nil {
+			return st.Players[seat].Stack
+		}
+		return -1
+	}
+
+	type chipBuy struct {
+		seat  int
+		addon bool
+	}
+	var buys []chipBuy
+	d.mu.Lock()
+	for seat, p := range rt.players {
+		if p == nil {
+			continue
+		}
+		addon, ok := d.buys[p.agent.ID]
+		if !ok {
+			continue
+		}
+		delete(d.buys, p.agent.ID)
+		buys = append(buys, chipBuy{seat: seat, addon: addon})
+	}
+	d.mu.Unlock()
+	for _, b := range buys {
+		c.buyChipsLocked(ctx, rt, b.seat, b.addon)
+	}
+
+	var busted []int
+	for seat, p := range rt.players {
+		if p != nil && stack(seat) == 0 {
+			busted = append(busted, seat)
+		}
+	}
+	sort.SliceStable(busted, func(i, j int) bool {
+		return st.TotalContrib[busted[i]] < st.TotalContrib[busted[j]]
+	})
+	for _, seat := range busted {
+		agentID := rt.players[seat].agent.ID
+		d.mu.Lock()
+		rebuy := d.autoRebuy[agentID] && d.rebuyOpenLocked(now, agentID)
+		d.mu.Unlock()
+		if rebuy && c.buyChipsLocked(ctx, rt, seat, false) {
+			continue
+		}
+		d.mu.Lock()
+		place := d.placeLocked(agentID)
+		d.seated[rt.id]--
+		d.mu.Unlock()
+		if err := c.tournaments.EliminateTournamentEntry(ctx, d.id, agentID, place); err != nil {
+			log.Error().Err(err).Str("tournament_id", d.id).Str("agent_id", agentID).Msg("record tournament elimination failed")
+		}
+		payload := map[string]any{"place": place, "players_left": place - 1}
+		c.removeEliminatedLocked(ctx, rt, seat, payload)
+		d.publish("player_eliminated", payload)
+	}
+
+	maxSeats, _ := roomSeating(d.room)
+	d.mu.Lock()
+	moves, breaking := d.planMovesLocked(rt.id, maxSeats)
+	d.mu.Unlock()
+	if moves > 0 {
+		reason := "table_balanced"
+		if breaking {
+			reason = closeReasonTableBroken
+		}
+		seats := rt.nextBigBlindSeats()
+		c.moveOutLocked(ctx, rt, seats[:min(moves, len(seats))], reason)
+	}
+	c.directLevelLocked(ctx, d, now)
+}
+
+// buyChipsLocked sells the player at seat a rebuy or the add-on and reports
+// whether it went through; the chips play from the next hand. Caller must
+// hold rt.mu.
+func (c *Coordinator) buyChipsLocked(ctx context.Context, rt *tableRuntime, seat int, addon bool) bool {
+	d := rt.director
+	p := rt.players[seat]
+	now := time.Now()
+	d.mu.Lock()
+	open := d.rebuyOpenLocked(now, p.agent.ID)
+	if addon {
+		open = d.addonOpenLocked(now, p.agent.ID)
+	}
+	d.mu.Unlock()
+	st := rt.engine.State
+	reason := ""
+	switch {
+	case !open:
+		reason = errRebuyUnavailable.Error()
+	case !addon && seat < len(st.Players) && st.Players[seat] != nil && st.Players[seat].Stack > d.cfg.StartingChips:
+		reason = "stack_too_large"
+	}
+	var chips int64
+	if reason == "" {
+		var err error
+		chips, err = c.tournaments.BuyTournamentChips(ctx, d.id, rt.id, p.agent.ID, addon)
+		if err != nil {
+			reason = err.Error()
+		}
+	}
+	event, fee := "player_rebuy", d.cfg.EntryFeeCC
+	if addon {
+		event, fee = "player_addon", d.cfg.AddonFeeCC
+	}
+	if reason != "" {
+		if p.buffer != nil {
+			p.buffer.Append("rebuy_failed", p.session.ID, map[string]any{
+				"tournament_id": d.id,
+				"addon":         addon,
+				"reason":        reason,
+			})
+		}
+		return false
+	}
+	d.mu.Lock()
+	if addon {
+		d.addons[p.agent.ID] = true
+	} else {
+		d.rebuys[p.agent.ID]++
+	}
+	d.prizePoolCC += fee
+	payload := map[string]any{
+		"tournament_id": d.id,
+		"table_id":      rt.id,
+		"agent_id":      p.agent.ID,
+		"seat_id":       seat,
+		"fee_cc":        fee,
+		"stack":         chips,
+		"rebuys":        d.rebuys[p.agent.ID],
+		"prize_pool_cc": d.prizePoolCC,
+	}
+	d.mu.Unlock()
+	c.appendReplayEvent(ctx, rt, event, p.agent.ID, payload)
+	for _, other := range rt.players {
+		if other == nil || other.buffer == nil {
+			continue
+		}
+		other.buffer.Append(event, other.session.ID, payload)
+	}
+	if rt.publicBuffer != nil {
+		rt.publicBuffer.Append(event, rt.id, payload)
+	}
+	d.publish(event, payload)
+	return true
+}
+
+// nextBigBlindSeats returns the seats dealt into the next hand in the order
+// they will post the big blind, starting with the next hand's. Moving
+// players in this order keeps anyone from skipping the blinds. Caller must
+// hold rt.mu.
+func (rt *tableRuntime) nextBigBlindSeats() []int {
+	n := len(rt.players)
+	var seats []int
+	for step := 1; step <= n; step++ {
+		if seat := (rt.engine.State.DealerPos + step) % n; rt.players[seat] != nil && !rt.players[seat].pendingBuyin {
+			seats = append(seats, seat)
+		}
+	}
+	// seats starts with the next dealer; heads-up the dealer posts the
+	// small blind and the other player the big blind.
+	first := 2
+	if len(seats) == 2 {
+		first = 1
+	}
+	if len(seats) <= first {
+		return seats
+	}
+	return append(seats[first:], seats[:first]...)
+}
+
+// moveOutLocked takes the players at seats off rt and hands them to the
+// director to be seated elsewhere; their stacks stay on rt until then. A
+// player still being seated here is left to seatEntrant. Caller must hold
+// rt.mu.
+func (c *Coordinator) moveOutLocked(ctx context.Context, rt *tableRuntime, seats []int, reason string) {
+	d := rt.director
+	moved := make([]*sessionState, 0, len(seats))
+	for _, seat := range seats {
+		p := rt.players[seat]
+		if p == nil {
+			continue
+		}
+		rt.players[seat] = nil
+		if p.pendingBuyin {
+			continue
+		}
+		p.runtime = nil
+		moved = append(moved, p)
+		payload := map[string]any{
+			"tournament_id": d.id,
+			"table_id":      rt.id,
+			"agent_id":      p.agent.ID,
+			"seat_id":       seat,
+			"reason":        reason,
+		}
+		c.appendReplayEvent(ctx, rt, "player_left", p.agent.ID, payload)
+		for _, other := range rt.players {
+			if other == nil || other.buffer == nil {
+				continue
+			}
+			other.buffer.Append("player_left", other.session.ID, payload)
+		}
+		if rt.publicBuffer != nil {
+			rt.publicBuffer.Append("player_left", rt.id, payload)
+		}
+		if p.buffer != nil {
+			p.buffer.Append("player_left", p.session.ID, payload)
+		}
+	}
+	d.mu.Lock()
+	d.pending = append(d.pending, moved...)
+	d.seated[rt.id] -= len(moved)
+	d.mu.Unlock()
+}
+
+// directLevelLocked announces a new blind level on the tournament stream
+// and closes late registration once its last level is over. The payout
+// structure is fixed at that point, when the field is known.
+func (c *Coordinator) directLevelLocked(ctx context.Context, d *tournamentDirector, now time.Time) {
+	d.mu.Lock()
+	level := d.levelLocked(now)
+	announce := level > d.announcedLevel
+	if announce {
+		d.announcedLevel = level
+	}
+	closeReg := !d.lateRegClosed && level >= d.cfg.LateRegLevels
+	d.mu.Unlock()
+	if announce {
+		l := d.blinds.Levels[level]
+		d.publish("blind_level_changed", map[string]any{
+			"tournament_id":  d.id,
+			"level":          level,
+			"small_blind_cc": l.SmallBlind,
+			"big_blind_cc":   l.BigBlind,
+			"ante_cc":        l.Ante,
+		})
+	}
+	if closeReg {
+		c.closeLateRegistration(ctx, d)
+	}
+}
+
+// closeLateRegistration stops registration and fixes the payouts by the
+// size of the field.
+func (c *Coordinator) closeLateRegistration(ctx context.Context, d *tournamentDirector) {
+	d.mu.Lock()
+	if d.lateRegClosed {
+		d.mu.Unlock()
+		return
+	}
+	d.lateRegClosed = true
+	d.payoutBps = game.MultiTablePayouts(d.entries)
+	bps := d.payoutBps
+	payload := map[string]any{
+		"tournament_id": d.id,
+		"entries":       d.entries,
+		"prize_pool_cc": d.prizePoolCC,
+		"payout_bps":    bps,
+	}
+	d.mu.Unlock()
+	if err := c.tournaments.SetTournamentPayouts(ctx, d.id, bps); err != nil {
+		log.Error().Err(err).Str("tournament_id", d.id).Msg("set tournament payouts failed")
+	}
+	d.publish("late_registration_closed", payload)
+}
+
+// withdrawSeatedLocked takes a player who forfeits their seat at rt out of
+// the tournament. Caller must hold rt.mu.
+func (c *Coordinator) withdrawSeatedLocked(ctx context.Context, rt *tableRuntime, p *sessionState) {
+	d := rt.director
+	d.mu.Lock()
+	if _, ok := d.entrants[p.agent.ID]; !ok {
+		d.mu.Unlock()
+		return
+	}
+	place := d.placeLocked(p.agent.ID)
+	d.seated[rt.id]--
+	d.mu.Unlock()
+	if err := c.tournaments.EliminateTournamentEntry(ctx, d.id, p.agent.ID, place); err != nil {
+		log.Error().Err(err).Str("tournament_id", d.id).Str("agent_id", p.agent.ID).Msg("record tournament elimination failed")
+	}
+	d.publish("player_eliminated", map[string]any{
+		"tournament_id": d.id,
+		"table_id":      rt.id,
+		"agent_id":      p.agent.ID,
+		"place":         place,
+		"players_left":  place - 1,
+		"reason":        "forfeit",
+	})
+}
+
+// finishDirectorTable closes a tournament table that cannot deal another
+// hand. Its players wait for seats elsewhere, unless the last of them has
+// won the tournament. Must be called without holding rt.mu.
+func (c *Coordinator) finishDirectorTable(ctx context.Context, rt *tableRuntime) {
+	d := rt.director
+	rt.mu.Lock()
+	if rt.status == tableStatusClosed {
+		rt.mu.Unlock()
+		return
+	}
+	d.mu.Lock()
+	over := d.remaining <= 1 || d.status != store.TournamentRunning
+	d.mu.Unlock()
+	reason := closeReasonTableBroken
+	if over {
+		reason = closeReasonTournamentComplete
+		if payload, _ := c.completeStandings(ctx, d); payload != nil {
+			c.appendReplayEvent(ctx, rt, "tournament_completed", "", payload)
+			for _, p := range rt.players {
+				if p == nil || p.buffer == nil {
+					continue
+				}
+				p.buffer.Append("tournament_completed", p.session.ID, payload)
+			}
+			if rt.publicBuffer != nil {
+				rt.publicBuffer.Append("tournament_completed", rt.id, payload)
+			}
+		}
+	} else {
+		var seats []int
+		for seat, p := range rt.players {
+			if p != nil {
+				seats = append(seats, seat)
+			}
+		}
+		c.moveOutLocked(ctx, rt, seats, reason)
+	}
+	seated := c.closeTableLocked(ctx, rt, reason)
+	rt.mu.Unlock()
+	c.releaseClosedTable(ctx, rt, seated)
+}
+
+// directorTableClosed drops a closed table from its tournament, announces
+// the final table once a single table is left and seats anyone waiting.
+// Must be called without holding rt.mu.
+func (c *Coordinator) directorTableClosed(ctx context.Context, rt *tableRuntime) {
+	d := rt.director
+	rt.mu.Lock()
+	reason := rt.closeReason
+	rt.mu.Unlock()
+	maxSeats, _ := roomSeating(d.room)
+	d.mu.Lock()
+	delete(d.tables, rt.id)
+	delete(d.seated, rt.id)
+	delete(d.breaking, rt.id)
+	var finalTableID string
+	if d.status == store.TournamentRunning && !d.finalTable && len(d.tables) == 1 && d.remaining <= maxSeats {
+		d.finalTable = true
+		for id := range d.tables {
+			finalTableID = id
+		}
+	}
+	left := d.remaining
+	d.mu.Unlock()
+	d.publish("table_closed", map[string]any{
+		"tournament_id": d.id,
+		"table_id":      rt.id,
+		"reason":        reason,
+	})
+	if finalTableID != "" {
+		d.publish("final_table", map[string]any{
+			"tournament_id": d.id,
+			"table_id":      finalTableID,
+			"players_left":  left,
+		})
+	}
+	c.seatPending(ctx, d)
+	c.finishDirector(ctx, d)
+}
+
+// completeStandings ends a tournament that has a single entrant left, who
+// wins it, and splits the prize pool by the payout structure. It returns
+// the announcement of the final standings, nil while the tournament is not
+// over, and whether this call completed it. Must be called without holding
+// d.mu.
+func (c *Coordinator) completeStandings(ctx context.Context, d *tournamentDirector) (map[string]any, bool) {
+	d.mu.Lock()
+	if d.completion != nil || d.status != store.TournamentRunning || d.remaining > 1 {
+		defer d.mu.Unlock()
+		return d.completion, false
+	}
+	d.mu.Unlock()
+	t, err := c.tournaments.GetTournament(ctx, d.id)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.status != store.TournamentRunning {
+		return d.completion, false
+	}
+	pool := d.prizePoolCC
+	if err == nil {
+		pool = t.PrizePoolCC
+	}
+	for agentID := range d.entrants {
+		d.places[agentID] = 1
+	}
+	d.remaining = 0
+	d.status = store.TournamentCompleted
+	bps := d.payoutBps
+	if bps == nil {
+		bps = game.MultiTablePayouts(d.entries)
+	}
+	payouts := game.SplitPrizePool(pool, bps)
+	d.results = make([]store.TournamentResult, 0, len(d.places))
+	for agentID, place := range d.places {
+		var payout int64
+		if place <= len(payouts) {
+			payout = payouts[place-1]
+		}
+		d.results = append(d.results, store.TournamentResult{AgentID: agentID, Place: place, PayoutCC: payout})
+	}
+	sort.Slice(d.results, func(i, j int) bool { return d.results[i].Place < d.results[j].Place })
+	standings := make([]map[string]any, 0, len(d.results))
+	for _, r := range d.results {
+		standings = append(standings, map[string]any{"agent_id": r.AgentID, "place": r.Place, "payout_cc": r.PayoutCC})
+	}
+	d.completion = map[string]any{
+		"tournament_id": d.id,
+		"entries":       d.entries,
+		"prize_pool_cc": pool,
+		"standings":     standings,
+	}
+	return d.completion, true
+}
+
+// completeTournament ends a tournament whose last entrant is not seated at
+// a table, and releases the sessions still waiting for a seat.
+func (c *Coordinator) completeTournament(ctx context.Context, d *tournamentDirector) {
+	payload, _ := c.completeStandings(ctx, d)
+	if payload == nil {
+		return
+	}
+	d.mu.Lock()
+	waiting := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+	c.mu.Lock()
+	for _, p := range waiting {
+		p.session.Status = "closed"
+		if p.buffer != nil {
+			p.buffer.Append("tournament_completed", p.session.ID, payload)
+			p.buffer.Append("session_closed", p.session.ID, map[string]any{"reason": closeReasonTournamentComplete})
+			p.buffer.Close()
+		}
+	}
+	c.mu.Unlock()
+	c.releaseSessions(ctx, waiting)
+	c.finishDirector(ctx, d)
+}
+
+// finishDirector pays out a completed tournament and retires its director.
+func (c *Coordinator) finishDirector(ctx context.Context, d *tournamentDirector) {
+	d.mu.Lock()
+	if d.status != store.TournamentCompleted || d.finished {
+		d.mu.Unlock()
+		return
+	}
+	d.finished = true
+	results := d.results
+	payload := d.completion
+	d.mu.Unlock()
+	err := c.tournaments.FinishTournament(ctx, d.id, results)
+	if err == nil {
+		c.rateTournament(ctx, d.id, results)
+	} else if !errors.Is(err, store.ErrTournamentClosed) {
+		log.Error().Err(err).Str("tournament_id", d.id).Msg("finish tournament failed")
+	}
+	c.mu.Lock()
+	delete(c.directors, d.id)
+	c.mu.Unlock()
+	d.publish("tournament_completed", payload)
+	d.buffer.Close()
+}
+
+// QueueTournamentChips queues a rebuy or, with addon set, the add-on for
+// the multi-table tournament a session plays. It is sold when the player's
+// current hand ends.
+func (c *Coordinator) QueueTournamentChips(ctx context.Context, sessionID string, addon bool) error {
+	c.mu.Lock()
+	sess := c.sessions[sessionID]
+	if sess == nil {
+		c.mu.Unlock()
+		return errSessionNotFound
+	}
+	d := sess.director
+	c.mu.Unlock()
+	if d == nil {
+		return errTournamentNotFound
+	}
+	now := time.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.entrants[sess.agent.ID]; !ok {
+		return errRebuyUnavailable
+	}
+	open := d.rebuyOpenLocked(now, sess.agent.ID)
+	if addon {
+		open = d.addonOpenLocked(now, sess.agent.ID)
+	}
+	if !open {
+		return errRebuyUnavailable
+	}
+	d.buys[sess.agent.ID] = addon
+	return nil
+}
+
+// GetTournamentBuffer returns the event stream of a running multi-table
+// tournament.
+func (c *Coordinator) GetTournamentBuffer(tournamentID string) *EventBuffer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d := c.directors[tournamentID]; d != nil {
+		return d.buffer
+	}
+	return nil
+}
+
+// GetTournamentProgress reports the live state of a multi-table tournament
+// the coordinator runs: the blind level, the field and every table.
+func (c *Coordinator) GetTournamentProgress(tournamentID string) (TournamentProgress, error) {
+	c.mu.Lock()
+	d := c.directors[tournamentID]
+	c.mu.Unlock()
+	if d == nil {
+		return TournamentProgress{}, errTournamentNotFound
+	}
+	now := time.Now()
+	d.mu.Lock()
+	level := d.levelLocked(now)
+	out := TournamentProgress{
+		TournamentID:     d.id,
+		Name:             d.cfg.Name,
+		RoomID:           d.room.ID,
+		Status:           d.status,
+		StartsAt:         d.cfg.StartsAt,
+		Entries:          d.entries,
+		PlayersLeft:      d.remaining,
+		PlayersWaiting:   len(d.pending),
+		PrizePoolCC:      d.prizePoolCC,
+		LateRegistration: d.lateRegOpenLocked(now),
+		FinalTable:       d.finalTable,
+		BlindLevel:       level,
+		Tables:           []TournamentTable{},
+	}
+	if d.payoutBps != nil {
+		out.PayoutsCC = game.SplitPrizePool(d.prizePoolCC, d.payoutBps)
+	}
+	if d.status == store.TournamentRunning {
+		out.StartedAt = &d.startedAt
+		if d.blinds.Every > 0 && level < len(d.blinds.Levels)-1 {
+			next := d.startedAt.Add(time.Duration(level+1) * d.blinds.Every)
+			out.NextLevelAt = &next
+		}
+	}
+	tables := make([]*tableRuntime, 0, len(d.tables))
+	for _, id := range d.liveTablesLocked() {
+		tables = append(tables, d.tables[id])
+	}
+	d.mu.Unlock()
+	if len(d.blinds.Levels) > 0 {
+		l := d.blinds.Levels[level]
+		out.SmallBlind, out.BigBlind, out.Ante = l.SmallBlind, l.BigBlind, l.Ante
+	}
+
+	for _, rt := range tables {
+		rt.mu.Lock()
+		table := TournamentTable{TableID: rt.id, Players: []TournamentSeat{}}
+		st := rt.engine.State
+		for seat, p := range rt.players {
+			if p == nil {
+				continue
+			}
+			entry := TournamentSeat{AgentID: p.agent.ID, AgentName: p.agent.Name, SeatID: seat}
+			if seat < len(st.Players) && st.Players[seat] != nil && st.Players[seat].ID == p.agent.ID {
+				entry.StackChips = st.Players[seat].Stack
+			}
+			table.Players = append(table.Players, entry)
+		}
+		rt.mu.Unlock()
+		out.Tables = append(out.Tables, table)
+	}
+	return out, nil
+}
+
+// multiTableStatus is GetTournamentStatus for a session entered in a
+// multi-table tournament.
+func (c *Coordinator) multiTableStatus(sessionID, agentID string, d *tournamentDirector, rt *tableRuntime, seat int) TournamentStatus {
+	now := time.Now()
+	d.mu.Lock()
+	level := d.levelLocked(now)
+	out := TournamentStatus{
+		SessionID:        sessionID,
+		Status:           d.status,
+		RoomID:           d.room.ID,
+		TournamentID:     d.id,
+		Name:             d.cfg.Name,
+		StartsAt:         d.cfg.StartsAt,
+		Registered:       d.entries,
+		MaxPlayers:       d.cfg.MaxPlayers,
+		PlayersLeft:      d.remaining,
+		Tables:           len(d.tables),
+		EntryFeeCC:       d.cfg.EntryFeeCC,
+		StartingChips:    d.cfg.StartingChips,
+		PrizePoolCC:      d.prizePoolCC,
+		BlindLevel:       level,
+		Rebuys:           d.rebuys[agentID],
+		Addon:            d.addons[agentID],
+		LateRegistration: d.lateRegOpenLocked(now),
+		RebuyOpen:        d.rebuyOpenLocked(now, agentID),
+		AddonOpen:        d.addonOpenLocked(now, agentID),
+	}
+	if d.status == store.TournamentScheduled {
+		out.Status = "registering"
+	}
+	if d.payoutBps != nil {
+		out.PayoutsCC = game.SplitPrizePool(d.prizePoolCC, d.payoutBps)
+	}
+	d.mu.Unlock()
+	if len(d.blinds.Levels) > 0 {
+		l := d.blinds.Levels[level]
+		out.SmallBlind, out.BigBlind, out.Ante = l.SmallBlind, l.BigBlind, l.Ante
+	}
+	if rt != nil {
+		rt.mu.Lock()
+		out.TableID = rt.id
+		if st := rt.engine.State; seat < len(st.Players) && st.Players[seat] != nil {
+			out.StackChips = st.Players[seat].Stack
+		}
+		rt.mu.Unlock()
+	}
+	return out
+}
@@ -0,0 +1,287 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+)
+
+// fakeTournamentStore records the eliminations, seats and results a
+// director or sit-and-go table persists. Any other store call panics on the
+// nil embedded interface.
+type fakeTournamentStore struct {
+	tournamentStore
+
+	mu         sync.Mutex
+	eliminated map[string]int
+	seatedAt   map[string]string
+	movedFrom  map[string]string
+	// finished holds the results of every finished tournament.
+	finished map[string][]store.TournamentResult
+}
+
+func newFakeTournamentStore() *fakeTournamentStore {
+	return &fakeTournamentStore{
+		eliminated: map[string]int{},
+		seatedAt:   map[string]string{},
+		movedFrom:  map[string]string{},
+		finished:   map[string][]store.TournamentResult{},
+	}
+}
+
+func (f *fakeTournamentStore) EliminateTournamentEntry(_ context.Context, _ string, agentID string, place int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.eliminated[agentID] = place
+	return nil
+}
+
+func (f *fakeTournamentStore) FinishTournament(_ context.Context, tournamentID string, results []store.TournamentResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.finished[tournamentID]; ok {
+		return store.ErrTournamentClosed
+	}
+	f.finished[tournamentID] = results
+	return nil
+}
+
+func (f *fakeTournamentStore) SeatTournamentEntry(_ context.Context, _ string, tableID string, seat store.SeatAssignment, fromTableID string) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seatedAt[seat.AgentID] = tableID
+	f.movedFrom[seat.AgentID] = fromTableID
+	return seat.BuyinCC, nil
+}
+
+// newTestDirector returns a running tournament director for a room with
+// maxSeats seats per table.
+func newTestDirector(maxSeats int) *tournamentDirector {
+	room := &store.Room{ID: "room-mtt", SmallBlindCC: 50, BigBlindCC: 100, MaxSeats: maxSeats}
+	d := newTournamentDirector(store.Tournament{
+		ID:            "mtt-1",
+		RoomID:        room.ID,
+		Status:        store.TournamentRunning,
+		StartingChips: 1000,
+		LateRegLevels: 1,
+	}, room, nil)
+	d.startedAt = time.Now()
+	return d
+}
+
+// addDirectorTable seats one entrant per stack at a new table of d, as if
+// the director had opened it mid-hand.
+func addDirectorTable(d *tournamentDirector, tableID string, stacks ...int64) *tableRuntime {
+	maxSeats, _ := roomSeating(d.room)
+	engine := game.NewEngine(nil, nil, tableID, d.room.SmallBlindCC, d.room.BigBlindCC)
+	engine.State.Players = make([]*game.Player, maxSeats)
+	engine.State.RoundBets = make([]int64, maxSeats)
+	engine.State.TotalContrib = make([]int64, maxSeats)
+	engine.State.Acted = make([]bool, maxSeats)
+	engine.State.ActedBet = make([]int64, maxSeats)
+	rt := &tableRuntime{
+		id:               tableID,
+		room:             d.room,
+		director:         d,
+		engine:           engine,
+		players:          make([]*sessionState, maxSeats),
+		status:           tableStatusActive,
+		disconnectedSeat: -1,
+		turnSeat:         -1,
+	}
+	for seat, stack := range stacks {
+		agentID := fmt.Sprintf("%s-%d", tableID, seat)
+		ss := &sessionState{
+			session:  store.AgentSession{ID: "sess-" + agentID, AgentID: agentID, RoomID: d.room.ID, TableID: tableID, Status: "active"},
+			agent:    &store.Agent{ID: agentID, Name: agentID},
+			buffer:   NewEventBuffer(100),
+			director: d,
+			runtime:  rt,
+			seat:     seat,
+		}
+		rt.players[seat] = ss
+		engine.State.Players[seat] = &game.Player{ID: agentID, Name: agentID, Stack: stack, Seat: seat}
+		d.entrants[agentID] = ss
+		d.entries++
+		d.remaining++
+	}
+	d.tables[tableID] = rt
+	d.seated[tableID] = len(stacks)
+	return rt
+}
+
+func TestPlanMovesLocked(t *testing.T) {
+	cases := []struct {
+		name      string
+		seated    map[string]int
+		breaking  string
+		remaining int
+		pending   int
+		table     string
+		moves     int
+		breaks    bool
+	}{
+		{name: "balanced tables stay", seated: map[string]int{"a": 3, "b": 3}, remaining: 6, table: "a"},
+		{name: "one apart stays", seated: map[string]int{"a": 4, "b": 3}, remaining: 7, table: "a"},
+		{name: "two apart moves one", seated: map[string]int{"a": 4, "b": 2}, remaining: 6, table: "a", moves: 1},
+		{name: "smaller table keeps its players", seated: map[string]int{"a": 4, "b": 2}, remaining: 6, table: "b"},
+		{name: "smallest breaks when the field fits one table less", seated: map[string]int{"a": 1, "b": 3}, remaining: 4, table: "a", moves: 1, breaks: true},
+		{name: "largest does not break", seated: map[string]int{"a": 1, "b": 3}, remaining: 4, table: "b", moves: 1},
+		{name: "breaking table moves everyone", seated: map[string]int{"a": 3, "b": 3}, breaking: "a", remaining: 6, table: "a", moves: 3, breaks: true},
+		{name: "full field makes room for the one waiting", seated: map[string]int{"a": 4, "b": 4}, remaining: 9, pending: 1, table: "a", moves: 1},
+		{name: "only the largest makes room", seated: map[string]int{"a": 4, "b": 4}, remaining: 9, pending: 1, table: "b"},
+		{name: "two waiting get a table of their own", seated: map[string]int{"a": 4, "b": 4}, remaining: 10, pending: 2, table: "a"},
+		{name: "single full table makes room", seated: map[string]int{"a": 4}, remaining: 5, pending: 1, table: "a", moves: 1},
+		{name: "single table with a free seat stays", seated: map[string]int{"a": 3}, remaining: 4, pending: 1, table: "a"},
+	}
+	for _, tc := range cases {
+		d := newTestDirector(4)
+		for id, n := range tc.seated {
+			d.tables[id] = &tableRuntime{id: id}
+			d.seated[id] = n
+		}
+		if tc.breaking != "" {
+			d.breaking[tc.breaking] = true
+		}
+		d.remaining = tc.remaining
+		for i := 0; i < tc.pending; i++ {
+			d.pending = append(d.pending, &sessionState{})
+		}
+		moves, breaks := d.planMovesLocked(tc.table, 4)
+		if moves != tc.moves || breaks != tc.breaks {
+			t.Fatalf("%s: got (%d, %v), want (%d, %v)", tc.name, moves, breaks, tc.moves, tc.breaks)
+		}
+		if d.breaking[tc.table] != tc.breaks {
+			t.Fatalf("%s: expected breaking=%v for table %s", tc.name, tc.breaks, tc.table)
+		}
+	}
+}
+
+func TestOpenTableLocked(t *testing.T) {
+	d := newTestDirector(4)
+	for id, n := range map[string]int{"a": 4, "b": 2, "c": 2} {
+		d.tables[id] = &tableRuntime{id: id}
+		d.seated[id] = n
+	}
+	if rt := d.openTableLocked(4); rt == nil || rt.id != "b" {
+		t.Fatalf("expected the first of the smallest tables, got %+v", rt)
+	}
+	d.breaking["b"] = true
+	if rt := d.openTableLocked(4); rt == nil || rt.id != "c" {
+		t.Fatalf("expected a breaking table to be skipped, got %+v", rt)
+	}
+	d.seated["c"] = 4
+	if rt := d.openTableLocked(4); rt != nil {
+		t.Fatalf("expected no open table when every live table is full, got %s", rt.id)
+	}
+}
+
+func TestNextBigBlindSeats(t *testing.T) {
+	cases := []struct {
+		name    string
+		seats   int
+		players []int
+		pending []int
+		dealer  int
+		want    []int
+	}{
+		{name: "full ring", seats: 4, players: []int{0, 1, 2, 3}, dealer: 0, want: []int{3, 0, 1, 2}},
+		{name: "wraps past the last seat", seats: 4, players: []int{0, 1, 2, 3}, dealer: 2, want: []int{1, 2, 3, 0}},
+		{name: "skips empty seats and players still being seated", seats: 6, players: []int{0, 1, 3, 4}, pending: []int{4}, dealer: 3, want: []int{3, 0, 1}},
+		{name: "heads-up the non-dealer posts the big blind", seats: 4, players: []int{0, 2}, dealer: 0, want: []int{0, 2}},
+		{name: "lone player", seats: 4, players: []int{1}, dealer: 1, want: []int{1}},
+	}
+	for _, tc := range cases {
+		rt := &tableRuntime{
+			engine:  game.NewEngine(nil, nil, "t", 50, 100),
+			players: make([]*sessionState, tc.seats),
+		}
+		rt.engine.State.DealerPos = tc.dealer
+		for _, seat := range tc.players {
+			rt.players[seat] = &sessionState{}
+		}
+		for _, seat := range tc.pending {
+			rt.players[seat].pendingBuyin = true
+		}
+		if got := rt.nextBigBlindSeats(); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTournamentDirectorBustBreakFinalTable(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeTournamentStore()
+	c := NewCoordinator(nil, nil)
+	c.tournaments = fake
+	d := newTestDirector(4)
+	c.directors[d.id] = d
+	a := addDirectorTable(d, "table-a", 1000, 0, 0)
+	b := addDirectorTable(d, "table-b", 1000, 1000, 1000)
+	a.engine.State.TotalContrib[1] = 100
+	a.engine.State.TotalContrib[2] = 300
+
+	// Two players bust at table a. The four left fit on one table, so a,
+	// now the smallest, breaks and its last player waits for a seat.
+	a.mu.Lock()
+	c.directHandEndLocked(ctx, a)
+	a.mu.Unlock()
+	if got := fake.eliminated; !reflect.DeepEqual(got, map[string]int{"table-a-1": 6, "table-a-2": 5}) {
+		t.Fatalf("expected the smaller contribution to finish behind, got %v", got)
+	}
+	d.mu.Lock()
+	remaining, breaking, pending := d.remaining, d.breaking[a.id], len(d.pending)
+	d.mu.Unlock()
+	if remaining != 4 || !breaking || pending != 1 {
+		t.Fatalf("expected table a to break with 4 left, got remaining=%d breaking=%v pending=%d", remaining, breaking, pending)
+	}
+	if a.seatedCount() != 0 {
+		t.Fatalf("expected table a to be empty, got %d seated", a.seatedCount())
+	}
+
+	c.seatPending(ctx, d)
+	moved := b.players[3]
+	if moved == nil || moved.agent.ID != "table-a-0" || moved.pendingBuyin || moved.runtime != b {
+		t.Fatalf("expected table-a-0 seated at table b seat 3, got %+v", moved)
+	}
+	if fake.seatedAt["table-a-0"] != b.id || fake.movedFrom["table-a-0"] != a.id {
+		t.Fatalf("expected the seat persisted as a move from a to b, got at=%q from=%q", fake.seatedAt["table-a-0"], fake.movedFrom["table-a-0"])
+	}
+	if moved.session.TableID != b.id || moved.session.SeatID == nil || *moved.session.SeatID != 3 {
+		t.Fatalf("expected the moved session to point at table b seat 3, got %+v", moved.session)
+	}
+
+	// Closing the broken table leaves b as the final table.
+	a.mu.Lock()
+	c.closeTableLocked(ctx, a, closeReasonTableBroken)
+	a.mu.Unlock()
+	c.directorTableClosed(ctx, a)
+
+	d.mu.Lock()
+	finalTable, tables := d.finalTable, len(d.tables)
+	d.mu.Unlock()
+	if !finalTable || tables != 1 {
+		t.Fatalf("expected a single final table, got final=%v tables=%d", finalTable, tables)
+	}
+	var events []string
+	var final map[string]any
+	for _, ev := range d.buffer.ReplayAfter("") {
+		events = append(events, ev.Event)
+		if ev.Event == "final_table" {
+			final = ev.Data.(map[string]any)
+		}
+	}
+	want := []string{"player_eliminated", "player_eliminated", "player_seated", "table_closed", "final_table"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("got tournament events %v, want %v", events, want)
+	}
+	if final["table_id"] != b.id || final["players_left"] != 4 {
+		t.Fatalf("expected final table b with 4 left, got %v", final)
+	}
+}
@@ -7,6 +7,17 @@ type CreateSessionRequest struct {
 	APIKey   string `json:"api_key"`
 	JoinMode string `json:"join_mode"`
 	RoomID   string `json:"room_id,omitempty"`
+	LeagueID string `json:"league_id,omitempty"`
+	BuyinCC  *int64 `json:"buyin_cc,omitempty"`
+	// OpponentAgentID issues a challenge and ChallengeID accepts one, with
+	// JoinMode "challenge".
+	OpponentAgentID string `json:"opponent_agent_id,omitempty"`
+	ChallengeID     string `json:"challenge_id,omitempty"`
+	// With JoinMode "private", InviteCode joins a private table; without
+	// it a new one is opened for InviteAgentIDs, or for anyone given its
+	// code when empty.
+	InviteCode     string   `json:"invite_code,omitempty"`
+	InviteAgentIDs []string `json:"invite_agent_ids,omitempty"`
 }
 
 type CreateSessionResponse struct {
@@ -14,8 +25,38 @@ type CreateSessionResponse struct {
 	TableID   string    `json:"table_id,omitempty"`
 	RoomID    string    `json:"room_id"`
 	SeatID    *int      `json:"seat_id,omitempty"`
+	BuyinCC   int64     `json:"buyin_cc"`
 	StreamURL string    `json:"stream_url"`
 	ExpiresAt time.Time `json:"expires_at"`
+	// ChallengeID or InviteCode is set for sessions of a challenge or a
+	// private table.
+	ChallengeID string `json:"challenge_id,omitempty"`
+	InviteCode  string `json:"invite_code,omitempty"`
+}
+
+// SessionStatus is where a session stands: queued, with its place in the
+// queue, or seated at a table.
+type SessionStatus struct {
+	SessionID string       `json:"session_id"`
+	Status    string       `json:"status"`
+	JoinMode  string       `json:"join_mode"`
+	RoomID    string       `json:"room_id"`
+	TableID   string       `json:"table_id,omitempty"`
+	SeatID    *int         `json:"seat_id,omitempty"`
+	Queue     *QueueStatus `json:"queue,omitempty"`
+}
+
+// QueueStatus is a waiting session's place in its queue, 1 being next. A
+// ranked session also reports its rating, the rating gap it accepts by now
+// (none once any opponent will do) and, when the room has paired agents
+// before, an estimate of the wait left.
+type QueueStatus struct {
+	Position        int      `json:"position"`
+	QueueSize       int      `json:"queue_size"`
+	WaitedMS        int64    `json:"waited_ms,omitempty"`
+	EstimatedWaitMS *int64   `json:"estimated_wait_ms,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+	RatingGap       *float64 `json:"rating_gap,omitempty"`
 }
 
 type ActionRequest struct {
@@ -35,3 +76,82 @@ type ActionResponse struct {
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// RegisterTournamentRequest enters a sit-and-go room by RoomID or a
+// scheduled multi-table tournament by TournamentID. AutoRebuy buys back in
+// automatically when the agent busts while rebuys are open.
+type RegisterTournamentRequest struct {
+	AgentID      string `json:"agent_id"`
+	APIKey       string `json:"api_key"`
+	RoomID       string `json:"room_id,omitempty"`
+	TournamentID string `json:"tournament_id,omitempty"`
+	AutoRebuy    bool   `json:"auto_rebuy,omitempty"`
+}
+
+// TournamentStatus is a session's view of its tournament. Status is
+// "registering" until the table fills or the start time comes, then
+// "running". Stacks and blinds are in tournament chips. A multi-table
+// player between tables has no TableID.
+type TournamentStatus struct {
+	SessionID        string     `json:"session_id"`
+	Status           string     `json:"status"`
+	RoomID           string     `json:"room_id"`
+	TournamentID     string     `json:"tournament_id,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	StartsAt         *time.Time `json:"starts_at,omitempty"`
+	TableID          string     `json:"table_id,omitempty"`
+	Registered       int        `json:"registered"`
+	MaxPlayers       int        `json:"max_players"`
+	PlayersLeft      int        `json:"players_left,omitempty"`
+	Tables           int        `json:"tables,omitempty"`
+	EntryFeeCC       int64      `json:"entry_fee_cc"`
+	StartingChips    int64      `json:"starting_chips"`
+	PrizePoolCC      int64      `json:"prize_pool_cc,omitempty"`
+	PayoutsCC        []int64    `json:"payouts_cc,omitempty"`
+	StackChips       int64      `json:"stack_chips,omitempty"`
+	BlindLevel       int        `json:"blind_level"`
+	SmallBlind       int64      `json:"small_blind"`
+	BigBlind         int64      `json:"big_blind"`
+	Ante             int64      `json:"ante"`
+	Rebuys           int        `json:"rebuys,omitempty"`
+	Addon            bool       `json:"addon,omitempty"`
+	LateRegistration bool       `json:"late_registration_open,omitempty"`
+	RebuyOpen        bool       `json:"rebuy_open,omitempty"`
+	AddonOpen        bool       `json:"addon_open,omitempty"`
+}
+
+// TournamentProgress is the public live view of a multi-table tournament.
+// Stacks are those of the last hand dealt at each table.
+type TournamentProgress struct {
+	TournamentID     string            `json:"tournament_id"`
+	Name             string            `json:"name"`
+	RoomID           string            `json:"room_id"`
+	Status           string            `json:"status"`
+	StartsAt         *time.Time        `json:"starts_at,omitempty"`
+	StartedAt        *time.Time        `json:"started_at,omitempty"`
+	Entries          int               `json:"entries"`
+	PlayersLeft      int               `json:"players_left"`
+	PlayersWaiting   int               `json:"players_waiting"`
+	PrizePoolCC      int64             `json:"prize_pool_cc"`
+	PayoutsCC        []int64           `json:"payouts_cc,omitempty"`
+	LateRegistration bool              `json:"late_registration_open"`
+	FinalTable       bool              `json:"final_table"`
+	BlindLevel       int               `json:"blind_level"`
+	SmallBlind       int64             `json:"small_blind"`
+	BigBlind         int64             `json:"big_blind"`
+	Ante             int64             `json:"ante"`
+	NextLevelAt      *time.Time        `json:"next_level_at,omitempty"`
+	Tables           []TournamentTable `json:"tables"`
+}
+
+type TournamentTable struct {
+	TableID string           `json:"table_id"`
+	Players []TournamentSeat `json:"players"`
+}
+
+type TournamentSeat struct {
+	AgentID    string `json:"agent_id"`
+	AgentName  string `json:"agent_name"`
+	SeatID     int    `json:"seat_id"`
+	StackChips int64  `json:"stack_chips"`
+}
@@ -15,6 +15,11 @@ type CreateSessionResponse = runtime.CreateSessionResponse
 type ActionRequest = runtime.ActionRequest
 type ActionResponse = runtime.ActionResponse
 type ErrorResponse = runtime.ErrorResponse
+type RegisterTournamentRequest = runtime.RegisterTournamentRequest
+type TournamentStatus = runtime.TournamentStatus
+type TournamentProgress = runtime.TournamentProgress
+type SessionStatus = runtime.SessionStatus
+type QueueStatus = runtime.QueueStatus
 
 type TableMeta = runtime.TableMeta
 type TableLifecycleObserver = runtime.TableLifecycleObserver
@@ -35,6 +40,22 @@ func IsSessionNotFound(err error) bool {
 	return runtime.IsSessionNotFound(err)
 }
 
+func IsTournamentNotFound(err error) bool {
+	return runtime.IsTournamentNotFound(err)
+}
+
+func IsRebuyUnavailable(err error) bool {
+	return runtime.IsRebuyUnavailable(err)
+}
+
+func IsChallengeNotFound(err error) bool {
+	return runtime.IsChallengeNotFound(err)
+}
+
+func IsChallengeClosed(err error) bool {
+	return runtime.IsChallengeClosed(err)
+}
+
 func SetReconnectGracePeriodForTest(d time.Duration) {
 	runtime.SetReconnectGracePeriodForTest(d)
 }
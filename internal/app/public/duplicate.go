@@ -0,0 +1,109 @@
+package public
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store"
+)
+
+func (s *Service) DuplicateMatches(ctx context.Context, agentID, status string, limit, offset int) (*DuplicateMatchesResponse, error) {
+	matches, err := s.store.ListDuplicateMatches(ctx, agentID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.duplicateMatchItems(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+	return &DuplicateMatchesResponse{Items: items, Limit: limit, Offset: offset}, nil
+}
+
+func (s *Service) DuplicateMatch(ctx context.Context, matchID string) (*DuplicateMatchItem, error) {
+	if matchID == "" {
+		return nil, ErrInvalidRequest
+	}
+	m, err := s.store.GetDuplicateMatch(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	items, err := s.duplicateMatchItems(ctx, []store.DuplicateMatch{*m})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+func (s *Service) duplicateMatchItems(ctx context.Context, matches []store.DuplicateMatch) ([]DuplicateMatchItem, error) {
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.ID)
+	}
+	results, err := s.store.ListDuplicateMatchResults(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byMatch := map[string][]DuplicateMatchResultItem{}
+	for _, r := range results {
+		byMatch[r.MatchID] = append(byMatch[r.MatchID], DuplicateMatchResultItem{
+			AgentID:     r.AgentID,
+			AgentName:   r.AgentName,
+			Leg1NetCC:   r.Leg1NetCC,
+			Leg2NetCC:   r.Leg2NetCC,
+			NetCC:       r.NetCC,
+			HandsPlayed: r.HandsPlayed,
+		})
+	}
+	out := make([]DuplicateMatchItem, 0, len(matches))
+	for _, m := range matches {
+		item := DuplicateMatchItem{
+			MatchID:     m.ID,
+			RoomID:      m.RoomID,
+			AgentAID:    m.AgentAID,
+			AgentBID:    m.AgentBID,
+			Leg1TableID: m.Leg1TableID,
+			Leg2TableID: m.Leg2TableID,
+			HandsPerLeg: m.HandsPerLeg,
+			BuyinCC:     m.BuyinCC,
+			CurrentLeg:  m.CurrentLeg,
+			Status:      m.Status,
+			Results:     byMatch[m.ID],
+			CreatedAt:   m.CreatedAt,
+			EndedAt:     m.EndedAt,
+		}
+		if item.Results == nil {
+			item.Results = []DuplicateMatchResultItem{}
+		}
+		if m.Status != store.DuplicateMatchRunning {
+			item.SeedSchedule = m.SeedSchedule
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// DuplicateStandings ranks agents by bb/100 over their completed duplicate
+// matches.
+func (s *Service) DuplicateStandings(ctx context.Context, roomID string, limit, offset int) (*DuplicateStandingsResponse, error) {
+	rows, err := s.store.ListDuplicateStandings(ctx, roomID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DuplicateStandingItem, 0, len(rows))
+	for idx, r := range rows {
+		out = append(out, DuplicateStandingItem{
+			Rank:        offset + idx + 1,
+			AgentID:     r.AgentID,
+			Name:        r.Name,
+			Matches:     r.Matches,
+			Wins:        r.Wins,
+			NetCC:       r.NetCC,
+			HandsPlayed: r.HandsPlayed,
+			BBPer100:    r.BBPer100,
+		})
+	}
+	return &DuplicateStandingsResponse{Items: out, Limit: limit, Offset: offset}, nil
+}
@@ -0,0 +1,62 @@
+package public
+
+import (
+	"context"
+
+	"silicon-casino/internal/game"
+)
+
+// maxEquityIterations caps the boards one request may sample.
+const maxEquityIterations = 100000
+
+// Equity computes the all-in equity of the given hole cards in q.Variant,
+// Hold'em by default. Iterations default to game.DefaultEquityIterations and
+// are capped at maxEquityIterations.
+func (s *Service) Equity(_ context.Context, q EquityQuery) (*EquityResponse, error) {
+	variant, ok := game.VariantByName(q.Variant)
+	if !ok {
+		return nil, ErrInvalidRequest
+	}
+	holes := make([][]game.Card, 0, len(q.Hands))
+	for _, h := range q.Hands {
+		cards, err := game.ParseCards(h)
+		if err != nil {
+			return nil, ErrInvalidRequest
+		}
+		holes = append(holes, cards)
+	}
+	board, err := game.ParseCards(q.Board)
+	if err != nil {
+		return nil, ErrInvalidRequest
+	}
+	dead, err := game.ParseCards(q.Dead)
+	if err != nil {
+		return nil, ErrInvalidRequest
+	}
+	iterations := q.Iterations
+	if iterations <= 0 {
+		iterations = game.DefaultEquityIterations
+	}
+	iterations = min(iterations, maxEquityIterations)
+	res, err := game.CalculateEquity(variant, holes, board, dead, iterations, nil)
+	if err != nil {
+		return nil, ErrInvalidRequest
+	}
+	items := make([]EquityItem, 0, len(holes))
+	for i, h := range holes {
+		items = append(items, EquityItem{
+			HoleCards: game.CardStrings(h),
+			Win:       res.Players[i].Win,
+			Tie:       res.Players[i].Tie,
+			Equity:    res.Players[i].Equity,
+		})
+	}
+	return &EquityResponse{
+		Variant: variant.Name(),
+		Board:   game.CardStrings(board),
+		Dead:    game.CardStrings(dead),
+		Exact:   res.Exact,
+		Boards:  res.Boards,
+		Items:   items,
+	}, nil
+}
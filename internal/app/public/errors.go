@@ -6,4 +6,6 @@ var (
 	ErrInvalidRequest = errors.New("invalid_request")
 	ErrTableNotFound  = errors.New("table_not_found")
 	ErrNotFound       = errors.New("not_found")
+	ErrHandInProgress = errors.New("hand_in_progress")
+	ErrNotVerifiable  = errors.New("hand_not_verifiable")
 )
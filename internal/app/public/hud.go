@@ -0,0 +1,63 @@
+package public
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store"
+)
+
+// AgentHUD returns agentID's HUD stats over window.
+func (s *Service) AgentHUD(ctx context.Context, agentID, window string) (*AgentHUDResponse, error) {
+	if agentID == "" {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.store.GetAgentByID(ctx, agentID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	stats, err := s.agentHUDStats(ctx, agentID, window)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentHUDResponse{AgentID: agentID, Window: window, Stats: stats}, nil
+}
+
+func (s *Service) agentHUDStats(ctx context.Context, agentID, window string) (AgentHUDStats, error) {
+	counts, err := s.store.GetAgentHUDCounts(ctx, agentID, leaderboardWindowStart(window))
+	if err != nil {
+		return AgentHUDStats{}, err
+	}
+	return hudStats(*counts), nil
+}
+
+// hudStats turns HUD tallies into rates. VPIP and PFR are shares of the
+// hands played, 3-bet and c-bet of the chances to make one, WTSD of the
+// flops seen and W$SD of the showdowns; the aggression factor is postflop
+// bets and raises per call. A rate without a chance behind it is nil.
+func hudStats(c store.HUDCounts) AgentHUDStats {
+	return AgentHUDStats{
+		Hands:                 c.Hands,
+		VPIP:                  hudRate(c.VPIPHands, c.Hands),
+		PFR:                   hudRate(c.PFRHands, c.Hands),
+		ThreeBet:              hudRate(c.ThreeBets, c.ThreeBetOpportunities),
+		ThreeBetOpportunities: c.ThreeBetOpportunities,
+		CBet:                  hudRate(c.CBets, c.CBetOpportunities),
+		CBetOpportunities:     c.CBetOpportunities,
+		AggressionFactor:      hudRate(c.PostflopRaises, c.PostflopCalls),
+		WTSD:                  hudRate(c.Showdowns, c.SawFlop),
+		SawFlop:               c.SawFlop,
+		WSD:                   hudRate(c.ShowdownsWon, c.Showdowns),
+		Showdowns:             c.Showdowns,
+	}
+}
+
+func hudRate(n, of int) *float64 {
+	if of <= 0 {
+		return nil
+	}
+	r := float64(n) / float64(of)
+	return &r
+}
@@ -0,0 +1,46 @@
+package public
+
+import (
+	"testing"
+
+	"silicon-casino/internal/store"
+)
+
+func TestHUDStats(t *testing.T) {
+	got := hudStats(store.HUDCounts{
+		Hands:                 20,
+		VPIPHands:             5,
+		PFRHands:              4,
+		ThreeBetOpportunities: 8,
+		ThreeBets:             2,
+		PostflopRaises:        6,
+		PostflopCalls:         3,
+		SawFlop:               5,
+		Showdowns:             2,
+		ShowdownsWon:          1,
+	})
+	rates := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"vpip", got.VPIP, 0.25},
+		{"pfr", got.PFR, 0.2},
+		{"three_bet", got.ThreeBet, 0.25},
+		{"aggression_factor", got.AggressionFactor, 2},
+		{"wtsd", got.WTSD, 0.4},
+		{"wsd", got.WSD, 0.5},
+	}
+	for _, r := range rates {
+		if r.got == nil || *r.got != r.want {
+			t.Fatalf("%s: got %v, want %v", r.name, r.got, r.want)
+		}
+	}
+	if got.CBet != nil {
+		t.Fatalf("c-bet without a chance should be nil, got %v", *got.CBet)
+	}
+
+	if empty := hudStats(store.HUDCounts{}); empty.VPIP != nil || empty.AggressionFactor != nil || empty.WSD != nil {
+		t.Fatalf("expected nil rates without hands, got %+v", empty)
+	}
+}
@@ -0,0 +1,113 @@
+package public
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store"
+)
+
+func (s *Service) Leagues(ctx context.Context, status string, limit, offset int) (*LeaguesResponse, error) {
+	leagues, err := s.store.ListLeagues(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]LeagueItem, 0, len(leagues))
+	for _, l := range leagues {
+		items = append(items, leagueItem(l))
+	}
+	return &LeaguesResponse{Items: items, Limit: limit, Offset: offset}, nil
+}
+
+// League returns a league with every match scheduled so far, by round.
+func (s *Service) League(ctx context.Context, leagueID string) (*LeagueDetailResponse, error) {
+	l, err := s.league(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.store.ListLeagueMatches(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeagueMatchItem, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, LeagueMatchItem{
+			MatchID:        m.ID,
+			Round:          m.Round,
+			AgentAID:       m.AgentAID,
+			AgentAName:     m.AgentAName,
+			AgentBID:       m.AgentBID,
+			AgentBName:     m.AgentBName,
+			TableID:        m.TableID,
+			Status:         m.Status,
+			WinnerAgentID:  m.WinnerAgentID,
+			ForfeitAgentID: m.ForfeitAgentID,
+			AgentANetCC:    m.AgentANetCC,
+			AgentBNetCC:    m.AgentBNetCC,
+			HandsPlayed:    m.HandsPlayed,
+			StartedAt:      m.StartedAt,
+			EndedAt:        m.EndedAt,
+		})
+	}
+	return &LeagueDetailResponse{LeagueItem: leagueItem(*l), Matches: out}, nil
+}
+
+// LeagueStandings returns the league table: points, then net chips, then
+// seed.
+func (s *Service) LeagueStandings(ctx context.Context, leagueID string) (*LeagueStandingsResponse, error) {
+	l, err := s.league(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.store.ListLeagueStandings(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeagueStandingItem, 0, len(rows))
+	for idx, r := range rows {
+		out = append(out, LeagueStandingItem{
+			Rank:        idx + 1,
+			AgentID:     r.AgentID,
+			AgentName:   r.AgentName,
+			Seed:        r.Seed,
+			Points:      r.Points,
+			Played:      r.Played,
+			Wins:        r.Wins,
+			Draws:       r.Draws,
+			Losses:      r.Losses,
+			Byes:        r.Byes,
+			NetCC:       r.NetCC,
+			HandsPlayed: r.HandsPlayed,
+		})
+	}
+	return &LeagueStandingsResponse{LeagueID: l.ID, Status: l.Status, Items: out}, nil
+}
+
+func (s *Service) league(ctx context.Context, leagueID string) (*store.League, error) {
+	if leagueID == "" {
+		return nil, ErrInvalidRequest
+	}
+	l, err := s.store.GetLeague(ctx, leagueID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+func leagueItem(l store.League) LeagueItem {
+	return LeagueItem{
+		LeagueID:      l.ID,
+		RoomID:        l.RoomID,
+		Name:          l.Name,
+		Format:        l.Format,
+		HandsPerMatch: l.HandsPerMatch,
+		Rounds:        l.Rounds,
+		CurrentRound:  l.CurrentRound,
+		Status:        l.Status,
+		CreatedAt:     l.CreatedAt,
+		EndedAt:       l.EndedAt,
+	}
+}
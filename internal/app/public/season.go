@@ -0,0 +1,106 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store"
+)
+
+func (s *Service) Seasons(ctx context.Context, status string, limit, offset int) (*SeasonsResponse, error) {
+	seasons, err := s.store.ListSeasons(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]SeasonItem, 0, len(seasons))
+	for _, season := range seasons {
+		items = append(items, seasonItem(season))
+	}
+	return &SeasonsResponse{Items: items, Limit: limit, Offset: offset}, nil
+}
+
+// SeasonStandings returns a page of a season's standings: the archived
+// final standings once it has closed, and the live leaderboard since its
+// start while it is running. A season that has not started has none.
+func (s *Service) SeasonStandings(ctx context.Context, seasonID string, limit, offset int) (*SeasonStandingsResponse, error) {
+	if seasonID == "" {
+		return nil, ErrInvalidRequest
+	}
+	season, err := s.store.GetSeason(ctx, seasonID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	resp := &SeasonStandingsResponse{
+		SeasonItem: seasonItem(*season),
+		Final:      season.Status == store.SeasonClosed,
+		Items:      []SeasonStandingItem{},
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if resp.Final {
+		rows, err := s.store.ListSeasonStandings(ctx, season.ID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			resp.Items = append(resp.Items, SeasonStandingItem{
+				Rank:          r.Rank,
+				AgentID:       r.AgentID,
+				AgentName:     r.AgentName,
+				Score:         r.Score,
+				BBPer100:      r.BBPer100,
+				NetCCFromPlay: r.NetCCFromPlay,
+				HandsPlayed:   r.HandsPlayed,
+				WinRate:       r.WinRate,
+				Rating:        r.Rating,
+			})
+		}
+		resp.Total = season.Entrants
+		return resp, nil
+	}
+	if time.Now().Before(season.StartsAt) {
+		return resp, nil
+	}
+	limit = clampLeaderboardLimit(limit)
+	total, err := s.store.CountLeaderboardSince(ctx, season.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.store.ListLeaderboardSince(ctx, season.StartsAt, limit, max(offset, 0))
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range rows {
+		resp.Items = append(resp.Items, SeasonStandingItem{
+			Rank:          it.Rank,
+			AgentID:       it.AgentID,
+			AgentName:     it.Name,
+			Score:         it.Score,
+			BBPer100:      it.BBPer100,
+			NetCCFromPlay: it.NetCCFromPlay,
+			HandsPlayed:   it.HandsPlayed,
+			WinRate:       it.WinRate,
+			Rating:        it.Rating,
+		})
+	}
+	resp.Total = total
+	resp.Limit = limit
+	return resp, nil
+}
+
+func seasonItem(s store.Season) SeasonItem {
+	return SeasonItem{
+		SeasonID:  s.ID,
+		Name:      s.Name,
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+		Status:    s.Status,
+		Entrants:  s.Entrants,
+		CreatedAt: s.CreatedAt,
+		ClosedAt:  s.ClosedAt,
+	}
+}
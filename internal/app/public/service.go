@@ -2,10 +2,14 @@ package public
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
+	"silicon-casino/internal/game"
 	"silicon-casino/internal/store"
 )
 
@@ -13,7 +17,13 @@ type Service struct {
 	store *store.Store
 }
 
-const leaderboardMaxRows = 100
+const (
+	leaderboardDefaultLimit = 50
+	leaderboardMaxLimit     = 100
+	// profileRatingHistory is how many of an agent's latest rating changes
+	// its profile lists.
+	profileRatingHistory = 20
+)
 
 func NewService(st *store.Store) *Service {
 	return &Service{store: st}
@@ -27,11 +37,29 @@ func (s *Service) Rooms(ctx context.Context) (*RoomsResponse, error) {
 	out := make([]RoomItem, 0, len(items))
 	for _, it := range items {
 		out = append(out, RoomItem{
-			ID:           it.ID,
-			Name:         it.Name,
-			MinBuyinCC:   it.MinBuyinCC,
-			SmallBlindCC: it.SmallBlindCC,
-			BigBlindCC:   it.BigBlindCC,
+			ID:                it.ID,
+			Name:              it.Name,
+			MinBuyinCC:        it.MinBuyinCC,
+			MaxBuyinCC:        it.MaxBuyinCC,
+			RakeBps:           it.RakeBps,
+			RakeCapCC:         it.RakeCapCC,
+			SeededDeck:        it.DeckSeedSchedule != "",
+			SmallBlindCC:      it.SmallBlindCC,
+			BigBlindCC:        it.BigBlindCC,
+			MaxSeats:          it.MaxSeats,
+			MinPlayers:        it.MinPlayers,
+			MatchFormat:       it.MatchFormat,
+			DuplicateHands:    it.DuplicateHands,
+			Variant:           it.Variant,
+			AnteCC:            it.AnteCC,
+			BigBlindAnte:      it.BigBlindAnte,
+			Straddle:          it.Straddle,
+			BettingStructure:  it.BettingStructure,
+			RaiseCap:          it.RaiseCap,
+			BlindLevelHands:   it.BlindLevelHands,
+			BlindLevelMinutes: it.BlindLevelMinutes,
+			EntryFeeCC:        it.EntryFeeCC,
+			StartingChips:     it.StartingChips,
 		})
 	}
 	return &RoomsResponse{Items: out}, nil
@@ -51,6 +79,7 @@ func (s *Service) Tables(ctx context.Context, roomID string, limit, offset int)
 			CreatedAt:    it.CreatedAt,
 			SmallBlindCC: it.SmallBlindCC,
 			BigBlindCC:   it.BigBlindCC,
+			TournamentID: it.TournamentID,
 		})
 	}
 	return &TablesResponse{Items: out, Limit: limit, Offset: offset}, nil
@@ -135,6 +164,38 @@ func (s *Service) AgentProfile(ctx context.Context, agentID string, limit, offse
 	if err != nil {
 		return nil, err
 	}
+	hud30d, err := s.agentHUDStats(ctx, agentID, "30d")
+	if err != nil {
+		return nil, err
+	}
+	hudAll, err := s.agentHUDStats(ctx, agentID, "all")
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.agentRating(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	finishes, err := s.store.ListAgentSeasonFinishes(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	seasons := make([]SeasonFinishItem, 0, len(finishes))
+	for _, f := range finishes {
+		seasons = append(seasons, SeasonFinishItem{
+			SeasonID:      f.SeasonID,
+			Name:          f.SeasonName,
+			StartsAt:      f.StartsAt,
+			EndsAt:        f.EndsAt,
+			Rank:          f.Rank,
+			Entrants:      f.Entrants,
+			Score:         f.Score,
+			BBPer100:      f.BBPer100,
+			NetCCFromPlay: f.NetCCFromPlay,
+			HandsPlayed:   f.HandsPlayed,
+			Rating:        f.Rating,
+		})
+	}
 	total, err := s.store.CountTableHistoryByScope(ctx, "", agentID)
 	if err != nil {
 		return nil, err
@@ -172,21 +233,29 @@ func (s *Service) AgentProfile(ctx context.Context, agentID string, limit, offse
 			CreatedAt: agent.CreatedAt,
 		},
 		Stats30D: AgentPerformanceSnapshot{
-			Score:         stats30d.Score,
-			BBPer100:      stats30d.BBPer100,
-			NetCCFromPlay: stats30d.NetCCFromPlay,
-			HandsPlayed:   stats30d.HandsPlayed,
-			WinRate:       stats30d.WinRate,
-			LastActiveAt:  stats30d.LastActiveAt,
+			Score:           stats30d.Score,
+			BBPer100:        stats30d.BBPer100,
+			BBPerHandStdDev: stats30d.BBPerHandStdDev,
+			BBPer100CI:      bbPer100Interval(stats30d.BBPer100, stats30d.BBPerHandStdDev, stats30d.HandsPlayed),
+			NetCCFromPlay:   stats30d.NetCCFromPlay,
+			HandsPlayed:     stats30d.HandsPlayed,
+			WinRate:         stats30d.WinRate,
+			LastActiveAt:    stats30d.LastActiveAt,
 		},
 		StatsAll: AgentPerformanceSnapshot{
-			Score:         statsAll.Score,
-			BBPer100:      statsAll.BBPer100,
-			NetCCFromPlay: statsAll.NetCCFromPlay,
-			HandsPlayed:   statsAll.HandsPlayed,
-			WinRate:       statsAll.WinRate,
-			LastActiveAt:  statsAll.LastActiveAt,
+			Score:           statsAll.Score,
+			BBPer100:        statsAll.BBPer100,
+			BBPerHandStdDev: statsAll.BBPerHandStdDev,
+			BBPer100CI:      bbPer100Interval(statsAll.BBPer100, statsAll.BBPerHandStdDev, statsAll.HandsPlayed),
+			NetCCFromPlay:   statsAll.NetCCFromPlay,
+			HandsPlayed:     statsAll.HandsPlayed,
+			WinRate:         statsAll.WinRate,
+			LastActiveAt:    statsAll.LastActiveAt,
 		},
+		HUD30D:  hud30d,
+		HUDAll:  hudAll,
+		Rating:  *rating,
+		Seasons: seasons,
 		Tables: TableHistoryResponse{
 			Items:  out,
 			Total:  total,
@@ -196,6 +265,45 @@ func (s *Service) AgentProfile(ctx context.Context, agentID string, limit, offse
 	}, nil
 }
 
+// agentRating is the agent's current rating with its latest changes; an
+// agent that has not been rated yet reports the starting rating.
+func (s *Service) agentRating(ctx context.Context, agentID string) (*AgentRatingSnapshot, error) {
+	out := &AgentRatingSnapshot{
+		Rating:          game.DefaultRating,
+		RatingDeviation: game.DefaultRatingDeviation,
+		Volatility:      game.DefaultRatingVolatility,
+	}
+	current, err := s.store.GetAgentRating(ctx, agentID)
+	switch {
+	case err == nil:
+		out.Rating = current.Rating
+		out.RatingDeviation = current.Deviation
+		out.Volatility = current.Volatility
+		out.Games = current.Games
+		updatedAt := current.UpdatedAt
+		out.UpdatedAt = &updatedAt
+	case !errors.Is(err, store.ErrNotFound):
+		return nil, err
+	}
+	history, err := s.store.ListRatingHistory(ctx, agentID, profileRatingHistory)
+	if err != nil {
+		return nil, err
+	}
+	out.History = make([]RatingChangeItem, 0, len(history))
+	for _, h := range history {
+		out.History = append(out.History, RatingChangeItem{
+			SourceType:      h.SourceType,
+			SourceID:        h.SourceID,
+			Opponents:       h.Opponents,
+			RatingBefore:    h.RatingBefore,
+			Rating:          h.Rating,
+			RatingDeviation: h.Deviation,
+			CreatedAt:       h.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
 func (s *Service) TableReplay(ctx context.Context, tableID string, fromSeq int64, limit int) (*ReplayResponse, error) {
 	if tableID == "" {
 		return nil, ErrInvalidRequest
@@ -211,12 +319,19 @@ func (s *Service) TableReplay(ctx context.Context, tableID string, fromSeq int64
 	if err != nil {
 		return nil, err
 	}
+	live, err := s.liveHands(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
 	out := make([]ReplayEvent, 0, len(items))
 	for _, it := range items {
 		var payload any
 		if len(it.Payload) > 0 {
 			_ = json.Unmarshal(it.Payload, &payload)
 		}
+		if m, ok := payload.(map[string]any); ok && live[it.HandID] {
+			delete(m, "equity")
+		}
 		out = append(out, ReplayEvent{
 			ID:           it.ID,
 			TableID:      it.TableID,
@@ -239,6 +354,21 @@ func (s *Service) TableReplay(ctx context.Context, tableID string, fromSeq int64
 	}, nil
 }
 
+// liveHands returns the hands of a table still being played. Their replay
+// equity would tell a watching agent what the other hole cards are, so it is
+// only published once the hand has ended.
+func (s *Service) liveHands(ctx context.Context, tableID string) (map[string]bool, error) {
+	ids, err := s.store.ListOpenHandIDs(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out, nil
+}
+
 func (s *Service) TableTimeline(ctx context.Context, tableID string) (*TimelineResponse, error) {
 	if tableID == "" {
 		return nil, ErrInvalidRequest
@@ -321,7 +451,9 @@ func (s *Service) TableSnapshot(ctx context.Context, tableID string, atSeq int64
 		snapshotSeq = snap.AtGlobalSeq
 		_ = json.Unmarshal(snap.StateBlob, &replayState)
 	}
-	fromSeq := snapshotSeq + 1
+	// The event at the snapshot itself is read again: its replay equity
+	// may have been added after the snapshot was taken.
+	fromSeq := snapshotSeq
 	limit := int(atSeq - snapshotSeq + 1)
 	if limit < 1 {
 		limit = 1
@@ -344,44 +476,81 @@ func (s *Service) TableSnapshot(ctx context.Context, tableID string, atSeq int64
 		replayState["last_event_type"] = ev.EventType
 		replayState["global_seq"] = ev.GlobalSeq
 	}
+	live, err := s.liveHands(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if handID, _ := replayState["hand_id"].(string); live[handID] {
+		delete(replayState, "equity")
+	}
 	return &SnapshotResponse{TableID: tableID, AtSeq: atSeq, State: replayState, Hit: hit}, nil
 }
 
 func (s *Service) Leaderboard(ctx context.Context, q LeaderboardQuery, limit, offset int) (*LeaderboardResponse, error) {
-	windowStart := leaderboardWindowStart(q.Window)
-	allItems, err := s.store.ListLeaderboard(ctx, store.LeaderboardFilter{
-		WindowStart: windowStart,
-		RoomScope:   q.RoomID,
-		SortBy:      q.SortBy,
-	}, leaderboardMaxRows, 0)
+	if q.RoomID != "" && q.RoomID != "all" {
+		if _, err := s.store.GetRoom(ctx, q.RoomID); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+	}
+	f := store.LeaderboardFilter{
+		Window: q.Window,
+		RoomID: q.RoomID,
+		SortBy: q.SortBy,
+	}
+	if q.Cursor != "" {
+		sortKey, agentID, ok := decodeLeaderboardCursor(q.Cursor)
+		if !ok {
+			return nil, ErrInvalidRequest
+		}
+		f.AfterSortKey, f.AfterAgentID = sortKey, agentID
+		offset = 0
+	}
+	return s.leaderboardPage(ctx, f, limit, offset)
+}
+
+// leaderboardPage returns the page of f's leaderboard at offset, or after
+// f's keyset. NextCursor continues after its last entry while there are
+// more.
+func (s *Service) leaderboardPage(ctx context.Context, f store.LeaderboardFilter, limit, offset int) (*LeaderboardResponse, error) {
+	limit = clampLeaderboardLimit(limit)
+	if offset < 0 {
+		offset = 0
+	}
+	total, err := s.store.CountLeaderboard(ctx, f)
 	if err != nil {
 		return nil, err
 	}
-	total := len(allItems)
-	limit, ok := clampLeaderboardPage(limit, offset)
-	if !ok || offset >= total {
-		return &LeaderboardResponse{Items: []LeaderboardItem{}, Total: total, Limit: limit, Offset: offset}, nil
-	}
-	end := offset + limit
-	if end > total {
-		end = total
-	}
-	pageItems := allItems[offset:end]
-	out := make([]LeaderboardItem, 0, len(pageItems))
-	for idx, it := range pageItems {
-		out = append(out, LeaderboardItem{
-			Rank:          offset + idx + 1,
-			AgentID:       it.AgentID,
-			Name:          it.Name,
-			Score:         it.Score,
-			BBPer100:      it.BBPer100,
-			NetCCFromPlay: it.NetCCFromPlay,
-			HandsPlayed:   it.HandsPlayed,
-			WinRate:       it.WinRate,
-			LastActiveAt:  it.LastActiveAt,
+	items, err := s.store.ListLeaderboard(ctx, f, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+	resp := &LeaderboardResponse{Items: []LeaderboardItem{}, Total: total, Limit: limit, Offset: offset}
+	if len(items) > limit {
+		items = items[:limit]
+		last := items[len(items)-1]
+		resp.NextCursor = encodeLeaderboardCursor(last.SortKey, last.AgentID)
+	}
+	for _, it := range items {
+		resp.Items = append(resp.Items, LeaderboardItem{
+			Rank:            it.Rank,
+			AgentID:         it.AgentID,
+			Name:            it.Name,
+			Score:           it.Score,
+			BBPer100:        it.BBPer100,
+			BBPerHandStdDev: it.BBPerHandStdDev,
+			BBPer100CI:      bbPer100Interval(it.BBPer100, it.BBPerHandStdDev, it.HandsPlayed),
+			NetCCFromPlay:   it.NetCCFromPlay,
+			HandsPlayed:     it.HandsPlayed,
+			WinRate:         it.WinRate,
+			Rating:          it.Rating,
+			RatingDeviation: it.RatingDeviation,
+			LastActiveAt:    it.LastActiveAt,
 		})
 	}
-	return &LeaderboardResponse{Items: out, Total: total, Limit: limit, Offset: offset}, nil
+	return resp, nil
 }
 
 func leaderboardWindowStart(window string) *time.Time {
@@ -398,16 +567,30 @@ func leaderboardWindowStart(window string) *time.Time {
 	}
 }
 
-func clampLeaderboardPage(limit, offset int) (int, bool) {
-	if offset >= leaderboardMaxRows {
-		return 0, false
-	}
+func clampLeaderboardLimit(limit int) int {
 	if limit <= 0 {
-		limit = 50
+		return leaderboardDefaultLimit
+	}
+	return min(limit, leaderboardMaxLimit)
+}
+
+// A leaderboard cursor is the sort key and agent ID of the last entry of
+// a page, opaque to clients.
+func encodeLeaderboardCursor(sortKey, agentID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(sortKey + "|" + agentID))
+}
+
+func decodeLeaderboardCursor(cursor string) (sortKey, agentID string, ok bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", false
+	}
+	sortKey, agentID, ok = strings.Cut(string(raw), "|")
+	if !ok || agentID == "" {
+		return "", "", false
 	}
-	remaining := leaderboardMaxRows - offset
-	if limit > remaining {
-		limit = remaining
+	if _, err := strconv.ParseFloat(sortKey, 64); err != nil {
+		return "", "", false
 	}
-	return limit, true
+	return sortKey, agentID, true
 }
@@ -1,6 +1,7 @@
 package public
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -9,35 +10,41 @@ import (
 	"silicon-casino/internal/testutil"
 )
 
-func TestClampLeaderboardPage(t *testing.T) {
+func TestClampLeaderboardLimit(t *testing.T) {
 	tests := []struct {
-		name      string
-		limit     int
-		offset    int
-		wantLimit int
-		wantOK    bool
+		name  string
+		limit int
+		want  int
 	}{
-		{name: "default limit", limit: 0, offset: 0, wantLimit: 50, wantOK: true},
-		{name: "explicit small limit", limit: 20, offset: 0, wantLimit: 20, wantOK: true},
-		{name: "limit clipped at top100 boundary", limit: 10, offset: 95, wantLimit: 5, wantOK: true},
-		{name: "limit exactly remaining", limit: 1, offset: 99, wantLimit: 1, wantOK: true},
-		{name: "offset 100 rejected", limit: 10, offset: 100, wantLimit: 0, wantOK: false},
-		{name: "offset beyond 100 rejected", limit: 10, offset: 150, wantLimit: 0, wantOK: false},
+		{name: "default limit", limit: 0, want: 50},
+		{name: "negative limit", limit: -5, want: 50},
+		{name: "explicit small limit", limit: 20, want: 20},
+		{name: "limit at max", limit: 100, want: 100},
+		{name: "limit above max", limit: 500, want: 100},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotLimit, gotOK := clampLeaderboardPage(tt.limit, tt.offset)
-			if gotOK != tt.wantOK {
-				t.Fatalf("ok = %v, want %v", gotOK, tt.wantOK)
-			}
-			if gotLimit != tt.wantLimit {
-				t.Fatalf("limit = %d, want %d", gotLimit, tt.wantLimit)
+			if got := clampLeaderboardLimit(tt.limit); got != tt.want {
+				t.Fatalf("limit = %d, want %d", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestLeaderboardCursorRoundTrip(t *testing.T) {
+	cursor := encodeLeaderboardCursor("12.5000", "agent-1")
+	sortKey, agentID, ok := decodeLeaderboardCursor(cursor)
+	if !ok || sortKey != "12.5000" || agentID != "agent-1" {
+		t.Fatalf("decode = %q %q %v", sortKey, agentID, ok)
+	}
+	for _, bad := range []string{"!!", encodeLeaderboardCursor("x", "agent-1"), encodeLeaderboardCursor("1", "")} {
+		if _, _, ok := decodeLeaderboardCursor(bad); ok {
+			t.Fatalf("expected cursor %q to be rejected", bad)
+		}
+	}
+}
+
 func TestLeaderboardWindowStart(t *testing.T) {
 	now := time.Now().UTC()
 	tests := []struct {
@@ -184,7 +191,7 @@ func TestAgentProfileIncludesStatsAndTables(t *testing.T) {
 	}); err != nil {
 		t.Fatalf("create session B: %v", err)
 	}
-	handID, err := st.CreateHand(ctx, tableID)
+	handID, err := st.CreateHand(ctx, tableID, 100)
 	if err != nil {
 		t.Fatalf("create hand: %v", err)
 	}
@@ -320,3 +327,24 @@ func TestAgentProfileRejectsEmptyAgentID(t *testing.T) {
 		t.Fatalf("expected ErrInvalidRequest, got %v", err)
 	}
 }
+
+func TestEquity(t *testing.T) {
+	svc := NewService(nil)
+	resp, err := svc.Equity(context.Background(), EquityQuery{Hands: []string{"AsAh", "KsKh"}, Board: "2c7d9cJd"})
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	if !resp.Exact || resp.Boards != 44 || len(resp.Items) != 2 || resp.Items[1].HoleCards[0] != "Ks" {
+		t.Fatalf("unexpected equity response: %+v", resp)
+	}
+	for _, q := range []EquityQuery{
+		{Hands: []string{"AsAh"}},
+		{Hands: []string{"AsAh", "KsKx"}},
+		{Hands: []string{"AsAh", "KsKh"}, Board: "2c7d"},
+		{Hands: []string{"AsAh", "KsKh"}, Dead: "As"},
+	} {
+		if _, err := svc.Equity(context.Background(), q); !errors.Is(err, ErrInvalidRequest) {
+			t.Fatalf("expected invalid request for %+v, got %v", q, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"silicon-casino/internal/store"
+)
+
+// winRateZ95 is the two-sided 95% quantile of the standard normal
+// distribution.
+const winRateZ95 = 1.959964
+
+// bbPer100StdErr is the standard error of a bb/100 win rate measured over
+// hands hands whose results in big blinds have sample standard deviation
+// sd. It is undefined, ok false, for fewer than two hands.
+func bbPer100StdErr(sd float64, hands int) (se float64, ok bool) {
+	if hands < 2 {
+		return 0, false
+	}
+	return 100 * sd / math.Sqrt(float64(hands)), true
+}
+
+// bbPer100Interval is the 95% confidence interval of a bb/100 win rate, nil
+// when it is undefined.
+func bbPer100Interval(bbPer100, sd float64, hands int) *ConfidenceInterval {
+	se, ok := bbPer100StdErr(sd, hands)
+	if !ok {
+		return nil
+	}
+	return &ConfidenceInterval{Low: bbPer100 - winRateZ95*se, High: bbPer100 + winRateZ95*se}
+}
+
+// CompareAgents reports whether one agent's bb/100 over window is
+// statistically ahead of the other's, with a two-sided z-test on the
+// difference of the two win rates at the 5% level. Hands the two agents
+// played against each other are accounted for as paired results.
+func (s *Service) CompareAgents(ctx context.Context, agentAID, agentBID, window string) (*AgentComparisonResponse, error) {
+	if agentAID == "" || agentBID == "" || agentAID == agentBID {
+		return nil, ErrInvalidRequest
+	}
+	windowStart := leaderboardWindowStart(window)
+	rates := make([]AgentWinRate, 0, 2)
+	for _, agentID := range []string{agentAID, agentBID} {
+		agent, err := s.store.GetAgentByID(ctx, agentID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrNotFound
+			}
+			return nil, err
+		}
+		perf, err := s.store.GetAgentPerformanceByWindowAndAgent(ctx, agentID, windowStart)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, AgentWinRate{
+			AgentID:         agent.ID,
+			Name:            agent.Name,
+			HandsPlayed:     perf.HandsPlayed,
+			BBPer100:        perf.BBPer100,
+			BBPerHandStdDev: perf.BBPerHandStdDev,
+			BBPer100CI:      bbPer100Interval(perf.BBPer100, perf.BBPerHandStdDev, perf.HandsPlayed),
+		})
+	}
+	shared, err := s.store.GetSharedHands(ctx, agentAID, agentBID, windowStart)
+	if err != nil {
+		return nil, err
+	}
+	resp := compareWinRates(rates[0], rates[1], shared.Hands, shared.BBCovariance)
+	resp.Window = window
+	return resp, nil
+}
+
+// compareWinRates tests the difference between two win rates. The agents'
+// results on the shared hands they both played are not independent: in a
+// heads-up pot one's win is the other's loss. Their covariance cov over
+// those hands enters the variance of the difference as
+//
+//	Var(meanA - meanB) = varA/nA + varB/nB - 2*shared*cov/(nA*nB)
+//
+// which is the independent test for disjoint samples and the paired test
+// over per-hand differences when every hand is shared. Without a standard
+// error for both, or with no spread left, nothing can be told apart and the
+// p-value is 1.
+func compareWinRates(a, b AgentWinRate, shared int, cov float64) *AgentComparisonResponse {
+	out := &AgentComparisonResponse{
+		AgentA:       a,
+		AgentB:       b,
+		DiffBBPer100: a.BBPer100 - b.BBPer100,
+		SharedHands:  shared,
+		PValue:       1,
+	}
+	seA, okA := bbPer100StdErr(a.BBPerHandStdDev, a.HandsPlayed)
+	seB, okB := bbPer100StdErr(b.BBPerHandStdDev, b.HandsPlayed)
+	if !okA || !okB {
+		return out
+	}
+	variance := seA*seA + seB*seB
+	if shared > 0 {
+		variance -= 2 * 100 * 100 * float64(shared) * cov / (float64(a.HandsPlayed) * float64(b.HandsPlayed))
+	}
+	if variance <= 0 {
+		return out
+	}
+	se := math.Sqrt(variance)
+	out.DiffCI = &ConfidenceInterval{Low: out.DiffBBPer100 - winRateZ95*se, High: out.DiffBBPer100 + winRateZ95*se}
+	out.ZScore = out.DiffBBPer100 / se
+	out.PValue = math.Erfc(math.Abs(out.ZScore) / math.Sqrt2)
+	out.Significant = out.PValue < 0.05
+	if out.Significant {
+		out.AheadAgentID = a.AgentID
+		if out.ZScore < 0 {
+			out.AheadAgentID = b.AgentID
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package public
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBBPer100Interval(t *testing.T) {
+	if got := bbPer100Interval(5, 10, 1); got != nil {
+		t.Fatalf("expected no interval for one hand, got %+v", got)
+	}
+	// 400 hands at 10 bb/hand standard deviation: a standard error of
+	// 50 bb/100.
+	got := bbPer100Interval(5, 10, 400)
+	if got == nil || math.Abs(got.Low-(5-winRateZ95*50)) > 1e-9 || math.Abs(got.High-(5+winRateZ95*50)) > 1e-9 {
+		t.Fatalf("unexpected interval: %+v", got)
+	}
+}
+
+func TestCompareWinRates(t *testing.T) {
+	a := AgentWinRate{AgentID: "a", HandsPlayed: 10000, BBPer100: 30, BBPerHandStdDev: 10}
+	b := AgentWinRate{AgentID: "b", HandsPlayed: 10000, BBPer100: -10, BBPerHandStdDev: 10}
+	got := compareWinRates(a, b, 0, 0)
+	if !got.Significant || got.AheadAgentID != "a" || got.ZScore <= 0 || got.PValue >= 0.05 || got.DiffCI == nil {
+		t.Fatalf("expected a to be ahead: %+v", got)
+	}
+	if got := compareWinRates(b, a, 0, 0); got.AheadAgentID != "a" || got.ZScore >= 0 {
+		t.Fatalf("expected a ahead from either side: %+v", got)
+	}
+
+	b.BBPer100 = 20
+	if got := compareWinRates(a, b, 0, 0); got.Significant || got.AheadAgentID != "" || got.PValue < 0.05 {
+		t.Fatalf("expected a 10 bb/100 gap over 10000 hands to be noise: %+v", got)
+	}
+
+	b.HandsPlayed = 1
+	if got := compareWinRates(a, b, 0, 0); got.Significant || got.PValue != 1 || got.DiffCI != nil {
+		t.Fatalf("expected no test without a standard error: %+v", got)
+	}
+}
+
+func TestCompareWinRatesSharedHands(t *testing.T) {
+	// Heads-up, every hand shared and one's result the other's negated:
+	// the paired differences have a standard deviation of 20 bb, twice the
+	// 14.1 bb the independent test would assume.
+	a := AgentWinRate{AgentID: "a", HandsPlayed: 10000, BBPer100: 5, BBPerHandStdDev: 10}
+	b := AgentWinRate{AgentID: "b", HandsPlayed: 10000, BBPer100: -5, BBPerHandStdDev: 10}
+	got := compareWinRates(a, b, 10000, -100)
+	if math.Abs(got.ZScore-0.5) > 1e-9 || got.SharedHands != 10000 {
+		t.Fatalf("expected the paired z-score of 0.5, got %+v", got)
+	}
+
+	// Two agents that win and lose the same shared hands together: the
+	// gap that is noise between independent samples is significant once
+	// the common part of their results cancels out.
+	a.BBPer100, b.BBPer100 = 30, 20
+	if got := compareWinRates(a, b, 0, 0); got.Significant {
+		t.Fatalf("expected noise between independent samples: %+v", got)
+	}
+	got = compareWinRates(a, b, 10000, 90)
+	if !got.Significant || got.AheadAgentID != "a" || math.Abs(got.ZScore-10/math.Sqrt(20)) > 1e-9 {
+		t.Fatalf("expected a ahead on paired results: %+v", got)
+	}
+
+	// Perfectly correlated results leave no spread to test against.
+	if got := compareWinRates(a, b, 10000, 100); got.Significant || got.PValue != 1 || got.DiffCI != nil {
+		t.Fatalf("expected no test without spread: %+v", got)
+	}
+}
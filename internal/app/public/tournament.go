@@ -0,0 +1,107 @@
+package public
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+)
+
+func (s *Service) Tournaments(ctx context.Context, roomID, status string, limit, offset int) (*TournamentsResponse, error) {
+	tournaments, err := s.store.ListTournaments(ctx, roomID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.tournamentItems(ctx, tournaments)
+	if err != nil {
+		return nil, err
+	}
+	return &TournamentsResponse{Items: items, Limit: limit, Offset: offset}, nil
+}
+
+func (s *Service) Tournament(ctx context.Context, tournamentID string) (*TournamentItem, error) {
+	if tournamentID == "" {
+		return nil, ErrInvalidRequest
+	}
+	t, err := s.store.GetTournament(ctx, tournamentID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	items, err := s.tournamentItems(ctx, []store.Tournament{*t})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+func (s *Service) tournamentItems(ctx context.Context, tournaments []store.Tournament) ([]TournamentItem, error) {
+	ids := make([]string, 0, len(tournaments))
+	for _, t := range tournaments {
+		ids = append(ids, t.ID)
+	}
+	entries, err := s.store.ListTournamentEntries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byTournament := map[string][]TournamentEntryItem{}
+	for _, e := range entries {
+		byTournament[e.TournamentID] = append(byTournament[e.TournamentID], TournamentEntryItem{
+			AgentID:      e.AgentID,
+			AgentName:    e.AgentName,
+			TableID:      e.TableID,
+			SeatID:       e.SeatID,
+			Rebuys:       e.Rebuys,
+			Addon:        e.Addon,
+			FinishPlace:  e.FinishPlace,
+			PayoutCC:     e.PayoutCC,
+			EliminatedAt: e.EliminatedAt,
+		})
+	}
+	out := make([]TournamentItem, 0, len(tournaments))
+	for _, t := range tournaments {
+		item := TournamentItem{
+			TournamentID:  t.ID,
+			RoomID:        t.RoomID,
+			Status:        t.Status,
+			MaxPlayers:    t.MaxPlayers,
+			EntryFeeCC:    t.EntryFeeCC,
+			StartingChips: t.StartingChips,
+			PrizePoolCC:   t.PrizePoolCC,
+			PayoutsCC:     game.SplitPrizePool(t.PrizePoolCC, t.PayoutBps),
+			StartsAt:      t.StartsAt,
+			StartedAt:     t.StartedAt,
+			LateRegLevels: t.LateRegLevels,
+			MaxRebuys:     t.MaxRebuys,
+			RebuyLevels:   t.RebuyLevels,
+			AddonFeeCC:    t.AddonFeeCC,
+			AddonChips:    t.AddonChips,
+			Entries:       byTournament[t.ID],
+			CreatedAt:     t.CreatedAt,
+			EndedAt:       t.EndedAt,
+		}
+		if item.Entries == nil {
+			item.Entries = []TournamentEntryItem{}
+		}
+		if t.MultiTable() {
+			item.Name = t.Name
+			item.MinPlayers = t.MinPlayers
+			if len(t.PayoutBps) == 0 {
+				item.PayoutsCC = game.SplitPrizePool(t.PrizePoolCC, game.MultiTablePayouts(len(item.Entries)))
+			}
+		}
+		for _, e := range item.Entries {
+			if e.FinishPlace == nil {
+				item.PlayersLeft++
+			}
+		}
+		if t.Status != store.TournamentRunning {
+			item.PlayersLeft = 0
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
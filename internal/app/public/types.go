@@ -7,11 +7,33 @@ type RoomsResponse struct {
 }
 
 type RoomItem struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	MinBuyinCC   int64  `json:"min_buyin_cc"`
-	SmallBlindCC int64  `json:"small_blind_cc"`
-	BigBlindCC   int64  `json:"big_blind_cc"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	MinBuyinCC       int64  `json:"min_buyin_cc"`
+	MaxBuyinCC       int64  `json:"max_buyin_cc"`
+	RakeBps          int    `json:"rake_bps"`
+	RakeCapCC        int64  `json:"rake_cap_cc"`
+	SmallBlindCC     int64  `json:"small_blind_cc"`
+	BigBlindCC       int64  `json:"big_blind_cc"`
+	MaxSeats         int    `json:"max_seats"`
+	MinPlayers       int    `json:"min_players"`
+	SeededDeck       bool   `json:"seeded_deck"`
+	MatchFormat      string `json:"match_format"`
+	DuplicateHands   int    `json:"duplicate_hands,omitempty"`
+	Variant          string `json:"variant"`
+	AnteCC           int64  `json:"ante_cc,omitempty"`
+	BigBlindAnte     bool   `json:"big_blind_ante,omitempty"`
+	Straddle         bool   `json:"straddle,omitempty"`
+	BettingStructure string `json:"betting_structure"`
+	RaiseCap         int    `json:"raise_cap,omitempty"`
+	// Blinds rise every BlindLevelHands hands or BlindLevelMinutes minutes
+	// on rooms with a blind schedule.
+	BlindLevelHands   int `json:"blind_level_hands,omitempty"`
+	BlindLevelMinutes int `json:"blind_level_minutes,omitempty"`
+	// Sit-and-go rooms charge EntryFeeCC and seat every entrant with
+	// StartingChips tournament chips.
+	EntryFeeCC    int64 `json:"entry_fee_cc,omitempty"`
+	StartingChips int64 `json:"starting_chips,omitempty"`
 }
 
 type TablesResponse struct {
@@ -27,6 +49,7 @@ type TableItem struct {
 	CreatedAt    time.Time `json:"created_at"`
 	SmallBlindCC int64     `json:"small_blind_cc"`
 	BigBlindCC   int64     `json:"big_blind_cc"`
+	TournamentID string    `json:"tournament_id,omitempty"`
 }
 
 type TableHistoryResponse struct {
@@ -67,21 +90,81 @@ type AgentIdentity struct {
 }
 
 type AgentPerformanceSnapshot struct {
-	Score         float64    `json:"score"`
-	BBPer100      float64    `json:"bb_per_100"`
-	NetCCFromPlay int64      `json:"net_cc_from_play"`
-	HandsPlayed   int        `json:"hands_played"`
-	WinRate       float64    `json:"win_rate"`
-	LastActiveAt  *time.Time `json:"last_active_at"`
+	Score           float64             `json:"score"`
+	BBPer100        float64             `json:"bb_per_100"`
+	BBPerHandStdDev float64             `json:"bb_per_hand_stddev"`
+	BBPer100CI      *ConfidenceInterval `json:"bb_per_100_ci"`
+	NetCCFromPlay   int64               `json:"net_cc_from_play"`
+	HandsPlayed     int                 `json:"hands_played"`
+	WinRate         float64             `json:"win_rate"`
+	LastActiveAt    *time.Time          `json:"last_active_at"`
+}
+
+// AgentHUDStats are an agent's playing-style tendencies over the Hands
+// hands it acted in, as fractions: VPIP, PFR, 3-bet, c-bet, went to
+// showdown (WTSD) and won at showdown (WSD, W$SD), and the postflop
+// aggression factor. A stat is null until there is a chance behind it; the
+// counts next to a stat are its sample.
+type AgentHUDStats struct {
+	Hands                 int      `json:"hands"`
+	VPIP                  *float64 `json:"vpip"`
+	PFR                   *float64 `json:"pfr"`
+	ThreeBet              *float64 `json:"three_bet"`
+	ThreeBetOpportunities int      `json:"three_bet_opportunities"`
+	CBet                  *float64 `json:"cbet"`
+	CBetOpportunities     int      `json:"cbet_opportunities"`
+	AggressionFactor      *float64 `json:"aggression_factor"`
+	WTSD                  *float64 `json:"wtsd"`
+	SawFlop               int      `json:"saw_flop"`
+	WSD                   *float64 `json:"wsd"`
+	Showdowns             int      `json:"showdowns"`
+}
+
+type AgentHUDResponse struct {
+	AgentID string        `json:"agent_id"`
+	Window  string        `json:"window"`
+	Stats   AgentHUDStats `json:"stats"`
+}
+
+// ConfidenceInterval is a 95% confidence interval.
+type ConfidenceInterval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
 }
 
 type AgentProfileResponse struct {
 	Agent    AgentIdentity            `json:"agent"`
 	Stats30D AgentPerformanceSnapshot `json:"stats_30d"`
 	StatsAll AgentPerformanceSnapshot `json:"stats_all"`
+	HUD30D   AgentHUDStats            `json:"hud_30d"`
+	HUDAll   AgentHUDStats            `json:"hud_all"`
+	Rating   AgentRatingSnapshot      `json:"rating"`
+	Seasons  []SeasonFinishItem       `json:"seasons"`
 	Tables   TableHistoryResponse     `json:"tables"`
 }
 
+// AgentRatingSnapshot is an agent's Glicko-2 rating, Games being the
+// tables, duplicate matches and tournaments it was rated on, and History
+// its latest changes, newest first.
+type AgentRatingSnapshot struct {
+	Rating          float64            `json:"rating"`
+	RatingDeviation float64            `json:"rating_deviation"`
+	Volatility      float64            `json:"volatility"`
+	Games           int                `json:"games"`
+	UpdatedAt       *time.Time         `json:"updated_at"`
+	History         []RatingChangeItem `json:"history"`
+}
+
+type RatingChangeItem struct {
+	SourceType      string    `json:"source_type"`
+	SourceID        string    `json:"source_id"`
+	Opponents       int       `json:"opponents"`
+	RatingBefore    float64   `json:"rating_before"`
+	Rating          float64   `json:"rating"`
+	RatingDeviation float64   `json:"rating_deviation"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
 type ReplayResponse struct {
 	Items       []ReplayEvent `json:"items"`
 	NextFromSeq int64         `json:"next_from_seq"`
@@ -126,26 +209,336 @@ type SnapshotResponse struct {
 }
 
 type LeaderboardResponse struct {
-	Items  []LeaderboardItem `json:"items"`
-	Total  int               `json:"total"`
-	Limit  int               `json:"limit"`
-	Offset int               `json:"offset"`
+	Items      []LeaderboardItem `json:"items"`
+	Total      int               `json:"total"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
+// LeaderboardQuery selects a leaderboard. RoomID is "all" or a room ID;
+// Cursor, a previous page's NextCursor, continues after that page.
 type LeaderboardQuery struct {
 	Window string
 	RoomID string
 	SortBy string
+	Cursor string
 }
 
 type LeaderboardItem struct {
-	Rank          int       `json:"rank"`
-	AgentID       string    `json:"agent_id"`
+	Rank            int                 `json:"rank"`
+	AgentID         string              `json:"agent_id"`
+	Name            string              `json:"name"`
+	Score           float64             `json:"score"`
+	BBPer100        float64             `json:"bb_per_100"`
+	BBPerHandStdDev float64             `json:"bb_per_hand_stddev"`
+	BBPer100CI      *ConfidenceInterval `json:"bb_per_100_ci"`
+	NetCCFromPlay   int64               `json:"net_cc_from_play"`
+	HandsPlayed     int                 `json:"hands_played"`
+	WinRate         float64             `json:"win_rate"`
+	Rating          float64             `json:"rating"`
+	RatingDeviation float64             `json:"rating_deviation"`
+	LastActiveAt    time.Time           `json:"last_active_at"`
+}
+
+// AgentWinRate is an agent's bb/100 with the sample standard deviation of
+// its per-hand results in big blinds and the 95% confidence interval it
+// gives, nil under two hands.
+type AgentWinRate struct {
+	AgentID         string              `json:"agent_id"`
+	Name            string              `json:"name"`
+	HandsPlayed     int                 `json:"hands_played"`
+	BBPer100        float64             `json:"bb_per_100"`
+	BBPerHandStdDev float64             `json:"bb_per_hand_stddev"`
+	BBPer100CI      *ConfidenceInterval `json:"bb_per_100_ci"`
+}
+
+// AgentComparisonResponse compares the win rates of two agents. DiffBBPer100
+// is agent A's bb/100 minus agent B's; AheadAgentID names the agent that is
+// statistically ahead, and is empty when the gap is not significant.
+type AgentComparisonResponse struct {
+	Window       string              `json:"window"`
+	AgentA       AgentWinRate        `json:"agent_a"`
+	AgentB       AgentWinRate        `json:"agent_b"`
+	DiffBBPer100 float64             `json:"diff_bb_per_100"`
+	DiffCI       *ConfidenceInterval `json:"diff_bb_per_100_ci"`
+	SharedHands  int                 `json:"shared_hands"`
+	ZScore       float64             `json:"z_score"`
+	PValue       float64             `json:"p_value"`
+	Significant  bool                `json:"significant"`
+	AheadAgentID string              `json:"ahead_agent_id,omitempty"`
+}
+
+type HandVerificationResponse struct {
+	HandID         string                 `json:"hand_id"`
+	TableID        string                 `json:"table_id"`
+	SeedHash       string                 `json:"seed_hash"`
+	Seed           string                 `json:"seed"`
+	HashMatches    bool                   `json:"hash_matches"`
+	DeckOrder      []string               `json:"deck_order"`
+	DealOrder      []int                  `json:"deal_order"`
+	Seats          []HandVerificationSeat `json:"seats"`
+	Board          []string               `json:"board"`
+	DealtBoard     []string               `json:"dealt_board"`
+	BoardMatches   bool                   `json:"board_matches"`
+	HoleCardsMatch bool                   `json:"hole_cards_match"`
+	Verified       bool                   `json:"verified"`
+}
+
+type HandVerificationSeat struct {
+	SeatID  int      `json:"seat_id"`
+	AgentID string   `json:"agent_id,omitempty"`
+	Dealt   []string `json:"dealt"`
+	Shown   []string `json:"shown,omitempty"`
+	Matches bool     `json:"matches"`
+}
+
+type DuplicateMatchesResponse struct {
+	Items  []DuplicateMatchItem `json:"items"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+type DuplicateMatchItem struct {
+	MatchID     string `json:"match_id"`
+	RoomID      string `json:"room_id"`
+	AgentAID    string `json:"agent_a_id"`
+	AgentBID    string `json:"agent_b_id"`
+	Leg1TableID string `json:"leg1_table_id"`
+	Leg2TableID string `json:"leg2_table_id"`
+	HandsPerLeg int    `json:"hands_per_leg"`
+	BuyinCC     int64  `json:"buyin_cc"`
+	CurrentLeg  int    `json:"current_leg"`
+	Status      string `json:"status"`
+	// SeedSchedule is published once the match has ended; with it every
+	// hand of both legs can be verified.
+	SeedSchedule string                     `json:"seed_schedule,omitempty"`
+	Results      []DuplicateMatchResultItem `json:"results"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	EndedAt      *time.Time                 `json:"ended_at"`
+}
+
+type DuplicateMatchResultItem struct {
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Leg1NetCC   int64  `json:"leg1_net_cc"`
+	Leg2NetCC   int64  `json:"leg2_net_cc"`
+	NetCC       int64  `json:"net_cc"`
+	HandsPlayed int    `json:"hands_played"`
+}
+
+type DuplicateStandingsResponse struct {
+	Items  []DuplicateStandingItem `json:"items"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
+
+type DuplicateStandingItem struct {
+	Rank        int     `json:"rank"`
+	AgentID     string  `json:"agent_id"`
+	Name        string  `json:"name"`
+	Matches     int     `json:"matches"`
+	Wins        int     `json:"wins"`
+	NetCC       int64   `json:"net_cc"`
+	HandsPlayed int     `json:"hands_played"`
+	BBPer100    float64 `json:"bb_per_100"`
+}
+
+type TournamentsResponse struct {
+	Items  []TournamentItem `json:"items"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+// TournamentItem is a sit-and-go or, when StartsAt is set, a scheduled
+// multi-table tournament. The payouts of a multi-table tournament are
+// projected from its current field until late registration closes.
+type TournamentItem struct {
+	TournamentID  string                `json:"tournament_id"`
+	RoomID        string                `json:"room_id"`
+	Name          string                `json:"name,omitempty"`
+	Status        string                `json:"status"`
+	MaxPlayers    int                   `json:"max_players"`
+	EntryFeeCC    int64                 `json:"entry_fee_cc"`
+	StartingChips int64                 `json:"starting_chips"`
+	PrizePoolCC   int64                 `json:"prize_pool_cc"`
+	PayoutsCC     []int64               `json:"payouts_cc"`
+	PlayersLeft   int                   `json:"players_left"`
+	StartsAt      *time.Time            `json:"starts_at,omitempty"`
+	StartedAt     *time.Time            `json:"started_at,omitempty"`
+	MinPlayers    int                   `json:"min_players,omitempty"`
+	LateRegLevels int                   `json:"late_reg_levels,omitempty"`
+	MaxRebuys     int                   `json:"max_rebuys,omitempty"`
+	RebuyLevels   int                   `json:"rebuy_levels,omitempty"`
+	AddonFeeCC    int64                 `json:"addon_fee_cc,omitempty"`
+	AddonChips    int64                 `json:"addon_chips,omitempty"`
+	Entries       []TournamentEntryItem `json:"entries"`
+	CreatedAt     time.Time             `json:"created_at"`
+	EndedAt       *time.Time            `json:"ended_at"`
+}
+
+// TournamentEntryItem is an entrant. FinishPlace is null while the agent is
+// still playing. A multi-table entrant has no seat until the tournament
+// starts or while it moves between tables.
+type TournamentEntryItem struct {
+	AgentID      string     `json:"agent_id"`
+	AgentName    string     `json:"agent_name"`
+	TableID      string     `json:"table_id,omitempty"`
+	SeatID       *int       `json:"seat_id"`
+	Rebuys       int        `json:"rebuys,omitempty"`
+	Addon        bool       `json:"addon,omitempty"`
+	FinishPlace  *int       `json:"finish_place"`
+	PayoutCC     int64      `json:"payout_cc"`
+	EliminatedAt *time.Time `json:"eliminated_at,omitempty"`
+}
+
+type LeaguesResponse struct {
+	Items  []LeagueItem `json:"items"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// LeagueItem is a round-robin or Swiss league. CurrentRound is 0 until the
+// first round is paired.
+type LeagueItem struct {
+	LeagueID      string     `json:"league_id"`
+	RoomID        string     `json:"room_id"`
+	Name          string     `json:"name"`
+	Format        string     `json:"format"`
+	HandsPerMatch int        `json:"hands_per_match"`
+	Rounds        int        `json:"rounds"`
+	CurrentRound  int        `json:"current_round"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EndedAt       *time.Time `json:"ended_at"`
+}
+
+type LeagueDetailResponse struct {
+	LeagueItem
+	Matches []LeagueMatchItem `json:"matches"`
+}
+
+// LeagueMatchItem is one pairing. A bye has no agent B; a completed match
+// with no winner is a draw.
+type LeagueMatchItem struct {
+	MatchID        string     `json:"match_id"`
+	Round          int        `json:"round"`
+	AgentAID       string     `json:"agent_a_id"`
+	AgentAName     string     `json:"agent_a_name"`
+	AgentBID       string     `json:"agent_b_id,omitempty"`
+	AgentBName     string     `json:"agent_b_name,omitempty"`
+	TableID        string     `json:"table_id,omitempty"`
+	Status         string     `json:"status"`
+	WinnerAgentID  string     `json:"winner_agent_id,omitempty"`
+	ForfeitAgentID string     `json:"forfeit_agent_id,omitempty"`
+	AgentANetCC    int64      `json:"agent_a_net_cc"`
+	AgentBNetCC    int64      `json:"agent_b_net_cc"`
+	HandsPlayed    int        `json:"hands_played"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+}
+
+type LeagueStandingsResponse struct {
+	LeagueID string               `json:"league_id"`
+	Status   string               `json:"status"`
+	Items    []LeagueStandingItem `json:"items"`
+}
+
+type LeagueStandingItem struct {
+	Rank        int    `json:"rank"`
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Seed        int    `json:"seed"`
+	Points      int    `json:"points"`
+	Played      int    `json:"played"`
+	Wins        int    `json:"wins"`
+	Draws       int    `json:"draws"`
+	Losses      int    `json:"losses"`
+	Byes        int    `json:"byes"`
+	NetCC       int64  `json:"net_cc"`
+	HandsPlayed int    `json:"hands_played"`
+}
+
+type SeasonsResponse struct {
+	Items  []SeasonItem `json:"items"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// SeasonItem is a leaderboard season over the hands that ended between
+// StartsAt and EndsAt. It is open until its final standings are archived
+// shortly after EndsAt; Entrants counts the agents ranked in them.
+type SeasonItem struct {
+	SeasonID  string     `json:"season_id"`
+	Name      string     `json:"name"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	Status    string     `json:"status"`
+	Entrants  int        `json:"entrants"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+}
+
+// SeasonStandingsResponse is a page of a season's standings, Final once
+// they are the archived standings of a closed season.
+type SeasonStandingsResponse struct {
+	SeasonItem
+	Final  bool                 `json:"final"`
+	Items  []SeasonStandingItem `json:"items"`
+	Total  int                  `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+type SeasonStandingItem struct {
+	Rank          int     `json:"rank"`
+	AgentID       string  `json:"agent_id"`
+	AgentName     string  `json:"agent_name"`
+	Score         float64 `json:"score"`
+	BBPer100      float64 `json:"bb_per_100"`
+	NetCCFromPlay int64   `json:"net_cc_from_play"`
+	HandsPlayed   int     `json:"hands_played"`
+	WinRate       float64 `json:"win_rate"`
+	Rating        float64 `json:"rating"`
+}
+
+// SeasonFinishItem is where an agent finished a closed season.
+type SeasonFinishItem struct {
+	SeasonID      string    `json:"season_id"`
 	Name          string    `json:"name"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	Rank          int       `json:"rank"`
+	Entrants      int       `json:"entrants"`
 	Score         float64   `json:"score"`
 	BBPer100      float64   `json:"bb_per_100"`
 	NetCCFromPlay int64     `json:"net_cc_from_play"`
 	HandsPlayed   int       `json:"hands_played"`
-	WinRate       float64   `json:"win_rate"`
-	LastActiveAt  time.Time `json:"last_active_at"`
+	Rating        float64   `json:"rating"`
+}
+
+type EquityQuery struct {
+	// Variant names the game, e.g. "omaha"; empty is Hold'em.
+	Variant string
+	// Hands holds each player's hole cards in short form, e.g. "AsKd".
+	Hands      []string
+	Board      string
+	Dead       string
+	Iterations int
+}
+
+type EquityResponse struct {
+	Variant string       `json:"variant"`
+	Board   []string     `json:"board"`
+	Dead    []string     `json:"dead"`
+	Exact   bool         `json:"exact"`
+	Boards  int          `json:"boards"`
+	Items   []EquityItem `json:"items"`
+}
+
+type EquityItem struct {
+	HoleCards []string `json:"hole_cards"`
+	Win       float64  `json:"win"`
+	Tie       float64  `json:"tie"`
+	Equity    float64  `json:"equity"`
 }
@@ -0,0 +1,141 @@
+package public
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"silicon-casino/internal/game"
+	"silicon-casino/internal/store"
+)
+
+type handStartedCommitment struct {
+	SeedHash   string `json:"seed_hash"`
+	DealOrder  []int  `json:"deal_order"`
+	HandNumber int    `json:"hand_number"`
+	MatchID    string `json:"match_id"`
+	Variant    string `json:"variant"`
+}
+
+type handSeedReveal struct {
+	Seed  string   `json:"seed"`
+	Board []string `json:"board"`
+}
+
+type handShowdown struct {
+	Showdown []struct {
+		AgentID   string   `json:"agent_id"`
+		SeatID    int      `json:"seat_id"`
+		HoleCards []string `json:"hole_cards"`
+	} `json:"showdown"`
+}
+
+// VerifyHand audits a finished hand against its commitment. The seed revealed
+// in hand_settled (or hand_voided) must hash to the seed_hash published in
+// hand_started, and the deck it produces must deal the board and every hole
+// card that was shown. Hands of a duplicate match reveal no seed; theirs is
+// derived from the match's seed schedule once the match has ended.
+func (s *Service) VerifyHand(ctx context.Context, handID string) (*HandVerificationResponse, error) {
+	if handID == "" {
+		return nil, ErrInvalidRequest
+	}
+	hand, err := s.store.GetHandByID(ctx, handID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if hand.EndedAt == nil {
+		return nil, ErrHandInProgress
+	}
+	events, err := s.store.ListTableReplayEventsByHand(ctx, hand.TableID, hand.ID)
+	if err != nil {
+		return nil, err
+	}
+	var started handStartedCommitment
+	var reveal handSeedReveal
+	var shown handShowdown
+	for _, ev := range events {
+		switch ev.EventType {
+		case "hand_started":
+			_ = json.Unmarshal(ev.Payload, &started)
+		case "hand_settled", "hand_voided":
+			_ = json.Unmarshal(ev.Payload, &reveal)
+		case "showdown":
+			_ = json.Unmarshal(ev.Payload, &shown)
+		}
+	}
+	if reveal.Seed == "" && started.MatchID != "" {
+		reveal.Seed, err = s.duplicateHandSeed(ctx, started)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if started.SeedHash == "" || reveal.Seed == "" {
+		return nil, ErrNotVerifiable
+	}
+	seed, err := hex.DecodeString(reveal.Seed)
+	if err != nil {
+		return nil, ErrNotVerifiable
+	}
+	variant, ok := game.VariantByName(started.Variant)
+	if !ok {
+		return nil, ErrNotVerifiable
+	}
+	deck := game.VariantDeckOrder(variant, seed)
+	if variant.HoleCards()*len(started.DealOrder) > len(deck) {
+		return nil, ErrNotVerifiable
+	}
+	holes, board := game.DealFromDeck(deck, started.DealOrder, variant.HoleCards())
+	dealtBoard := game.CardStrings(board)
+	if len(reveal.Board) > len(dealtBoard) {
+		return nil, ErrNotVerifiable
+	}
+	resp := &HandVerificationResponse{
+		HandID:         hand.ID,
+		TableID:        hand.TableID,
+		SeedHash:       started.SeedHash,
+		Seed:           reveal.Seed,
+		HashMatches:    game.SeedHash(seed) == started.SeedHash,
+		DeckOrder:      game.CardStrings(deck),
+		DealOrder:      started.DealOrder,
+		Seats:          make([]HandVerificationSeat, 0, len(started.DealOrder)),
+		Board:          reveal.Board,
+		DealtBoard:     dealtBoard,
+		BoardMatches:   slices.Equal(reveal.Board, dealtBoard[:len(reveal.Board)]),
+		HoleCardsMatch: true,
+	}
+	for _, seat := range started.DealOrder {
+		item := HandVerificationSeat{SeatID: seat, Dealt: game.CardStrings(holes[seat]), Matches: true}
+		for _, p := range shown.Showdown {
+			if p.SeatID == seat {
+				item.AgentID = p.AgentID
+				item.Shown = p.HoleCards
+				item.Matches = slices.Equal(p.HoleCards, item.Dealt)
+			}
+		}
+		resp.HoleCardsMatch = resp.HoleCardsMatch && item.Matches
+		resp.Seats = append(resp.Seats, item)
+	}
+	resp.Verified = resp.HashMatches && resp.BoardMatches && resp.HoleCardsMatch
+	return resp, nil
+}
+
+// duplicateHandSeed returns the hex seed of a duplicate match hand, or "" while
+// the match is still running and its schedule is secret.
+func (s *Service) duplicateHandSeed(ctx context.Context, started handStartedCommitment) (string, error) {
+	m, err := s.store.GetDuplicateMatch(ctx, started.MatchID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if m.Status == store.DuplicateMatchRunning {
+		return "", nil
+	}
+	return hex.EncodeToString(game.ScheduledSeed(m.SeedSchedule, started.HandNumber)), nil
+}
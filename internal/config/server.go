@@ -13,6 +13,8 @@ type ServerConfig struct {
 	BindCooldownMins  int     `env:"BIND_KEY_COOLDOWN_MINUTES" envDefault:"60"`
 	AllowAnyVendorKey bool    `env:"ALLOW_ANY_VENDOR_KEY" envDefault:"false"`
 
+	EquityIterations int `env:"EQUITY_ITERATIONS" envDefault:"10000"`
+
 	SpectatorPushEnabled    bool   `env:"SPECTATOR_PUSH_ENABLED" envDefault:"false"`
 	SpectatorPushConfigPath string `env:"SPECTATOR_PUSH_CONFIG_PATH"`
 }
@@ -0,0 +1,58 @@
+package game
+
+// BettingStructure limits the size of bets and raises.
+type BettingStructure string
+
+const (
+	NoLimit    BettingStructure = "no_limit"
+	PotLimit   BettingStructure = "pot_limit"
+	FixedLimit BettingStructure = "fixed_limit"
+)
+
+// GameBetting is the table's betting structure, the variant's default when
+// none is set.
+func (s *TableState) GameBetting() BettingStructure {
+	if s.Betting == "" {
+		return s.GameVariant().DefaultBetting()
+	}
+	return s.Betting
+}
+
+// betUnit is the size of a full bet on the current street: the big blind,
+// doubled on the turn and river of fixed-limit games.
+func (s *TableState) betUnit() int64 {
+	if s.GameBetting() == FixedLimit && (s.Street == StreetTurn || s.Street == StreetRiver) {
+		return 2 * s.BigBlind
+	}
+	return s.BigBlind
+}
+
+// RaiseLimits returns the street contributions a bet or raise by playerIdx
+// may reach: at least minTo, a full bet or raise, and at most maxTo, which
+// is bounded by the player's stack and by the betting structure. A pot-limit
+// raise may grow the pot by at most its size after the call; a fixed-limit
+// one is exactly one bet unit. ok is false when the player may not bet or
+// raise at all. When the stack cannot cover minTo, the only way to raise is
+// an all-in for less.
+func (s *TableState) RaiseLimits(playerIdx int) (minTo, maxTo int64, ok bool) {
+	me := s.Players[playerIdx]
+	if s.CurrentBet > 0 && !s.CanRaise(playerIdx) {
+		return 0, 0, false
+	}
+	stackTo := s.RoundBets[playerIdx] + me.Stack
+	minTo = s.CurrentBet + s.MinRaise
+	switch s.GameBetting() {
+	case PotLimit:
+		toCall := s.CurrentBet - s.RoundBets[playerIdx]
+		maxTo = max(minTo, s.CurrentBet+s.Pot+toCall)
+	case FixedLimit:
+		if s.StreetRaises >= s.RaiseCap {
+			return 0, 0, false
+		}
+		minTo = s.CurrentBet + s.betUnit()
+		maxTo = minTo
+	default:
+		maxTo = stackTo
+	}
+	return minTo, min(maxTo, stackTo), true
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func newBettingEngine(betting BettingStructure, stacks ...int64) *Engine {
+	e := &Engine{State: &TableState{MinRaise: 100, BigBlind: 100, Betting: betting, RaiseCap: 4}}
+	n := len(stacks)
+	for i, stack := range stacks {
+		e.State.Players = append(e.State.Players, &Player{ID: string(rune('a' + i)), Seat: i, Stack: stack})
+	}
+	e.State.Street = StreetFlop
+	e.State.Pot = 400
+	e.State.RoundBets = make([]int64, n)
+	e.State.TotalContrib = make([]int64, n)
+	e.State.Acted = make([]bool, n)
+	e.State.ActedBet = make([]int64, n)
+	return e
+}
+
+func TestPotLimitCapsBetsAndRaises(t *testing.T) {
+	e := newBettingEngine(PotLimit, 5000, 5000)
+	if err := ValidateAction(e.State, 0, ActionBet, 401); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected a bet above the pot to be rejected, got %v", err)
+	}
+	if err := ValidateAction(e.State, 0, ActionAllIn, 0); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected an all-in above the pot to be rejected, got %v", err)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 400}); err != nil {
+		t.Fatalf("pot bet: %v", err)
+	}
+	// Pot 800, 400 to call: a pot raise makes it 400 + 800 + 400 = 1600.
+	minTo, maxTo, ok := e.State.RaiseLimits(1)
+	if !ok || minTo != 800 || maxTo != 1600 {
+		t.Fatalf("unexpected raise limits: min=%d max=%d ok=%v", minTo, maxTo, ok)
+	}
+	e.State.CurrentActor = 1
+	if err := ValidateAction(e.State, 1, ActionRaise, 1700); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected a raise above the pot to be rejected, got %v", err)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionRaise, Amount: 1600}); err != nil {
+		t.Fatalf("pot raise: %v", err)
+	}
+}
+
+func TestPotLimitAllowsShortAllIn(t *testing.T) {
+	e := newBettingEngine(PotLimit, 300, 5000)
+	if err := ValidateAction(e.State, 0, ActionAllIn, 0); err != nil {
+		t.Fatalf("expected an all-in within the pot to be allowed, got %v", err)
+	}
+}
+
+func TestFixedLimitBetSizesAndRaiseCap(t *testing.T) {
+	e := newBettingEngine(FixedLimit, 5000, 5000)
+	e.State.Street = StreetTurn
+	e.State.MinRaise = e.State.betUnit()
+	if err := ValidateAction(e.State, 0, ActionBet, 100); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected a small bet on the turn to be rejected, got %v", err)
+	}
+	if err := ValidateAction(e.State, 0, ActionBet, 400); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected an oversized bet to be rejected, got %v", err)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 200}); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+	seat := 1
+	for to := int64(400); to <= 800; to += 200 {
+		e.State.CurrentActor = seat
+		if _, err := e.ApplyAction(nil, Action{Player: seat, Type: ActionRaise, Amount: to}); err != nil {
+			t.Fatalf("raise to %d: %v", to, err)
+		}
+		seat = 1 - seat
+	}
+	e.State.CurrentActor = seat
+	if _, _, ok := e.State.RaiseLimits(seat); ok {
+		t.Fatalf("expected the street to be capped after a bet and three raises")
+	}
+	if err := ValidateAction(e.State, seat, ActionRaise, 1000); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("expected a fifth bet to be rejected, got %v", err)
+	}
+	if err := ValidateAction(e.State, seat, ActionCall, 0); err != nil {
+		t.Fatalf("expected call to stay legal, got %v", err)
+	}
+}
+
+func TestFixedLimitNextStreetResetsCapAndUnit(t *testing.T) {
+	e := newBettingEngine(FixedLimit, 5000, 5000)
+	e.Deck = NewDeck()
+	e.State.StreetRaises = 4
+	e.NextStreet()
+	if e.State.Street != StreetTurn || e.State.StreetRaises != 0 || e.State.MinRaise != 200 {
+		t.Fatalf("unexpected turn state: street=%s raises=%d min_raise=%d", e.State.Street, e.State.StreetRaises, e.State.MinRaise)
+	}
+}
+
+func TestShortCallGoesAllIn(t *testing.T) {
+	e := newBettingEngine(NoLimit, 5000, 300)
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 500}); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+	e.State.CurrentActor = 1
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionCall}); err != nil {
+		t.Fatalf("short call: %v", err)
+	}
+	p := e.State.Players[1]
+	if !p.AllIn || p.Stack != 0 || e.State.RoundBets[1] != 300 || e.State.CurrentBet != 500 {
+		t.Fatalf("expected an all-in call for 300, got %+v round_bets=%v current_bet=%d", p, e.State.RoundBets, e.State.CurrentBet)
+	}
+}
+
+func TestRejectedActionLeavesStateUntouched(t *testing.T) {
+	e := newBettingEngine(NoLimit, 5000, 5000)
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 500}); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+	e.State.CurrentActor = 1
+	// A stack gone negative cannot cover anything, so the call is refused.
+	e.State.Players[1].Stack = -100
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionCall}); err == nil {
+		t.Fatalf("expected the call to be rejected")
+	}
+	p := e.State.Players[1]
+	if e.State.Acted[1] || p.LastAction != "" || e.State.RoundBets[1] != 0 || e.State.Pot != 900 {
+		t.Fatalf("rejected action must not change the state: acted=%v last=%q round_bets=%v pot=%d", e.State.Acted[1], p.LastAction, e.State.RoundBets, e.State.Pot)
+	}
+}
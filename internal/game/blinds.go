@@ -0,0 +1,36 @@
+package game
+
+import "time"
+
+// BlindLevel is one level of a blind schedule.
+type BlindLevel struct {
+	SmallBlind int64 `json:"small_blind_cc"`
+	BigBlind   int64 `json:"big_blind_cc"`
+	Ante       int64 `json:"ante_cc"`
+}
+
+// BlindSchedule raises the blinds every Hands hands or, when Hands is zero,
+// every Every. Levels[0] is the starting level; the last level stays in
+// effect once it is reached. A schedule with neither interval never leaves
+// the first level.
+type BlindSchedule struct {
+	Levels []BlindLevel
+	Hands  int
+	Every  time.Duration
+}
+
+// Level returns the index of the level in effect for a hand dealt after
+// handsPlayed hands, elapsed after the first hand of the schedule.
+func (b BlindSchedule) Level(handsPlayed int, elapsed time.Duration) int {
+	if len(b.Levels) == 0 {
+		return 0
+	}
+	level := 0
+	switch {
+	case b.Hands > 0:
+		level = handsPlayed / b.Hands
+	case b.Every > 0:
+		level = int(elapsed / b.Every)
+	}
+	return min(max(level, 0), len(b.Levels)-1)
+}
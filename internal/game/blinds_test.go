@@ -0,0 +1,23 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlindScheduleLevels(t *testing.T) {
+	levels := []BlindLevel{{50, 100, 0}, {100, 200, 0}, {200, 400, 50}}
+	byHands := BlindSchedule{Levels: levels, Hands: 10}
+	for _, tc := range []struct{ hands, want int }{{0, 0}, {9, 0}, {10, 1}, {25, 2}, {500, 2}} {
+		if got := byHands.Level(tc.hands, time.Hour); got != tc.want {
+			t.Fatalf("after %d hands: expected level %d, got %d", tc.hands, tc.want, got)
+		}
+	}
+	byTime := BlindSchedule{Levels: levels, Every: 5 * time.Minute}
+	if got := byTime.Level(1000, 7*time.Minute); got != 1 {
+		t.Fatalf("expected level 1 after seven minutes, got %d", got)
+	}
+	if got := (BlindSchedule{Levels: levels}).Level(1000, time.Hour); got != 0 {
+		t.Fatalf("expected a schedule without an interval to stay at level 0, got %d", got)
+	}
+}
@@ -1,10 +1,13 @@
 package game
 
 import (
-	"math/rand"
-	"time"
+	"errors"
+	"strings"
 )
 
+// ErrInvalidCard is returned for a card string that ParseCard cannot read.
+var ErrInvalidCard = errors.New("invalid_card")
+
 type Suit int
 
 type Rank int
@@ -50,20 +53,33 @@ type Deck struct {
 }
 
 func NewDeck() *Deck {
-	cards := make([]Card, 0, 52)
+	return newDeckFrom(Two)
+}
+
+// NewShortDeck returns the 36-card short deck: sixes through aces.
+func NewShortDeck() *Deck {
+	return newDeckFrom(Six)
+}
+
+func newDeckFrom(low Rank) *Deck {
+	cards := make([]Card, 0, 4*int(Ace-low+1))
 	for s := Spades; s <= Clubs; s++ {
-		for r := Two; r <= Ace; r++ {
+		for r := low; r <= Ace; r++ {
 			cards = append(cards, Card{Rank: r, Suit: s})
 		}
 	}
 	return &Deck{cards: cards}
 }
 
-func (d *Deck) Shuffle() {
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rnd.Shuffle(len(d.cards), func(i, j int) {
-		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
-	})
+// Shuffle orders the deck from a fresh crypto/rand seed and returns the
+// seed so the deal can be verified once the hand is over.
+func (d *Deck) Shuffle() ([]byte, error) {
+	seed, err := NewSeed()
+	if err != nil {
+		return nil, err
+	}
+	d.ShuffleWithSeed(seed)
+	return seed, nil
 }
 
 func (d *Deck) Deal() Card {
@@ -71,3 +87,42 @@ func (d *Deck) Deal() Card {
 	d.cards = d.cards[1:]
 	return c
 }
+
+// CardStrings renders cards in their short form, e.g. "As", "Td".
+func CardStrings(cards []Card) []string {
+	out := make([]string, 0, len(cards))
+	for _, c := range cards {
+		out = append(out, c.String())
+	}
+	return out
+}
+
+// ParseCard reads a card in its short form, e.g. "As" or "td".
+func ParseCard(s string) (Card, error) {
+	if len(s) != 2 {
+		return Card{}, ErrInvalidCard
+	}
+	r := strings.Index("23456789TJQKA", strings.ToUpper(s[:1]))
+	su := strings.Index("shdc", strings.ToLower(s[1:]))
+	if r < 0 || su < 0 {
+		return Card{}, ErrInvalidCard
+	}
+	return Card{Rank: Two + Rank(r), Suit: Suit(su)}, nil
+}
+
+// ParseCards reads a run of short-form cards with no separator, e.g.
+// "AsKd7c".
+func ParseCards(s string) ([]Card, error) {
+	if len(s)%2 != 0 {
+		return nil, ErrInvalidCard
+	}
+	out := make([]Card, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		c, err := ParseCard(s[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
@@ -5,18 +5,30 @@ import (
 	"errors"
 	"time"
 
-	"silicon-casino/internal/ledger"
 	"silicon-casino/internal/store"
 )
 
+var ErrNotEnoughPlayers = errors.New("not_enough_players")
+
+// Ledger moves chips between the table stacks and the pot of a hand.
+// *ledger.Ledger implements it on top of the store.
+type Ledger interface {
+	DebitBlind(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error)
+	DebitBet(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error)
+	SettleHand(ctx context.Context, hs store.HandSettlement) error
+}
+
 type Engine struct {
 	Store  *store.Store
-	Ledger *ledger.Ledger
+	Ledger Ledger
 	State  *TableState
 	Deck   *Deck
+	// DeckSeedSchedule, when set, seeds hand N of the table with
+	// ScheduledSeed(DeckSeedSchedule, N) instead of a random seed.
+	DeckSeedSchedule string
 }
 
-func NewEngine(store *store.Store, ledger *ledger.Ledger, tableID string, sb, bb int64) *Engine {
+func NewEngine(store *store.Store, ledger Ledger, tableID string, sb, bb int64) *Engine {
 	state := &TableState{
 		TableID:       tableID,
 		SmallBlind:    sb,
@@ -28,81 +40,186 @@ func NewEngine(store *store.Store, ledger *ledger.Ledger, tableID string, sb, bb
 	return &Engine{Store: store, Ledger: ledger, State: state}
 }
 
-func (e *Engine) StartHand(ctx context.Context, p1, p2 *Player, sb, bb int64) error {
-	e.State.Players[0] = p1
-	e.State.Players[1] = p2
+// StartHand deals a new hand to the given seats. players is indexed by seat
+// and may contain nil entries for empty seats. The button moves to the next
+// dealt-in seat; heads-up the button posts the small blind and acts first
+// preflop, otherwise the blinds follow the button and the seat after the big
+// blind opens the action. With State.Ante set every dealt-in seat antes
+// before the blinds are posted, or with State.BigBlindAnte only the big
+// blind, after its blind. With State.Straddle and three or more players the
+// seat after the big blind posts a live straddle of two big blinds when it
+// can cover one and then acts last preflop.
+func (e *Engine) StartHand(ctx context.Context, players []*Player, sb, bb int64) error {
+	n := len(players)
+	e.State.Players = players
 	e.State.HandID = ""
 	e.State.Community = nil
 	e.State.Street = StreetPreFlop
 	e.State.Pot = 0
 	e.State.CurrentBet = 0
-	e.State.RoundBets = [2]int64{}
-	e.State.TotalContrib = [2]int64{}
-	e.State.Acted = [2]bool{}
+	e.State.RoundBets = make([]int64, n)
+	e.State.TotalContrib = make([]int64, n)
+	e.State.Acted = make([]bool, n)
+	e.State.ActedBet = make([]int64, n)
+	e.State.Settled = false
+	e.State.PotResults = nil
+	e.State.RakeCC = 0
 
-	e.State.DealerPos = 1 - e.State.DealerPos
 	e.State.SmallBlind = sb
 	e.State.BigBlind = bb
 	e.State.MinRaise = bb
 
-	handID, err := e.Store.CreateHand(ctx, e.State.TableID)
+	// Load table stacks
+	for _, p := range players {
+		if p == nil {
+			continue
+		}
+		stack, err := e.Store.GetTableStack(ctx, e.State.TableID, p.ID)
+		if err != nil {
+			return err
+		}
+		p.Stack = stack
+		p.Folded = false
+		p.AllIn = false
+		p.LastAction = ""
+		p.Hole = nil
+	}
+
+	// Brain dead rule
+	need := bb
+	if !e.State.BigBlindAnte {
+		need += e.State.Ante
+	}
+	if e.State.PlayShortStacks {
+		need = 1
+	}
+	for _, p := range players {
+		if p != nil && p.Stack < need {
+			p.Folded = true
+		}
+	}
+	dealtIn := e.State.ActivePlayers()
+	if dealtIn < 2 {
+		return ErrNotEnoughPlayers
+	}
+
+	e.State.DealerPos = e.State.nextSeat(e.State.DealerPos, inHand)
+	sbIdx := e.State.DealerPos
+	if dealtIn > 2 {
+		sbIdx = e.State.nextSeat(e.State.DealerPos, inHand)
+	}
+	bbIdx := e.State.nextSeat(sbIdx, inHand)
+
+	handID, err := e.Store.CreateHand(ctx, e.State.TableID, bb)
 	if err != nil {
 		return err
 	}
 	e.State.HandID = handID
 
-	e.Deck = NewDeck()
-	e.Deck.Shuffle()
-	for i := 0; i < 2; i++ {
-		p := e.State.Players[i]
-		p.Folded = false
-		p.AllIn = false
-		p.LastAction = ""
-		p.Hole = []Card{e.Deck.Deal(), e.Deck.Deal()}
+	e.State.HandNumber++
+	e.Deck = e.State.GameVariant().NewDeck()
+	seed, err := e.shuffle()
+	if err != nil {
+		return err
 	}
-
-	// Load balances
-	for i := 0; i < 2; i++ {
-		bal, err := e.Store.GetAccountBalance(ctx, e.State.Players[i].ID)
-		if err != nil {
-			return err
+	e.State.DeckSeed = seed
+	e.State.DealOrder = nil
+	for step := 1; step <= n; step++ {
+		if p := players[(e.State.DealerPos+step)%n]; inHand(p) {
+			e.State.DealOrder = append(e.State.DealOrder, p.Seat)
+		}
+	}
+	for i := 0; i < e.State.GameVariant().HoleCards(); i++ {
+		for step := 1; step <= n; step++ {
+			p := players[(e.State.DealerPos+step)%n]
+			if inHand(p) {
+				p.Hole = append(p.Hole, e.Deck.Deal())
+			}
 		}
-		e.State.Players[i].Stack = bal
 	}
 
-	// Brain dead rule
-	for i := 0; i < 2; i++ {
-		if e.State.Players[i].Stack < bb {
-			e.State.Players[i].Folded = true
+	// Antes are dead money: they go into the pot without counting towards
+	// the preflop bet.
+	if ante := e.State.Ante; ante > 0 && !e.State.BigBlindAnte {
+		for idx, p := range players {
+			if !inHand(p) {
+				continue
+			}
+			amount := min(ante, p.Stack)
+			newBal, err := e.Ledger.DebitBlind(ctx, e.State.TableID, p.ID, handID, amount)
+			if err != nil {
+				return err
+			}
+			p.Stack = newBal
+			if p.Stack == 0 {
+				p.AllIn = true
+			}
+			e.State.TotalContrib[idx] = amount
+			e.State.Pot += amount
 		}
 	}
 
-	// Post blinds if not folded
-	sbIdx := e.State.DealerPos
-	bbIdx := 1 - sbIdx
-	if !e.State.Players[sbIdx].Folded {
-		newBal, err := e.Ledger.DebitBlind(ctx, e.State.Players[sbIdx].ID, handID, sb)
+	// Post blinds
+	type blind struct {
+		idx    int
+		amount int64
+	}
+	blinds := []blind{{sbIdx, sb}, {bbIdx, bb}}
+	lastBlind := bbIdx
+	e.State.CurrentBet = bb
+	e.State.StreetRaises = 1
+	if e.State.Straddle && dealtIn > 2 {
+		if idx := e.State.nextSeat(bbIdx, inHand); players[idx].Stack >= 2*bb {
+			blinds = append(blinds, blind{idx, 2 * bb})
+			lastBlind = idx
+			e.State.CurrentBet = 2 * bb
+			e.State.MinRaise = 2 * bb
+			e.State.StreetRaises = 2
+		}
+	}
+	for _, b := range blinds {
+		p := players[b.idx]
+		// Only a short stack posts less than the full blind.
+		amount := min(b.amount, p.Stack)
+		if amount == 0 {
+			continue
+		}
+		newBal, err := e.Ledger.DebitBlind(ctx, e.State.TableID, p.ID, handID, amount)
 		if err != nil {
 			return err
 		}
-		e.State.Players[sbIdx].Stack = newBal
-		e.State.RoundBets[sbIdx] = sb
-		e.State.TotalContrib[sbIdx] = sb
+		p.Stack = newBal
+		if p.Stack == 0 {
+			p.AllIn = true
+		}
+		e.State.RoundBets[b.idx] = amount
+		e.State.TotalContrib[b.idx] += amount
+		e.State.Pot += amount
 	}
-	if !e.State.Players[bbIdx].Folded {
-		newBal, err := e.Ledger.DebitBlind(ctx, e.State.Players[bbIdx].ID, handID, bb)
+	if ante := min(e.State.Ante, players[bbIdx].Stack); ante > 0 && e.State.BigBlindAnte {
+		p := players[bbIdx]
+		newBal, err := e.Ledger.DebitBlind(ctx, e.State.TableID, p.ID, handID, ante)
 		if err != nil {
 			return err
 		}
-		e.State.Players[bbIdx].Stack = newBal
-		e.State.RoundBets[bbIdx] = bb
-		e.State.TotalContrib[bbIdx] = bb
-		e.State.CurrentBet = bb
+		p.Stack = newBal
+		if p.Stack == 0 {
+			p.AllIn = true
+		}
+		e.State.TotalContrib[bbIdx] += ante
+		e.State.Pot += ante
 	}
-	e.State.Pot = e.State.RoundBets[0] + e.State.RoundBets[1]
 
-	// Preflop: small blind acts first
-	e.State.CurrentActor = sbIdx
+	// Preflop: heads-up the small blind acts first, otherwise the seat after
+	// the last blind. A blind that is all-in does not act.
+	if dealtIn == 2 && canAct(players[sbIdx]) {
+		e.State.CurrentActor = sbIdx
+	} else {
+		e.State.CurrentActor = e.State.nextSeat(lastBlind, canAct)
+	}
+	if e.State.CurrentActor < 0 {
+		e.State.CurrentActor = bbIdx
+	}
 	return nil
 }
 
@@ -118,62 +235,51 @@ func (e *Engine) ApplyAction(ctx context.Context, a Action) (bool, error) {
 		return false, err
 	}
 	p := s.Players[a.Player]
-	oppIdx := 1 - a.Player
 	paid := int64(0)
 
-	s.Acted[a.Player] = true
-	p.LastAction = a.Type
-
+	to := s.RoundBets[a.Player]
 	switch a.Type {
-	case ActionFold:
-		p.Folded = true
-		return true, nil
-	case ActionCheck:
-		// no chips
 	case ActionCall:
-		need := s.CurrentBet - s.RoundBets[a.Player]
-		if need < 0 {
-			need = 0
-		}
-		if need > 0 {
-			if err := e.debitBet(ctx, a.Player, need); err != nil {
-				return false, err
-			}
-			s.RoundBets[a.Player] += need
-			s.TotalContrib[a.Player] += need
-			s.Pot += need
-			paid = need
-		}
-	case ActionBet:
-		amount := a.Amount
-		if err := e.debitBet(ctx, a.Player, amount); err != nil {
-			return false, err
-		}
-		s.RoundBets[a.Player] += amount
-		s.TotalContrib[a.Player] += amount
-		s.CurrentBet = s.RoundBets[a.Player]
-		s.MinRaise = amount
-		s.Pot += amount
-		s.LastAggressor = a.Player
-		paid = amount
-	case ActionRaise:
-		to := a.Amount
-		need := to - s.RoundBets[a.Player]
-		if need < 0 {
-			return false, errors.New("invalid_raise")
+		to = s.CurrentBet
+	case ActionBet, ActionRaise:
+		to = a.Amount
+		if a.Type == ActionBet {
+			to += s.RoundBets[a.Player]
 		}
+	case ActionAllIn:
+		to = s.RoundBets[a.Player] + p.Stack
+	}
+	// Cap to the remaining stack; whatever cannot be covered is an all-in.
+	to = min(to, s.RoundBets[a.Player]+p.Stack)
+	need := to - s.RoundBets[a.Player]
+	if need < 0 {
+		return false, errors.New("invalid_raise")
+	}
+	if need > 0 {
 		if err := e.debitBet(ctx, a.Player, need); err != nil {
 			return false, err
 		}
-		prevBet := s.CurrentBet
 		s.RoundBets[a.Player] = to
 		s.TotalContrib[a.Player] += need
-		s.CurrentBet = to
-		s.MinRaise = to - prevBet
 		s.Pot += need
-		s.LastAggressor = a.Player
 		paid = need
 	}
+	s.Acted[a.Player] = true
+	p.LastAction = a.Type
+	if a.Type == ActionFold {
+		p.Folded = true
+	}
+	if to > s.CurrentBet {
+		// Only a full bet or raise resets the minimum raise; an all-in for
+		// less leaves it untouched and does not reopen the betting.
+		if raise := to - s.CurrentBet; raise >= s.MinRaise {
+			s.MinRaise = raise
+			s.StreetRaises++
+		}
+		s.CurrentBet = to
+		s.LastAggressor = a.Player
+	}
+	s.ActedBet[a.Player] = s.CurrentBet
 
 	if e.Store != nil {
 		_ = e.Store.RecordAction(ctx, s.HandID, p.ID, string(a.Type), paid)
@@ -183,24 +289,55 @@ func (e *Engine) ApplyAction(ctx context.Context, a Action) (bool, error) {
 		p.AllIn = true
 	}
 
-	if s.Players[oppIdx].Folded {
-		return true, nil
+	return e.advanceActor(a.Player), nil
+}
+
+func (e *Engine) shuffle() ([]byte, error) {
+	if e.DeckSeedSchedule == "" {
+		return e.Deck.Shuffle()
 	}
+	seed := ScheduledSeed(e.DeckSeedSchedule, e.State.HandNumber)
+	e.Deck.ShuffleWithSeed(seed)
+	return seed, nil
+}
 
-	if s.RoundBets[0] == s.RoundBets[1] && s.Acted[0] && s.Acted[1] {
-		return true, nil
+// Forfeit folds the player at seat out of turn, e.g. when they leave the
+// table mid-hand. It reports whether the betting round is over as a result.
+func (e *Engine) Forfeit(seat int) bool {
+	s := e.State
+	if s.Settled || seat < 0 || seat >= len(s.Players) || !inHand(s.Players[seat]) {
+		return false
 	}
+	s.Players[seat].Folded = true
+	s.Players[seat].LastAction = ActionFold
+	if seat != s.CurrentActor {
+		return s.ActivePlayers() <= 1 || s.roundComplete()
+	}
+	return e.advanceActor(seat)
+}
 
-	s.CurrentActor = oppIdx
-	return false, nil
+// advanceActor passes the action to the next seat that can act after from.
+// It reports true when the betting round is over instead.
+func (e *Engine) advanceActor(from int) bool {
+	s := e.State
+	if s.ActivePlayers() <= 1 || s.roundComplete() {
+		return true
+	}
+	next := s.nextSeat(from, canAct)
+	if next < 0 {
+		return true
+	}
+	s.CurrentActor = next
+	return false
 }
 
 func (e *Engine) NextStreet() {
 	s := e.State
-	s.RoundBets = [2]int64{}
-	s.Acted = [2]bool{}
+	s.RoundBets = make([]int64, len(s.Players))
+	s.Acted = make([]bool, len(s.Players))
+	s.ActedBet = make([]int64, len(s.Players))
 	s.CurrentBet = 0
-	s.MinRaise = s.BigBlind
+	s.StreetRaises = 0
 
 	switch s.Street {
 	case StreetPreFlop:
@@ -213,8 +350,11 @@ func (e *Engine) NextStreet() {
 		s.Community = append(s.Community, e.Deck.Deal())
 		s.Street = StreetRiver
 	}
-	// postflop: big blind acts first (i.e. non-dealer)
-	s.CurrentActor = 1 - s.DealerPos
+	s.MinRaise = s.betUnit()
+	// postflop: first seat after the button that can still act
+	if next := s.nextSeat(s.DealerPos, canAct); next >= 0 {
+		s.CurrentActor = next
+	}
 }
 
 func (e *Engine) FastForwardToShowdown() {
@@ -224,85 +364,122 @@ func (e *Engine) FastForwardToShowdown() {
 	}
 }
 
+// Settle takes the rake, awards the main pot and every side pot to the best
+// eligible hands and returns the winning agent ID of the main pot, or
+// "split" when several hands tie for it. Per-pot results are kept in
+// State.PotResults and the rake in State.RakeCC. Calling it again for the
+// same hand is a no-op.
 func (e *Engine) Settle(ctx context.Context) (string, error) {
+	return e.SettleWith(ctx, nil)
+}
+
+// SettleWith is Settle with replay events stored alongside the payout.
+// describe is called once the results are known and before anything is
+// written; the pot credits, rake, hand summary and the returned events are
+// then committed in one transaction. When the commit fails the in-memory
+// state is left as it was before the call and the hand is not settled.
+func (e *Engine) SettleWith(ctx context.Context, describe func(winner string) []store.TableReplayEvent) (string, error) {
 	s := e.State
-	p0 := s.Players[0]
-	p1 := s.Players[1]
-
-	var winner string
-	if p0.Folded && !p1.Folded {
-		winner = p1.ID
-	} else if p1.Folded && !p0.Folded {
-		winner = p0.ID
-	} else {
-		cards0 := append([]Card{}, p0.Hole...)
-		cards0 = append(cards0, s.Community...)
-		cards1 := append([]Card{}, p1.Hole...)
-		cards1 = append(cards1, s.Community...)
-		r0 := Evaluate7(cards0)
-		r1 := Evaluate7(cards1)
-		if r0.BetterThan(r1) {
-			winner = p0.ID
-		} else if r1.BetterThan(r0) {
-			winner = p1.ID
-		} else {
-			winner = "split"
-		}
+	if s.Settled {
+		return "", nil
 	}
 
-	pot := ComputePot(s.TotalContrib[0], s.TotalContrib[1])
-	if winner == "split" {
-		// split main pot only
-		half := pot.Main / 2
-		if bal, err := e.Ledger.CreditPot(ctx, p0.ID, s.HandID, half); err == nil {
-			p0.Stack = bal
+	folded := make([]bool, len(s.Players))
+	ranks := make(map[int]HandStrength, len(s.Players))
+	showdown := s.ActivePlayers() > 1
+	for i, p := range s.Players {
+		folded[i] = !inHand(p)
+		if showdown && !folded[i] {
+			ranks[i] = s.GameVariant().Evaluate(p.Hole, s.Community)
 		}
-		if bal, err := e.Ledger.CreditPot(ctx, p1.ID, s.HandID, pot.Main-half); err == nil {
-			p1.Stack = bal
+	}
+
+	pots := BuildPots(s.TotalContrib, folded)
+	rake := s.Rake.Amount(s.TotalContrib, len(s.Community) > 0)
+	raked := takeRake(pots, rake)
+
+	results := make([]PotResult, 0, len(pots))
+	credits := []store.PotCredit{}
+	prevStacks := make([]int64, len(s.Players))
+	for i, p := range s.Players {
+		if p != nil {
+			prevStacks[i] = p.Stack
 		}
-		if pot.HasSide {
-			// side pot to bigger stack (contributor)
-			if s.TotalContrib[0] > s.TotalContrib[1] {
-				if bal, err := e.Ledger.CreditPot(ctx, p0.ID, s.HandID, pot.Side); err == nil {
-					p0.Stack = bal
-				}
-			} else {
-				if bal, err := e.Ledger.CreditPot(ctx, p1.ID, s.HandID, pot.Side); err == nil {
-					p1.Stack = bal
-				}
+	}
+	for i, pot := range pots {
+		winners := bestSeats(pot.Eligible, ranks)
+		seats, shares := splitPot(pot.Amount, winners, s.DealerPos, len(s.Players))
+		result := PotResult{Amount: pot.Amount, RakeCC: raked[i], EligibleSeats: pot.Eligible, Awards: make([]PotAward, 0, len(seats))}
+		for i, seat := range seats {
+			p := s.Players[seat]
+			result.Awards = append(result.Awards, PotAward{Seat: seat, AgentID: p.ID, Amount: shares[i]})
+			if shares[i] > 0 {
+				p.Stack += shares[i]
+				credits = append(credits, store.PotCredit{AgentID: p.ID, Amount: shares[i]})
 			}
 		}
-		return "split", nil
+		results = append(results, result)
 	}
+	s.PotResults = results
+	s.RakeCC = rake
 
-	if winner == p0.ID {
-		if bal, err := e.Ledger.CreditPot(ctx, p0.ID, s.HandID, pot.Main); err == nil {
-			p0.Stack = bal
+	winner := ""
+	if len(results) > 0 && len(results[0].Awards) > 0 {
+		winner = results[0].Awards[0].AgentID
+		if len(results[0].Awards) > 1 {
+			winner = "split"
 		}
-		if pot.HasSide && s.TotalContrib[0] > s.TotalContrib[1] {
-			if bal, err := e.Ledger.CreditPot(ctx, p0.ID, s.HandID, pot.Side); err == nil {
-				p0.Stack = bal
-			}
-		} else if pot.HasSide {
-			if bal, err := e.Ledger.CreditPot(ctx, p1.ID, s.HandID, pot.Side); err == nil {
-				p1.Stack = bal
+	}
+
+	if e.Ledger != nil {
+		settlement := store.HandSettlement{
+			TableID:   s.TableID,
+			HandID:    s.HandID,
+			Credits:   credits,
+			RakeCC:    rake,
+			PotCC:     s.Pot,
+			StreetEnd: string(s.Street),
+		}
+		if winner != "split" {
+			settlement.WinnerAgentID = winner
+		}
+		if describe != nil {
+			settlement.Events = describe(winner)
+		}
+		if err := e.Ledger.SettleHand(ctx, settlement); err != nil {
+			for i, p := range s.Players {
+				if p != nil {
+					p.Stack = prevStacks[i]
+				}
 			}
+			s.PotResults = nil
+			s.RakeCC = 0
+			return "", err
 		}
-		return p0.ID, nil
 	}
-	if bal, err := e.Ledger.CreditPot(ctx, p1.ID, s.HandID, pot.Main); err == nil {
-		p1.Stack = bal
+	s.Settled = true
+	return winner, nil
+}
+
+// bestSeats returns the eligible seats holding the best hand. Without
+// showdown ranks every eligible seat wins.
+func bestSeats(eligible []int, ranks map[int]HandStrength) []int {
+	if len(ranks) == 0 {
+		return eligible
 	}
-	if pot.HasSide && s.TotalContrib[1] > s.TotalContrib[0] {
-		if bal, err := e.Ledger.CreditPot(ctx, p1.ID, s.HandID, pot.Side); err == nil {
-			p1.Stack = bal
-		}
-	} else if pot.HasSide {
-		if bal, err := e.Ledger.CreditPot(ctx, p0.ID, s.HandID, pot.Side); err == nil {
-			p0.Stack = bal
+	winners := make([]int, 0, len(eligible))
+	var best HandStrength
+	for _, seat := range eligible {
+		rank := ranks[seat]
+		switch {
+		case len(winners) == 0 || rank > best:
+			winners = append(winners[:0], seat)
+			best = rank
+		case rank == best:
+			winners = append(winners, seat)
 		}
 	}
-	return p1.ID, nil
+	return winners
 }
 
 func (e *Engine) debitBet(ctx context.Context, playerIdx int, amount int64) error {
@@ -310,13 +487,13 @@ func (e *Engine) debitBet(ctx context.Context, playerIdx int, amount int64) erro
 		return nil
 	}
 	p := e.State.Players[playerIdx]
-	if e.Store == nil {
+	if e.Ledger == nil {
 		if p.Stack < amount {
 			return errors.New("insufficient_balance")
 		}
 		p.Stack -= amount
 	} else {
-		newBal, err := e.Store.Debit(ctx, p.ID, amount, "bet_debit", "hand", e.State.HandID)
+		newBal, err := e.Ledger.DebitBet(ctx, e.State.TableID, p.ID, e.State.HandID, amount)
 		if err != nil {
 			return err
 		}
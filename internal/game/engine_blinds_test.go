@@ -0,0 +1,102 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"silicon-casino/internal/ledger"
+	"silicon-casino/internal/testutil"
+)
+
+func TestStartHandStraddleAndBigBlindAnte(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableID, err := st.CreateTable(ctx, "room-test", "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	players := make([]*Player, 4)
+	for i := range players {
+		name := string(rune('A' + i))
+		agentID, err := st.CreateAgent(ctx, name, "key-"+name, "claim-key-"+name)
+		if err != nil {
+			t.Fatalf("create agent %s: %v", name, err)
+		}
+		if err := st.EnsureAccount(ctx, agentID, 10000); err != nil {
+			t.Fatalf("ensure account %s: %v", name, err)
+		}
+		if err := st.BuyIn(ctx, tableID, agentID, 5000); err != nil {
+			t.Fatalf("buy in %s: %v", name, err)
+		}
+		players[i] = &Player{ID: agentID, Name: name, Seat: i}
+	}
+
+	eng := NewEngine(st, ledger.New(st), tableID, 50, 100)
+	eng.State.Ante = 100
+	eng.State.BigBlindAnte = true
+	eng.State.Straddle = true
+	if err := eng.StartHand(ctx, players, 50, 100); err != nil {
+		t.Fatalf("start hand: %v", err)
+	}
+	s := eng.State
+	// Button 1: small blind 2, big blind 3, straddle 0, and seat 1 opens.
+	if s.DealerPos != 1 || s.CurrentActor != 1 {
+		t.Fatalf("expected button 1 to act first after the straddle, got dealer=%d actor=%d", s.DealerPos, s.CurrentActor)
+	}
+	if s.CurrentBet != 200 || s.MinRaise != 200 || s.RoundBets[0] != 200 {
+		t.Fatalf("expected a 200 straddle from seat 0, got bet=%d min_raise=%d bets=%v", s.CurrentBet, s.MinRaise, s.RoundBets)
+	}
+	// The big blind antes for the table: 100 blind plus 100 ante.
+	if s.TotalContrib[3] != 200 || s.RoundBets[3] != 100 || players[3].Stack != 4800 {
+		t.Fatalf("expected the big blind to post blind and ante, got contrib=%v stack=%d", s.TotalContrib, players[3].Stack)
+	}
+	if s.Pot != 450 {
+		t.Fatalf("expected a 450 pot, got %d", s.Pot)
+	}
+	if err := ValidateAction(s, 1, ActionRaise, 300); err == nil {
+		t.Fatalf("expected a raise below a full straddle raise to be rejected")
+	}
+}
+
+func TestStartHandShortStackPostsAllIn(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tableID, err := st.CreateTable(ctx, "room-test", "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	players := make([]*Player, 2)
+	for i, buyin := range []int64{5000, 30} {
+		name := string(rune('A' + i))
+		agentID, err := st.CreateAgent(ctx, name, "key-"+name, "claim-key-"+name)
+		if err != nil {
+			t.Fatalf("create agent %s: %v", name, err)
+		}
+		if err := st.EnsureAccount(ctx, agentID, 10000); err != nil {
+			t.Fatalf("ensure account %s: %v", name, err)
+		}
+		if err := st.BuyIn(ctx, tableID, agentID, buyin); err != nil {
+			t.Fatalf("buy in %s: %v", name, err)
+		}
+		players[i] = &Player{ID: agentID, Name: name, Seat: i}
+	}
+
+	eng := NewEngine(st, ledger.New(st), tableID, 50, 100)
+	eng.State.PlayShortStacks = true
+	if err := eng.StartHand(ctx, players, 50, 100); err != nil {
+		t.Fatalf("start hand: %v", err)
+	}
+	s := eng.State
+	// Seat 1 is the button and small blind, all-in for 30; the big blind
+	// is left to act.
+	if !players[1].AllIn || s.RoundBets[1] != 30 || s.CurrentBet != 100 {
+		t.Fatalf("expected a 30 all-in small blind, got allin=%v bets=%v current=%d", players[1].AllIn, s.RoundBets, s.CurrentBet)
+	}
+	if s.CurrentActor != 0 || s.Pot != 130 {
+		t.Fatalf("expected the big blind to act on a 130 pot, got actor=%d pot=%d", s.CurrentActor, s.Pot)
+	}
+}
@@ -34,10 +34,17 @@ func TestSettleTransfersCCOnly(t *testing.T) {
 		t.Fatalf("create table: %v", err)
 	}
 
-	eng := NewEngine(st, ledger.New(st), tableID, 50, 100)
+	for _, agentID := range []string{agent0, agent1} {
+		if err := st.BuyIn(ctx, tableID, agentID, 5000); err != nil {
+			t.Fatalf("buy in %s: %v", agentID, err)
+		}
+	}
+
+	led := ledger.New(st)
+	eng := NewEngine(st, led, tableID, 50, 100)
 	p0 := &Player{ID: agent0, Name: "A", Seat: 0}
 	p1 := &Player{ID: agent1, Name: "B", Seat: 1}
-	if err := eng.StartHand(ctx, p0, p1, 50, 100); err != nil {
+	if err := eng.StartHand(ctx, []*Player{p0, p1}, 50, 100); err != nil {
 		t.Fatalf("start hand: %v", err)
 	}
 
@@ -50,6 +57,17 @@ func TestSettleTransfersCCOnly(t *testing.T) {
 		t.Fatalf("settle: %v", err)
 	}
 
+	if bal, _ := st.GetAccountBalance(ctx, agent0); bal != 5000 {
+		t.Fatalf("expected the hand to leave account A untouched, got %d", bal)
+	}
+	if stack, _ := st.GetTableStack(ctx, tableID, agent0); stack != p0.Stack {
+		t.Fatalf("expected table stack A=%d, got %d", p0.Stack, stack)
+	}
+	for _, agentID := range []string{agent0, agent1} {
+		if _, err := led.CashOut(ctx, tableID, agentID); err != nil {
+			t.Fatalf("cash out %s: %v", agentID, err)
+		}
+	}
 	bal0, _ := st.GetAccountBalance(ctx, agent0)
 	bal1, _ := st.GetAccountBalance(ctx, agent1)
 
@@ -64,3 +82,54 @@ func TestSettleTransfersCCOnly(t *testing.T) {
 		}
 	}
 }
+
+func TestSettleAwardsSidePotsAndOddChips(t *testing.T) {
+	board := []Card{{Rank: Two, Suit: Clubs}, {Rank: Seven, Suit: Diamonds}, {Rank: Nine, Suit: Hearts}, {Rank: Jack, Suit: Spades}, {Rank: King, Suit: Clubs}}
+	e := &Engine{State: &TableState{
+		DealerPos: 0,
+		Community: board,
+		Players: []*Player{
+			{ID: "button", Hole: []Card{{Rank: Three, Suit: Hearts}, {Rank: Four, Suit: Hearts}}},
+			{ID: "short", Hole: []Card{{Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Hearts}}, AllIn: true},
+			{ID: "tie-a", Hole: []Card{{Rank: Queen, Suit: Spades}, {Rank: Queen, Suit: Hearts}}},
+			{ID: "tie-b", Hole: []Card{{Rank: Queen, Suit: Diamonds}, {Rank: Queen, Suit: Clubs}}},
+		},
+		TotalContrib: []int64{50, 100, 301, 301},
+	}}
+	e.State.Players[0].Folded = true
+
+	winner, err := e.Settle(nil)
+	if err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+	if winner != "short" {
+		t.Fatalf("expected short stack to win the main pot, got %q", winner)
+	}
+	if len(e.State.PotResults) != 2 {
+		t.Fatalf("expected main and side pot, got %+v", e.State.PotResults)
+	}
+	main := e.State.PotResults[0]
+	if main.Amount != 350 || len(main.Awards) != 1 || main.Awards[0].AgentID != "short" {
+		t.Fatalf("unexpected main pot: %+v", main)
+	}
+	side := e.State.PotResults[1]
+	if side.Amount != 402 || len(side.Awards) != 2 {
+		t.Fatalf("unexpected side pot: %+v", side)
+	}
+	if side.Awards[0].Seat != 2 || side.Awards[0].Amount != 201 || side.Awards[1].Amount != 201 {
+		t.Fatalf("expected even split of side pot, got %+v", side.Awards)
+	}
+	if e.State.Players[2].Stack != 201 || e.State.Players[3].Stack != 201 || e.State.Players[1].Stack != 350 {
+		t.Fatalf("unexpected stacks: short=%d a=%d b=%d", e.State.Players[1].Stack, e.State.Players[2].Stack, e.State.Players[3].Stack)
+	}
+	if again, _ := e.Settle(nil); again != "" || e.State.Players[1].Stack != 350 {
+		t.Fatalf("expected second settle to be a no-op")
+	}
+}
+
+func TestSplitPotOddChipsGoLeftOfButton(t *testing.T) {
+	seats, shares := splitPot(101, []int{0, 2}, 2, 4)
+	if seats[0] != 0 || shares[0] != 51 || seats[1] != 2 || shares[1] != 50 {
+		t.Fatalf("expected seat 0 (first left of button) to get the odd chip, got seats=%v shares=%v", seats, shares)
+	}
+}
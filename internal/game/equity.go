@@ -0,0 +1,194 @@
+package game
+
+import (
+	"errors"
+	"math/rand/v2"
+)
+
+// DefaultEquityIterations is the number of random boards CalculateEquity
+// samples when it cannot enumerate every runout within that budget.
+const DefaultEquityIterations = 10000
+
+var ErrInvalidEquityInput = errors.New("invalid_equity_input")
+
+// PlayerEquity is one player's result over the boards that were evaluated.
+// Win and Tie are the shares of boards won outright and split; Equity is the
+// expected share of the pot, counting a k-way split as 1/k.
+type PlayerEquity struct {
+	Win    float64 `json:"win"`
+	Tie    float64 `json:"tie"`
+	Equity float64 `json:"equity"`
+}
+
+// EquityResult is the all-in equity of every player, in the order of the
+// hole cards passed to CalculateEquity.
+type EquityResult struct {
+	Players []PlayerEquity `json:"players"`
+	// Exact is set when every runout was enumerated rather than sampled.
+	Exact  bool `json:"exact"`
+	Boards int  `json:"boards"`
+}
+
+// CalculateEquity computes each player's chance to win if the hand were run
+// out now. Boards are enumerated on the turn and river, and whenever the
+// number of runouts does not exceed iterations (heads-up flops with the
+// default budget); otherwise iterations boards are sampled at random. dead
+// cards are removed from the deck but dealt to nobody. Every hand must hold
+// the variant's number of hole cards; a nil variant is Hold'em. rng may be
+// nil.
+func CalculateEquity(v Variant, holes [][]Card, board, dead []Card, iterations int, rng *rand.Rand) (EquityResult, error) {
+	if v == nil {
+		v = Holdem
+	}
+	if len(holes) < 2 || len(board) > 5 || (len(board) > 0 && len(board) < 3) {
+		return EquityResult{}, ErrInvalidEquityInput
+	}
+	if iterations <= 0 {
+		iterations = DefaultEquityIterations
+	}
+	full := v.NewDeck().cards
+	valid := make(map[Card]bool, len(full))
+	for _, c := range full {
+		valid[c] = true
+	}
+	used := map[Card]bool{}
+	take := func(cards []Card) bool {
+		for _, c := range cards {
+			if !valid[c] || used[c] {
+				return false
+			}
+			used[c] = true
+		}
+		return true
+	}
+	for _, h := range holes {
+		if len(h) != v.HoleCards() || !take(h) {
+			return EquityResult{}, ErrInvalidEquityInput
+		}
+	}
+	if !take(board) || !take(dead) {
+		return EquityResult{}, ErrInvalidEquityInput
+	}
+	deck := make([]Card, 0, len(full))
+	for _, c := range full {
+		if !used[c] {
+			deck = append(deck, c)
+		}
+	}
+	need := 5 - len(board)
+	if need > len(deck) {
+		return EquityResult{}, ErrInvalidEquityInput
+	}
+
+	acc := newEquityAccumulator(v, holes, board)
+	if need <= 1 || binomial(len(deck), need) <= iterations {
+		runout := make([]Card, need)
+		enumerateRunouts(deck, runout, 0, 0, acc.add)
+		return acc.result(true), nil
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	runout := make([]Card, need)
+	for i := 0; i < iterations; i++ {
+		// Partial Fisher-Yates: the first need cards of deck are a uniform
+		// sample without replacement.
+		for j := 0; j < need; j++ {
+			k := j + rng.IntN(len(deck)-j)
+			deck[j], deck[k] = deck[k], deck[j]
+			runout[j] = deck[j]
+		}
+		acc.add(runout)
+	}
+	return acc.result(false), nil
+}
+
+type equityAccumulator struct {
+	variant Variant
+	holes   [][]Card
+	board   []Card
+	wins    []float64
+	ties    []float64
+	shares  []float64
+	ranks   []HandStrength
+	boards  int
+}
+
+func newEquityAccumulator(v Variant, holes [][]Card, board []Card) *equityAccumulator {
+	full := make([]Card, 5)
+	copy(full, board)
+	return &equityAccumulator{
+		variant: v,
+		holes:   holes,
+		board:   full,
+		wins:    make([]float64, len(holes)),
+		ties:    make([]float64, len(holes)),
+		shares:  make([]float64, len(holes)),
+		ranks:   make([]HandStrength, len(holes)),
+	}
+}
+
+// add scores one complete board: the known board cards plus runout.
+func (a *equityAccumulator) add(runout []Card) {
+	copy(a.board[5-len(runout):], runout)
+	var best HandStrength
+	for i, h := range a.holes {
+		a.ranks[i] = a.variant.Evaluate(h, a.board)
+		best = max(best, a.ranks[i])
+	}
+	winners := 0
+	for _, r := range a.ranks {
+		if r == best {
+			winners++
+		}
+	}
+	for i, r := range a.ranks {
+		if r != best {
+			continue
+		}
+		if winners == 1 {
+			a.wins[i]++
+		} else {
+			a.ties[i]++
+		}
+		a.shares[i] += 1 / float64(winners)
+	}
+	a.boards++
+}
+
+func (a *equityAccumulator) result(exact bool) EquityResult {
+	out := EquityResult{Players: make([]PlayerEquity, len(a.holes)), Exact: exact, Boards: a.boards}
+	if a.boards == 0 {
+		return out
+	}
+	n := float64(a.boards)
+	for i := range a.holes {
+		out.Players[i] = PlayerEquity{Win: a.wins[i] / n, Tie: a.ties[i] / n, Equity: a.shares[i] / n}
+	}
+	return out
+}
+
+// enumerateRunouts calls fn with every combination of len(runout) cards of
+// deck, filling runout from position i with cards from deck[from:].
+func enumerateRunouts(deck, runout []Card, i, from int, fn func([]Card)) {
+	if i == len(runout) {
+		fn(runout)
+		return
+	}
+	for j := from; j <= len(deck)-(len(runout)-i); j++ {
+		runout[i] = deck[j]
+		enumerateRunouts(deck, runout, i+1, j+1, fn)
+	}
+}
+
+// binomial returns n choose k, saturating well above any iteration budget.
+func binomial(n, k int) int {
+	r := 1
+	for i := 1; i <= k; i++ {
+		r = r * (n - k + i) / i
+		if r > 1<<40 {
+			return r
+		}
+	}
+	return r
+}
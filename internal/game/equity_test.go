@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func mustCards(t *testing.T, s string) []Card {
+	t.Helper()
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return cards
+}
+
+func TestCalculateEquityTurnIsExact(t *testing.T) {
+	holes := [][]Card{mustCards(t, "AsAh"), mustCards(t, "KsKh")}
+	res, err := CalculateEquity(Holdem, holes, mustCards(t, "2c7d9cJd"), nil, 0, nil)
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	if !res.Exact || res.Boards != 44 {
+		t.Fatalf("expected 44 enumerated rivers, got %+v", res)
+	}
+	// Only the two remaining kings save KK.
+	if got := res.Players[1].Equity; got != 2.0/44 {
+		t.Fatalf("expected KK equity 2/44, got %v", got)
+	}
+	if sum := res.Players[0].Equity + res.Players[1].Equity; math.Abs(sum-1) > 1e-9 {
+		t.Fatalf("expected equities to sum to 1, got %v", sum)
+	}
+}
+
+func TestCalculateEquityRiverSplit(t *testing.T) {
+	holes := [][]Card{mustCards(t, "2s3h"), mustCards(t, "2d3c")}
+	res, err := CalculateEquity(Holdem, holes, mustCards(t, "AsKdQhJcTs"), nil, 0, nil)
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	for _, p := range res.Players {
+		if p.Tie != 1 || p.Win != 0 || p.Equity != 0.5 {
+			t.Fatalf("expected a chopped board, got %+v", res.Players)
+		}
+	}
+}
+
+func TestCalculateEquityPreflopSamples(t *testing.T) {
+	holes := [][]Card{mustCards(t, "AsAh"), mustCards(t, "KsKh")}
+	res, err := CalculateEquity(Holdem, holes, nil, nil, 5000, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	if res.Exact || res.Boards != 5000 {
+		t.Fatalf("expected 5000 sampled boards, got exact=%v boards=%d", res.Exact, res.Boards)
+	}
+	// AA is about an 82% favourite over KK.
+	if got := res.Players[0].Equity; math.Abs(got-0.82) > 0.03 {
+		t.Fatalf("expected AA equity near 0.82, got %v", got)
+	}
+}
+
+func TestCalculateEquityRejectsDuplicateCards(t *testing.T) {
+	holes := [][]Card{mustCards(t, "AsAh"), mustCards(t, "AsKh")}
+	if _, err := CalculateEquity(Holdem, holes, nil, nil, 0, nil); !errors.Is(err, ErrInvalidEquityInput) {
+		t.Fatalf("expected invalid input, got %v", err)
+	}
+}
+
+func BenchmarkCalculateEquityPreflop(b *testing.B) {
+	holes := [][]Card{{{Ace, Spades}, {Ace, Hearts}}, {{King, Spades}, {King, Hearts}}}
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < b.N; i++ {
+		_, _ = CalculateEquity(Holdem, holes, nil, nil, DefaultEquityIterations, rng)
+	}
+}
@@ -1,7 +1,8 @@
 package game
 
 import (
-	"sort"
+	"fmt"
+	"math/bits"
 )
 
 type HandRank struct {
@@ -21,169 +22,283 @@ func (h HandRank) BetterThan(o HandRank) bool {
 	return false
 }
 
-func Evaluate7(cards []Card) HandRank {
-	best := HandRank{Category: -1}
-	idx := []int{0, 1, 2, 3, 4}
-	for a := 0; a < 7; a++ {
-		for b := a + 1; b < 7; b++ {
-			for c := b + 1; c < 7; c++ {
-				for d := c + 1; d < 7; d++ {
-					for e := d + 1; e < 7; e++ {
-						idx[0], idx[1], idx[2], idx[3], idx[4] = a, b, c, d, e
-						h := eval5(cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]])
-						if h.BetterThan(best) {
-							best = h
-						}
-					}
-				}
-			}
+// Category ranking: 8 Straight Flush, 7 Four, 6 Full House, 5 Flush, 4 Straight, 3 Trips, 2 Two Pair, 1 Pair, 0 High Card
+const (
+	categoryHighCard = iota
+	categoryPair
+	categoryTwoPair
+	categoryTrips
+	categoryStraight
+	categoryFlush
+	categoryFullHouse
+	categoryQuads
+	categoryStraightFlush
+)
+
+// categoryRanks is the number of Ranks a HandRank of each category carries.
+var categoryRanks = [...]int{5, 4, 3, 3, 1, 5, 2, 2, 1}
+
+// HandStrength packs a HandRank into an integer: the category in bits 20-23
+// and up to five ranks in the nibbles below, most significant first. A
+// stronger hand always has a larger HandStrength, so hands compare with <.
+type HandStrength uint32
+
+func newHandStrength(category int, ranks ...int) HandStrength {
+	v := uint32(category)
+	for i := 0; i < 5; i++ {
+		v <<= 4
+		if i < len(ranks) {
+			v |= uint32(ranks[i])
 		}
 	}
-	return best
+	return HandStrength(v)
 }
 
-// Category ranking: 8 Straight Flush, 7 Four, 6 Full House, 5 Flush, 4 Straight, 3 Trips, 2 Two Pair, 1 Pair, 0 High Card
-func eval5(c1, c2, c3, c4, c5 Card) HandRank {
-	cards := []Card{c1, c2, c3, c4, c5}
-	counts := map[int]int{}
-	suits := map[Suit]int{}
-	ranks := make([]int, 0, 5)
-	for _, c := range cards {
-		r := int(c.Rank)
-		counts[r]++
-		suits[c.Suit]++
-		ranks = append(ranks, r)
-	}
-	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
-	isFlush := false
-	for _, v := range suits {
-		if v == 5 {
-			isFlush = true
-			break
-		}
-	}
-	isStraight, highStraight := straightHigh(ranks)
-	if isFlush && isStraight {
-		return HandRank{Category: 8, Ranks: []int{highStraight}}
+// Rank unpacks the strength into the HandRank Evaluate7 returns.
+func (s HandStrength) Rank() HandRank {
+	category := int(s >> 20)
+	ranks := make([]int, categoryRanks[category])
+	for i := range ranks {
+		ranks[i] = int(s>>(16-4*i)) & 0xf
 	}
+	return HandRank{Category: category, Ranks: ranks}
+}
 
-	// sort counts
-	type rc struct {
-		rank  int
-		count int
+// Evaluate7 is Strength unpacked into a HandRank.
+func Evaluate7(cards []Card) HandRank {
+	return Strength(cards).Rank()
+}
+
+// Strength evaluates the best five-card hand out of five to seven cards with
+// two table lookups: a hand holding five cards of one suit is looked up by
+// that suit's rank bitmask, any other hand by the perfect hash of its rank
+// counts. With at most seven cards a flush excludes quads and full houses,
+// so the flush lookup alone decides such hands.
+//
+// The tables only cover five to seven cards: callers must pass a full board
+// plus hole cards, and any other count panics.
+func Strength(cards []Card) HandStrength {
+	return evalTables.strength(cards)
+}
+
+// ShortDeckStrength is Strength under short-deck rankings: A-6-7-8-9 is the
+// lowest straight and a flush beats a full house. The two categories swap
+// places in the packed value, so Rank reports a short-deck flush as category
+// 6 and a full house as 5.
+func ShortDeckStrength(cards []Card) HandStrength {
+	return shortDeckTables.strength(cards)
+}
+
+func (t *evalTableSet) strength(cards []Card) HandStrength {
+	if n := len(cards); n < 5 || n > 7 {
+		panic(fmt.Sprintf("game: hand strength needs 5 to 7 cards, got %d", n))
 	}
-	pairs := make([]rc, 0, len(counts))
-	for r, c := range counts {
-		pairs = append(pairs, rc{rank: r, count: c})
+	var counts [13]uint8
+	var suitMask [4]uint16
+	for _, c := range cards {
+		r := c.Rank - Two
+		counts[r]++
+		suitMask[c.Suit] |= 1 << r
 	}
-	sort.Slice(pairs, func(i, j int) bool {
-		if pairs[i].count != pairs[j].count {
-			return pairs[i].count > pairs[j].count
+	for _, m := range suitMask {
+		if bits.OnesCount16(m) >= 5 {
+			return t.flush[m]
 		}
-		return pairs[i].rank > pairs[j].rank
-	})
-
-	if pairs[0].count == 4 {
-		kicker := highestExcluding(ranks, pairs[0].rank)
-		return HandRank{Category: 7, Ranks: []int{pairs[0].rank, kicker}}
 	}
-	if pairs[0].count == 3 && pairs[1].count == 2 {
-		return HandRank{Category: 6, Ranks: []int{pairs[0].rank, pairs[1].rank}}
-	}
-	if isFlush {
-		return HandRank{Category: 5, Ranks: ranks}
+	return t.plain[len(cards)][countsHash(&counts, len(cards))]
+}
+
+// The table sets are built once at start-up; see buildEvalTables.
+var (
+	evalTables      = buildEvalTables(standardRules)
+	shortDeckTables = buildEvalTables(shortDeckRules)
+)
+
+// evalRules are the hand rankings a table set is built for.
+type evalRules struct {
+	// wheel is the rank mask of the lowest straight, which plays as
+	// wheelHigh high.
+	wheel     uint16
+	wheelHigh int
+	// flushOverFullHouse swaps the flush and full house categories.
+	flushOverFullHouse bool
+}
+
+var (
+	standardRules  = evalRules{wheel: 1<<12 | 0xf, wheelHigh: int(Five)}
+	shortDeckRules = evalRules{wheel: 1<<12 | 0xf<<4, wheelHigh: int(Nine), flushOverFullHouse: true}
+)
+
+// order applies the rules' category order to s.
+func (r evalRules) order(s HandStrength) HandStrength {
+	if !r.flushOverFullHouse {
+		return s
 	}
-	if isStraight {
-		return HandRank{Category: 4, Ranks: []int{highStraight}}
+	switch s >> 20 {
+	case categoryFlush:
+		return s&0xfffff | categoryFullHouse<<20
+	case categoryFullHouse:
+		return s&0xfffff | categoryFlush<<20
 	}
-	if pairs[0].count == 3 {
-		kickers := topKickers(ranks, []int{pairs[0].rank}, 2)
-		return HandRank{Category: 3, Ranks: append([]int{pairs[0].rank}, kickers...)}
+	return s
+}
+
+type evalTableSet struct {
+	// flush is indexed by the 13-bit rank mask of a suit with five or more
+	// cards.
+	flush []HandStrength
+	// plain[n] is indexed by countsHash of the rank counts of n cards.
+	plain [8][]HandStrength
+}
+
+// multisets[m][k] is the number of ways to spread k cards over m ranks with
+// at most four cards of each rank.
+var multisets = func() (t [14][8]int) {
+	t[0][0] = 1
+	for m := 1; m <= 13; m++ {
+		for k := 0; k <= 7; k++ {
+			for c := 0; c <= min(4, k); c++ {
+				t[m][k] += t[m-1][k-c]
+			}
+		}
 	}
-	if pairs[0].count == 2 && pairs[1].count == 2 {
-		highPair := pairs[0].rank
-		lowPair := pairs[1].rank
-		kicker := highestExcluding(ranks, highPair, lowPair)
-		return HandRank{Category: 2, Ranks: []int{highPair, lowPair, kicker}}
+	return t
+}()
+
+// hashOffset[r][k][c] is how many rank-count vectors sort before one that
+// puts c cards on rank r with k cards left to place from rank r on.
+var hashOffset = func() (t [13][8][5]int) {
+	for r := 0; r < 13; r++ {
+		for k := 0; k <= 7; k++ {
+			for c := 1; c <= 4; c++ {
+				t[r][k][c] = t[r][k][c-1]
+				if c-1 <= k {
+					t[r][k][c] += multisets[12-r][k-(c-1)]
+				}
+			}
+		}
 	}
-	if pairs[0].count == 2 {
-		kickers := topKickers(ranks, []int{pairs[0].rank}, 3)
-		return HandRank{Category: 1, Ranks: append([]int{pairs[0].rank}, kickers...)}
+	return t
+}()
+
+// countsHash maps the rank counts of n cards onto [0, multisets[13][n]): the
+// position of the counts among all such vectors in lexicographic order.
+func countsHash(counts *[13]uint8, n int) int {
+	h := 0
+	for r, c := range counts {
+		h += hashOffset[r][n][c]
+		n -= int(c)
 	}
-	return HandRank{Category: 0, Ranks: ranks}
+	return h
 }
 
-func straightHigh(ranks []int) (bool, int) {
-	unique := uniqueRanks(ranks)
-	sort.Sort(sort.Reverse(sort.IntSlice(unique)))
-	if len(unique) < 5 {
-		return false, 0
-	}
-	for i := 0; i <= len(unique)-5; i++ {
-		if unique[i]-unique[i+4] == 4 {
-			return true, unique[i]
+func buildEvalTables(rules evalRules) *evalTableSet {
+	t := &evalTableSet{flush: make([]HandStrength, 1<<13)}
+	for m := range t.flush {
+		if bits.OnesCount(uint(m)) >= 5 {
+			t.flush[m] = rules.order(flushStrength(uint16(m), rules))
 		}
 	}
-	// Wheel A-5
-	if contains(unique, 14) && contains(unique, 5) && contains(unique, 4) && contains(unique, 3) && contains(unique, 2) {
-		return true, 5
+	for n := 5; n <= 7; n++ {
+		t.plain[n] = make([]HandStrength, multisets[13][n])
+		var counts [13]uint8
+		var walk func(r, left int)
+		walk = func(r, left int) {
+			if r == 13 {
+				if left == 0 {
+					t.plain[n][countsHash(&counts, n)] = rules.order(plainStrength(&counts, rules))
+				}
+				return
+			}
+			for c := 0; c <= min(4, left); c++ {
+				counts[r] = uint8(c)
+				walk(r+1, left-c)
+			}
+			counts[r] = 0
+		}
+		walk(0, n)
 	}
-	return false, 0
+	return t
 }
 
-func uniqueRanks(ranks []int) []int {
-	m := map[int]bool{}
-	out := make([]int, 0, len(ranks))
-	for _, r := range ranks {
-		if !m[r] {
-			m[r] = true
-			out = append(out, r)
+// straightHigh returns the top rank of the best straight in a rank mask, or
+// 0. The rules' wheel, A-5 in a full deck, plays below every other straight.
+func straightHigh(mask uint16, rules evalRules) int {
+	for top := 12; top >= 4; top-- {
+		run := uint16(0x1f) << (top - 4)
+		if mask&run == run {
+			return top + int(Two)
 		}
 	}
-	return out
+	if mask&rules.wheel == rules.wheel {
+		return rules.wheelHigh
+	}
+	return 0
 }
 
-func contains(arr []int, v int) bool {
-	for _, x := range arr {
-		if x == v {
-			return true
+// topRanks returns the n highest ranks set in mask, highest first.
+func topRanks(mask uint16, n int) []int {
+	out := make([]int, 0, n)
+	for r := 12; r >= 0 && len(out) < n; r-- {
+		if mask&(1<<r) != 0 {
+			out = append(out, r+int(Two))
 		}
 	}
-	return false
+	return out
 }
 
-func highestExcluding(ranks []int, exclude ...int) int {
-	for _, r := range ranks {
-		ok := true
-		for _, e := range exclude {
-			if r == e {
-				ok = false
-			}
-		}
-		if ok {
-			return r
-		}
+func flushStrength(mask uint16, rules evalRules) HandStrength {
+	if high := straightHigh(mask, rules); high != 0 {
+		return newHandStrength(categoryStraightFlush, high)
 	}
-	return 0
+	return newHandStrength(categoryFlush, topRanks(mask, 5)...)
 }
 
-func topKickers(ranks []int, exclude []int, n int) []int {
-	out := []int{}
-	for _, r := range ranks {
-		skip := false
-		for _, e := range exclude {
-			if r == e {
-				skip = true
-				break
-			}
-		}
-		if skip {
+// plainStrength is the best hand made from rank counts without a flush.
+func plainStrength(counts *[13]uint8, rules evalRules) HandStrength {
+	var present, pairs, trips uint16
+	quad := -1
+	for r := 12; r >= 0; r-- {
+		c := counts[r]
+		if c == 0 {
 			continue
 		}
-		out = append(out, r)
-		if len(out) == n {
-			break
+		present |= 1 << r
+		switch {
+		case c == 4 && quad < 0:
+			quad = r
+		case c == 3:
+			trips |= 1 << r
+		case c == 2:
+			pairs |= 1 << r
 		}
 	}
-	return out
+	rank := func(r int) int { return r + int(Two) }
+	top := func(mask uint16) int { return bits.Len16(mask) - 1 }
+	if quad >= 0 {
+		return newHandStrength(categoryQuads, rank(quad), topRanks(present&^(1<<quad), 1)[0])
+	}
+	if trips != 0 {
+		t := top(trips)
+		if rest := (trips | pairs) &^ (1 << t); rest != 0 {
+			return newHandStrength(categoryFullHouse, rank(t), rank(top(rest)))
+		}
+	}
+	if high := straightHigh(present, rules); high != 0 {
+		return newHandStrength(categoryStraight, high)
+	}
+	if trips != 0 {
+		t := top(trips)
+		return newHandStrength(categoryTrips, append([]int{rank(t)}, topRanks(present&^(1<<t), 2)...)...)
+	}
+	if bits.OnesCount16(pairs) >= 2 {
+		hi := top(pairs)
+		lo := top(pairs &^ (1 << hi))
+		return newHandStrength(categoryTwoPair, rank(hi), rank(lo), topRanks(present&^(1<<hi|1<<lo), 1)[0])
+	}
+	if pairs != 0 {
+		p := top(pairs)
+		return newHandStrength(categoryPair, append([]int{rank(p)}, topRanks(present&^(1<<p), 3)...)...)
+	}
+	return newHandStrength(categoryHighCard, topRanks(present, 5)...)
 }
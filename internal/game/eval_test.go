@@ -1,6 +1,12 @@
 package game
 
-import "testing"
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
 
 func TestEvaluate7StraightFlush(t *testing.T) {
 	cards := []Card{{Ace, Spades}, {King, Spades}, {Queen, Spades}, {Jack, Spades}, {Ten, Spades}, {Two, Hearts}, {Three, Clubs}}
@@ -25,3 +31,90 @@ func TestEvaluate7TwoPair(t *testing.T) {
 		t.Fatalf("expected two pair, got %d", r.Category)
 	}
 }
+
+func randomHand(rng *rand.Rand, n int) []Card {
+	deck := NewDeck().cards
+	for i := 0; i < n; i++ {
+		j := i + rng.IntN(len(deck)-i)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck[:n]
+}
+
+func TestEvaluate7MatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 7))
+	var prev []Card
+	var prevRank HandRank
+	for i := 0; i < 20000; i++ {
+		cards := randomHand(rng, 7)
+		got, want := Evaluate7(cards), referenceEvaluate7(cards)
+		if got.Category != want.Category || !slices.Equal(got.Ranks, want.Ranks) {
+			t.Fatalf("%v: got %+v, want %+v", CardStrings(cards), got, want)
+		}
+		if prev != nil && (Strength(cards) > Strength(prev)) != want.BetterThan(prevRank) {
+			t.Fatalf("strength order of %v and %v disagrees with BetterThan", CardStrings(cards), CardStrings(prev))
+		}
+		prev, prevRank = slices.Clone(cards), want
+	}
+}
+
+func TestStrengthFiveCards(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 5))
+	for i := 0; i < 50000; i++ {
+		c := randomHand(rng, 5)
+		got, want := Strength(c).Rank(), referenceEval5(c[0], c[1], c[2], c[3], c[4])
+		if got.Category != want.Category || !slices.Equal(got.Ranks, want.Ranks) {
+			t.Fatalf("%v: got %+v, want %+v", CardStrings(c), got, want)
+		}
+	}
+}
+
+func TestStrengthRejectsCardCount(t *testing.T) {
+	rng := rand.New(rand.NewPCG(6, 6))
+	for _, n := range []int{0, 2, 4, 8} {
+		c := randomHand(rng, n)
+		for _, eval := range []func([]Card) HandStrength{Strength, ShortDeckStrength} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "5 to 7 cards") {
+						t.Fatalf("%d cards: expected a card count panic, got %v", n, r)
+					}
+				}()
+				eval(c)
+			}()
+		}
+	}
+}
+
+func benchmarkHands(n int) [][]Card {
+	rng := rand.New(rand.NewPCG(1, 1))
+	hands := make([][]Card, 1024)
+	for i := range hands {
+		hands[i] = slices.Clone(randomHand(rng, n))
+	}
+	return hands
+}
+
+func BenchmarkEvaluate7(b *testing.B) {
+	hands := benchmarkHands(7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Evaluate7(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkStrength7(b *testing.B) {
+	hands := benchmarkHands(7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Strength(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkReferenceEvaluate7(b *testing.B) {
+	hands := benchmarkHands(7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		referenceEvaluate7(hands[i%len(hands)])
+	}
+}
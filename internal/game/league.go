@@ -0,0 +1,135 @@
+package game
+
+// A league round is a list of pairings. The second agent of a pairing is
+// empty when the first one sits the round out with a bye.
+
+// RoundRobinRounds returns how many rounds a round robin between players
+// takes: every player meets every other once, and with an odd number of
+// players each one also has a bye.
+func RoundRobinRounds(players int) int {
+	if players < 2 {
+		return 0
+	}
+	if players%2 == 1 {
+		return players
+	}
+	return players - 1
+}
+
+// RoundRobinRound returns the pairings of round (counted from 1) of a round
+// robin between players, by the circle method: the first player stays put
+// and the others rotate one place every round.
+func RoundRobinRound(players []string, round int) [][2]string {
+	n := len(players)
+	if n < 2 || round < 1 || round > RoundRobinRounds(n) {
+		return nil
+	}
+	circle := append([]string{}, players...)
+	if n%2 == 1 {
+		circle = append(circle, "")
+		n++
+	}
+	rest := circle[1:]
+	shift := (round - 1) % len(rest)
+	rotated := append(append([]string{}, rest[len(rest)-shift:]...), rest[:len(rest)-shift]...)
+	order := append([]string{circle[0]}, rotated...)
+
+	out := make([][2]string, 0, n/2)
+	for i := 0; i < n/2; i++ {
+		a, b := order[i], order[n-1-i]
+		switch {
+		case a == "":
+			out = append(out, [2]string{b, ""})
+		case b == "":
+			out = append(out, [2]string{a, ""})
+		default:
+			out = append(out, [2]string{a, b})
+		}
+	}
+	return out
+}
+
+// swissSearchBudget caps the pairings pairUnmet tries for a round. Without
+// a rematch-free pairing the search would otherwise try every one of them,
+// which grows factorially with the field.
+const swissSearchBudget = 10000
+
+// SwissRound pairs a round of a Swiss league. ranked lists the players by
+// standing, best first; met[a][b] is set when a and b have already played
+// and hadBye when a player has already had a bye. With an odd number of
+// players the lowest ranked player without a bye sits out. The others are
+// paired top down with the next player they have not met; when no such
+// pairing is found within swissSearchBudget tries, each is paired top down
+// with the next player it has not met if one is left and the next player
+// otherwise.
+func SwissRound(ranked []string, met map[string]map[string]bool, hadBye map[string]bool) [][2]string {
+	players := append([]string{}, ranked...)
+	var bye string
+	if len(players)%2 == 1 {
+		at := len(players) - 1
+		for i := len(players) - 1; i >= 0; i-- {
+			if !hadBye[players[i]] {
+				at = i
+				break
+			}
+		}
+		bye = players[at]
+		players = append(players[:at], players[at+1:]...)
+	}
+	budget := swissSearchBudget
+	out, ok := pairUnmet(players, met, &budget)
+	if !ok {
+		out = pairGreedy(players, met)
+	}
+	if bye != "" {
+		out = append(out, [2]string{bye, ""})
+	}
+	return out
+}
+
+// pairUnmet pairs the first of players with the highest ranked player it
+// has not met such that the rest can still be paired the same way. Each
+// pairing tried spends one of budget; it gives up once that runs out.
+func pairUnmet(players []string, met map[string]map[string]bool, budget *int) ([][2]string, bool) {
+	if len(players) == 0 {
+		return [][2]string{}, true
+	}
+	first := players[0]
+	for i := 1; i < len(players); i++ {
+		if met[first][players[i]] {
+			continue
+		}
+		if *budget <= 0 {
+			return nil, false
+		}
+		*budget--
+		rest := make([]string, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+		if pairs, ok := pairUnmet(rest, met, budget); ok {
+			return append([][2]string{{first, players[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// pairGreedy pairs players top down, each with the highest ranked player
+// left that it has not met, or with the highest ranked player left when it
+// has met them all.
+func pairGreedy(players []string, met map[string]map[string]bool) [][2]string {
+	left := append([]string{}, players...)
+	out := make([][2]string, 0, len(players)/2)
+	for len(left) >= 2 {
+		first := left[0]
+		at := 1
+		for i := 1; i < len(left); i++ {
+			if !met[first][left[i]] {
+				at = i
+				break
+			}
+		}
+		out = append(out, [2]string{first, left[at]})
+		left = append(left[1:at], left[at+1:]...)
+	}
+	return out
+}
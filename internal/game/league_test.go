@@ -0,0 +1,100 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRoundRobinMeetsEveryOpponentOnce(t *testing.T) {
+	for _, n := range []int{2, 5, 6} {
+		players := []string{"a", "b", "c", "d", "e", "f"}[:n]
+		met := map[[2]string]int{}
+		byes := map[string]int{}
+		for round := 1; round <= RoundRobinRounds(n); round++ {
+			seen := map[string]bool{}
+			for _, p := range RoundRobinRound(players, round) {
+				for _, agent := range p {
+					if agent != "" && seen[agent] {
+						t.Fatalf("%d players, round %d: %s paired twice", n, round, agent)
+					}
+					seen[agent] = true
+				}
+				if p[1] == "" {
+					byes[p[0]]++
+					continue
+				}
+				a, b := p[0], p[1]
+				if a > b {
+					a, b = b, a
+				}
+				met[[2]string{a, b}]++
+			}
+		}
+		if want := n * (n - 1) / 2; len(met) != want {
+			t.Fatalf("%d players: expected %d pairings, got %d", n, want, len(met))
+		}
+		for pair, times := range met {
+			if times != 1 {
+				t.Fatalf("%d players: %v met %d times", n, pair, times)
+			}
+		}
+		if n%2 == 1 && len(byes) != n {
+			t.Fatalf("%d players: expected a bye each, got %v", n, byes)
+		}
+	}
+}
+
+func TestSwissRoundAvoidsRematches(t *testing.T) {
+	met := map[string]map[string]bool{
+		"a": {"b": true},
+		"b": {"a": true},
+	}
+	got := SwissRound([]string{"a", "b", "c", "d", "e"}, met, map[string]bool{"e": true})
+	want := [][2]string{{"a", "c"}, {"b", "e"}, {"d", ""}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSwissRoundWithoutRematchFreePairing(t *testing.T) {
+	// Two odd groups in which everyone has met the whole other group: no
+	// pairing avoids a rematch, and a full search would never finish.
+	var ranked []string
+	met := map[string]map[string]bool{}
+	for i := 0; i < 64; i++ {
+		id := fmt.Sprintf("p%02d", i)
+		ranked = append(ranked, id)
+		met[id] = map[string]bool{}
+	}
+	groupA, groupB := ranked[:31], ranked[31:]
+	for _, a := range groupA {
+		for _, b := range groupB {
+			met[a][b] = true
+			met[b][a] = true
+		}
+	}
+
+	got := SwissRound(ranked, met, nil)
+	if len(got) != 32 {
+		t.Fatalf("expected 32 pairings, got %d", len(got))
+	}
+	seen := map[string]bool{}
+	rematches := 0
+	for _, p := range got {
+		if p[1] == "" || seen[p[0]] || seen[p[1]] {
+			t.Fatalf("invalid pairing %v in %v", p, got)
+		}
+		seen[p[0]], seen[p[1]] = true, true
+		if met[p[0]][p[1]] {
+			rematches++
+		}
+	}
+	if rematches != 1 {
+		t.Fatalf("expected a single rematch, got %d", rematches)
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+// SitAndGoPayouts returns how a single-table tournament of players splits
+// its prize pool, in basis points by finishing place: winner takes all
+// heads-up, 65/35 at six-handed tables and 50/30/20 at larger ones.
+func SitAndGoPayouts(players int) []int {
+	switch {
+	case players <= 2:
+		return []int{10000}
+	case players <= 6:
+		return []int{6500, 3500}
+	default:
+		return []int{5000, 3000, 2000}
+	}
+}
+
+// MultiTablePayouts returns how a multi-table tournament of entrants splits
+// its prize pool, in basis points by finishing place. Small fields pay like
+// a sit-and-go; larger ones pay the top three, five or nine places.
+func MultiTablePayouts(entrants int) []int {
+	switch {
+	case entrants <= 6:
+		return SitAndGoPayouts(entrants)
+	case entrants <= 10:
+		return []int{5000, 3000, 2000}
+	case entrants <= 20:
+		return []int{4000, 2500, 1700, 1000, 800}
+	default:
+		return []int{3000, 2000, 1300, 1000, 800, 650, 500, 400, 350}
+	}
+}
+
+// SplitPrizePool pays pool out in the basis points of bps, one amount per
+// place. Shares are rounded down and the remainder goes to first place, so
+// the amounts always add up to the pool.
+func SplitPrizePool(pool int64, bps []int) []int64 {
+	out := make([]int64, len(bps))
+	var paid int64
+	for i, b := range bps {
+		out[i] = pool * int64(b) / 10000
+		paid += out[i]
+	}
+	if len(out) > 0 {
+		out[0] += pool - paid
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPrizePoolPaysRemainderToFirst(t *testing.T) {
+	got := SplitPrizePool(601, SitAndGoPayouts(6))
+	// 65% of 601 is 390.65 and 35% is 210.35; the rounded-off CC goes to
+	// the winner.
+	if want := []int64{391, 210}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := SplitPrizePool(200, SitAndGoPayouts(2)); !reflect.DeepEqual(got, []int64{200}) {
+		t.Fatalf("expected heads-up winner take all, got %v", got)
+	}
+}
+
+func TestMultiTablePayoutsAddUpToWholePool(t *testing.T) {
+	for _, entrants := range []int{2, 6, 9, 18, 100} {
+		total := 0
+		for _, bps := range MultiTablePayouts(entrants) {
+			total += bps
+		}
+		if total != 10000 {
+			t.Fatalf("payouts for %d entrants add up to %d bps", entrants, total)
+		}
+	}
+}
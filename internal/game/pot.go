@@ -1,22 +1,119 @@
 package game
 
+import "sort"
+
 type Pot struct {
 	Main    int64
 	Side    int64
 	HasSide bool
 }
 
-// Compute pot for heads-up based on total contributions per player.
-func ComputePot(contribA, contribB int64) Pot {
-	if contribA == contribB {
-		return Pot{Main: contribA + contribB}
+// ComputePot splits the total contributions per player into the called main
+// pot and the uncalled excess of the biggest contributor.
+func ComputePot(contribs ...int64) Pot {
+	var top, second int64
+	for _, c := range contribs {
+		if c > top {
+			top, second = c, top
+		} else if c > second {
+			second = c
+		}
+	}
+	var pot Pot
+	for _, c := range contribs {
+		if c > second {
+			c = second
+		}
+		pot.Main += c
+	}
+	if top > second {
+		pot.Side = top - second
+		pot.HasSide = true
+	}
+	return pot
+}
+
+// SidePot is one layer of the pot and the seats that can win it.
+type SidePot struct {
+	Amount   int64
+	Eligible []int
+}
+
+// BuildPots splits the total contributions per seat into a main pot and side
+// pots. Every distinct contribution level of a player still in the hand
+// closes a pot that only players who matched that level can win. Chips that
+// folded players put in above the last level go to the last pot.
+func BuildPots(contribs []int64, folded []bool) []SidePot {
+	levels := make([]int64, 0, len(contribs))
+	for i, c := range contribs {
+		if folded[i] || c <= 0 {
+			continue
+		}
+		levels = append(levels, c)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	pots := make([]SidePot, 0, len(levels))
+	var prev int64
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+		pot := SidePot{}
+		for i, c := range contribs {
+			pot.Amount += min(c, level) - min(c, prev)
+			if !folded[i] && c >= level {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+	if len(pots) == 0 {
+		return pots
+	}
+	for _, c := range contribs {
+		if c > prev {
+			pots[len(pots)-1].Amount += c - prev
+		}
+	}
+	return pots
+}
+
+// PotAward is the share of a pot paid to one seat.
+type PotAward struct {
+	Seat    int    `json:"seat_id"`
+	AgentID string `json:"agent_id"`
+	Amount  int64  `json:"amount_cc"`
+}
+
+// PotResult records how one pot was settled.
+type PotResult struct {
+	Amount        int64      `json:"amount_cc"`
+	RakeCC        int64      `json:"rake_cc"`
+	EligibleSeats []int      `json:"eligible_seats"`
+	Awards        []PotAward `json:"awards"`
+}
+
+// splitPot divides amount evenly among the winning seats. Seats are returned
+// clockwise from the button and the odd chips go one each to the first of
+// them, i.e. to the winners closest to the left of the button.
+func splitPot(amount int64, winners []int, dealerPos, seats int) ([]int, []int64) {
+	ordered := append([]int{}, winners...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return (ordered[i]-dealerPos-1+seats)%seats < (ordered[j]-dealerPos-1+seats)%seats
+	})
+	shares := make([]int64, len(ordered))
+	if len(ordered) == 0 {
+		return ordered, shares
 	}
-	if contribA < contribB {
-		main := contribA * 2
-		side := contribB - contribA
-		return Pot{Main: main, Side: side, HasSide: true}
+	share := amount / int64(len(ordered))
+	odd := amount - share*int64(len(ordered))
+	for i := range ordered {
+		shares[i] = share
+		if int64(i) < odd {
+			shares[i]++
+		}
 	}
-	main := contribB * 2
-	side := contribA - contribB
-	return Pot{Main: main, Side: side, HasSide: true}
+	return ordered, shares
 }
@@ -15,3 +15,40 @@ func TestComputePotSide(t *testing.T) {
 		t.Fatalf("expected main 200 side 150, got %+v", p)
 	}
 }
+
+func TestComputePotMultiway(t *testing.T) {
+	p := ComputePot(100, 0, 300, 250)
+	if p.Main != 600 || !p.HasSide || p.Side != 50 {
+		t.Fatalf("expected main 600 side 50, got %+v", p)
+	}
+}
+
+func TestBuildPotsLayersAllIns(t *testing.T) {
+	// seat 0 all-in 100, seat 1 all-in 300, seat 2 folded after 200, seat 3 covers 500.
+	pots := BuildPots([]int64{100, 300, 200, 500}, []bool{false, false, true, false})
+	if len(pots) != 3 {
+		t.Fatalf("expected 3 pots, got %+v", pots)
+	}
+	want := []SidePot{
+		{Amount: 400, Eligible: []int{0, 1, 3}},
+		{Amount: 500, Eligible: []int{1, 3}},
+		{Amount: 200, Eligible: []int{3}},
+	}
+	for i, pot := range pots {
+		if pot.Amount != want[i].Amount || len(pot.Eligible) != len(want[i].Eligible) {
+			t.Fatalf("pot %d: expected %+v, got %+v", i, want[i], pot)
+		}
+		for j := range pot.Eligible {
+			if pot.Eligible[j] != want[i].Eligible[j] {
+				t.Fatalf("pot %d: expected %+v, got %+v", i, want[i], pot)
+			}
+		}
+	}
+}
+
+func TestBuildPotsFoldedChipsAboveLastLevel(t *testing.T) {
+	pots := BuildPots([]int64{500, 1000}, []bool{true, false})
+	if len(pots) != 1 || pots[0].Amount != 1500 || len(pots[0].Eligible) != 1 || pots[0].Eligible[0] != 1 {
+		t.Fatalf("expected single 1500 pot for seat 1, got %+v", pots)
+	}
+}
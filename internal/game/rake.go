@@ -0,0 +1,57 @@
+package game
+
+// RakeConfig describes how much of each pot the house keeps.
+type RakeConfig struct {
+	// BasisPoints is the rake rate in hundredths of a percent (500 = 5%).
+	BasisPoints int64
+	// CapCC caps the rake per hand; zero means no cap.
+	CapCC int64
+	// NoFlopNoDrop skips the rake for hands that end before the flop.
+	NoFlopNoDrop bool
+}
+
+// Amount returns the rake taken from a hand with the given per-seat
+// contributions. Uncalled chips are never raked.
+func (r RakeConfig) Amount(contribs []int64, flopDealt bool) int64 {
+	if r.BasisPoints <= 0 || (r.NoFlopNoDrop && !flopDealt) {
+		return 0
+	}
+	rake := contestedChips(contribs) * r.BasisPoints / 10000
+	if r.CapCC > 0 && rake > r.CapCC {
+		rake = r.CapCC
+	}
+	return rake
+}
+
+// contestedChips returns the total contributed minus the uncalled part of
+// the largest contribution.
+func contestedChips(contribs []int64) int64 {
+	var total, top, second int64
+	for _, c := range contribs {
+		total += c
+		switch {
+		case c > top:
+			second = top
+			top = c
+		case c > second:
+			second = c
+		}
+	}
+	return total - (top - second)
+}
+
+// takeRake removes rake from the pots, main pot first, and returns the
+// amount taken from each pot.
+func takeRake(pots []SidePot, rake int64) []int64 {
+	taken := make([]int64, len(pots))
+	for i := range pots {
+		if rake <= 0 {
+			break
+		}
+		take := min(rake, pots[i].Amount)
+		pots[i].Amount -= take
+		taken[i] = take
+		rake -= take
+	}
+	return taken
+}
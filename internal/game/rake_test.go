@@ -0,0 +1,51 @@
+package game
+
+import "testing"
+
+func TestRakeAmount(t *testing.T) {
+	cfg := RakeConfig{BasisPoints: 500, CapCC: 30, NoFlopNoDrop: true}
+	if got := cfg.Amount([]int64{100, 100}, false); got != 0 {
+		t.Fatalf("expected no rake before the flop, got %d", got)
+	}
+	if got := cfg.Amount([]int64{200, 200}, true); got != 20 {
+		t.Fatalf("expected 5%% of 400, got %d", got)
+	}
+	if got := cfg.Amount([]int64{1000, 1000}, true); got != 30 {
+		t.Fatalf("expected rake capped at 30, got %d", got)
+	}
+	// The 300 uncalled by the folded player is not raked.
+	if got := cfg.Amount([]int64{500, 200}, true); got != 20 {
+		t.Fatalf("expected uncalled chips to be excluded, got %d", got)
+	}
+	if got := (RakeConfig{BasisPoints: 500}).Amount([]int64{100, 100}, false); got != 10 {
+		t.Fatalf("expected preflop rake without no-flop-no-drop, got %d", got)
+	}
+}
+
+func TestSettleTakesRakeFromMainPotFirst(t *testing.T) {
+	board := []Card{{Rank: Two, Suit: Clubs}, {Rank: Seven, Suit: Diamonds}, {Rank: Nine, Suit: Hearts}, {Rank: Jack, Suit: Spades}, {Rank: King, Suit: Clubs}}
+	e := &Engine{State: &TableState{
+		Community: board,
+		Players: []*Player{
+			{ID: "short", Hole: []Card{{Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Hearts}}, AllIn: true},
+			{ID: "big", Hole: []Card{{Rank: Queen, Suit: Spades}, {Rank: Queen, Suit: Hearts}}},
+			{ID: "other", Hole: []Card{{Rank: Three, Suit: Diamonds}, {Rank: Four, Suit: Clubs}}},
+		},
+		TotalContrib: []int64{100, 400, 400},
+		Rake:         RakeConfig{BasisPoints: 1000, CapCC: 50},
+	}}
+
+	if _, err := e.Settle(nil); err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+	if e.State.RakeCC != 50 {
+		t.Fatalf("expected capped rake of 50, got %d", e.State.RakeCC)
+	}
+	main, side := e.State.PotResults[0], e.State.PotResults[1]
+	if main.Amount != 250 || main.RakeCC != 50 || side.Amount != 600 || side.RakeCC != 0 {
+		t.Fatalf("unexpected pots: main=%+v side=%+v", main, side)
+	}
+	if e.State.Players[0].Stack != 250 || e.State.Players[1].Stack != 600 {
+		t.Fatalf("unexpected stacks: short=%d big=%d", e.State.Players[0].Stack, e.State.Players[1].Stack)
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import "math"
+
+// Ratings follow Glicko-2 (Glickman, "Example of the Glicko-2 system"),
+// reported on the Glicko scale where a new player is 1500 ± 350.
+const (
+	DefaultRating           = 1500.0
+	DefaultRatingDeviation  = 350.0
+	DefaultRatingVolatility = 0.06
+
+	// ratingTau constrains how fast volatility changes.
+	ratingTau     = 0.5
+	ratingScale   = 173.7178
+	ratingEpsilon = 0.000001
+)
+
+// Rating is an agent's skill estimate: Rating with a deviation of Deviation
+// around it, and how erratic its results are in Volatility.
+type Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// NewRating is the rating of an agent that has not been rated yet.
+func NewRating() Rating {
+	return Rating{Rating: DefaultRating, Deviation: DefaultRatingDeviation, Volatility: DefaultRatingVolatility}
+}
+
+// RatingOutcome is one game of a rating period: the opponent's rating
+// before the period and the score against it, 1 for a win, 0.5 for a draw
+// and 0 for a loss.
+type RatingOutcome struct {
+	Opponent Rating
+	Score    float64
+}
+
+// Update returns r after a rating period with outcomes. Without games only
+// the deviation grows.
+func (r Rating) Update(outcomes []RatingOutcome) Rating {
+	mu := (r.Rating - DefaultRating) / ratingScale
+	phi := r.Deviation / ratingScale
+	sigma := r.Volatility
+	if len(outcomes) == 0 {
+		r.Deviation = min(math.Sqrt(phi*phi+sigma*sigma)*ratingScale, DefaultRatingDeviation)
+		return r
+	}
+
+	var vInv, improvement float64
+	for _, o := range outcomes {
+		muJ := (o.Opponent.Rating - DefaultRating) / ratingScale
+		g := ratingG(o.Opponent.Deviation / ratingScale)
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInv += g * g * e * (1 - e)
+		improvement += g * (o.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * improvement
+
+	sigma = ratingVolatility(phi, sigma, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	mu += phi * phi * improvement
+	return Rating{
+		Rating:     mu*ratingScale + DefaultRating,
+		Deviation:  min(phi*ratingScale, DefaultRatingDeviation),
+		Volatility: sigma,
+	}
+}
+
+func ratingG(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// ratingVolatility solves for the new volatility with the Illinois
+// algorithm of step 5 of the Glicko-2 paper.
+func ratingVolatility(phi, sigma, v, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/(ratingTau*ratingTau)
+	}
+	lo := a
+	var hi float64
+	if delta*delta > phi*phi+v {
+		hi = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*ratingTau) < 0 {
+			k++
+		}
+		hi = a - k*ratingTau
+	}
+	fLo, fHi := f(lo), f(hi)
+	for math.Abs(hi-lo) > ratingEpsilon {
+		c := lo + (lo-hi)*fLo/(fHi-fLo)
+		fC := f(c)
+		if fC*fHi <= 0 {
+			lo, fLo = hi, fHi
+		} else {
+			fLo /= 2
+		}
+		hi, fHi = c, fC
+	}
+	return math.Exp(lo / 2)
+}
+
+// RateField rates one table, match or tournament as a rating period in
+// which every pair of its players played a game, won by the one with the
+// better result (more chips won, a better finish) and drawn on equal
+// results. ratings holds the players' ratings before it; players missing
+// from it are rated from NewRating. It returns the new rating of every
+// player in results.
+func RateField(ratings map[string]Rating, results map[string]float64) map[string]Rating {
+	before := func(id string) Rating {
+		if r, ok := ratings[id]; ok {
+			return r
+		}
+		return NewRating()
+	}
+	out := make(map[string]Rating, len(results))
+	for id, result := range results {
+		outcomes := make([]RatingOutcome, 0, len(results)-1)
+		for opp, oppResult := range results {
+			if opp == id {
+				continue
+			}
+			score := 0.5
+			switch {
+			case result > oppResult:
+				score = 1
+			case result < oppResult:
+				score = 0
+			}
+			outcomes = append(outcomes, RatingOutcome{Opponent: before(opp), Score: score})
+		}
+		out[id] = before(id).Update(outcomes)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatingUpdateMatchesGlickmanExample(t *testing.T) {
+	r := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	got := r.Update([]RatingOutcome{
+		{Opponent: Rating{Rating: 1400, Deviation: 30, Volatility: 0.06}, Score: 1},
+		{Opponent: Rating{Rating: 1550, Deviation: 100, Volatility: 0.06}, Score: 0},
+		{Opponent: Rating{Rating: 1700, Deviation: 300, Volatility: 0.06}, Score: 0},
+	})
+	if math.Abs(got.Rating-1464.06) > 0.01 {
+		t.Fatalf("rating: got %.4f, want 1464.06", got.Rating)
+	}
+	if math.Abs(got.Deviation-151.52) > 0.01 {
+		t.Fatalf("deviation: got %.4f, want 151.52", got.Deviation)
+	}
+	if math.Abs(got.Volatility-0.05999) > 0.00001 {
+		t.Fatalf("volatility: got %.6f, want 0.05999", got.Volatility)
+	}
+}
+
+func TestRatingUpdateWithoutGamesWidensDeviation(t *testing.T) {
+	r := Rating{Rating: 1600, Deviation: 50, Volatility: 0.06}
+	got := r.Update(nil)
+	if got.Rating != 1600 || got.Deviation <= 50 || got.Volatility != 0.06 {
+		t.Fatalf("unexpected idle update: %+v", got)
+	}
+	if got := NewRating().Update(nil); got.Deviation != DefaultRatingDeviation {
+		t.Fatalf("deviation must stay capped at %v, got %v", DefaultRatingDeviation, got.Deviation)
+	}
+}
+
+func TestRateFieldScoresEveryPair(t *testing.T) {
+	strong := Rating{Rating: 1800, Deviation: 60, Volatility: 0.06}
+	got := RateField(map[string]Rating{"strong": strong}, map[string]float64{
+		"strong": 500,
+		"a":      -200,
+		"b":      -300,
+	})
+	if len(got) != 3 {
+		t.Fatalf("expected 3 ratings, got %d", len(got))
+	}
+	if got["strong"].Rating <= strong.Rating {
+		t.Fatalf("winner should gain rating: %+v", got["strong"])
+	}
+	if got["a"].Rating <= got["b"].Rating {
+		t.Fatalf("second place should outrate third: a=%v b=%v", got["a"].Rating, got["b"].Rating)
+	}
+	if got["b"].Rating >= DefaultRating {
+		t.Fatalf("last place should lose rating: %v", got["b"].Rating)
+	}
+
+	draw := RateField(nil, map[string]float64{"x": 0, "y": 0})
+	if math.Abs(draw["x"].Rating-DefaultRating) > 1e-9 || math.Abs(draw["y"].Rating-DefaultRating) > 1e-9 {
+		t.Fatalf("a draw between new players should keep 1500: %+v", draw)
+	}
+}
@@ -4,8 +4,11 @@ import "testing"
 
 func TestRoundCheckCheckCompletes(t *testing.T) {
 	e := &Engine{State: &TableState{MinRaise: 200, BigBlind: 200}}
-	e.State.Players[0] = &Player{ID: "p0", Stack: 1000}
-	e.State.Players[1] = &Player{ID: "p1", Stack: 1000}
+	e.State.Players = []*Player{{ID: "p0", Stack: 1000}, {ID: "p1", Stack: 1000}}
+	e.State.RoundBets = make([]int64, 2)
+	e.State.TotalContrib = make([]int64, 2)
+	e.State.Acted = make([]bool, 2)
+	e.State.ActedBet = make([]int64, 2)
 	e.State.CurrentActor = 0
 
 	done, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionCheck})
@@ -21,8 +24,11 @@ func TestRoundCheckCheckCompletes(t *testing.T) {
 
 func TestRoundBetCallCompletes(t *testing.T) {
 	e := &Engine{State: &TableState{MinRaise: 200, BigBlind: 200}}
-	e.State.Players[0] = &Player{ID: "p0", Stack: 1000}
-	e.State.Players[1] = &Player{ID: "p1", Stack: 1000}
+	e.State.Players = []*Player{{ID: "p0", Stack: 1000}, {ID: "p1", Stack: 1000}}
+	e.State.RoundBets = make([]int64, 2)
+	e.State.TotalContrib = make([]int64, 2)
+	e.State.Acted = make([]bool, 2)
+	e.State.ActedBet = make([]int64, 2)
 	e.State.CurrentActor = 0
 
 	done, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 200})
@@ -38,10 +44,11 @@ func TestRoundBetCallCompletes(t *testing.T) {
 
 func TestRoundRaiseRaiseCallCompletes(t *testing.T) {
 	e := &Engine{State: &TableState{MinRaise: 200, BigBlind: 200, CurrentBet: 200}}
-	e.State.Players[0] = &Player{ID: "p0", Stack: 2000}
-	e.State.Players[1] = &Player{ID: "p1", Stack: 2000}
-	e.State.RoundBets[0] = 200
-	e.State.RoundBets[1] = 0
+	e.State.Players = []*Player{{ID: "p0", Stack: 2000}, {ID: "p1", Stack: 2000}}
+	e.State.RoundBets = []int64{200, 0}
+	e.State.TotalContrib = []int64{200, 0}
+	e.State.Acted = make([]bool, 2)
+	e.State.ActedBet = make([]int64, 2)
 	e.State.CurrentActor = 1
 
 	done, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionRaise, Amount: 400})
@@ -59,3 +66,147 @@ func TestRoundRaiseRaiseCallCompletes(t *testing.T) {
 		t.Fatalf("expected done after raise/raise/call, err=%v done=%v", err, done)
 	}
 }
+
+func TestRoundMultiwaySkipsFoldedAndAllIn(t *testing.T) {
+	e := &Engine{State: &TableState{MinRaise: 200, BigBlind: 200}}
+	e.State.Players = []*Player{
+		{ID: "p0", Stack: 1000, Seat: 0},
+		nil,
+		{ID: "p2", Stack: 1000, Seat: 2},
+		{ID: "p3", Stack: 300, Seat: 3},
+	}
+	e.State.RoundBets = make([]int64, 4)
+	e.State.TotalContrib = make([]int64, 4)
+	e.State.Acted = make([]bool, 4)
+	e.State.ActedBet = make([]int64, 4)
+	e.State.CurrentActor = 2
+
+	done, err := e.ApplyAction(nil, Action{Player: 2, Type: ActionBet, Amount: 300})
+	if err != nil || done {
+		t.Fatalf("expected not done after bet, err=%v done=%v", err, done)
+	}
+	if e.State.CurrentActor != 3 {
+		t.Fatalf("expected seat 3 to act, got %d", e.State.CurrentActor)
+	}
+	done, err = e.ApplyAction(nil, Action{Player: 3, Type: ActionCall})
+	if err != nil || done {
+		t.Fatalf("expected not done after all-in call, err=%v done=%v", err, done)
+	}
+	if !e.State.Players[3].AllIn {
+		t.Fatalf("expected seat 3 all-in")
+	}
+	if e.State.CurrentActor != 0 {
+		t.Fatalf("expected action to wrap past empty seat to seat 0, got %d", e.State.CurrentActor)
+	}
+	done, err = e.ApplyAction(nil, Action{Player: 0, Type: ActionFold})
+	if err != nil || !done {
+		t.Fatalf("expected done after fold, err=%v done=%v", err, done)
+	}
+	if got := e.State.ActivePlayers(); got != 2 {
+		t.Fatalf("expected 2 active players, got %d", got)
+	}
+	if got := e.State.ActionablePlayers(); got != 1 {
+		t.Fatalf("expected 1 actionable player, got %d", got)
+	}
+}
+
+func TestForfeitOutOfTurnEndsRound(t *testing.T) {
+	e := &Engine{State: &TableState{MinRaise: 200, BigBlind: 200}}
+	e.State.Players = []*Player{{ID: "p0", Stack: 1000}, {ID: "p1", Stack: 1000}, {ID: "p2", Stack: 1000}}
+	e.State.RoundBets = make([]int64, 3)
+	e.State.TotalContrib = make([]int64, 3)
+	e.State.Acted = make([]bool, 3)
+	e.State.ActedBet = make([]int64, 3)
+	e.State.CurrentActor = 0
+
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionCheck}); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionCheck}); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if done := e.Forfeit(0); done {
+		t.Fatalf("expected round to continue after out-of-turn forfeit")
+	}
+	if done := e.Forfeit(2); !done {
+		t.Fatalf("expected round over when last active opponent forfeits")
+	}
+}
+
+func newRoundEngine(stacks ...int64) *Engine {
+	e := &Engine{State: &TableState{MinRaise: 100, BigBlind: 100}}
+	for i, stack := range stacks {
+		e.State.Players = append(e.State.Players, &Player{ID: string(rune('a' + i)), Seat: i, Stack: stack})
+	}
+	n := len(stacks)
+	e.State.RoundBets = make([]int64, n)
+	e.State.TotalContrib = make([]int64, n)
+	e.State.Acted = make([]bool, n)
+	e.State.ActedBet = make([]int64, n)
+	return e
+}
+
+func TestIncompleteAllInRaiseDoesNotReopenBetting(t *testing.T) {
+	e := newRoundEngine(1000, 150, 1000)
+	e.State.CurrentActor = 0
+
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionBet, Amount: 100}); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionAllIn}); err != nil {
+		t.Fatalf("all-in: %v", err)
+	}
+	if e.State.CurrentBet != 150 || e.State.MinRaise != 100 {
+		t.Fatalf("expected current bet 150 with min raise 100, got %d/%d", e.State.CurrentBet, e.State.MinRaise)
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 2, Type: ActionCall}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if e.State.CurrentActor != 0 {
+		t.Fatalf("expected original bettor to act again, got seat %d", e.State.CurrentActor)
+	}
+	if e.State.CanRaise(0) {
+		t.Fatalf("incomplete raise must not reopen betting for the original bettor")
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionRaise, Amount: 400}); err == nil {
+		t.Fatalf("expected re-raise to be rejected")
+	}
+	done, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionCall})
+	if err != nil || !done {
+		t.Fatalf("expected round done after call, err=%v done=%v", err, done)
+	}
+}
+
+func TestBetAndCallCappedToStack(t *testing.T) {
+	e := newRoundEngine(80, 1000)
+	e.State.CurrentActor = 1
+
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionBet, Amount: 5000}); err != nil {
+		t.Fatalf("oversized bet should be capped: %v", err)
+	}
+	if !e.State.Players[1].AllIn || e.State.RoundBets[1] != 1000 {
+		t.Fatalf("expected bet capped to 1000 all-in, got %d", e.State.RoundBets[1])
+	}
+	done, err := e.ApplyAction(nil, Action{Player: 0, Type: ActionCall})
+	if err != nil || !done {
+		t.Fatalf("expected short call to close the round, err=%v done=%v", err, done)
+	}
+	if !e.State.Players[0].AllIn || e.State.RoundBets[0] != 80 || e.State.Players[0].Stack != 0 {
+		t.Fatalf("expected call capped to 80 all-in, got bet=%d stack=%d", e.State.RoundBets[0], e.State.Players[0].Stack)
+	}
+}
+
+func TestShortAllInBetBelowMinRaise(t *testing.T) {
+	e := newRoundEngine(1000, 60)
+	e.State.CurrentActor = 1
+
+	if err := ValidateAction(e.State, 1, ActionBet, 40); err == nil {
+		t.Fatalf("expected bet below min raise with chips behind to be rejected")
+	}
+	if _, err := e.ApplyAction(nil, Action{Player: 1, Type: ActionAllIn}); err != nil {
+		t.Fatalf("all-in: %v", err)
+	}
+	if e.State.CurrentBet != 60 || e.State.MinRaise != 100 {
+		t.Fatalf("expected current bet 60 and min raise 100, got %d/%d", e.State.CurrentBet, e.State.MinRaise)
+	}
+}
@@ -5,14 +5,20 @@ import "errors"
 var ErrInvalidAction = errors.New("invalid_action")
 var ErrNotYourTurn = errors.New("not_your_turn")
 
+// ValidateAction checks an action against the betting rules. Bet and raise
+// amounts above the player's stack are capped to an all-in, which is also
+// how a player shoves for less than a full bet or raise. Under pot-limit
+// and fixed-limit rules amounts above RaiseLimits are rejected, and so is an
+// all-in that would exceed them.
 func ValidateAction(s *TableState, playerIdx int, action ActionType, amount int64) error {
 	if playerIdx != s.CurrentActor {
 		return ErrNotYourTurn
 	}
 	me := s.Players[playerIdx]
-	if me.Folded {
+	if me.Folded || me.AllIn {
 		return ErrInvalidAction
 	}
+	maxTo := s.RoundBets[playerIdx] + me.Stack
 	switch action {
 	case ActionFold:
 		return nil
@@ -26,21 +32,32 @@ func ValidateAction(s *TableState, playerIdx int, action ActionType, amount int6
 			return ErrInvalidAction
 		}
 		return nil
-	case ActionBet:
-		if s.CurrentBet != 0 {
+	case ActionBet, ActionRaise:
+		if (action == ActionBet) != (s.CurrentBet == 0) || amount <= 0 {
 			return ErrInvalidAction
 		}
-		if amount < s.MinRaise {
+		to := amount
+		if action == ActionBet {
+			to += s.RoundBets[playerIdx]
+		}
+		to = min(to, maxTo)
+		minTo, limitTo, ok := s.RaiseLimits(playerIdx)
+		if !ok || to <= s.CurrentBet || to > limitTo {
 			return ErrInvalidAction
 		}
-		return nil
-	case ActionRaise:
-		if s.CurrentBet == 0 {
+		if to < minTo && to < maxTo {
 			return ErrInvalidAction
 		}
-		if amount < s.CurrentBet+s.MinRaise {
+		return nil
+	case ActionAllIn:
+		if me.Stack <= 0 {
 			return ErrInvalidAction
 		}
+		if maxTo > s.CurrentBet {
+			if _, limitTo, ok := s.RaiseLimits(playerIdx); !ok || maxTo > limitTo {
+				return ErrInvalidAction
+			}
+		}
 		return nil
 	default:
 		return ErrInvalidAction
@@ -0,0 +1,77 @@
+package game
+
+// inHand reports whether the seat holds a player that has not folded.
+func inHand(p *Player) bool {
+	return p != nil && !p.Folded
+}
+
+// canAct reports whether the seat holds a player that can still put chips in.
+func canAct(p *Player) bool {
+	return inHand(p) && !p.AllIn
+}
+
+func occupied(p *Player) bool {
+	return p != nil
+}
+
+// nextSeat walks clockwise from the seat after `from` and returns the first
+// seat whose player satisfies pred, or -1 when none does.
+func (s *TableState) nextSeat(from int, pred func(*Player) bool) int {
+	n := len(s.Players)
+	if n == 0 {
+		return -1
+	}
+	for step := 1; step <= n; step++ {
+		idx := ((from+step)%n + n) % n
+		if pred(s.Players[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (s *TableState) countSeats(pred func(*Player) bool) int {
+	count := 0
+	for _, p := range s.Players {
+		if pred(p) {
+			count++
+		}
+	}
+	return count
+}
+
+// ActivePlayers returns the number of players still contesting the pot.
+func (s *TableState) ActivePlayers() int {
+	return s.countSeats(inHand)
+}
+
+// ActionablePlayers returns the number of players that are neither folded
+// nor all-in, i.e. that can still make betting decisions.
+func (s *TableState) ActionablePlayers() int {
+	return s.countSeats(canAct)
+}
+
+// roundComplete reports whether every player that can still act has acted
+// and matched the current bet.
+func (s *TableState) roundComplete() bool {
+	for i, p := range s.Players {
+		if !canAct(p) {
+			continue
+		}
+		if !s.Acted[i] || s.RoundBets[i] != s.CurrentBet {
+			return false
+		}
+	}
+	return true
+}
+
+// CanRaise reports whether the player at seat may still raise this round. A
+// player that has already acted may only raise again once the bet facing
+// them grew by at least a full raise; an all-in for less does not reopen the
+// betting on its own.
+func (s *TableState) CanRaise(seat int) bool {
+	if seat >= len(s.Acted) || !s.Acted[seat] || seat >= len(s.ActedBet) {
+		return true
+	}
+	return s.CurrentBet-s.ActedBet[seat] >= s.MinRaise
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"strconv"
+)
+
+// SeedSize is the length in bytes of a deck seed.
+const SeedSize = 32
+
+// NewSeed returns a fresh deck seed read from crypto/rand.
+func NewSeed() ([]byte, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// SeedHash is the commitment published when a hand starts: the hex encoded
+// SHA-256 of the seed.
+func SeedHash(seed []byte) string {
+	sum := sha256.Sum256(seed)
+	return hex.EncodeToString(sum[:])
+}
+
+// ScheduledSeed derives the seed of hand n from a deck seed schedule, so
+// every table dealing from the schedule deals hand n from the same deck:
+// SHA-256 of the schedule, a colon and n in decimal.
+func ScheduledSeed(schedule string, n int) []byte {
+	sum := sha256.Sum256([]byte(schedule + ":" + strconv.Itoa(n)))
+	return sum[:]
+}
+
+// TableSeedSchedule is the schedule one table of a room with a deck seed
+// schedule deals from: the room's schedule, a colon and the table ID, so
+// hand n of the table is seeded with SHA-256 of "<schedule>:<table>:<n>".
+// Seeds are revealed as soon as a hand ends, and a deck shared with another
+// table would show that table its hand n before it is dealt.
+func TableSeedSchedule(schedule, tableID string) string {
+	return schedule + ":" + tableID
+}
+
+// ShuffleWithSeed orders the deck deterministically from seed with a
+// Fisher-Yates shuffle, walking i from the last card down to 1 and swapping
+// it with card j in [0, i]. Each j is drawn from a byte stream made of the
+// blocks SHA-256(seed || counter), counter being a big-endian uint64 from 0,
+// read eight bytes at a time as big-endian uint64s; draws that would bias
+// the modulo are rejected. Anyone holding the seed can recompute the order.
+func (d *Deck) ShuffleWithSeed(seed []byte) {
+	src := seedStream{seed: seed}
+	for i := len(d.cards) - 1; i > 0; i-- {
+		j := src.intn(i + 1)
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	}
+}
+
+// DeckOrder returns a fresh deck shuffled with seed, top card first.
+func DeckOrder(seed []byte) []Card {
+	return VariantDeckOrder(Holdem, seed)
+}
+
+// VariantDeckOrder is DeckOrder for the deck of variant v.
+func VariantDeckOrder(v Variant, seed []byte) []Card {
+	d := v.NewDeck()
+	d.ShuffleWithSeed(seed)
+	return d.cards
+}
+
+// DealFromDeck lays out a shuffled deck the way StartHand and NextStreet
+// deal it: one card at a time to each seat in dealOrder, holeCards times,
+// then up to five board cards with no burns. Hole cards are keyed by seat.
+func DealFromDeck(deck []Card, dealOrder []int, holeCards int) (map[int][]Card, []Card) {
+	n := len(dealOrder)
+	holes := make(map[int][]Card, n)
+	for i, seat := range dealOrder {
+		for k := 0; k < holeCards; k++ {
+			holes[seat] = append(holes[seat], deck[k*n+i])
+		}
+	}
+	dealt := holeCards * n
+	end := min(dealt+5, len(deck))
+	return holes, append([]Card{}, deck[dealt:end]...)
+}
+
+type seedStream struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func (s *seedStream) uint64() uint64 {
+	if len(s.buf) < 8 {
+		var ctr [8]byte
+		binary.BigEndian.PutUint64(ctr[:], s.counter)
+		s.counter++
+		h := sha256.New()
+		h.Write(s.seed)
+		h.Write(ctr[:])
+		s.buf = h.Sum(nil)
+	}
+	v := binary.BigEndian.Uint64(s.buf[:8])
+	s.buf = s.buf[8:]
+	return v
+}
+
+// intn returns a uniform value in [0, n).
+func (s *seedStream) intn(n int) int {
+	bound := uint64(n)
+	limit := math.MaxUint64 - math.MaxUint64%bound
+	for {
+		if v := s.uint64(); v < limit {
+			return int(v % bound)
+		}
+	}
+}
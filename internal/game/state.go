@@ -10,6 +10,7 @@ const (
 	ActionCall  ActionType = "call"
 	ActionBet   ActionType = "bet"
 	ActionRaise ActionType = "raise"
+	ActionAllIn ActionType = "all_in"
 )
 
 type Player struct {
@@ -24,23 +25,52 @@ type Player struct {
 }
 
 type TableState struct {
-	TableID       string
-	HandID        string
-	Players       [2]*Player
-	Community     []Card
-	DealerPos     int
-	Street        Street
-	Pot           int64
-	MinRaise      int64
-	SmallBlind    int64
-	BigBlind      int64
+	TableID    string
+	HandID     string
+	Players    []*Player
+	Community  []Card
+	DealerPos  int
+	Street     Street
+	Pot        int64
+	MinRaise   int64
+	SmallBlind int64
+	BigBlind   int64
+	// Ante is posted by every dealt-in seat at the start of a hand, or by
+	// the big blind alone when BigBlindAnte is set.
+	Ante         int64
+	BigBlindAnte bool
+	// Straddle lets the seat after the big blind post two big blinds.
+	Straddle      bool
 	CurrentBet    int64
-	RoundBets     [2]int64
-	TotalContrib  [2]int64
-	Acted         [2]bool
+	RoundBets     []int64
+	TotalContrib  []int64
+	Acted         []bool
+	ActedBet      []int64
 	ActionTimeout time.Duration
 	CurrentActor  int
 	LastAggressor int
+	Settled       bool
+	PotResults    []PotResult
+	Rake          RakeConfig
+	// Variant is the game dealt at the table; nil means Hold'em.
+	Variant Variant
+	// Betting is the betting structure; empty means the variant's default.
+	Betting BettingStructure
+	// RaiseCap caps the full bets and raises per fixed-limit street, the
+	// big blind counting as the first preflop bet. StreetRaises counts them.
+	RaiseCap     int
+	StreetRaises int
+	RakeCC       int64
+	// DeckSeed shuffled the current hand's deck. It stays secret until the
+	// hand is settled; only SeedHash(DeckSeed) is published before.
+	DeckSeed  []byte
+	DealOrder []int
+	// HandNumber counts the hands dealt at the table, starting at 1.
+	HandNumber int
+	// PlayShortStacks deals in every seat with chips: a stack that cannot
+	// cover the blinds and ante posts what it has and is all-in. Tournament
+	// tables play this way; cash tables sit such stacks out.
+	PlayShortStacks bool
 }
 
 type Street string
@@ -80,7 +110,13 @@ type Opponent struct {
 
 func (s *TableState) SnapshotFor(playerIdx int, includeHole bool) Snapshot {
 	me := s.Players[playerIdx]
-	opp := s.Players[1-playerIdx]
+	opponents := []Opponent{}
+	for i, opp := range s.Players {
+		if opp == nil || i == playerIdx {
+			continue
+		}
+		opponents = append(opponents, Opponent{Seat: opp.Seat, Name: opp.Name, Stack: opp.Stack, Action: string(opp.LastAction)})
+	}
 	hole := []string{}
 	if includeHole {
 		for _, c := range me.Hole {
@@ -103,7 +139,7 @@ func (s *TableState) SnapshotFor(playerIdx int, includeHole bool) Snapshot {
 		CurrentBet:       s.CurrentBet,
 		CallAmount:       max64(0, s.CurrentBet-s.RoundBets[playerIdx]),
 		MyBalance:        me.Stack,
-		Opponents:        []Opponent{{Seat: opp.Seat, Name: opp.Name, Stack: opp.Stack, Action: string(opp.LastAction)}},
+		Opponents:        opponents,
 		ActionTimeoutMS:  int64(s.ActionTimeout / time.Millisecond),
 		Street:           string(s.Street),
 		CurrentActorSeat: s.Players[s.CurrentActor].Seat,
@@ -0,0 +1,113 @@
+package game
+
+// Variant is a poker game the engine can deal: the deck, how many hole
+// cards each player gets, how a showdown hand is scored and the betting
+// structure the game is played with unless the table sets its own.
+type Variant interface {
+	Name() string
+	// NewDeck returns the variant's deck in its unshuffled order.
+	NewDeck() *Deck
+	HoleCards() int
+	// Evaluate scores the best hand a player makes from hole and a board of
+	// three to five cards.
+	Evaluate(hole, board []Card) HandStrength
+	DefaultBetting() BettingStructure
+}
+
+// Variant names as stored on rooms.
+const (
+	VariantHoldem    = "holdem"
+	VariantOmaha     = "omaha"
+	VariantShortDeck = "short_deck"
+)
+
+var (
+	// Holdem deals two hole cards and plays the best five of all seven.
+	Holdem Variant = holdem{}
+	// Omaha deals four hole cards; a hand is exactly two of them with
+	// exactly three board cards.
+	Omaha Variant = omaha{}
+	// ShortDeck is Hold'em with the 36-card deck: A-6-7-8-9 is the lowest
+	// straight and a flush beats a full house.
+	ShortDeck Variant = shortDeck{}
+)
+
+var variants = map[string]Variant{
+	VariantHoldem:    Holdem,
+	VariantOmaha:     Omaha,
+	VariantShortDeck: ShortDeck,
+}
+
+// VariantByName returns the variant with the given name. An empty name is
+// Hold'em.
+func VariantByName(name string) (Variant, bool) {
+	if name == "" {
+		return Holdem, true
+	}
+	v, ok := variants[name]
+	return v, ok
+}
+
+// GameVariant is the table's variant, Hold'em when none is set.
+func (s *TableState) GameVariant() Variant {
+	if s.Variant == nil {
+		return Holdem
+	}
+	return s.Variant
+}
+
+type holdem struct{}
+
+func (holdem) Name() string                     { return VariantHoldem }
+func (holdem) NewDeck() *Deck                   { return NewDeck() }
+func (holdem) HoleCards() int                   { return 2 }
+func (holdem) DefaultBetting() BettingStructure { return NoLimit }
+
+func (holdem) Evaluate(hole, board []Card) HandStrength {
+	var cards [7]Card
+	n := copy(cards[:], hole)
+	n += copy(cards[n:], board)
+	return Strength(cards[:n])
+}
+
+type omaha struct{}
+
+func (omaha) Name() string                     { return VariantOmaha }
+func (omaha) NewDeck() *Deck                   { return NewDeck() }
+func (omaha) HoleCards() int                   { return 4 }
+func (omaha) DefaultBetting() BettingStructure { return PotLimit }
+
+// Evaluate tries every pair of hole cards with every three board cards: 60
+// five-card hands on the river.
+func (omaha) Evaluate(hole, board []Card) HandStrength {
+	var best HandStrength
+	var cards [5]Card
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			cards[0], cards[1] = hole[i], hole[j]
+			for a := 0; a < len(board); a++ {
+				for b := a + 1; b < len(board); b++ {
+					for c := b + 1; c < len(board); c++ {
+						cards[2], cards[3], cards[4] = board[a], board[b], board[c]
+						best = max(best, Strength(cards[:]))
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+type shortDeck struct{}
+
+func (shortDeck) Name() string                     { return VariantShortDeck }
+func (shortDeck) NewDeck() *Deck                   { return NewShortDeck() }
+func (shortDeck) HoleCards() int                   { return 2 }
+func (shortDeck) DefaultBetting() BettingStructure { return NoLimit }
+
+func (shortDeck) Evaluate(hole, board []Card) HandStrength {
+	var cards [7]Card
+	n := copy(cards[:], hole)
+	n += copy(cards[n:], board)
+	return ShortDeckStrength(cards[:n])
+}
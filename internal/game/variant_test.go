@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOmahaUsesExactlyTwoHoleCards(t *testing.T) {
+	hole := mustCards(t, "Ts3h4c5d")
+	board := mustCards(t, "AsKsQsJs2d")
+	if got := Holdem.Evaluate(hole[:2], board); got.Rank().Category != categoryStraightFlush {
+		t.Fatalf("expected Ts3h to make a hold'em royal flush, got %+v", got.Rank())
+	}
+	// One spade in hand makes no flush, and no two hole cards complete a
+	// straight: the best hand is ace high with the ten and five.
+	want := newHandStrength(categoryHighCard, int(Ace), int(King), int(Queen), int(Ten), int(Five))
+	if got := Omaha.Evaluate(hole, board); got != want {
+		t.Fatalf("expected %+v, got %+v", want.Rank(), got.Rank())
+	}
+}
+
+func TestOmahaPlaysTwoPairFromTheHand(t *testing.T) {
+	hole := mustCards(t, "AhAd9c9s")
+	board := mustCards(t, "9h5c5d2s3h")
+	// AA with 9-5-5 is only two pair; 99 with 9-5-5 fills up.
+	want := newHandStrength(categoryFullHouse, int(Nine), int(Five))
+	if got := Omaha.Evaluate(hole, board); got != want {
+		t.Fatalf("expected %+v, got %+v", want.Rank(), got.Rank())
+	}
+}
+
+func TestOmahaEquityOnTheRiver(t *testing.T) {
+	holes := [][]Card{mustCards(t, "AhAd9c9s"), mustCards(t, "KcKd4s6h")}
+	res, err := CalculateEquity(Omaha, holes, mustCards(t, "9h5c5d2s3h"), nil, 0, nil)
+	if err != nil {
+		t.Fatalf("equity: %v", err)
+	}
+	if !res.Exact || res.Boards != 1 || res.Players[0].Equity != 1 {
+		t.Fatalf("expected the nine full house to win, got %+v", res)
+	}
+	if _, err := CalculateEquity(Omaha, [][]Card{mustCards(t, "AsAh"), mustCards(t, "KsKh")}, nil, nil, 0, nil); !errors.Is(err, ErrInvalidEquityInput) {
+		t.Fatalf("expected two-card hands to be rejected in omaha, got %v", err)
+	}
+}
+
+func TestShortDeckWheelAndFlushOverFullHouse(t *testing.T) {
+	board := mustCards(t, "6h7c8d9s")
+	wheel := ShortDeck.Evaluate(mustCards(t, "AcKd"), board)
+	if got := wheel.Rank(); got.Category != categoryStraight || got.Ranks[0] != int(Nine) {
+		t.Fatalf("expected A-6-7-8-9 to be a nine-high straight, got %+v", got)
+	}
+	if ten := ShortDeck.Evaluate(mustCards(t, "TcKd"), board); ten <= wheel {
+		t.Fatalf("expected 6-T to beat the wheel")
+	}
+
+	flushBoard := mustCards(t, "KhQh6hJcJd")
+	flush := ShortDeck.Evaluate(mustCards(t, "Ah7h"), flushBoard)
+	fullHouse := ShortDeck.Evaluate(mustCards(t, "JsKs"), flushBoard)
+	if flush <= fullHouse {
+		t.Fatalf("expected a short-deck flush to beat a full house")
+	}
+	if Holdem.Evaluate(mustCards(t, "Ah7h"), flushBoard) >= Holdem.Evaluate(mustCards(t, "JsKs"), flushBoard) {
+		t.Fatalf("expected a hold'em full house to beat a flush")
+	}
+}
+
+func TestShortDeckDealsThirtySixCards(t *testing.T) {
+	deck := ShortDeck.NewDeck().cards
+	if len(deck) != 36 {
+		t.Fatalf("expected 36 cards, got %d", len(deck))
+	}
+	for _, c := range deck {
+		if c.Rank < Six {
+			t.Fatalf("unexpected %s in the short deck", c)
+		}
+	}
+	holes := [][]Card{mustCards(t, "AsAh"), mustCards(t, "5s5h")}
+	if _, err := CalculateEquity(ShortDeck, holes, nil, nil, 0, nil); !errors.Is(err, ErrInvalidEquityInput) {
+		t.Fatalf("expected fives to be rejected in short deck, got %v", err)
+	}
+}
@@ -26,12 +26,20 @@ type AgentStateView struct {
 	MySeat              int                `json:"my_seat"`
 	MyBalance           int64              `json:"my_balance"`
 	MyHoleCards         []string           `json:"my_hole_cards"`
+	Variant             string             `json:"variant"`
+	SmallBlindCC        int64              `json:"small_blind_cc"`
+	BigBlindCC          int64              `json:"big_blind_cc"`
+	AnteCC              int64              `json:"ante_cc,omitempty"`
+	BettingStructure    string             `json:"betting_structure"`
 	LegalActions        []string           `json:"legal_actions,omitempty"`
 	ActionConstraints   *ActionConstraints `json:"action_constraints,omitempty"`
 	Seats               []SeatView         `json:"seats"`
 	TableStatus         string             `json:"table_status,omitempty"`
 	ReconnectDeadlineTS int64              `json:"reconnect_deadline_ts,omitempty"`
 	CloseReason         string             `json:"close_reason,omitempty"`
+	// TournamentID is set at a tournament table, where stacks and blinds
+	// are tournament chips rather than CC.
+	TournamentID string `json:"tournament_id,omitempty"`
 }
 
 type BetConstraint struct {
@@ -44,9 +52,25 @@ type RaiseConstraint struct {
 	MaxTo int64 `json:"max_to"`
 }
 
+// AllInConstraint describes the chips an all_in action puts in (Amount) and
+// the resulting street contribution (To).
+type AllInConstraint struct {
+	Amount int64 `json:"amount"`
+	To     int64 `json:"to"`
+}
+
+// CallConstraint describes the chips a call puts in (Amount), capped by the
+// stack, and the resulting street contribution (To).
+type CallConstraint struct {
+	Amount int64 `json:"amount"`
+	To     int64 `json:"to"`
+}
+
 type ActionConstraints struct {
+	Call  *CallConstraint  `json:"call,omitempty"`
 	Bet   *BetConstraint   `json:"bet,omitempty"`
 	Raise *RaiseConstraint `json:"raise,omitempty"`
+	AllIn *AllInConstraint `json:"all_in,omitempty"`
 }
 
 type PublicStateView struct {
@@ -69,8 +93,10 @@ func BuildAgentState(st *game.TableState, mySeat int, turnID string, includeOthe
 	}
 
 	myCards := []string{}
-	for _, c := range st.Players[mySeat].Hole {
-		myCards = append(myCards, c.String())
+	if mySeat >= 0 && mySeat < len(st.Players) && st.Players[mySeat] != nil {
+		for _, c := range st.Players[mySeat].Hole {
+			myCards = append(myCards, c.String())
+		}
 	}
 
 	seats := make([]SeatView, 0, len(st.Players))
@@ -101,7 +127,7 @@ func BuildAgentState(st *game.TableState, mySeat int, turnID string, includeOthe
 	}
 
 	myBalance := int64(0)
-	if st.Players[mySeat] != nil {
+	if mySeat >= 0 && mySeat < len(st.Players) && st.Players[mySeat] != nil {
 		myBalance = st.Players[mySeat].Stack
 	}
 	legalActions, actionConstraints := buildLegalActionsAndConstraints(st, mySeat)
@@ -110,12 +136,17 @@ func BuildAgentState(st *game.TableState, mySeat int, turnID string, includeOthe
 		Street:            string(st.Street),
 		Pot:               st.Pot,
 		CommunityCards:    community,
-		CurrentActorSeat:  st.Players[st.CurrentActor].Seat,
+		CurrentActorSeat:  currentActorSeat(st),
 		TurnID:            turnID,
 		ActionTimeoutMS:   int64(st.ActionTimeout.Milliseconds()),
 		MySeat:            mySeat,
 		MyBalance:         myBalance,
 		MyHoleCards:       myCards,
+		Variant:           st.GameVariant().Name(),
+		SmallBlindCC:      st.SmallBlind,
+		BigBlindCC:        st.BigBlind,
+		AnteCC:            st.Ante,
+		BettingStructure:  string(st.GameBetting()),
 		LegalActions:      legalActions,
 		ActionConstraints: actionConstraints,
 		Seats:             seats,
@@ -131,45 +162,51 @@ func buildLegalActionsAndConstraints(st *game.TableState, mySeat int) ([]string,
 		return nil, nil
 	}
 	me := st.Players[mySeat]
-	if me.Folded || st.CurrentActor != mySeat {
+	if me.Folded || me.AllIn || st.CurrentActor != mySeat {
 		return nil, nil
 	}
 
-	legal := make([]string, 0, 5)
+	legal := make([]string, 0, 6)
 	constraints := &ActionConstraints{}
 	legal = append(legal, string(game.ActionFold))
 
 	if st.CurrentBet == st.RoundBets[mySeat] {
 		legal = append(legal, string(game.ActionCheck))
 	}
-	if st.CurrentBet > st.RoundBets[mySeat] {
-		toCall := st.CurrentBet - st.RoundBets[mySeat]
-		if toCall > 0 && me.Stack >= toCall {
-			legal = append(legal, string(game.ActionCall))
+	if toCall := st.CurrentBet - st.RoundBets[mySeat]; toCall > 0 {
+		// A call for more than the stack puts in what is left.
+		amount := min(toCall, me.Stack)
+		legal = append(legal, string(game.ActionCall))
+		constraints.Call = &CallConstraint{
+			Amount: amount,
+			To:     st.RoundBets[mySeat] + amount,
 		}
 	}
-	if st.CurrentBet == 0 {
-		maxBet := me.Stack
-		if maxBet >= st.MinRaise {
+	maxTo := st.RoundBets[mySeat] + me.Stack
+	minTo, limitTo, canRaise := st.RaiseLimits(mySeat)
+	if canRaise && maxTo >= minTo {
+		if st.CurrentBet == 0 {
 			legal = append(legal, string(game.ActionBet))
 			constraints.Bet = &BetConstraint{
-				Min: st.MinRaise,
-				Max: maxBet,
+				Min: minTo - st.RoundBets[mySeat],
+				Max: limitTo - st.RoundBets[mySeat],
 			}
-		}
-	}
-	if st.CurrentBet > 0 {
-		minTo := st.CurrentBet + st.MinRaise
-		maxTo := st.RoundBets[mySeat] + me.Stack
-		if maxTo >= minTo {
+		} else {
 			legal = append(legal, string(game.ActionRaise))
 			constraints.Raise = &RaiseConstraint{
 				MinTo: minTo,
-				MaxTo: maxTo,
+				MaxTo: limitTo,
 			}
 		}
 	}
-	if constraints.Bet == nil && constraints.Raise == nil {
+	if me.Stack > 0 && (maxTo <= st.CurrentBet || (canRaise && maxTo <= limitTo)) {
+		legal = append(legal, string(game.ActionAllIn))
+		constraints.AllIn = &AllInConstraint{
+			Amount: me.Stack,
+			To:     maxTo,
+		}
+	}
+	if constraints.Call == nil && constraints.Bet == nil && constraints.Raise == nil && constraints.AllIn == nil {
 		return legal, nil
 	}
 	return legal, constraints
@@ -217,8 +254,15 @@ func BuildPublicState(st *game.TableState) PublicStateView {
 		Street:           string(st.Street),
 		Pot:              st.Pot,
 		CommunityCards:   community,
-		CurrentActorSeat: st.Players[st.CurrentActor].Seat,
+		CurrentActorSeat: currentActorSeat(st),
 		ActionTimeoutMS:  int64(st.ActionTimeout.Milliseconds()),
 		Seats:            seats,
 	}
 }
+
+func currentActorSeat(st *game.TableState) int {
+	if st.CurrentActor < 0 || st.CurrentActor >= len(st.Players) || st.Players[st.CurrentActor] == nil {
+		return st.CurrentActor
+	}
+	return st.Players[st.CurrentActor].Seat
+}
@@ -1,6 +1,7 @@
 package viewmodel
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -16,9 +17,9 @@ func TestBuildAgentStateVisibilityAndSeatData(t *testing.T) {
 		CurrentActor:  0,
 		CurrentBet:    400,
 		MinRaise:      200,
-		RoundBets:     [2]int64{200, 400},
+		RoundBets:     []int64{200, 400},
 		ActionTimeout: 5 * time.Second,
-		Players: [2]*game.Player{
+		Players: []*game.Player{
 			{ID: "a1", Seat: 0, Stack: 1000, Hole: []game.Card{{Rank: game.Two, Suit: game.Clubs}, {Rank: game.Three, Suit: game.Clubs}}, LastAction: game.ActionCall},
 			{ID: "a2", Seat: 1, Stack: 800, Hole: []game.Card{{Rank: game.Four, Suit: game.Clubs}, {Rank: game.Five, Suit: game.Clubs}}, LastAction: game.ActionRaise},
 		},
@@ -70,9 +71,9 @@ func TestBuildAgentStateBetOnlyConstraintsWhenCurrentBetIsZero(t *testing.T) {
 		CurrentActor:  1,
 		CurrentBet:    0,
 		MinRaise:      100,
-		RoundBets:     [2]int64{0, 0},
+		RoundBets:     []int64{0, 0},
 		ActionTimeout: 5 * time.Second,
-		Players: [2]*game.Player{
+		Players: []*game.Player{
 			{ID: "a1", Seat: 0, Stack: 500},
 			{ID: "a2", Seat: 1, Stack: 1200},
 		},
@@ -114,9 +115,9 @@ func TestBuildAgentStateRaiseConstraintRespectsEffectiveStack(t *testing.T) {
 		CurrentActor:  0,
 		CurrentBet:    400,
 		MinRaise:      200,
-		RoundBets:     [2]int64{100, 400},
+		RoundBets:     []int64{100, 400},
 		ActionTimeout: 5 * time.Second,
-		Players: [2]*game.Player{
+		Players: []*game.Player{
 			{ID: "a1", Seat: 0, Stack: 450},
 			{ID: "a2", Seat: 1, Stack: 3000},
 		},
@@ -138,7 +139,7 @@ func TestBuildAgentStateRaiseConstraintRespectsEffectiveStack(t *testing.T) {
 	}
 }
 
-func TestBuildAgentStateCallNotLegalWhenInsufficientStack(t *testing.T) {
+func TestBuildAgentStateCallCappedByStack(t *testing.T) {
 	st := &game.TableState{
 		HandID:        "hand_4",
 		Street:        game.StreetTurn,
@@ -146,19 +147,26 @@ func TestBuildAgentStateCallNotLegalWhenInsufficientStack(t *testing.T) {
 		CurrentActor:  0,
 		CurrentBet:    500,
 		MinRaise:      100,
-		RoundBets:     [2]int64{100, 500},
+		RoundBets:     []int64{100, 500},
 		ActionTimeout: 5 * time.Second,
-		Players: [2]*game.Player{
+		Players: []*game.Player{
 			{ID: "a1", Seat: 0, Stack: 300},
 			{ID: "a2", Seat: 1, Stack: 3000},
 		},
 	}
 
 	view := BuildAgentState(st, 0, "turn_4", false)
-	for _, a := range view.LegalActions {
-		if a == string(game.ActionCall) {
-			t.Fatalf("call should not be legal when stack is below to_call: %+v", view.LegalActions)
-		}
+	if !slices.Contains(view.LegalActions, string(game.ActionCall)) {
+		t.Fatalf("call should be legal when stack is below to_call: %+v", view.LegalActions)
+	}
+	if c := view.ActionConstraints; c == nil || c.Call == nil || c.Call.Amount != 300 || c.Call.To != 400 {
+		t.Fatalf("expected the call capped at the 300 stack, got %+v", view.ActionConstraints)
+	}
+
+	st.Players[0].Stack = 1000
+	view = BuildAgentState(st, 0, "turn_4", false)
+	if c := view.ActionConstraints; c == nil || c.Call == nil || c.Call.Amount != 400 || c.Call.To != 500 {
+		t.Fatalf("expected a full call of 400, got %+v", view.ActionConstraints)
 	}
 }
 
@@ -170,9 +178,9 @@ func TestBuildPublicStateIncludesSeatHoleCards(t *testing.T) {
 		Community:     []game.Card{{Rank: game.Ace, Suit: game.Spades}},
 		CurrentActor:  0,
 		CurrentBet:    200,
-		RoundBets:     [2]int64{200, 200},
+		RoundBets:     []int64{200, 200},
 		ActionTimeout: 5 * time.Second,
-		Players: [2]*game.Player{
+		Players: []*game.Player{
 			{ID: "p1", Name: "Alpha", Seat: 0, Stack: 900, Hole: []game.Card{{Rank: game.King, Suit: game.Hearts}, {Rank: game.Queen, Suit: game.Hearts}}},
 			{ID: "p2", Name: "Beta", Seat: 1, Stack: 800, Hole: []game.Card{{Rank: game.Ten, Suit: game.Clubs}, {Rank: game.Seven, Suit: game.Diamonds}}},
 		},
@@ -191,3 +199,107 @@ func TestBuildPublicStateIncludesSeatHoleCards(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildAgentStateExposesAllInWhenShortOfCall(t *testing.T) {
+	st := &game.TableState{
+		HandID:        "hand_5",
+		Street:        game.StreetTurn,
+		CurrentActor:  0,
+		CurrentBet:    500,
+		MinRaise:      100,
+		RoundBets:     []int64{100, 500},
+		ActionTimeout: 5 * time.Second,
+		Players: []*game.Player{
+			{ID: "a1", Seat: 0, Stack: 300},
+			{ID: "a2", Seat: 1, Stack: 3000},
+		},
+	}
+
+	view := BuildAgentState(st, 0, "turn_5", false)
+	hasAllIn := false
+	for _, a := range view.LegalActions {
+		if a == string(game.ActionAllIn) {
+			hasAllIn = true
+		}
+	}
+	if !hasAllIn {
+		t.Fatalf("expected all_in in legal_actions: %+v", view.LegalActions)
+	}
+	if view.ActionConstraints == nil || view.ActionConstraints.AllIn == nil {
+		t.Fatalf("expected all_in constraint")
+	}
+	if view.ActionConstraints.AllIn.Amount != 300 || view.ActionConstraints.AllIn.To != 400 {
+		t.Fatalf("unexpected all_in constraint: %+v", view.ActionConstraints.AllIn)
+	}
+}
+
+func TestBuildAgentStateNoRaiseAfterIncompleteAllIn(t *testing.T) {
+	st := &game.TableState{
+		HandID:        "hand_6",
+		Street:        game.StreetFlop,
+		CurrentActor:  0,
+		CurrentBet:    150,
+		MinRaise:      100,
+		RoundBets:     []int64{100, 150},
+		Acted:         []bool{true, true},
+		ActedBet:      []int64{100, 150},
+		ActionTimeout: 5 * time.Second,
+		Players: []*game.Player{
+			{ID: "a1", Seat: 0, Stack: 2000},
+			{ID: "a2", Seat: 1, Stack: 0, AllIn: true},
+		},
+	}
+
+	view := BuildAgentState(st, 0, "turn_6", false)
+	for _, a := range view.LegalActions {
+		if a == string(game.ActionRaise) || a == string(game.ActionAllIn) {
+			t.Fatalf("betting must not be reopened by an incomplete all-in: %+v", view.LegalActions)
+		}
+	}
+}
+
+func TestBuildAgentStateLimitConstraints(t *testing.T) {
+	st := &game.TableState{
+		HandID:       "hand_1",
+		Street:       game.StreetFlop,
+		Pot:          600,
+		CurrentActor: 0,
+		CurrentBet:   200,
+		MinRaise:     200,
+		BigBlind:     100,
+		RoundBets:    []int64{0, 200},
+		Acted:        []bool{false, true},
+		ActedBet:     []int64{0, 200},
+		Players: []*game.Player{
+			{ID: "a1", Seat: 0, Stack: 5000},
+			{ID: "a2", Seat: 1, Stack: 5000},
+		},
+	}
+
+	st.Betting = game.PotLimit
+	view := BuildAgentState(st, 0, "turn_1", false)
+	if view.BettingStructure != "pot_limit" {
+		t.Fatalf("expected pot_limit, got %q", view.BettingStructure)
+	}
+	// 200 to call, pot 800 after the call: raise to at most 200 + 800 = 1000.
+	if c := view.ActionConstraints; c == nil || c.Raise == nil || c.Raise.MinTo != 400 || c.Raise.MaxTo != 1000 || c.AllIn != nil {
+		t.Fatalf("unexpected pot-limit constraints: %+v", view.ActionConstraints)
+	}
+	if slices.Contains(view.LegalActions, string(game.ActionAllIn)) {
+		t.Fatalf("did not expect all_in above the pot limit: %v", view.LegalActions)
+	}
+
+	st.Betting = game.FixedLimit
+	st.RaiseCap = 4
+	st.StreetRaises = 1
+	st.MinRaise = 100
+	view = BuildAgentState(st, 0, "turn_1", false)
+	if c := view.ActionConstraints; c == nil || c.Raise == nil || c.Raise.MinTo != 300 || c.Raise.MaxTo != 300 {
+		t.Fatalf("unexpected fixed-limit constraints: %+v", view.ActionConstraints)
+	}
+	st.StreetRaises = 4
+	view = BuildAgentState(st, 0, "turn_1", false)
+	if c := view.ActionConstraints; c == nil || c.Raise != nil || slices.Contains(view.LegalActions, string(game.ActionRaise)) {
+		t.Fatalf("expected no raise once the street is capped: %v %+v", view.LegalActions, view.ActionConstraints)
+	}
+}
@@ -14,10 +14,28 @@ func New(s *store.Store) *Ledger {
 	return &Ledger{Store: s}
 }
 
-func (l *Ledger) DebitBlind(ctx context.Context, agentID, handID string, amount int64) (int64, error) {
-	return l.Store.Debit(ctx, agentID, amount, "blind_debit", "hand", handID)
+// DebitBlind posts a blind from the agent's stack at tableID.
+func (l *Ledger) DebitBlind(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error) {
+	return l.Store.DebitTableStack(ctx, tableID, agentID, amount, "blind_debit", "hand", handID)
 }
 
-func (l *Ledger) CreditPot(ctx context.Context, agentID, handID string, amount int64) (int64, error) {
-	return l.Store.Credit(ctx, agentID, amount, "pot_credit", "hand", handID)
+// DebitBet moves a bet from the agent's stack at tableID into the pot.
+func (l *Ledger) DebitBet(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error) {
+	return l.Store.DebitTableStack(ctx, tableID, agentID, amount, "bet_debit", "hand", handID)
+}
+
+// CreditPot pays a pot share into the agent's stack at tableID.
+func (l *Ledger) CreditPot(ctx context.Context, tableID, agentID, handID string, amount int64) (int64, error) {
+	return l.Store.CreditTableStack(ctx, tableID, agentID, amount, "pot_credit", "hand", handID)
+}
+
+// SettleHand pays out a finished hand, including the rake owed to the house
+// account, in one transaction.
+func (l *Ledger) SettleHand(ctx context.Context, hs store.HandSettlement) error {
+	return l.Store.SettleHand(ctx, hs)
+}
+
+// CashOut returns the agent's remaining stack at tableID to their account.
+func (l *Ledger) CashOut(ctx context.Context, tableID, agentID string) (int64, error) {
+	return l.Store.CashOut(ctx, tableID, agentID)
 }
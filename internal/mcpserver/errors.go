@@ -77,7 +77,7 @@ func mapDomainError(err error) *mcp.CallToolResult {
 			return toolError("agent_blacklisted", be.Reason)
 		}
 		return toolError("agent_blacklisted", err.Error())
-	case errors.Is(err, apppublic.ErrTableNotFound), errors.Is(err, appsession.ErrTableNotFound), errors.Is(err, store.ErrNotFound):
+	case errors.Is(err, apppublic.ErrTableNotFound), errors.Is(err, apppublic.ErrNotFound), errors.Is(err, appsession.ErrTableNotFound), errors.Is(err, store.ErrNotFound):
 		return toolError("not_found", err.Error())
 	default:
 		return toolError("internal_error", err.Error())
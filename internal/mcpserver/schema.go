@@ -54,10 +54,6 @@ func isAllowedLeaderboardWindow(v string) bool {
 	return v == "7d" || v == "30d" || v == "all"
 }
 
-func isAllowedLeaderboardRoom(v string) bool {
-	return v == "all" || v == "low" || v == "mid" || v == "high"
-}
-
 func isAllowedLeaderboardSort(v string) bool {
-	return v == "score" || v == "net_cc_from_play" || v == "hands_played" || v == "win_rate"
+	return v == "score" || v == "net_cc_from_play" || v == "hands_played" || v == "win_rate" || v == "rating"
 }
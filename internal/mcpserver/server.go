@@ -66,6 +66,8 @@ func New(st *store.Store, cfg config.ServerConfig, coord *agentgateway.Coordinat
 	s.registerPublicTools()
 	s.registerMatchmakingTools()
 	s.registerGameplayTools()
+	s.registerTournamentTools()
+	s.registerChallengeTools()
 	s.registerResources()
 	return s
 }
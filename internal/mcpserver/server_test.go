@@ -47,7 +47,13 @@ func TestMCPServerToolsAndFlows(t *testing.T) {
 		"list_rooms",
 		"list_live_tables",
 		"get_leaderboard",
+		"get_agent_hud_stats",
 		"find_agent_table",
+		"register_tournament",
+		"get_tournament_status",
+		"list_tournaments",
+		"list_challenges",
+		"decline_challenge",
 	)
 
 	a1 := mustRegisterAndClaim(t, mcpClient, "mcp-bot-a")
@@ -132,6 +138,9 @@ func TestNextDecisionWaitingMatchmakingStatus(t *testing.T) {
 	if asString(payload["status"]) != "waiting_matchmaking" {
 		t.Fatalf("expected waiting_matchmaking, got %v", payload)
 	}
+	if pos, _ := payload["queue_position"].(float64); pos != 1 {
+		t.Fatalf("expected queue_position 1, got %v", payload)
+	}
 }
 
 func TestMCPServerToolErrors(t *testing.T) {
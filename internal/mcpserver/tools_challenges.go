@@ -0,0 +1,80 @@
+package mcpserver
+
+import (
+	"context"
+
+	"silicon-casino/internal/agentgateway"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) registerChallengeTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"list_challenges",
+			mcp.WithDescription("List the pending challenges you have sent or received. Accept one with next_decision mode=challenge and its challenge id."),
+			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
+			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
+		),
+		s.handleListChallenges,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"decline_challenge",
+			mcp.WithDescription("Decline a challenge sent to you; the challenger's session is closed."),
+			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
+			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
+			mcp.WithString("challenge", mcp.Required(), mcp.Description("Challenge id")),
+		),
+		s.handleDeclineChallenge,
+	)
+}
+
+func (s *Server) handleListChallenges(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	apiKey, err := request.RequireString("api_key")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	if _, authErr := s.authAgent(ctx, agentID, apiKey); authErr != nil {
+		return authErr, nil
+	}
+	items, listErr := s.coord.ListChallenges(ctx, agentID)
+	if listErr != nil {
+		return toolError("internal_error", listErr.Error()), nil
+	}
+	return toolResult(map[string]any{"items": items}), nil
+}
+
+func (s *Server) handleDeclineChallenge(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	apiKey, err := request.RequireString("api_key")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	challengeID, err := request.RequireString("challenge")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	if _, authErr := s.authAgent(ctx, agentID, apiKey); authErr != nil {
+		return authErr, nil
+	}
+	if declineErr := s.coord.DeclineChallenge(ctx, agentID, challengeID); declineErr != nil {
+		switch {
+		case agentgateway.IsChallengeNotFound(declineErr):
+			return toolError("challenge_not_found", declineErr.Error()), nil
+		case agentgateway.IsChallengeClosed(declineErr):
+			return toolError("challenge_closed", declineErr.Error()), nil
+		default:
+			return toolError("internal_error", declineErr.Error()), nil
+		}
+	}
+	return toolResult(map[string]any{"ok": true, "status": "declined"}), nil
+}
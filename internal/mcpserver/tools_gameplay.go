@@ -17,8 +17,14 @@ func (s *Server) registerGameplayTools() {
 			mcp.WithDescription("Session-aware decision fetch. Creates/reuses session and returns either decision_request or noop."),
 			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
 			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
-			mcp.WithString("mode", mcp.Description("random|select, default random")),
-			mcp.WithString("room", mcp.Description("Room id when mode=select")),
+			mcp.WithString("mode", mcp.Description("random|select|ranked|league|challenge|private, default random")),
+			mcp.WithString("room", mcp.Description("Room id when mode=select or ranked, or when issuing a challenge or opening a private table")),
+			mcp.WithString("league", mcp.Description("League id when mode=league; you are seated once your next opponent is waiting too")),
+			mcp.WithString("opponent", mcp.Description("Agent id to challenge when mode=challenge")),
+			mcp.WithString("challenge", mcp.Description("Challenge id to accept when mode=challenge; see list_challenges")),
+			mcp.WithString("invite_code", mcp.Description("Invite code of the private table to join when mode=private; omit to open a new one")),
+			mcp.WithArray("invite_agents", mcp.WithStringItems(), mcp.Description("Agent ids allowed to join a new private table; anyone with the code when empty")),
+			mcp.WithNumber("buyin", mcp.Description("Optional table buy-in in CC within the room limits; defaults to the room max or your balance")),
 		),
 		s.handleNextDecision,
 	)
@@ -30,8 +36,8 @@ func (s *Server) registerGameplayTools() {
 			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
 			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
 			mcp.WithString("decision_id", mcp.Required(), mcp.Description("Decision id from next_decision")),
-			mcp.WithString("action", mcp.Required(), mcp.Description("fold|check|call|bet|raise")),
-			mcp.WithNumber("amount", mcp.Description("Required for bet/raise")),
+			mcp.WithString("action", mcp.Required(), mcp.Description("fold|check|call|bet|raise|all_in")),
+			mcp.WithNumber("amount", mcp.Description("Required for bet/raise; capped to the remaining stack")),
 			mcp.WithString("thought", mcp.Description("Optional thought log for spectator UI")),
 		),
 		s.handleSubmitNextDecision,
@@ -52,16 +58,36 @@ func (s *Server) handleNextDecision(ctx context.Context, request mcp.CallToolReq
 	}
 	mode := normalizeJoinMode(request.GetString("mode", ""))
 	roomID := request.GetString("room", "")
+	leagueID := request.GetString("league", "")
+	opponentID := request.GetString("opponent", "")
+	challengeID := request.GetString("challenge", "")
+	inviteCode := request.GetString("invite_code", "")
+	inviteAgents := request.GetStringSlice("invite_agents", nil)
+	var buyin *int64
+	if request.GetArguments()["buyin"] != nil {
+		v, convErr := request.RequireFloat("buyin")
+		if convErr != nil {
+			return toolError("invalid_request", convErr.Error()), nil
+		}
+		iv := int64(v)
+		buyin = &iv
+	}
 
 	var session *agentgateway.CreateSessionResponse
 	if existing, ok := s.coord.FindOpenSessionByAgent(agentID); ok {
 		session = existing
 	} else {
 		created, createErr := s.coord.CreateSession(ctx, agentgateway.CreateSessionRequest{
-			AgentID:  agentID,
-			APIKey:   apiKey,
-			JoinMode: mode,
-			RoomID:   roomID,
+			AgentID:         agentID,
+			APIKey:          apiKey,
+			JoinMode:        mode,
+			RoomID:          roomID,
+			LeagueID:        leagueID,
+			BuyinCC:         buyin,
+			OpponentAgentID: opponentID,
+			ChallengeID:     challengeID,
+			InviteCode:      inviteCode,
+			InviteAgentIDs:  inviteAgents,
 		})
 		if createErr != nil {
 			if _, code := agentgateway.MapSessionCreateError(createErr); code == "agent_already_in_session" {
@@ -92,14 +118,28 @@ func (s *Server) handleNextDecision(ctx context.Context, request mcp.CallToolReq
 		}), nil
 	}
 	if strings.TrimSpace(session.TableID) == "" {
-		return toolResult(map[string]any{
+		noop := map[string]any{
 			"type":           "noop",
 			"status":         "waiting_matchmaking",
 			"session_id":     session.SessionID,
 			"room_id":        session.RoomID,
 			"recoverable":    true,
 			"retry_after_ms": 1000,
-		}), nil
+		}
+		if session.ChallengeID != "" {
+			noop["challenge_id"] = session.ChallengeID
+		}
+		if session.InviteCode != "" {
+			noop["invite_code"] = session.InviteCode
+		}
+		if status, statusErr := s.coord.GetSessionStatus(session.SessionID); statusErr == nil && status.Queue != nil {
+			noop["queue_position"] = status.Queue.Position
+			noop["queue_size"] = status.Queue.QueueSize
+			if status.Queue.EstimatedWaitMS != nil {
+				noop["estimated_wait_ms"] = *status.Queue.EstimatedWaitMS
+			}
+		}
+		return toolResult(noop), nil
 	}
 	state, stateErr := s.coord.GetState(session.SessionID)
 	if stateErr != nil {
@@ -33,14 +33,25 @@ func (s *Server) registerPublicTools() {
 			"get_leaderboard",
 			mcp.WithDescription("Get leaderboard"),
 			mcp.WithString("window", mcp.Description("7d|30d|all")),
-			mcp.WithString("room", mcp.Description("all|low|mid|high")),
-			mcp.WithString("sort", mcp.Description("score|net_cc_from_play|hands_played|win_rate")),
+			mcp.WithString("room", mcp.Description("all or a room ID")),
+			mcp.WithString("sort", mcp.Description("score|net_cc_from_play|hands_played|win_rate|rating")),
 			mcp.WithNumber("limit", mcp.Description("Page size, default 50, max 100")),
 			mcp.WithNumber("offset", mcp.Description("Page offset, default 0")),
+			mcp.WithString("cursor", mcp.Description("next_cursor of the previous page; replaces offset")),
 		),
 		s.handleGetLeaderboard,
 	)
 
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"get_agent_hud_stats",
+			mcp.WithDescription("Get an agent's HUD stats: VPIP, PFR, 3-bet, c-bet, aggression factor, WTSD and W$SD"),
+			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
+			mcp.WithString("window", mcp.Description("7d|30d|all, default 30d")),
+		),
+		s.handleGetAgentHUDStats,
+	)
+
 	s.mcpServer.AddTool(
 		mcp.NewTool(
 			"find_agent_table",
@@ -78,12 +89,9 @@ func (s *Server) handleGetLeaderboard(ctx context.Context, request mcp.CallToolR
 		return toolError("invalid_request", "window must be 7d|30d|all"), nil
 	}
 	room := normalizeLeaderboardRoom(request.GetString("room", ""))
-	if !isAllowedLeaderboardRoom(room) {
-		return toolError("invalid_request", "room must be all|low|mid|high"), nil
-	}
 	sortBy := normalizeLeaderboardSort(request.GetString("sort", ""))
 	if !isAllowedLeaderboardSort(sortBy) {
-		return toolError("invalid_request", "sort must be score|net_cc_from_play|hands_played|win_rate"), nil
+		return toolError("invalid_request", "sort must be score|net_cc_from_play|hands_played|win_rate|rating"), nil
 	}
 	limit := request.GetInt("limit", defaultPageLimit)
 	offset := request.GetInt("offset", 0)
@@ -93,6 +101,7 @@ func (s *Server) handleGetLeaderboard(ctx context.Context, request mcp.CallToolR
 		Window: window,
 		RoomID: room,
 		SortBy: sortBy,
+		Cursor: request.GetString("cursor", ""),
 	}, limit, offset)
 	if err != nil {
 		return mapDomainError(err), nil
@@ -100,6 +109,22 @@ func (s *Server) handleGetLeaderboard(ctx context.Context, request mcp.CallToolR
 	return toolResult(resp), nil
 }
 
+func (s *Server) handleGetAgentHUDStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	window := normalizeLeaderboardWindow(request.GetString("window", ""))
+	if !isAllowedLeaderboardWindow(window) {
+		return toolError("invalid_request", "window must be 7d|30d|all"), nil
+	}
+	resp, svcErr := s.publicSvc.AgentHUD(ctx, agentID, window)
+	if svcErr != nil {
+		return mapDomainError(svcErr), nil
+	}
+	return toolResult(resp), nil
+}
+
 func (s *Server) handleFindAgentTable(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	agentID, err := request.RequireString("agent_id")
 	if err != nil {
@@ -0,0 +1,121 @@
+package mcpserver
+
+import (
+	"context"
+
+	"silicon-casino/internal/agentgateway"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) registerTournamentTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"register_tournament",
+			mcp.WithDescription("Register for the next sit-and-go of a room, or for a scheduled multi-table tournament by id. A sit-and-go charges its entry fee when the table fills, a multi-table tournament right away; play it with next_decision."),
+			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
+			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
+			mcp.WithString("room", mcp.Description("Sit-and-go room id")),
+			mcp.WithString("tournament_id", mcp.Description("Multi-table tournament id")),
+			mcp.WithBoolean("auto_rebuy", mcp.Description("Rebuy automatically on busting while rebuys are open")),
+		),
+		s.handleRegisterTournament,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"get_tournament_status",
+			mcp.WithDescription("Get the tournament you are registered for or playing: players left, your chips, blinds, payouts and whether rebuys are open"),
+			mcp.WithString("agent_id", mcp.Required(), mcp.Description("Agent id")),
+			mcp.WithString("api_key", mcp.Required(), mcp.Description("Agent api key")),
+		),
+		s.handleGetTournamentStatus,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"list_tournaments",
+			mcp.WithDescription("List sit-and-go and multi-table tournaments with their entrants and results"),
+			mcp.WithString("room", mcp.Description("Optional room id")),
+			mcp.WithString("status", mcp.Description("scheduled|running|completed|aborted")),
+			mcp.WithNumber("limit", mcp.Description("Page size, default 50, max 500")),
+			mcp.WithNumber("offset", mcp.Description("Page offset, default 0")),
+		),
+		s.handleListTournaments,
+	)
+}
+
+func (s *Server) handleRegisterTournament(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	apiKey, err := request.RequireString("api_key")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	roomID := request.GetString("room", "")
+	tournamentID := request.GetString("tournament_id", "")
+	if (roomID == "") == (tournamentID == "") {
+		return toolError("invalid_request", "exactly one of room and tournament_id is required"), nil
+	}
+	resp, regErr := s.coord.RegisterTournament(ctx, agentgateway.RegisterTournamentRequest{
+		AgentID:      agentID,
+		APIKey:       apiKey,
+		RoomID:       roomID,
+		TournamentID: tournamentID,
+		AutoRebuy:    request.GetBool("auto_rebuy", false),
+	})
+	if regErr != nil {
+		return sessionCreateError(regErr), nil
+	}
+	return toolResult(resp), nil
+}
+
+func (s *Server) handleGetTournamentStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	apiKey, err := request.RequireString("api_key")
+	if err != nil {
+		return toolError("invalid_request", err.Error()), nil
+	}
+	if _, authErr := s.authAgent(ctx, agentID, apiKey); authErr != nil {
+		return authErr, nil
+	}
+	session, ok := s.coord.FindOpenSessionByAgent(agentID)
+	if !ok {
+		return toolError("session_not_found", "agent has no open session"), nil
+	}
+	status, statusErr := s.coord.GetTournamentStatus(ctx, session.SessionID)
+	if statusErr != nil {
+		switch {
+		case agentgateway.IsSessionNotFound(statusErr):
+			return toolError("session_not_found", statusErr.Error()), nil
+		case agentgateway.IsTournamentNotFound(statusErr):
+			return toolError("tournament_not_found", statusErr.Error()), nil
+		default:
+			return toolError("internal_error", statusErr.Error()), nil
+		}
+	}
+	return toolResult(status), nil
+}
+
+func (s *Server) handleListTournaments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	status := request.GetString("status", "")
+	switch status {
+	case "", "scheduled", "running", "completed", "aborted":
+	default:
+		return toolError("invalid_request", "status must be scheduled|running|completed|aborted"), nil
+	}
+	limit := request.GetInt("limit", defaultPageLimit)
+	offset := request.GetInt("offset", 0)
+	limit, offset = clampPagination(limit, offset, maxPageLimit)
+
+	resp, err := s.publicSvc.Tournaments(ctx, request.GetString("room", ""), status, limit, offset)
+	if err != nil {
+		return mapDomainError(err), nil
+	}
+	return toolResult(resp), nil
+}
@@ -19,56 +19,63 @@ func EventsHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
 			_, _ = w.Write([]byte(`{"error":"table_not_found"}`))
 			return
 		}
-		flusher, ok := w.(http.Flusher)
-		if !ok {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = w.Write([]byte(`{"error":"stream_not_supported"}`))
+		streamBuffer(w, r, buf)
+	}
+}
+
+// streamBuffer writes the events of buf as server-sent events, replaying
+// those after Last-Event-ID first, until the client goes away or the buffer
+// is closed.
+func streamBuffer(w http.ResponseWriter, r *http.Request, buf *agentgateway.EventBuffer) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"stream_not_supported"}`))
+		return
+	}
+
+	metricSpectatorSSEConnectionsTotal.Add(1)
+	metricSpectatorSSEConnectionsActive.Add(1)
+	defer metricSpectatorSSEConnectionsActive.Add(-1)
+
+	agentgateway.SetSSEHeaders(w)
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	replay := buf.ReplayAfter(lastEventID)
+	for _, ev := range replay {
+		if err := agentgateway.WriteSSE(w, ev); err != nil {
 			return
 		}
+	}
+	flusher.Flush()
 
-		metricSpectatorSSEConnectionsTotal.Add(1)
-		metricSpectatorSSEConnectionsActive.Add(1)
-		defer metricSpectatorSSEConnectionsActive.Add(-1)
-
-		agentgateway.SetSSEHeaders(w)
+	ch := buf.Subscribe()
+	defer buf.Unsubscribe(ch)
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
 
-		lastEventID := r.Header.Get("Last-Event-ID")
-		replay := buf.ReplayAfter(lastEventID)
-		for _, ev := range replay {
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
 			if err := agentgateway.WriteSSE(w, ev); err != nil {
 				return
 			}
-		}
-		flusher.Flush()
-
-		ch := buf.Subscribe()
-		defer buf.Unsubscribe(ch)
-		ticker := time.NewTicker(pingInterval)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-r.Context().Done():
+			flusher.Flush()
+		case <-ticker.C:
+			ping := agentgateway.StreamEvent{
+				Event:    "ping",
+				ServerTS: time.Now().UnixMilli(),
+				Data:     map[string]any{"ts": time.Now().UnixMilli()},
+			}
+			if err := agentgateway.WriteSSE(w, ping); err != nil {
 				return
-			case ev, ok := <-ch:
-				if !ok {
-					return
-				}
-				if err := agentgateway.WriteSSE(w, ev); err != nil {
-					return
-				}
-				flusher.Flush()
-			case <-ticker.C:
-				ping := agentgateway.StreamEvent{
-					Event:    "ping",
-					ServerTS: time.Now().UnixMilli(),
-					Data:     map[string]any{"ts": time.Now().UnixMilli()},
-				}
-				if err := agentgateway.WriteSSE(w, ping); err != nil {
-					return
-				}
-				flusher.Flush()
 			}
+			flusher.Flush()
 		}
 	}
 }
@@ -0,0 +1,40 @@
+package spectatorgateway
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silicon-casino/internal/agentgateway"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TournamentEventsHandler streams the events of a running multi-table
+// tournament: registrations, table openings and closings, player moves,
+// eliminations and the final standings.
+func TournamentEventsHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buf := coord.GetTournamentBuffer(chi.URLParam(r, "tournament_id"))
+		if buf == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"tournament_not_found"}`))
+			return
+		}
+		streamBuffer(w, r, buf)
+	}
+}
+
+// TournamentStateHandler reports the live progress of a multi-table
+// tournament: blinds, players left and every table with its stacks.
+func TournamentStateHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		progress, err := coord.GetTournamentProgress(chi.URLParam(r, "tournament_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "tournament_not_found"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(progress)
+	}
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAbortedDuplicateMatchRefundsBothLegs(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a := mustCreateAgent(t, st, ctx, "BotA", "key-a", 10000)
+	b := mustCreateAgent(t, st, ctx, "BotB", "key-b", 10000)
+	roomID, err := st.CreateRoomWithConfig(ctx, Room{
+		Name:         "Duplicate",
+		MinBuyinCC:   1000,
+		MaxBuyinCC:   2000,
+		SmallBlindCC: 50,
+		BigBlindCC:   100,
+		MaxSeats:     2,
+		MatchFormat:  MatchFormatDuplicate,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	seat := 0
+	waiter := AgentSession{ID: NewID(), AgentID: a, RoomID: roomID, SeatID: &seat, JoinMode: "select", Status: "waiting", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.CreateAgentSession(ctx, waiter); err != nil {
+		t.Fatalf("create waiter: %v", err)
+	}
+	m := DuplicateMatch{
+		ID:           NewID(),
+		AgentAID:     a,
+		AgentBID:     b,
+		Leg1TableID:  NewID(),
+		Leg2TableID:  NewID(),
+		SeedSchedule: "abc",
+		HandsPerLeg:  10,
+		BuyinCC:      2000,
+	}
+	joiner := AgentSession{ID: NewID(), AgentID: b, RoomID: roomID, TableID: m.Leg1TableID, JoinMode: "select", Status: "active", ExpiresAt: time.Now().Add(time.Hour)}
+	err = st.CreateDuplicateMatchAndSessions(ctx, m, roomID, 50, 100, SeatAssignment{SessionID: waiter.ID, AgentID: a, Seat: 0}, joiner, 1)
+	if err != nil {
+		t.Fatalf("create match: %v", err)
+	}
+	for _, agentID := range []string{a, b} {
+		if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 6000 {
+			t.Fatalf("expected both legs escrowed for %s, balance=%d", agentID, bal)
+		}
+	}
+
+	if err := st.CloseDuplicateMatch(ctx, m.ID, DuplicateMatchAborted); err != nil {
+		t.Fatalf("close match: %v", err)
+	}
+	for _, agentID := range []string{a, b} {
+		if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 10000 {
+			t.Fatalf("expected full refund for %s, balance=%d", agentID, bal)
+		}
+	}
+	got, err := st.GetDuplicateMatch(ctx, m.ID)
+	if err != nil || got.Status != DuplicateMatchAborted || got.EndedAt == nil {
+		t.Fatalf("expected aborted match, got %+v err=%v", got, err)
+	}
+	results, err := st.ListDuplicateMatchResults(ctx, []string{m.ID})
+	if err != nil || len(results) != 0 {
+		t.Fatalf("expected no results for an aborted match, got %+v err=%v", results, err)
+	}
+	if err := st.CloseDuplicateMatch(ctx, m.ID, DuplicateMatchCompleted); !errors.Is(err, ErrDuplicateMatchClosed) {
+		t.Fatalf("expected closed match error, got %v", err)
+	}
+}
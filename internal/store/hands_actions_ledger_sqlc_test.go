@@ -13,7 +13,7 @@ func TestHandsActionsAndLedgerQuery(t *testing.T) {
 	}
 	agentID := mustCreateAgent(t, st, ctx, "A", "key-a", 10000)
 
-	handID, err := st.CreateHand(ctx, tableID)
+	handID, err := st.CreateHand(ctx, tableID, 100)
 	if err != nil {
 		t.Fatalf("create hand: %v", err)
 	}
@@ -35,3 +35,34 @@ func TestHandsActionsAndLedgerQuery(t *testing.T) {
 		t.Fatalf("expected ledger entries")
 	}
 }
+
+func TestListRakeByRoom(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	roomID, _ := st.CreateRoomWithConfig(ctx, Room{Name: "Raked", MinBuyinCC: 1000, SmallBlindCC: 50, BigBlindCC: 100, RakeBps: 500, RakeCapCC: 300})
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	for _, amount := range []int64{20, 30} {
+		handID, err := st.CreateHand(ctx, tableID, 100)
+		if err != nil {
+			t.Fatalf("create hand: %v", err)
+		}
+		if _, err := st.Credit(ctx, HouseAccountID, amount, "rake", "hand", handID); err != nil {
+			t.Fatalf("credit rake: %v", err)
+		}
+	}
+
+	items, err := st.ListRakeByRoom(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("list rake: %v", err)
+	}
+	if len(items) != 1 || items[0].RoomID != roomID || items[0].HandsRaked != 2 || items[0].RakeCC != 50 {
+		t.Fatalf("unexpected rake report: %+v", items)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, HouseAccountID); bal != 50 {
+		t.Fatalf("expected house balance 50, got %d", bal)
+	}
+}
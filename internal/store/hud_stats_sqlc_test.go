@@ -0,0 +1,104 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestAgentHUDCounts(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a := mustCreateAgent(t, st, ctx, "A", "key-a", 100000)
+	b := mustCreateAgent(t, st, ctx, "B", "key-b", 100000)
+	roomID, err := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	var seq int64
+	play := func(handID, agentID, eventType, payload string) {
+		t.Helper()
+		seq++
+		if eventType == "action_applied" {
+			if err := st.RecordAction(ctx, handID, agentID, "x", 0); err != nil {
+				t.Fatalf("record action: %v", err)
+			}
+		}
+		body := json.RawMessage(fmt.Sprintf(`{"hand_id":%q,%s}`, handID, payload))
+		if err := st.InsertTableReplayEvent(ctx, tableID, handID, seq, nil, eventType, agentID, body, 1); err != nil {
+			t.Fatalf("insert replay event: %v", err)
+		}
+	}
+
+	// A opens, B 3-bets and continuation bets the flop, A calls down and
+	// loses at showdown.
+	h1, err := st.CreateHand(ctx, tableID, 100)
+	if err != nil {
+		t.Fatalf("create hand: %v", err)
+	}
+	play(h1, a, "action_applied", `"action":"raise","street":"preflop","raise":true`)
+	play(h1, b, "action_applied", `"action":"raise","street":"preflop","raise":true`)
+	play(h1, a, "action_applied", `"action":"call","street":"preflop","raise":false`)
+	play(h1, "", "street_advanced", `"street":"flop"`)
+	play(h1, b, "action_applied", `"action":"bet","street":"flop","raise":true`)
+	play(h1, a, "action_applied", `"action":"call","street":"flop","raise":false`)
+	play(h1, "", "street_advanced", `"street":"turn"`)
+	play(h1, b, "action_applied", `"action":"check","street":"turn","raise":false`)
+	play(h1, a, "action_applied", `"action":"check","street":"turn","raise":false`)
+	if _, err := st.Credit(ctx, b, 600, "pot_credit", "hand", h1); err != nil {
+		t.Fatalf("credit: %v", err)
+	}
+	pot := int64(600)
+	if err := st.EndHandWithSummary(ctx, h1, b, &pot, "river"); err != nil {
+		t.Fatalf("end hand: %v", err)
+	}
+
+	// Events without street or raise fall back to the street_advanced
+	// events and the action type: A opens and B folds.
+	h2, err := st.CreateHand(ctx, tableID, 100)
+	if err != nil {
+		t.Fatalf("create hand: %v", err)
+	}
+	play(h2, a, "action_applied", `"action":"raise"`)
+	play(h2, b, "action_applied", `"action":"fold"`)
+	pot = 250
+	if err := st.EndHandWithSummary(ctx, h2, a, &pot, "preflop"); err != nil {
+		t.Fatalf("end hand: %v", err)
+	}
+
+	gotA, err := st.GetAgentHUDCounts(ctx, a, nil)
+	if err != nil {
+		t.Fatalf("hud a: %v", err)
+	}
+	wantA := HUDCounts{Hands: 2, VPIPHands: 2, PFRHands: 2, PostflopCalls: 1, SawFlop: 1, Showdowns: 1}
+	if *gotA != wantA {
+		t.Fatalf("agent A: got %+v, want %+v", *gotA, wantA)
+	}
+
+	gotB, err := st.GetAgentHUDCounts(ctx, b, nil)
+	if err != nil {
+		t.Fatalf("hud b: %v", err)
+	}
+	wantB := HUDCounts{
+		Hands:                 2,
+		VPIPHands:             1,
+		PFRHands:              1,
+		ThreeBetOpportunities: 2,
+		ThreeBets:             1,
+		CBetOpportunities:     1,
+		CBets:                 1,
+		PostflopRaises:        1,
+		SawFlop:               1,
+		Showdowns:             1,
+		ShowdownsWon:          1,
+	}
+	if *gotB != wantB {
+		t.Fatalf("agent B: got %+v, want %+v", *gotB, wantB)
+	}
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLeagueForfeitScoresWinAndBye(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a := mustCreateAgent(t, st, ctx, "BotA", "key-a", 10000)
+	b := mustCreateAgent(t, st, ctx, "BotB", "key-b", 10000)
+	c := mustCreateAgent(t, st, ctx, "BotC", "key-c", 10000)
+	roomID, err := st.CreateRoomWithConfig(ctx, Room{
+		Name:         "League",
+		MinBuyinCC:   1000,
+		SmallBlindCC: 10,
+		BigBlindCC:   20,
+		MaxSeats:     2,
+		MatchFormat:  MatchFormatLeague,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	league := League{ID: NewID(), RoomID: roomID, Name: "Weekly", Format: LeagueRoundRobin, HandsPerMatch: 50, Rounds: 3}
+	if err := st.CreateLeague(ctx, league, []string{a, b, c}); err != nil {
+		t.Fatalf("create league: %v", err)
+	}
+	if err := st.StartLeagueRound(ctx, league.ID, 1, [][2]string{{a, b}, {c, ""}}); err != nil {
+		t.Fatalf("start round: %v", err)
+	}
+	if err := st.StartLeagueRound(ctx, league.ID, 1, [][2]string{{a, b}, {c, ""}}); !errors.Is(err, ErrLeagueRoundStarted) {
+		t.Fatalf("expected round already started, got %v", err)
+	}
+	if err := st.StartLeagueRound(ctx, league.ID, 2, [][2]string{{a, c}, {b, ""}}); !errors.Is(err, ErrLeagueRoundStarted) {
+		t.Fatalf("expected round 1 still open, got %v", err)
+	}
+
+	matches, err := st.ListLeagueMatches(ctx, league.ID)
+	if err != nil || len(matches) != 2 {
+		t.Fatalf("list matches: %v %+v", err, matches)
+	}
+	var match LeagueMatch
+	for _, m := range matches {
+		if m.Status == LeagueMatchPending {
+			match = m
+		}
+	}
+	seats := make([]SeatAssignment, 0, 2)
+	for seat, agentID := range []string{match.AgentAID, match.AgentBID} {
+		sess := AgentSession{ID: NewID(), AgentID: agentID, RoomID: roomID, JoinMode: "league", Status: "waiting", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := st.CreateAgentSession(ctx, sess); err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+		seats = append(seats, SeatAssignment{SessionID: sess.ID, AgentID: agentID, Seat: seat, BuyinCC: 1000})
+	}
+	tableID := NewID()
+	if err := st.StartLeagueMatch(ctx, match.ID, tableID, roomID, 10, 20, seats); err != nil {
+		t.Fatalf("start match: %v", err)
+	}
+	if err := st.StartLeagueMatch(ctx, match.ID, NewID(), roomID, 10, 20, seats); err == nil {
+		t.Fatalf("expected a started match not to start again")
+	}
+	if err := st.FinishLeagueMatch(ctx, match.ID, match.AgentAID); err != nil {
+		t.Fatalf("finish match: %v", err)
+	}
+	for _, agentID := range []string{a, b} {
+		if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 10000 {
+			t.Fatalf("expected buy-in cashed out for %s, balance=%d", agentID, bal)
+		}
+	}
+
+	standings, err := st.ListLeagueStandings(ctx, league.ID)
+	if err != nil || len(standings) != 3 {
+		t.Fatalf("list standings: %v %+v", err, standings)
+	}
+	byAgent := map[string]LeagueStanding{}
+	for _, s := range standings {
+		byAgent[s.AgentID] = s
+	}
+	if s := byAgent[match.AgentBID]; s.Points != LeaguePointsWin || s.Wins != 1 || s.Played != 1 {
+		t.Fatalf("expected forfeit win for %s, got %+v", match.AgentBID, s)
+	}
+	if s := byAgent[match.AgentAID]; s.Points != 0 || s.Losses != 1 {
+		t.Fatalf("expected forfeit loss for %s, got %+v", match.AgentAID, s)
+	}
+	if s := byAgent[c]; s.Points != LeaguePointsWin || s.Byes != 1 || s.Played != 0 {
+		t.Fatalf("expected bye for %s, got %+v", c, s)
+	}
+	if err := st.StartLeagueRound(ctx, league.ID, 2, [][2]string{{a, c}, {b, ""}}); err != nil {
+		t.Fatalf("start round 2: %v", err)
+	}
+}
@@ -23,19 +23,97 @@ type Table struct {
 	Status       string
 	SmallBlindCC int64
 	BigBlindCC   int64
+	TournamentID string
 	CreatedAt    time.Time
 }
 
 type Room struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	MinBuyinCC   int64     `json:"min_buyin_cc"`
-	SmallBlindCC int64     `json:"small_blind_cc"`
-	BigBlindCC   int64     `json:"big_blind_cc"`
-	Status       string    `json:"status"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	MinBuyinCC        int64     `json:"min_buyin_cc"`
+	SmallBlindCC      int64     `json:"small_blind_cc"`
+	BigBlindCC        int64     `json:"big_blind_cc"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+	MaxSeats          int       `json:"max_seats"`
+	MinPlayers        int       `json:"min_players"`
+	MaxBuyinCC        int64     `json:"max_buyin_cc"`
+	RakeBps           int       `json:"rake_bps"`
+	RakeCapCC         int64     `json:"rake_cap_cc"`
+	RakeNoFlopNoDrop  bool      `json:"rake_no_flop_no_drop"`
+	DeckSeedSchedule  string    `json:"deck_seed_schedule"`
+	MatchFormat       string    `json:"match_format"`
+	DuplicateHands    int       `json:"duplicate_hands"`
+	BettingStructure  string    `json:"betting_structure"`
+	RaiseCap          int       `json:"raise_cap"`
+	Variant           string    `json:"variant"`
+	AnteCC            int64     `json:"ante_cc"`
+	BigBlindAnte      bool      `json:"big_blind_ante"`
+	Straddle          bool      `json:"straddle"`
+	BlindLevelHands   int       `json:"blind_level_hands"`
+	BlindLevelMinutes int       `json:"blind_level_minutes"`
+	EntryFeeCC        int64     `json:"entry_fee_cc"`
+	StartingChips     int64     `json:"starting_chips"`
 }
 
+// BlindLevel is a step of a room's blind schedule. Level 0 is the room's own
+// blinds and ante; stored levels start at 1.
+type BlindLevel struct {
+	Level        int   `json:"level"`
+	SmallBlindCC int64 `json:"small_blind_cc"`
+	BigBlindCC   int64 `json:"big_blind_cc"`
+	AnteCC       int64 `json:"ante_cc"`
+}
+
+const (
+	DefaultMaxSeats   = 2
+	DefaultMinPlayers = 2
+	MaxTableSeats     = 9
+
+	// DefaultMaxBuyinBigBlinds sizes the buy-in cap of rooms created without
+	// an explicit max_buyin_cc.
+	DefaultMaxBuyinBigBlinds = 100
+
+	// HouseAccountID is the agent account credited with rake.
+	HouseAccountID = "house"
+
+	// MatchFormatStandard rooms seat agents at tables that run until a
+	// player leaves. MatchFormatDuplicate rooms pair two agents for a
+	// duplicate match: DuplicateHands hands on one table, then the same
+	// decks again on a second table with the seats swapped.
+	// MatchFormatSitAndGo rooms fill a table, charge every entrant
+	// EntryFeeCC into a prize pool and play tournament chips until one
+	// player is left. MatchFormatMultiTable rooms hold the table size and
+	// blind schedule of the multi-table tournaments scheduled in them, and
+	// MatchFormatLeague rooms the heads-up tables of league matches.
+	MatchFormatStandard   = "standard"
+	MatchFormatDuplicate  = "duplicate"
+	MatchFormatSitAndGo   = "sit_and_go"
+	MatchFormatMultiTable = "multi_table"
+	MatchFormatLeague     = "league"
+
+	// DefaultDuplicateHands is the leg length of duplicate rooms created
+	// without an explicit duplicate_hands.
+	DefaultDuplicateHands = 100
+
+	// Betting structures of a room. Fixed-limit bets and raises are one
+	// big blind on the preflop and flop and two on the turn and river, at
+	// most RaiseCap of them per street.
+	BettingNoLimit    = "no_limit"
+	BettingPotLimit   = "pot_limit"
+	BettingFixedLimit = "fixed_limit"
+
+	// DefaultRaiseCap allows a bet and three raises per fixed-limit street.
+	DefaultRaiseCap = 4
+
+	// Game variants a room can deal. Omaha deals four hole cards, exactly
+	// two of which make the showdown hand; short deck plays Hold'em with
+	// sixes through aces.
+	VariantHoldem    = "holdem"
+	VariantOmaha     = "omaha"
+	VariantShortDeck = "short_deck"
+)
+
 type Hand struct {
 	ID            string
 	TableID       string
@@ -44,6 +122,285 @@ type Hand struct {
 	StreetEnd     string
 	StartedAt     time.Time
 	EndedAt       *time.Time
+	// BigBlindCC is the big blind the hand was dealt at, which blind levels
+	// raise above the table's.
+	BigBlindCC int64
+}
+
+// HandSettlement is everything a finished hand writes: the pot shares paid
+// into table stacks, the rake, the hand summary and the replay events that
+// describe the result. Store.SettleHand commits it in one transaction.
+type HandSettlement struct {
+	TableID       string
+	HandID        string
+	Credits       []PotCredit
+	RakeCC        int64
+	WinnerAgentID string
+	PotCC         int64
+	StreetEnd     string
+	Events        []TableReplayEvent
+}
+
+type PotCredit struct {
+	AgentID string
+	Amount  int64
+}
+
+// RecoveryReport summarizes the tables cleaned up after a restart.
+type RecoveryReport struct {
+	Tables         int   `json:"tables"`
+	VoidedHands    int   `json:"voided_hands"`
+	RefundedCC     int64 `json:"refunded_cc"`
+	CashedOutCC    int64 `json:"cashed_out_cc"`
+	AbortedMatches int   `json:"aborted_matches"`
+	// AbortedTournaments were running and refunded their entry fees.
+	AbortedTournaments int `json:"aborted_tournaments"`
+	// RequeuedLeagueMatches were running and will be played again.
+	RequeuedLeagueMatches int `json:"requeued_league_matches"`
+	// ClosedInvites are pending challenges and open private tables whose
+	// sessions did not survive the restart.
+	ClosedInvites int `json:"closed_invites"`
+}
+
+const (
+	DuplicateMatchRunning   = "running"
+	DuplicateMatchCompleted = "completed"
+	DuplicateMatchAborted   = "aborted"
+)
+
+// DuplicateMatch is one pair of agents playing the same decks twice. Leg 1
+// seats AgentAID at seat 0 of Leg1TableID; leg 2 swaps the seats on
+// Leg2TableID. Both legs deal hand N from ScheduledSeed(SeedSchedule, N).
+type DuplicateMatch struct {
+	ID           string     `json:"match_id"`
+	RoomID       string     `json:"room_id"`
+	AgentAID     string     `json:"agent_a_id"`
+	AgentBID     string     `json:"agent_b_id"`
+	Leg1TableID  string     `json:"leg1_table_id"`
+	Leg2TableID  string     `json:"leg2_table_id"`
+	SeedSchedule string     `json:"-"`
+	HandsPerLeg  int        `json:"hands_per_leg"`
+	BuyinCC      int64      `json:"buyin_cc"`
+	CurrentLeg   int        `json:"current_leg"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EndedAt      *time.Time `json:"ended_at,omitempty"`
+}
+
+// DuplicateMatchResult is one agent's result in a completed match. NetCC is
+// the sum of both legs.
+type DuplicateMatchResult struct {
+	MatchID     string `json:"match_id"`
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Leg1NetCC   int64  `json:"leg1_net_cc"`
+	Leg2NetCC   int64  `json:"leg2_net_cc"`
+	NetCC       int64  `json:"net_cc"`
+	HandsPlayed int    `json:"hands_played"`
+}
+
+type DuplicateStanding struct {
+	AgentID     string
+	Name        string
+	Matches     int
+	Wins        int
+	NetCC       int64
+	HandsPlayed int
+	BBPer100    float64
+}
+
+const (
+	TournamentScheduled = "scheduled"
+	TournamentRunning   = "running"
+	TournamentCompleted = "completed"
+	TournamentAborted   = "aborted"
+)
+
+// Tournament is a sit-and-go or a multi-table tournament. Every entrant
+// paid EntryFeeCC into PrizePoolCC and started with StartingChips
+// tournament chips, which are never worth CC. PayoutBps splits the pool by
+// finishing place; a multi-table tournament sets it once late registration
+// has closed.
+//
+// The remaining fields only apply to multi-table tournaments, which start
+// at StartsAt with at least MinPlayers and at most MaxPlayers entrants.
+// Registration stays open until blind level LateRegLevels. Before level
+// RebuyLevels an entrant may rebuy up to MaxRebuys times for the entry fee
+// and the starting chips; AddonChips are sold once for AddonFeeCC during
+// the last rebuy level.
+type Tournament struct {
+	ID            string     `json:"tournament_id"`
+	RoomID        string     `json:"room_id"`
+	Name          string     `json:"name,omitempty"`
+	Status        string     `json:"status"`
+	MaxPlayers    int        `json:"max_players"`
+	EntryFeeCC    int64      `json:"entry_fee_cc"`
+	StartingChips int64      `json:"starting_chips"`
+	PrizePoolCC   int64      `json:"prize_pool_cc"`
+	PayoutBps     []int      `json:"payout_bps"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	MinPlayers    int        `json:"min_players"`
+	LateRegLevels int        `json:"late_reg_levels"`
+	MaxRebuys     int        `json:"max_rebuys"`
+	RebuyLevels   int        `json:"rebuy_levels"`
+	AddonFeeCC    int64      `json:"addon_fee_cc"`
+	AddonChips    int64      `json:"addon_chips"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+}
+
+// MultiTable reports whether t is a scheduled multi-table tournament
+// rather than a sit-and-go.
+func (t Tournament) MultiTable() bool {
+	return t.StartsAt != nil
+}
+
+// TournamentEntry is one entrant. TableID and SeatID are where the agent
+// sits now, or sat when it was eliminated. FinishPlace is set when the
+// agent is eliminated, or for every entrant once the tournament completes.
+type TournamentEntry struct {
+	TournamentID string     `json:"tournament_id"`
+	AgentID      string     `json:"agent_id"`
+	AgentName    string     `json:"agent_name"`
+	TableID      string     `json:"table_id,omitempty"`
+	SeatID       *int       `json:"seat_id,omitempty"`
+	FinishPlace  *int       `json:"finish_place,omitempty"`
+	PayoutCC     int64      `json:"payout_cc"`
+	Rebuys       int        `json:"rebuys"`
+	Addon        bool       `json:"addon"`
+	EliminatedAt *time.Time `json:"eliminated_at,omitempty"`
+}
+
+// TournamentResult is the final place of an entrant and the CC it is paid.
+type TournamentResult struct {
+	AgentID  string
+	Place    int
+	PayoutCC int64
+}
+
+const (
+	LeagueRoundRobin = "round_robin"
+	LeagueSwiss      = "swiss"
+
+	LeagueRunning   = "running"
+	LeagueCompleted = "completed"
+
+	LeagueMatchPending   = "pending"
+	LeagueMatchRunning   = "running"
+	LeagueMatchCompleted = "completed"
+	LeagueMatchBye       = "bye"
+
+	// A won match, or a bye, scores LeaguePointsWin and a drawn one
+	// LeaguePointsDraw.
+	LeaguePointsWin  = 3
+	LeaguePointsDraw = 1
+)
+
+// League is a round robin or Swiss league between a fixed set of agents.
+// Every round pairs them into heads-up matches of HandsPerMatch hands on
+// the tables of RoomID. CurrentRound is 0 until the first round is paired.
+type League struct {
+	ID            string     `json:"league_id"`
+	RoomID        string     `json:"room_id"`
+	Name          string     `json:"name"`
+	Format        string     `json:"format"`
+	HandsPerMatch int        `json:"hands_per_match"`
+	Rounds        int        `json:"rounds"`
+	CurrentRound  int        `json:"current_round"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+}
+
+// LeagueMatch is one pairing of a league round. AgentBID is empty for a
+// bye. A completed match is won by WinnerAgentID, or drawn when it is
+// empty; ForfeitAgentID left the match before its last hand.
+type LeagueMatch struct {
+	ID             string     `json:"match_id"`
+	LeagueID       string     `json:"league_id"`
+	Round          int        `json:"round"`
+	AgentAID       string     `json:"agent_a_id"`
+	AgentAName     string     `json:"agent_a_name"`
+	AgentBID       string     `json:"agent_b_id,omitempty"`
+	AgentBName     string     `json:"agent_b_name,omitempty"`
+	TableID        string     `json:"table_id,omitempty"`
+	Status         string     `json:"status"`
+	WinnerAgentID  string     `json:"winner_agent_id,omitempty"`
+	ForfeitAgentID string     `json:"forfeit_agent_id,omitempty"`
+	AgentANetCC    int64      `json:"agent_a_net_cc"`
+	AgentBNetCC    int64      `json:"agent_b_net_cc"`
+	HandsPlayed    int        `json:"hands_played"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+}
+
+// LeagueStanding is a participant's row of the league table.
+type LeagueStanding struct {
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Seed        int    `json:"seed"`
+	Points      int    `json:"points"`
+	Played      int    `json:"played"`
+	Wins        int    `json:"wins"`
+	Draws       int    `json:"draws"`
+	Losses      int    `json:"losses"`
+	Byes        int    `json:"byes"`
+	NetCC       int64  `json:"net_cc"`
+	HandsPlayed int    `json:"hands_played"`
+}
+
+const (
+	ChallengePending   = "pending"
+	ChallengeAccepted  = "accepted"
+	ChallengeDeclined  = "declined"
+	ChallengeExpired   = "expired"
+	ChallengeCancelled = "cancelled"
+
+	PrivateTableOpen   = "open"
+	PrivateTableClosed = "closed"
+)
+
+// Challenge invites OpponentAgentID to a heads-up table in RoomID with the
+// challenger, who waits at it until the challenge is answered or expires.
+type Challenge struct {
+	ID                string     `json:"challenge_id"`
+	RoomID            string     `json:"room_id"`
+	ChallengerAgentID string     `json:"challenger_agent_id"`
+	ChallengerName    string     `json:"challenger_name,omitempty"`
+	OpponentAgentID   string     `json:"opponent_agent_id"`
+	OpponentName      string     `json:"opponent_name,omitempty"`
+	Status            string     `json:"status"`
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	RespondedAt       *time.Time `json:"responded_at,omitempty"`
+}
+
+// PrivateTable is a table in RoomID that only its creator and
+// InvitedAgentIDs may join with the invite code, or anyone holding the code
+// when InvitedAgentIDs is empty. TableID is set once the table starts.
+type PrivateTable struct {
+	InviteCode      string
+	RoomID          string
+	CreatorAgentID  string
+	InvitedAgentIDs []string
+	TableID         string
+	Status          string
+	CreatedAt       time.Time
+	ClosedAt        *time.Time
+}
+
+// Invited reports whether agentID may take a seat at the table.
+func (p PrivateTable) Invited(agentID string) bool {
+	if len(p.InvitedAgentIDs) == 0 || agentID == p.CreatorAgentID {
+		return true
+	}
+	for _, id := range p.InvitedAgentIDs {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
 }
 
 type Action struct {
@@ -65,7 +422,17 @@ type LedgerEntry struct {
 	CreatedAt time.Time
 }
 
+type RakeReportItem struct {
+	RoomID     string `json:"room_id"`
+	RoomName   string `json:"room_name"`
+	HandsRaked int64  `json:"hands_raked"`
+	RakeCC     int64  `json:"rake_cc"`
+}
+
+// LeaderboardEntry is an agent's place on the leaderboard. SortKey is the
+// value it is ranked by, exactly, to continue the ranking after it.
 type LeaderboardEntry struct {
+	Rank             int
 	AgentID          string
 	Name             string
 	Score            float64
@@ -75,6 +442,10 @@ type LeaderboardEntry struct {
 	WinRate          float64
 	ConfidenceFactor float64
 	LastActiveAt     time.Time
+	Rating           float64
+	RatingDeviation  float64
+	BBPerHandStdDev  float64
+	SortKey          string
 }
 
 type AgentPerformance struct {
@@ -86,6 +457,34 @@ type AgentPerformance struct {
 	WinRate          float64
 	ConfidenceFactor float64
 	LastActiveAt     *time.Time
+	BBPerHandStdDev  float64
+}
+
+// SharedHands are the hands two agents both played: how many, and the
+// sample covariance of their per-hand results in big blinds over them.
+type SharedHands struct {
+	Hands        int
+	BBCovariance float64
+}
+
+// HUDCounts are the tallies behind an agent's HUD stats over the hands it
+// acted in: the hands it put chips in voluntarily (VPIP) or raised
+// preflop (PFR), its 3-bet and flop continuation bet chances and takes, its
+// postflop raises and calls, and the flops it saw and the showdowns it
+// went to and won.
+type HUDCounts struct {
+	Hands                 int
+	VPIPHands             int
+	PFRHands              int
+	ThreeBetOpportunities int
+	ThreeBets             int
+	CBetOpportunities     int
+	CBets                 int
+	PostflopRaises        int
+	PostflopCalls         int
+	SawFlop               int
+	Showdowns             int
+	ShowdownsWon          int
 }
 
 type ProxyCall struct {
@@ -171,6 +570,88 @@ type AgentEventOffset struct {
 	UpdatedAt   time.Time
 }
 
+const (
+	RatingSourceTable          = "table"
+	RatingSourceDuplicateMatch = "duplicate_match"
+	RatingSourceTournament     = "tournament"
+)
+
+// AgentRating is an agent's current Glicko-2 rating and the number of
+// rated tables, matches and tournaments behind it.
+type AgentRating struct {
+	AgentID    string
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+	Games      int
+	UpdatedAt  time.Time
+}
+
+// RatingChange is the rating an agent left a rated table, duplicate match
+// or tournament (SourceType and SourceID) with, played against Opponents
+// other agents.
+type RatingChange struct {
+	AgentID      string
+	SourceType   string
+	SourceID     string
+	Opponents    int
+	RatingBefore float64
+	Rating       float64
+	Deviation    float64
+	Volatility   float64
+	CreatedAt    time.Time
+}
+
+const (
+	SeasonOpen   = "open"
+	SeasonClosed = "closed"
+)
+
+// Season is an admin-defined leaderboard season over the hands that ended
+// in [StartsAt, EndsAt). It stays open until its final standings are
+// archived; Entrants is the number of agents ranked in them.
+type Season struct {
+	ID        string
+	Name      string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Status    string
+	Entrants  int
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+// SeasonStanding is an agent's archived final standing in a season, ranked
+// by leaderboard score. Rating is its rating when the season closed.
+type SeasonStanding struct {
+	SeasonID      string
+	AgentID       string
+	Rank          int
+	AgentName     string
+	Score         float64
+	BBPer100      float64
+	NetCCFromPlay int64
+	HandsPlayed   int
+	WinRate       float64
+	Rating        float64
+}
+
+// SeasonFinish is where an agent finished a closed season.
+type SeasonFinish struct {
+	SeasonID      string
+	SeasonName    string
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Entrants      int
+	Rank          int
+	Score         float64
+	BBPer100      float64
+	NetCCFromPlay int64
+	HandsPlayed   int
+	WinRate       float64
+	Rating        float64
+}
+
 type TableReplayEvent struct {
 	ID           string
 	TableID      string
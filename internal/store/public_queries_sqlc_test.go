@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 )
@@ -33,9 +34,12 @@ func TestPublicLeaderboardExcludesTopupAndRanksByPlayResults(t *testing.T) {
 		}
 	}
 
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
 	lb, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "all",
-		SortBy:    "score",
+		RoomID: "all",
+		SortBy: "score",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list leaderboard: %v", err)
@@ -54,7 +58,7 @@ func TestPublicLeaderboardExcludesTopupAndRanksByPlayResults(t *testing.T) {
 	}
 }
 
-func TestPublicLeaderboardRespectsRoomScope(t *testing.T) {
+func TestPublicLeaderboardRespectsRoomID(t *testing.T) {
 	st, ctx, cleanup := openStore(t)
 	defer cleanup()
 
@@ -84,14 +88,17 @@ func TestPublicLeaderboardRespectsRoomScope(t *testing.T) {
 		}
 	}
 	for i := 0; i < 3; i++ {
-		if err := recordSettledHand(t, st, ctx, midTableID, a2, a1, 200); err != nil {
+		if err := recordSettledHandAt(t, st, ctx, midTableID, 200, a2, a1, 200); err != nil {
 			t.Fatalf("record mid hand %d: %v", i, err)
 		}
 	}
 
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
 	lbLow, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "low",
-		SortBy:    "score",
+		RoomID: lowRoomID,
+		SortBy: "score",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list low leaderboard: %v", err)
@@ -101,8 +108,8 @@ func TestPublicLeaderboardRespectsRoomScope(t *testing.T) {
 	}
 
 	lbMid, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "mid",
-		SortBy:    "score",
+		RoomID: midRoomID,
+		SortBy: "score",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list mid leaderboard: %v", err)
@@ -113,7 +120,7 @@ func TestPublicLeaderboardRespectsRoomScope(t *testing.T) {
 
 }
 
-func TestPublicLeaderboardRespectsWindowStart(t *testing.T) {
+func TestPublicLeaderboardRespectsWindow(t *testing.T) {
 	st, ctx, cleanup := openStore(t)
 	defer cleanup()
 
@@ -134,27 +141,42 @@ func TestPublicLeaderboardRespectsWindowStart(t *testing.T) {
 	}
 
 	future := time.Now().UTC().Add(1 * time.Hour)
-	lbFuture, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		WindowStart: &future,
-		RoomScope:   "all",
-		SortBy:      "score",
-	}, 10, 0)
+	lbFuture, err := st.ListLeaderboardSince(ctx, future, 10, 0)
 	if err != nil {
 		t.Fatalf("list future-scoped leaderboard: %v", err)
 	}
 	if len(lbFuture) != 0 {
-		t.Fatalf("expected no rows for future window_start, got %d", len(lbFuture))
+		t.Fatalf("expected no rows for a future start, got %d", len(lbFuture))
+	}
+	past := time.Now().UTC().Add(-2 * time.Hour)
+	lbSince, err := st.ListLeaderboardSince(ctx, past, 10, 0)
+	if err != nil {
+		t.Fatalf("list leaderboard since: %v", err)
+	}
+	if len(lbSince) != 2 || lbSince[0].AgentID != a1 {
+		t.Fatalf("expected %s first of 2 since %v, got %+v", a1, past, lbSince)
+	}
+
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
+	lb7d, err := st.ListLeaderboard(ctx, LeaderboardFilter{Window: "7d"}, 10, 0)
+	if err != nil {
+		t.Fatalf("list 7d leaderboard: %v", err)
+	}
+	if len(lb7d) != 2 {
+		t.Fatalf("expected 2 rows in the 7d window, got %d", len(lb7d))
 	}
 
 	lbAll, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "all",
-		SortBy:    "score",
+		RoomID: "all",
+		SortBy: "score",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list unscoped leaderboard: %v", err)
 	}
 	if len(lbAll) == 0 {
-		t.Fatal("expected rows when window_start is nil")
+		t.Fatal("expected rows in the all window")
 	}
 }
 
@@ -192,9 +214,12 @@ func TestPublicLeaderboardSortModes(t *testing.T) {
 		}
 	}
 
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
 	lbNet, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "all",
-		SortBy:    "net_cc_from_play",
+		RoomID: "all",
+		SortBy: "net_cc_from_play",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list net leaderboard: %v", err)
@@ -204,8 +229,8 @@ func TestPublicLeaderboardSortModes(t *testing.T) {
 	}
 
 	lbHands, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "all",
-		SortBy:    "hands_played",
+		RoomID: "all",
+		SortBy: "hands_played",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list hands leaderboard: %v", err)
@@ -215,8 +240,8 @@ func TestPublicLeaderboardSortModes(t *testing.T) {
 	}
 
 	lbWinRate, err := st.ListLeaderboard(ctx, LeaderboardFilter{
-		RoomScope: "all",
-		SortBy:    "win_rate",
+		RoomID: "all",
+		SortBy: "win_rate",
 	}, 10, 0)
 	if err != nil {
 		t.Fatalf("list win_rate leaderboard: %v", err)
@@ -226,6 +251,74 @@ func TestPublicLeaderboardSortModes(t *testing.T) {
 	}
 }
 
+func TestPublicLeaderboardKeysetPagination(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	roomID, err := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	loser := mustCreateAgent(t, st, ctx, "Loser", "key-loser", 1000000)
+	for i, name := range []string{"A", "B", "C", "D"} {
+		winner := mustCreateAgent(t, st, ctx, name, "key-"+name, 1000)
+		// Winners tie on net chips in pairs so the agent ID breaks the tie.
+		if err := recordSettledHand(t, st, ctx, tableID, winner, loser, int64(100*(i/2+1))); err != nil {
+			t.Fatalf("record hand %d: %v", i, err)
+		}
+	}
+
+	f := LeaderboardFilter{RoomID: "all", SortBy: "net_cc_from_play"}
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
+	total, err := st.CountLeaderboard(ctx, f)
+	if err != nil {
+		t.Fatalf("count leaderboard: %v", err)
+	}
+	if total != 5 {
+		t.Fatalf("expected 5 ranked agents, got %d", total)
+	}
+	all, err := st.ListLeaderboard(ctx, f, 10, 0)
+	if err != nil {
+		t.Fatalf("list leaderboard: %v", err)
+	}
+
+	byRank, err := st.ListLeaderboard(ctx, f, 2, 2)
+	if err != nil {
+		t.Fatalf("list page by offset: %v", err)
+	}
+	if len(byRank) != 2 || byRank[0].AgentID != all[2].AgentID || byRank[0].Rank != 3 {
+		t.Fatalf("expected the page at offset 2 to start at rank 3, got %+v", byRank)
+	}
+
+	var paged []LeaderboardEntry
+	for {
+		page, err := st.ListLeaderboard(ctx, f, 2, 0)
+		if err != nil {
+			t.Fatalf("list page: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		paged = append(paged, page...)
+		last := page[len(page)-1]
+		f.AfterSortKey, f.AfterAgentID = last.SortKey, last.AgentID
+	}
+	if len(paged) != len(all) {
+		t.Fatalf("expected %d paged entries, got %d", len(all), len(paged))
+	}
+	for i := range all {
+		if paged[i].AgentID != all[i].AgentID || paged[i].Rank != i+1 {
+			t.Fatalf("entry %d: got %s rank %d, want %s rank %d", i, paged[i].AgentID, paged[i].Rank, all[i].AgentID, i+1)
+		}
+	}
+}
+
 func TestTableHistoryIncludesHumanizedFieldsAndCount(t *testing.T) {
 	st, ctx, cleanup := openStore(t)
 	defer cleanup()
@@ -266,7 +359,7 @@ func TestTableHistoryIncludesHumanizedFieldsAndCount(t *testing.T) {
 	}
 
 	for i := 0; i < 3; i++ {
-		if err := recordSettledHand(t, st, ctx, tableID, a1, a2, 100); err != nil {
+		if err := recordSettledHandAt(t, st, ctx, tableID, 200, a1, a2, 100); err != nil {
 			t.Fatalf("record hand %d: %v", i, err)
 		}
 	}
@@ -346,10 +439,105 @@ func TestGetAgentPerformanceByWindowAndAgent(t *testing.T) {
 	}
 }
 
+func TestBBFiguresUseEachHandsBigBlind(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	winner := mustCreateAgent(t, st, ctx, "Winner", "key-winner", 200000)
+	loser := mustCreateAgent(t, st, ctx, "Loser", "key-loser", 200000)
+
+	roomID, err := st.CreateRoom(ctx, "Levels", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	// One big blind won at the opening level and one after the blinds
+	// went up to 200/400: two big blinds over two hands.
+	if err := recordSettledHandAt(t, st, ctx, tableID, 100, winner, loser, 100); err != nil {
+		t.Fatalf("record level 1 hand: %v", err)
+	}
+	if err := recordSettledHandAt(t, st, ctx, tableID, 400, winner, loser, 400); err != nil {
+		t.Fatalf("record level 2 hand: %v", err)
+	}
+
+	perf, err := st.GetAgentPerformanceByWindowAndAgent(ctx, winner, nil)
+	if err != nil {
+		t.Fatalf("performance: %v", err)
+	}
+	if perf.BBPer100 != 100 || perf.BBPerHandStdDev != 0 {
+		t.Fatalf("expected 100 bb/100 with no spread, got %v bb/100 stddev %v", perf.BBPer100, perf.BBPerHandStdDev)
+	}
+
+	if err := st.RefreshLeaderboard(ctx, time.Now()); err != nil {
+		t.Fatalf("refresh leaderboard: %v", err)
+	}
+	lb, err := st.ListLeaderboard(ctx, LeaderboardFilter{RoomID: roomID, SortBy: "net_cc_from_play"}, 10, 0)
+	if err != nil {
+		t.Fatalf("list leaderboard: %v", err)
+	}
+	if len(lb) != 2 || lb[0].AgentID != winner || lb[0].BBPer100 != 100 || lb[0].BBPerHandStdDev != 0 {
+		t.Fatalf("expected the winner at 100 bb/100 with no spread, got %+v", lb)
+	}
+}
+
+func TestGetSharedHands(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a := mustCreateAgent(t, st, ctx, "Shared A", "key-shared-a", 200000)
+	b := mustCreateAgent(t, st, ctx, "Shared B", "key-shared-b", 200000)
+	c := mustCreateAgent(t, st, ctx, "Shared C", "key-shared-c", 200000)
+
+	roomID, err := st.CreateRoom(ctx, "Shared", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	// Two heads-up hands between a and b, where one's result is the
+	// other's negated, and one hand of a against c.
+	if err := recordSettledHand(t, st, ctx, tableID, a, b, 100); err != nil {
+		t.Fatalf("record hand 1: %v", err)
+	}
+	if err := recordSettledHand(t, st, ctx, tableID, b, a, 300); err != nil {
+		t.Fatalf("record hand 2: %v", err)
+	}
+	if err := recordSettledHand(t, st, ctx, tableID, a, c, 100); err != nil {
+		t.Fatalf("record hand 3: %v", err)
+	}
+
+	shared, err := st.GetSharedHands(ctx, a, b, nil)
+	if err != nil {
+		t.Fatalf("shared hands: %v", err)
+	}
+	// a's results are +1 and -3 bb, b's -1 and +3: a covariance of -8.
+	if shared.Hands != 2 || math.Abs(shared.BBCovariance-(-8)) > 1e-9 {
+		t.Fatalf("expected 2 shared hands with covariance -8, got %+v", shared)
+	}
+	shared, err = st.GetSharedHands(ctx, b, c, nil)
+	if err != nil {
+		t.Fatalf("shared hands: %v", err)
+	}
+	if shared.Hands != 0 || shared.BBCovariance != 0 {
+		t.Fatalf("expected no shared hands, got %+v", shared)
+	}
+}
+
 func recordSettledHand(t *testing.T, st *Store, ctx context.Context, tableID, winnerID, loserID string, amount int64) error {
 	t.Helper()
+	return recordSettledHandAt(t, st, ctx, tableID, 100, winnerID, loserID, amount)
+}
+
+// recordSettledHandAt records a hand of tableID dealt at big blind bigBlind.
+func recordSettledHandAt(t *testing.T, st *Store, ctx context.Context, tableID string, bigBlind int64, winnerID, loserID string, amount int64) error {
+	t.Helper()
 
-	handID, err := st.CreateHand(ctx, tableID)
+	handID, err := st.CreateHand(ctx, tableID, bigBlind)
 	if err != nil {
 		return err
 	}
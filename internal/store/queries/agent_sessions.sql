@@ -70,3 +70,9 @@ WHERE session_id = $1;
 -- name: CountAgentSessions :one
 SELECT COUNT(*)::int
 FROM agent_sessions;
+
+-- name: ListRecentOpponentIDs :many
+SELECT DISTINCT o.agent_id
+FROM agent_sessions s
+JOIN agent_sessions o ON o.table_id = s.table_id AND o.agent_id <> s.agent_id
+WHERE s.agent_id = sqlc.arg(agent_id) AND s.table_id IS NOT NULL AND s.created_at >= sqlc.arg(since);
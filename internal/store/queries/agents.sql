@@ -69,3 +69,13 @@ SELECT status
 FROM agent_key_attempts
 WHERE agent_id = $1
 ORDER BY created_at DESC;
+
+-- name: GetAgentOwnerID :one
+SELECT COALESCE(owner_id, '')::text AS owner_id
+FROM agents
+WHERE id = $1;
+
+-- name: SetAgentOwnerID :execrows
+UPDATE agents
+SET owner_id = NULLIF(sqlc.arg(owner_id)::text, ''), updated_at = now()
+WHERE id = sqlc.arg(id);
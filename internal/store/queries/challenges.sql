@@ -0,0 +1,39 @@
+-- name: InsertChallenge :exec
+INSERT INTO challenges (id, room_id, challenger_agent_id, opponent_agent_id, expires_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetChallengeByID :one
+SELECT id, room_id, challenger_agent_id, opponent_agent_id, status, created_at, expires_at, responded_at
+FROM challenges
+WHERE id = $1;
+
+-- name: ListPendingChallengesByAgent :many
+SELECT c.id, c.room_id, c.challenger_agent_id, a.name AS challenger_name, c.opponent_agent_id, b.name AS opponent_name,
+  c.status, c.created_at, c.expires_at, c.responded_at
+FROM challenges c
+JOIN agents a ON a.id = c.challenger_agent_id
+JOIN agents b ON b.id = c.opponent_agent_id
+WHERE c.status = 'pending' AND c.expires_at > now()
+  AND (c.challenger_agent_id = $1 OR c.opponent_agent_id = $1)
+ORDER BY c.created_at DESC, c.id DESC;
+
+-- name: RespondChallenge :execrows
+UPDATE challenges
+SET status = $3, responded_at = now()
+WHERE id = $1 AND opponent_agent_id = $2 AND status = 'pending' AND expires_at > now();
+
+-- name: CancelChallenge :execrows
+UPDATE challenges
+SET status = 'cancelled', responded_at = now()
+WHERE id = $1 AND status = 'pending';
+
+-- name: ExpireChallenges :many
+UPDATE challenges
+SET status = 'expired', responded_at = now()
+WHERE status = 'pending' AND expires_at <= $1
+RETURNING id;
+
+-- name: CancelPendingChallenges :execrows
+UPDATE challenges
+SET status = 'cancelled', responded_at = now()
+WHERE status = 'pending';
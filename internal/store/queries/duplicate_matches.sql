@@ -0,0 +1,80 @@
+-- name: InsertDuplicateMatch :exec
+INSERT INTO duplicate_matches (id, room_id, agent_a_id, agent_b_id, leg1_table_id, leg2_table_id, seed_schedule, hands_per_leg, buyin_cc)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetDuplicateMatchByID :one
+SELECT id, room_id, agent_a_id, agent_b_id, leg1_table_id, leg2_table_id, seed_schedule, hands_per_leg, buyin_cc, current_leg, status, created_at, ended_at
+FROM duplicate_matches
+WHERE id = $1;
+
+-- name: GetDuplicateMatchForUpdate :one
+SELECT id, room_id, agent_a_id, agent_b_id, leg1_table_id, leg2_table_id, seed_schedule, hands_per_leg, buyin_cc, current_leg, status, created_at, ended_at
+FROM duplicate_matches
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetDuplicateMatchByTableID :one
+SELECT id, room_id, agent_a_id, agent_b_id, leg1_table_id, leg2_table_id, seed_schedule, hands_per_leg, buyin_cc, current_leg, status, created_at, ended_at
+FROM duplicate_matches
+WHERE leg1_table_id = sqlc.arg(table_id) OR leg2_table_id = sqlc.arg(table_id);
+
+-- name: SetDuplicateMatchLeg :exec
+UPDATE duplicate_matches
+SET current_leg = $2
+WHERE id = $1;
+
+-- name: FinishDuplicateMatch :exec
+UPDATE duplicate_matches
+SET status = $2, ended_at = now()
+WHERE id = $1;
+
+-- name: AbortRunningDuplicateMatches :execrows
+UPDATE duplicate_matches
+SET status = 'aborted', ended_at = now()
+WHERE status = 'running';
+
+-- name: InsertDuplicateMatchResult :exec
+INSERT INTO duplicate_match_results (match_id, agent_id, leg1_net_cc, leg2_net_cc, net_cc, hands_played)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListDuplicateMatches :many
+SELECT id, room_id, agent_a_id, agent_b_id, leg1_table_id, leg2_table_id, seed_schedule, hands_per_leg, buyin_cc, current_leg, status, created_at, ended_at
+FROM duplicate_matches
+WHERE (sqlc.arg(agent_id)::text = '' OR agent_a_id = sqlc.arg(agent_id)::text OR agent_b_id = sqlc.arg(agent_id)::text)
+  AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: ListDuplicateMatchResults :many
+SELECT r.match_id, r.agent_id, a.name AS agent_name, r.leg1_net_cc, r.leg2_net_cc, r.net_cc, r.hands_played
+FROM duplicate_match_results r
+JOIN agents a ON a.id = r.agent_id
+WHERE r.match_id = ANY(sqlc.arg(match_ids)::text[])
+ORDER BY r.match_id ASC, r.net_cc DESC, r.agent_id ASC;
+
+-- name: ListDuplicateStandings :many
+SELECT
+  r.agent_id,
+  a.name,
+  COUNT(*)::int AS matches,
+  SUM(CASE WHEN r.net_cc > 0 THEN 1 ELSE 0 END)::int AS wins,
+  SUM(r.net_cc)::bigint AS net_cc,
+  SUM(r.hands_played)::int AS hands_played,
+  COALESCE(SUM(hb.net_bb) * 100 / NULLIF(SUM(r.hands_played), 0), 0)::float8 AS bb_per_100
+FROM duplicate_match_results r
+JOIN duplicate_matches m ON m.id = r.match_id
+JOIN agents a ON a.id = r.agent_id
+LEFT JOIN LATERAL (
+  SELECT SUM(l.amount_cc::float8 / NULLIF(h.big_blind_cc, 0)) AS net_bb
+  FROM hands h
+  JOIN ledger_entries l ON l.ref_type = 'hand' AND l.ref_id = h.id
+  WHERE h.table_id IN (m.leg1_table_id, m.leg2_table_id)
+    AND h.street_end IS DISTINCT FROM 'voided'
+    AND l.agent_id = r.agent_id
+    AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
+) hb ON true
+WHERE m.status = 'completed'
+  AND (sqlc.arg(room_id)::text = '' OR m.room_id = sqlc.arg(room_id)::text)
+GROUP BY r.agent_id, a.name
+ORDER BY bb_per_100 DESC, matches DESC, r.agent_id ASC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
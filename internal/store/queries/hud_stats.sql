@@ -0,0 +1,99 @@
+-- name: GetAgentHUDCounts :one
+WITH agent_hands AS (
+  SELECT DISTINCT h.id AS hand_id, h.table_id, h.street_end, h.winner_agent_id
+  FROM actions a
+  JOIN hands h ON h.id = a.hand_id
+  WHERE a.agent_id = sqlc.arg(agent_id)::text
+    AND h.ended_at IS NOT NULL
+    AND h.street_end IS DISTINCT FROM 'voided'
+    AND (sqlc.arg(window_start)::timestamptz IS NULL OR h.ended_at >= sqlc.arg(window_start)::timestamptz)
+),
+hand_actions AS (
+  SELECT
+    e.hand_id,
+    e.global_seq,
+    e.actor_agent_id AS agent_id,
+    e.payload->>'action' AS action,
+    COALESCE(e.payload->>'street', (
+      SELECT sa.payload->>'street'
+      FROM table_replay_events sa
+      WHERE sa.table_id = e.table_id
+        AND sa.hand_id = e.hand_id
+        AND sa.event_type = 'street_advanced'
+        AND sa.global_seq < e.global_seq
+      ORDER BY sa.global_seq DESC
+      LIMIT 1
+    ), 'preflop') AS street,
+    COALESCE((e.payload->>'raise')::boolean, e.payload->>'action' IN ('bet', 'raise', 'all_in')) AS aggressive
+  FROM agent_hands ah
+  JOIN table_replay_events e ON e.table_id = ah.table_id AND e.hand_id = ah.hand_id
+  WHERE e.event_type = 'action_applied'
+),
+sequenced AS (
+  SELECT
+    ha.*,
+    COUNT(*) FILTER (WHERE ha.aggressive) OVER (
+      PARTITION BY ha.hand_id, ha.street ORDER BY ha.global_seq
+      ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+    ) AS prior_street_raises,
+    COUNT(*) FILTER (WHERE ha.aggressive) OVER (
+      PARTITION BY ha.hand_id, ha.street, ha.agent_id ORDER BY ha.global_seq
+      ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+    ) AS own_prior_street_raises,
+    ha.agent_id = sqlc.arg(agent_id)::text AS mine
+  FROM hand_actions ha
+),
+preflop_aggressors AS (
+  SELECT DISTINCT ON (s.hand_id) s.hand_id, s.agent_id
+  FROM sequenced s
+  WHERE s.street = 'preflop' AND s.aggressive
+  ORDER BY s.hand_id, s.global_seq DESC
+),
+players AS (
+  SELECT s.hand_id, s.agent_id, BOOL_OR(s.action = 'fold') AS folded
+  FROM sequenced s
+  GROUP BY s.hand_id, s.agent_id
+),
+per_hand AS (
+  SELECT
+    ah.hand_id,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'preflop' AND s.action IN ('call', 'bet', 'raise', 'all_in')), false) AS vpip,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'preflop' AND s.aggressive), false) AS pfr,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'preflop' AND s.prior_street_raises = 1 AND s.own_prior_street_raises = 0), false) AS three_bet_opportunity,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'preflop' AND s.prior_street_raises = 1 AND s.own_prior_street_raises = 0 AND s.aggressive), false) AS three_bet,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'flop' AND s.prior_street_raises = 0 AND pa.agent_id = s.agent_id), false) AS cbet_opportunity,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'flop' AND s.prior_street_raises = 0 AND pa.agent_id = s.agent_id AND s.aggressive), false) AS cbet,
+    COUNT(*) FILTER (WHERE s.mine AND s.street <> 'preflop' AND s.aggressive) AS postflop_raises,
+    COUNT(*) FILTER (WHERE s.mine AND s.street <> 'preflop' AND NOT s.aggressive AND s.action IN ('call', 'all_in')) AS postflop_calls,
+    COALESCE(BOOL_OR(s.mine AND s.street = 'preflop' AND s.action = 'fold'), false) AS folded_preflop,
+    ah.street_end IS DISTINCT FROM 'preflop' AS reached_flop,
+    NOT COALESCE((SELECT p.folded FROM players p WHERE p.hand_id = ah.hand_id AND p.agent_id = sqlc.arg(agent_id)::text), false)
+      AND EXISTS (SELECT 1 FROM players p WHERE p.hand_id = ah.hand_id AND p.agent_id <> sqlc.arg(agent_id)::text AND NOT p.folded) AS showdown,
+    ah.winner_agent_id = sqlc.arg(agent_id)::text OR EXISTS (
+      SELECT 1
+      FROM ledger_entries l
+      WHERE l.ref_type = 'hand'
+        AND l.ref_id = ah.hand_id
+        AND l.agent_id = sqlc.arg(agent_id)::text
+        AND l.type = 'pot_credit'
+        AND l.amount_cc > 0
+    ) AS won
+  FROM agent_hands ah
+  LEFT JOIN sequenced s ON s.hand_id = ah.hand_id
+  LEFT JOIN preflop_aggressors pa ON pa.hand_id = ah.hand_id
+  GROUP BY ah.hand_id, ah.street_end, ah.winner_agent_id
+)
+SELECT
+  COUNT(*)::int AS hands,
+  COUNT(*) FILTER (WHERE ph.vpip)::int AS vpip_hands,
+  COUNT(*) FILTER (WHERE ph.pfr)::int AS pfr_hands,
+  COUNT(*) FILTER (WHERE ph.three_bet_opportunity)::int AS three_bet_opportunities,
+  COUNT(*) FILTER (WHERE ph.three_bet)::int AS three_bets,
+  COUNT(*) FILTER (WHERE ph.cbet_opportunity)::int AS cbet_opportunities,
+  COUNT(*) FILTER (WHERE ph.cbet)::int AS cbets,
+  COALESCE(SUM(ph.postflop_raises), 0)::int AS postflop_raises,
+  COALESCE(SUM(ph.postflop_calls), 0)::int AS postflop_calls,
+  COUNT(*) FILTER (WHERE ph.reached_flop AND NOT ph.folded_preflop)::int AS saw_flop,
+  COUNT(*) FILTER (WHERE ph.reached_flop AND ph.showdown)::int AS showdowns,
+  COUNT(*) FILTER (WHERE ph.reached_flop AND ph.showdown AND ph.won)::int AS showdowns_won
+FROM per_hand ph;
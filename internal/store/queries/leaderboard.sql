@@ -0,0 +1,240 @@
+-- name: AddLeaderboardHand :exec
+INSERT INTO leaderboard_stats (agent_id, room_id, bucket, hands_played, wins, net_cc, net_bb, net_bb_sq, last_active_at)
+SELECT
+  hl.agent_id,
+  t.room_id,
+  date_trunc('hour', h.ended_at),
+  1,
+  CASE WHEN h.winner_agent_id = hl.agent_id THEN 1 ELSE 0 END,
+  hl.hand_net_cc,
+  COALESCE(hl.hand_net_cc::numeric / NULLIF(h.big_blind_cc::numeric, 0), 0),
+  COALESCE(hl.hand_net_cc::numeric / NULLIF(h.big_blind_cc::numeric, 0), 0) ^ 2,
+  h.ended_at
+FROM (
+  SELECT l.agent_id, SUM(l.amount_cc)::bigint AS hand_net_cc
+  FROM ledger_entries l
+  WHERE l.ref_type = 'hand'
+    AND l.ref_id = sqlc.arg(hand_id)::text
+    AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
+  GROUP BY l.agent_id
+) hl
+JOIN hands h ON h.id = sqlc.arg(hand_id)::text
+JOIN tables t ON t.id = h.table_id
+WHERE h.ended_at IS NOT NULL
+  AND h.street_end IS DISTINCT FROM 'voided'
+  AND t.tournament_id IS NULL
+  AND t.room_id IS NOT NULL
+ON CONFLICT (agent_id, room_id, bucket) DO UPDATE
+SET hands_played = leaderboard_stats.hands_played + EXCLUDED.hands_played,
+    wins = leaderboard_stats.wins + EXCLUDED.wins,
+    net_cc = leaderboard_stats.net_cc + EXCLUDED.net_cc,
+    net_bb = leaderboard_stats.net_bb + EXCLUDED.net_bb,
+    net_bb_sq = leaderboard_stats.net_bb_sq + EXCLUDED.net_bb_sq,
+    last_active_at = GREATEST(leaderboard_stats.last_active_at, EXCLUDED.last_active_at);
+
+-- name: CountLeaderboard :one
+SELECT COALESCE(MAX(lr.rank), 0)::int
+FROM leaderboard_rollups lr
+WHERE lr.window_key = sqlc.arg(window_key)::text
+  AND lr.room_id = sqlc.arg(room_id)::text
+  AND lr.sort_by = sqlc.arg(sort_by)::text;
+
+-- name: CountLeaderboardSince :one
+SELECT COUNT(DISTINCT ls.agent_id)::int
+FROM leaderboard_stats ls
+WHERE ls.hands_played > 0
+  AND ls.bucket >= sqlc.arg(window_start)::timestamptz;
+
+-- name: DeleteLeaderboardRollups :exec
+DELETE FROM leaderboard_rollups;
+
+-- name: InsertLeaderboardRollups :execrows
+WITH windows (window_key, window_start) AS (
+  VALUES
+    ('7d', sqlc.arg(start_7d)::timestamptz),
+    ('30d', sqlc.arg(start_30d)::timestamptz),
+    ('all', NULL::timestamptz)
+),
+aggregated AS (
+  SELECT
+    w.window_key,
+    CASE WHEN GROUPING(ls.room_id) = 1 THEN 'all' ELSE ls.room_id END AS room_id,
+    ls.agent_id,
+    SUM(ls.hands_played)::int AS hands_played,
+    SUM(ls.wins)::int AS wins,
+    SUM(ls.net_cc)::bigint AS net_cc_from_play,
+    SUM(ls.net_bb) AS net_bb,
+    SUM(ls.net_bb_sq) AS net_bb_sq,
+    MAX(ls.last_active_at) AS last_active_at
+  FROM windows w
+  JOIN leaderboard_stats ls ON w.window_start IS NULL OR ls.bucket >= w.window_start
+  GROUP BY GROUPING SETS ((w.window_key, ls.agent_id, ls.room_id), (w.window_key, ls.agent_id))
+  HAVING SUM(ls.hands_played) > 0
+),
+scored AS (
+  SELECT
+    agg.window_key,
+    agg.room_id,
+    agg.agent_id,
+    agg.hands_played,
+    agg.net_cc_from_play,
+    agg.last_active_at,
+    (agg.net_bb / agg.hands_played::numeric) * 100 AS bb_per_100,
+    agg.wins::numeric / agg.hands_played::numeric AS win_rate,
+    LEAST(1.0::numeric, agg.hands_played::numeric / 500.0) AS confidence_factor,
+    CASE WHEN agg.hands_played > 1 THEN
+      SQRT(GREATEST((agg.net_bb_sq - agg.net_bb * agg.net_bb / agg.hands_played::numeric) / (agg.hands_played - 1)::numeric, 0))
+    ELSE 0 END AS bb_stddev
+  FROM aggregated agg
+),
+keyed AS (
+  SELECT
+    sc.*,
+    sb.sort_by,
+    sc.bb_per_100 * sc.confidence_factor AS score,
+    COALESCE(ar.rating, 1500)::float8 AS rating,
+    COALESCE(ar.rating_deviation, 350)::float8 AS rating_deviation,
+    CASE sb.sort_by
+      WHEN 'net_cc_from_play' THEN sc.net_cc_from_play::numeric
+      WHEN 'hands_played' THEN sc.hands_played::numeric
+      WHEN 'win_rate' THEN sc.win_rate
+      WHEN 'rating' THEN COALESCE(ar.rating, 1500)::numeric
+      ELSE sc.bb_per_100 * sc.confidence_factor
+    END AS sort_key
+  FROM scored sc
+  CROSS JOIN (VALUES ('score'), ('net_cc_from_play'), ('hands_played'), ('win_rate'), ('rating')) AS sb (sort_by)
+  LEFT JOIN agent_ratings ar ON ar.agent_id = sc.agent_id
+)
+INSERT INTO leaderboard_rollups (
+  window_key, room_id, sort_by, agent_id, rank, sort_key, hands_played, net_cc_from_play,
+  bb_per_100, win_rate, confidence_factor, score, bb_per_hand_stddev, rating, rating_deviation, last_active_at
+)
+SELECT
+  k.window_key,
+  k.room_id,
+  k.sort_by,
+  k.agent_id,
+  ROW_NUMBER() OVER (PARTITION BY k.window_key, k.room_id, k.sort_by ORDER BY k.sort_key DESC, k.agent_id ASC)::int,
+  k.sort_key,
+  k.hands_played,
+  k.net_cc_from_play,
+  k.bb_per_100,
+  k.win_rate,
+  k.confidence_factor,
+  k.score,
+  k.bb_stddev,
+  k.rating,
+  k.rating_deviation,
+  k.last_active_at
+FROM keyed k;
+
+-- name: ListLeaderboardAfter :many
+SELECT
+  lr.agent_id,
+  a.name,
+  lr.bb_per_100::numeric AS bb_per_100,
+  lr.net_cc_from_play,
+  lr.hands_played,
+  lr.win_rate::numeric AS win_rate,
+  lr.confidence_factor::numeric AS confidence_factor,
+  lr.score::numeric AS score,
+  lr.last_active_at,
+  lr.rating,
+  lr.rating_deviation,
+  lr.bb_per_hand_stddev::numeric AS bb_per_hand_stddev,
+  lr.rank,
+  lr.sort_key::text AS sort_key
+FROM leaderboard_rollups lr
+JOIN agents a ON a.id = lr.agent_id
+WHERE lr.window_key = sqlc.arg(window_key)::text
+  AND lr.room_id = sqlc.arg(room_id)::text
+  AND lr.sort_by = sqlc.arg(sort_by)::text
+  AND lr.sort_key <= sqlc.arg(after_sort_key)::numeric
+  AND (lr.sort_key < sqlc.arg(after_sort_key)::numeric OR lr.agent_id > sqlc.arg(after_agent_id)::text)
+ORDER BY lr.sort_key DESC, lr.agent_id ASC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: ListLeaderboardByRank :many
+SELECT
+  lr.agent_id,
+  a.name,
+  lr.bb_per_100::numeric AS bb_per_100,
+  lr.net_cc_from_play,
+  lr.hands_played,
+  lr.win_rate::numeric AS win_rate,
+  lr.confidence_factor::numeric AS confidence_factor,
+  lr.score::numeric AS score,
+  lr.last_active_at,
+  lr.rating,
+  lr.rating_deviation,
+  lr.bb_per_hand_stddev::numeric AS bb_per_hand_stddev,
+  lr.rank,
+  lr.sort_key::text AS sort_key
+FROM leaderboard_rollups lr
+JOIN agents a ON a.id = lr.agent_id
+WHERE lr.window_key = sqlc.arg(window_key)::text
+  AND lr.room_id = sqlc.arg(room_id)::text
+  AND lr.sort_by = sqlc.arg(sort_by)::text
+  AND lr.rank > sqlc.arg(after_rank)::int
+ORDER BY lr.rank ASC
+LIMIT sqlc.arg(limit_rows);
+
+-- name: ListLeaderboardSince :many
+WITH aggregated AS (
+  SELECT
+    ls.agent_id,
+    SUM(ls.hands_played)::int AS hands_played,
+    SUM(ls.wins)::int AS wins,
+    SUM(ls.net_cc)::bigint AS net_cc_from_play,
+    SUM(ls.net_bb) AS net_bb,
+    SUM(ls.net_bb_sq) AS net_bb_sq,
+    MAX(ls.last_active_at) AS last_active_at
+  FROM leaderboard_stats ls
+  WHERE ls.bucket >= sqlc.arg(window_start)::timestamptz
+  GROUP BY ls.agent_id
+  HAVING SUM(ls.hands_played) > 0
+),
+scored AS (
+  SELECT
+    agg.agent_id,
+    agg.hands_played,
+    agg.net_cc_from_play,
+    agg.last_active_at,
+    (agg.net_bb / agg.hands_played::numeric) * 100 AS bb_per_100,
+    agg.wins::numeric / agg.hands_played::numeric AS win_rate,
+    LEAST(1.0::numeric, agg.hands_played::numeric / 500.0) AS confidence_factor,
+    CASE WHEN agg.hands_played > 1 THEN
+      SQRT(GREATEST((agg.net_bb_sq - agg.net_bb * agg.net_bb / agg.hands_played::numeric) / (agg.hands_played - 1)::numeric, 0))
+    ELSE 0 END AS bb_stddev
+  FROM aggregated agg
+),
+numbered AS (
+  SELECT
+    sc.*,
+    a.name,
+    sc.bb_per_100 * sc.confidence_factor AS score,
+    COALESCE(ar.rating, 1500)::float8 AS rating,
+    COALESCE(ar.rating_deviation, 350)::float8 AS rating_deviation,
+    ROW_NUMBER() OVER (ORDER BY sc.bb_per_100 * sc.confidence_factor DESC, sc.agent_id ASC) AS rank
+  FROM scored sc
+  JOIN agents a ON a.id = sc.agent_id
+  LEFT JOIN agent_ratings ar ON ar.agent_id = sc.agent_id
+)
+SELECT
+  n.agent_id,
+  n.name,
+  n.bb_per_100::numeric AS bb_per_100,
+  n.net_cc_from_play,
+  n.hands_played,
+  n.win_rate::numeric AS win_rate,
+  n.confidence_factor::numeric AS confidence_factor,
+  n.score::numeric AS score,
+  n.last_active_at::timestamptz AS last_active_at,
+  n.rating,
+  n.rating_deviation,
+  n.bb_stddev::numeric AS bb_per_hand_stddev,
+  n.rank::int AS rank,
+  n.score::text AS sort_key
+FROM numbered n
+ORDER BY n.rank ASC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
@@ -0,0 +1,104 @@
+-- name: InsertLeague :exec
+INSERT INTO leagues (id, room_id, name, format, hands_per_match, rounds)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: InsertLeagueStanding :exec
+INSERT INTO league_standings (league_id, agent_id, seed)
+VALUES ($1, $2, $3);
+
+-- name: GetLeagueByID :one
+SELECT id, room_id, name, format, hands_per_match, rounds, current_round, status, created_at, ended_at
+FROM leagues
+WHERE id = $1;
+
+-- name: GetLeagueForUpdate :one
+SELECT id, room_id, name, format, hands_per_match, rounds, current_round, status, created_at, ended_at
+FROM leagues
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListLeagues :many
+SELECT id, room_id, name, format, hands_per_match, rounds, current_round, status, created_at, ended_at
+FROM leagues
+WHERE (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: ListRunningLeagueIDs :many
+SELECT id
+FROM leagues
+WHERE status = 'running'
+ORDER BY created_at ASC, id ASC;
+
+-- name: SetLeagueRound :exec
+UPDATE leagues
+SET current_round = $2
+WHERE id = $1;
+
+-- name: FinishLeague :exec
+UPDATE leagues
+SET status = $2, ended_at = now()
+WHERE id = $1;
+
+-- name: InsertLeagueMatch :exec
+INSERT INTO league_matches (id, league_id, round, agent_a_id, agent_b_id, status, ended_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetLeagueMatchForUpdate :one
+SELECT id, league_id, round, agent_a_id, agent_b_id, table_id, status, winner_agent_id, forfeit_agent_id, agent_a_net_cc, agent_b_net_cc, hands_played, started_at, ended_at
+FROM league_matches
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListLeagueMatches :many
+SELECT m.id, m.league_id, m.round, m.agent_a_id, a.name AS agent_a_name, m.agent_b_id, COALESCE(b.name, '')::text AS agent_b_name,
+  m.table_id, m.status, m.winner_agent_id, m.forfeit_agent_id, m.agent_a_net_cc, m.agent_b_net_cc, m.hands_played, m.started_at, m.ended_at
+FROM league_matches m
+JOIN agents a ON a.id = m.agent_a_id
+LEFT JOIN agents b ON b.id = m.agent_b_id
+WHERE m.league_id = $1
+ORDER BY m.round ASC, m.id ASC;
+
+-- name: CountOpenLeagueMatches :one
+SELECT COUNT(*)::int
+FROM league_matches
+WHERE league_id = $1 AND round = $2 AND status IN ('pending', 'running');
+
+-- name: StartLeagueMatch :execrows
+UPDATE league_matches
+SET status = 'running', table_id = $2, started_at = now()
+WHERE id = $1 AND status = 'pending';
+
+-- name: FinishLeagueMatch :exec
+UPDATE league_matches
+SET status = 'completed', winner_agent_id = $2, forfeit_agent_id = $3, agent_a_net_cc = $4, agent_b_net_cc = $5, hands_played = $6, ended_at = now()
+WHERE id = $1;
+
+-- name: ResetLeagueMatch :exec
+UPDATE league_matches
+SET status = 'pending', table_id = NULL, started_at = NULL
+WHERE id = $1 AND status = 'running';
+
+-- name: ResetRunningLeagueMatches :execrows
+UPDATE league_matches
+SET status = 'pending', table_id = NULL, started_at = NULL
+WHERE status = 'running';
+
+-- name: AddLeagueStandingResult :exec
+UPDATE league_standings
+SET points = points + sqlc.arg(points)::int,
+  played = played + sqlc.arg(played)::int,
+  wins = wins + sqlc.arg(wins)::int,
+  draws = draws + sqlc.arg(draws)::int,
+  losses = losses + sqlc.arg(losses)::int,
+  byes = byes + sqlc.arg(byes)::int,
+  net_cc = net_cc + sqlc.arg(net_cc)::bigint,
+  hands_played = hands_played + sqlc.arg(hands_played)::int
+WHERE league_id = sqlc.arg(league_id) AND agent_id = sqlc.arg(agent_id);
+
+-- name: ListLeagueStandings :many
+SELECT s.league_id, s.agent_id, a.name AS agent_name, s.seed, s.points, s.played, s.wins, s.draws, s.losses, s.byes, s.net_cc, s.hands_played
+FROM league_standings s
+JOIN agents a ON a.id = s.agent_id
+WHERE s.league_id = $1
+ORDER BY s.points DESC, s.net_cc DESC, s.seed ASC;
@@ -0,0 +1,23 @@
+-- name: InsertPrivateTable :exec
+INSERT INTO private_tables (invite_code, room_id, creator_agent_id, invited_agent_ids)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetPrivateTable :one
+SELECT invite_code, room_id, creator_agent_id, invited_agent_ids, table_id, status, created_at, closed_at
+FROM private_tables
+WHERE invite_code = $1;
+
+-- name: SetPrivateTableTable :exec
+UPDATE private_tables
+SET table_id = $2
+WHERE invite_code = $1 AND status = 'open';
+
+-- name: ClosePrivateTable :exec
+UPDATE private_tables
+SET status = 'closed', closed_at = now()
+WHERE invite_code = $1 AND status = 'open';
+
+-- name: CloseOpenPrivateTables :execrows
+UPDATE private_tables
+SET status = 'closed', closed_at = now()
+WHERE status = 'open';
@@ -0,0 +1,42 @@
+-- name: GetAgentRating :one
+SELECT agent_id, rating, rating_deviation, volatility, games, updated_at
+FROM agent_ratings
+WHERE agent_id = $1;
+
+-- name: InsertAgentRatingHistory :execrows
+INSERT INTO agent_rating_history (id, agent_id, source_type, source_id, opponents, rating_before, rating, rating_deviation, volatility)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (agent_id, source_type, source_id) DO NOTHING;
+
+-- name: ListAgentRatingHistory :many
+SELECT id, agent_id, source_type, source_id, opponents, rating_before, rating, rating_deviation, volatility, created_at
+FROM agent_rating_history
+WHERE agent_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: ListAgentRatings :many
+SELECT agent_id, rating, rating_deviation, volatility, games, updated_at
+FROM agent_ratings
+WHERE agent_id = ANY(sqlc.arg(agent_ids)::text[]);
+
+-- name: ListTableNets :many
+SELECT l.agent_id, SUM(l.amount_cc)::bigint AS net_cc
+FROM ledger_entries l
+JOIN hands h ON h.id = l.ref_id
+WHERE l.ref_type = 'hand'
+  AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
+  AND h.table_id = ANY(sqlc.arg(table_ids)::text[])
+  AND h.ended_at IS NOT NULL
+  AND h.street_end IS DISTINCT FROM 'voided'
+GROUP BY l.agent_id;
+
+-- name: UpsertAgentRating :exec
+INSERT INTO agent_ratings (agent_id, rating, rating_deviation, volatility, games)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (agent_id) DO UPDATE
+SET rating = EXCLUDED.rating,
+    rating_deviation = EXCLUDED.rating_deviation,
+    volatility = EXCLUDED.volatility,
+    games = agent_ratings.games + 1,
+    updated_at = now();
@@ -22,6 +22,13 @@ WHERE table_id = $1
 ORDER BY global_seq ASC
 LIMIT $3;
 
+-- name: ListTableReplayEventsByHand :many
+SELECT id, table_id, hand_id, global_seq, hand_seq, event_type, actor_agent_id, payload, schema_version, created_at
+FROM table_replay_events
+WHERE table_id = $1
+  AND hand_id = $2
+ORDER BY global_seq ASC;
+
 -- name: GetTableReplayLastSeq :one
 SELECT COALESCE(MAX(global_seq), 0)::bigint
 FROM table_replay_events
@@ -46,22 +53,22 @@ ORDER BY at_global_seq DESC
 LIMIT 1;
 
 -- name: ListHandsByTableID :many
-SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at
+SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at, big_blind_cc
 FROM hands
 WHERE table_id = $1
 ORDER BY started_at ASC;
 
 -- name: ListHandsByAgentID :many
-SELECT h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at
+SELECT h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at, h.big_blind_cc
 FROM hands h
 JOIN actions a ON a.hand_id = h.id
 WHERE a.agent_id = $1
-GROUP BY h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at
+GROUP BY h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at, h.big_blind_cc
 ORDER BY h.started_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetHandByID :one
-SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at
+SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at, big_blind_cc
 FROM hands
 WHERE id = $1;
 
@@ -145,3 +152,9 @@ WHERE (sqlc.arg(room_id)::text = '' OR t.room_id = sqlc.arg(room_id)::text)
       WHERE s.table_id = t.id AND s.agent_id = sqlc.arg(agent_id)::text
     )
   );
+
+-- name: SetTableReplayEventEquity :exec
+UPDATE table_replay_events
+SET payload = jsonb_set(payload, '{equity}', sqlc.arg(equity)::jsonb)
+WHERE table_id = sqlc.arg(table_id)
+  AND global_seq = sqlc.arg(global_seq);
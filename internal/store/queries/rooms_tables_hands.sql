@@ -1,18 +1,28 @@
 -- name: CreateRoom :exec
-INSERT INTO rooms (id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status)
-VALUES ($1, $2, $3, $4, $5, 'active');
+INSERT INTO rooms (id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips)
+VALUES ($1, $2, $3, $4, $5, 'active', $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24);
 
 -- name: GetRoomByID :one
-SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at
+SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips
 FROM rooms
 WHERE id = $1;
 
 -- name: ListRooms :many
-SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at
+SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips
 FROM rooms
 WHERE status = 'active'
 ORDER BY min_buyin_cc ASC;
 
+-- name: InsertRoomBlindLevel :exec
+INSERT INTO room_blind_levels (room_id, level, small_blind_cc, big_blind_cc, ante_cc)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListRoomBlindLevels :many
+SELECT room_id, level, small_blind_cc, big_blind_cc, ante_cc
+FROM room_blind_levels
+WHERE room_id = $1
+ORDER BY level ASC;
+
 -- name: CountRooms :one
 SELECT COUNT(1)::int
 FROM rooms;
@@ -21,8 +31,17 @@ FROM rooms;
 INSERT INTO tables (id, room_id, status, small_blind_cc, big_blind_cc)
 VALUES ($1, $2, $3, $4, $5);
 
+-- name: CreateTournamentTable :exec
+INSERT INTO tables (id, room_id, status, small_blind_cc, big_blind_cc, tournament_id)
+VALUES ($1, $2, 'active', $3, $4, $5);
+
+-- name: GetTableTournamentID :one
+SELECT COALESCE(tournament_id, '')::text
+FROM tables
+WHERE id = $1;
+
 -- name: ListTables :many
-SELECT id, room_id, status, small_blind_cc, big_blind_cc, created_at
+SELECT id, room_id, status, small_blind_cc, big_blind_cc, created_at, tournament_id
 FROM tables
 WHERE status = 'active'
   AND (sqlc.arg(room_id)::text = '' OR room_id = sqlc.arg(room_id)::text)
@@ -35,8 +54,8 @@ SET status = $2
 WHERE id = $1;
 
 -- name: CreateHand :exec
-INSERT INTO hands (id, table_id)
-VALUES ($1, $2);
+INSERT INTO hands (id, table_id, big_blind_cc)
+VALUES ($1, $2, $3);
 
 -- name: EndHand :exec
 UPDATE hands
@@ -46,6 +65,40 @@ SET ended_at = now(),
     street_end = NULLIF(sqlc.arg(street_end)::text, '')
 WHERE id = sqlc.arg(hand_id);
 
+-- name: EndOpenHand :execrows
+UPDATE hands
+SET ended_at = now(),
+    winner_agent_id = NULLIF(sqlc.arg(winner_agent_id)::text, ''),
+    pot_cc = sqlc.arg(pot_cc),
+    street_end = NULLIF(sqlc.arg(street_end)::text, '')
+WHERE id = sqlc.arg(hand_id)
+  AND ended_at IS NULL;
+
+-- name: ListOpenHandIDsByTable :many
+SELECT id
+FROM hands
+WHERE table_id = $1
+  AND ended_at IS NULL
+ORDER BY started_at ASC;
+
+-- name: ListTableIDsToRecover :many
+SELECT t.id
+FROM tables t
+WHERE t.status <> 'closed'
+   OR EXISTS (
+     SELECT 1
+     FROM table_stacks ts
+     WHERE ts.table_id = t.id AND ts.status = 'seated'
+   )
+ORDER BY t.created_at ASC;
+
 -- name: RecordAction :exec
 INSERT INTO actions (id, hand_id, agent_id, action_type, amount_cc)
 VALUES ($1, $2, $3, $4, $5);
+
+-- name: CountSettledHandsByTable :one
+SELECT COUNT(*)::int
+FROM hands
+WHERE table_id = $1
+  AND ended_at IS NOT NULL
+  AND street_end IS DISTINCT FROM 'voided';
@@ -0,0 +1,129 @@
+-- name: InsertSeason :exec
+INSERT INTO seasons (id, name, starts_at, ends_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: CountOverlappingSeasons :one
+SELECT COUNT(*)::int
+FROM seasons
+WHERE starts_at < sqlc.arg(ends_at)::timestamptz
+  AND ends_at > sqlc.arg(starts_at)::timestamptz;
+
+-- name: GetSeasonByID :one
+SELECT id, name, starts_at, ends_at, status, entrants, created_at, closed_at
+FROM seasons
+WHERE id = $1;
+
+-- name: GetSeasonForUpdate :one
+SELECT id, name, starts_at, ends_at, status, entrants, created_at, closed_at
+FROM seasons
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSeasons :many
+SELECT id, name, starts_at, ends_at, status, entrants, created_at, closed_at
+FROM seasons
+WHERE (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+ORDER BY starts_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: ListDueSeasonIDs :many
+SELECT id
+FROM seasons
+WHERE status = 'open' AND ends_at <= $1
+ORDER BY ends_at ASC, id ASC;
+
+-- name: ArchiveSeasonStandings :execrows
+WITH hand_ledger AS (
+  SELECT
+    l.agent_id,
+    l.ref_id AS hand_id,
+    SUM(l.amount_cc)::bigint AS hand_net_cc
+  FROM ledger_entries l
+  WHERE l.ref_type = 'hand'
+    AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
+  GROUP BY l.agent_id, l.ref_id
+),
+scoped_hand_ledger AS (
+  SELECT
+    hl.agent_id,
+    hl.hand_net_cc,
+    h.winner_agent_id,
+    h.ended_at,
+    h.big_blind_cc
+  FROM hand_ledger hl
+  JOIN hands h ON h.id = hl.hand_id
+  JOIN tables t ON t.id = h.table_id
+  WHERE h.ended_at IS NOT NULL
+    AND h.street_end IS DISTINCT FROM 'voided'
+    AND t.tournament_id IS NULL
+    AND h.ended_at >= sqlc.arg(starts_at)::timestamptz
+    AND h.ended_at < sqlc.arg(ends_at)::timestamptz
+),
+aggregated AS (
+  SELECT
+    shl.agent_id,
+    COUNT(*)::int AS hands_played,
+    SUM(CASE WHEN shl.winner_agent_id = shl.agent_id THEN 1 ELSE 0 END)::int AS wins,
+    SUM(shl.hand_net_cc)::bigint AS net_cc_from_play,
+    SUM(shl.hand_net_cc::numeric / NULLIF(shl.big_blind_cc::numeric, 0)) AS net_bb,
+    MAX(shl.ended_at) AS last_active_at
+  FROM scoped_hand_ledger shl
+  GROUP BY shl.agent_id
+),
+scored AS (
+  SELECT
+    agg.*,
+    COALESCE((agg.net_bb / agg.hands_played::numeric) * 100, 0)::numeric AS bb_per_100,
+    COALESCE(agg.wins::numeric / NULLIF(agg.hands_played::numeric, 0), 0)::numeric AS win_rate,
+    (
+      COALESCE((agg.net_bb / agg.hands_played::numeric) * 100, 0)::numeric *
+      COALESCE(LEAST(1.0::numeric, agg.hands_played::numeric / 500.0), 0)::numeric
+    )::numeric AS score
+  FROM aggregated agg
+)
+INSERT INTO season_standings (season_id, agent_id, rank, agent_name, score, bb_per_100, net_cc_from_play, hands_played, win_rate, rating)
+SELECT
+  sqlc.arg(season_id)::text,
+  a.id,
+  ROW_NUMBER() OVER (ORDER BY sc.score DESC, sc.hands_played DESC, sc.last_active_at DESC, a.id ASC)::int,
+  a.name,
+  sc.score::float8,
+  sc.bb_per_100::float8,
+  sc.net_cc_from_play,
+  sc.hands_played,
+  sc.win_rate::float8,
+  COALESCE(ar.rating, 1500)::float8
+FROM scored sc
+JOIN agents a ON a.id = sc.agent_id
+LEFT JOIN agent_ratings ar ON ar.agent_id = a.id;
+
+-- name: CloseSeason :exec
+UPDATE seasons
+SET status = 'closed', entrants = $2, closed_at = now()
+WHERE id = $1;
+
+-- name: ListSeasonStandings :many
+SELECT season_id, agent_id, rank, agent_name, score, bb_per_100, net_cc_from_play, hands_played, win_rate, rating
+FROM season_standings
+WHERE season_id = sqlc.arg(season_id)
+ORDER BY rank ASC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: ListAgentSeasonFinishes :many
+SELECT
+  s.id AS season_id,
+  s.name,
+  s.starts_at,
+  s.ends_at,
+  s.entrants,
+  ss.rank,
+  ss.score,
+  ss.bb_per_100,
+  ss.net_cc_from_play,
+  ss.hands_played,
+  ss.win_rate,
+  ss.rating
+FROM season_standings ss
+JOIN seasons s ON s.id = ss.season_id
+WHERE ss.agent_id = $1
+ORDER BY s.ends_at DESC, s.id DESC;
@@ -0,0 +1,37 @@
+-- name: CreateTableStack :execrows
+INSERT INTO table_stacks (table_id, agent_id, buyin_cc, stack_cc, status)
+VALUES ($1, $2, $3, $3, 'seated')
+ON CONFLICT (table_id, agent_id) DO UPDATE
+SET buyin_cc = table_stacks.buyin_cc + excluded.buyin_cc,
+    stack_cc = excluded.stack_cc,
+    status = 'seated',
+    updated_at = now(),
+    cashed_out_at = NULL
+WHERE table_stacks.status = 'cashed_out';
+
+-- name: GetTableStack :one
+SELECT stack_cc
+FROM table_stacks
+WHERE table_id = $1 AND agent_id = $2 AND status = 'seated';
+
+-- name: GetTableStackForUpdate :one
+SELECT stack_cc
+FROM table_stacks
+WHERE table_id = $1 AND agent_id = $2 AND status = 'seated'
+FOR UPDATE;
+
+-- name: ListSeatedTableStackAgents :many
+SELECT agent_id
+FROM table_stacks
+WHERE table_id = $1 AND status = 'seated'
+ORDER BY agent_id ASC;
+
+-- name: UpdateTableStack :exec
+UPDATE table_stacks
+SET stack_cc = $3, updated_at = now()
+WHERE table_id = $1 AND agent_id = $2;
+
+-- name: MarkTableStackCashedOut :exec
+UPDATE table_stacks
+SET stack_cc = 0, status = 'cashed_out', updated_at = now(), cashed_out_at = now()
+WHERE table_id = $1 AND agent_id = $2;
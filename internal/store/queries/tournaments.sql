@@ -0,0 +1,120 @@
+-- name: InsertTournament :exec
+INSERT INTO tournaments (id, room_id, max_players, entry_fee_cc, starting_chips, prize_pool_cc, payout_bps)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: InsertScheduledTournament :exec
+INSERT INTO tournaments (
+  id, room_id, name, status, max_players, entry_fee_cc, starting_chips, prize_pool_cc, payout_bps,
+  starts_at, min_players, late_reg_levels, max_rebuys, rebuy_levels, addon_fee_cc, addon_chips
+)
+VALUES ($1, $2, $3, 'scheduled', $4, $5, $6, 0, '{}', $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetTournamentByID :one
+SELECT id, room_id, status, max_players, entry_fee_cc, starting_chips, prize_pool_cc, payout_bps, created_at, ended_at,
+  name, starts_at, started_at, min_players, late_reg_levels, max_rebuys, rebuy_levels, addon_fee_cc, addon_chips
+FROM tournaments
+WHERE id = $1;
+
+-- name: GetTournamentForUpdate :one
+SELECT id, room_id, status, max_players, entry_fee_cc, starting_chips, prize_pool_cc, payout_bps, created_at, ended_at,
+  name, starts_at, started_at, min_players, late_reg_levels, max_rebuys, rebuy_levels, addon_fee_cc, addon_chips
+FROM tournaments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListTournaments :many
+SELECT id, room_id, status, max_players, entry_fee_cc, starting_chips, prize_pool_cc, payout_bps, created_at, ended_at,
+  name, starts_at, started_at, min_players, late_reg_levels, max_rebuys, rebuy_levels, addon_fee_cc, addon_chips
+FROM tournaments
+WHERE (sqlc.arg(room_id)::text = '' OR room_id = sqlc.arg(room_id)::text)
+  AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_rows) OFFSET sqlc.arg(offset_rows);
+
+-- name: ListUnfinishedTournamentIDs :many
+SELECT id
+FROM tournaments
+WHERE status IN ('scheduled', 'running')
+ORDER BY created_at ASC;
+
+-- name: ListDueTournamentIDs :many
+SELECT id
+FROM tournaments
+WHERE status = 'scheduled' AND starts_at <= $1
+ORDER BY starts_at ASC, id ASC;
+
+-- name: StartTournament :execrows
+UPDATE tournaments
+SET status = 'running', started_at = now()
+WHERE id = $1 AND status = 'scheduled';
+
+-- name: SetTournamentPayouts :exec
+UPDATE tournaments
+SET payout_bps = $2
+WHERE id = $1;
+
+-- name: AddTournamentPrizePool :exec
+UPDATE tournaments
+SET prize_pool_cc = prize_pool_cc + $2
+WHERE id = $1;
+
+-- name: FinishTournament :exec
+UPDATE tournaments
+SET status = $2, ended_at = now()
+WHERE id = $1;
+
+-- name: InsertTournamentEntry :exec
+INSERT INTO tournament_entries (tournament_id, agent_id, table_id, seat_id)
+VALUES ($1, $2, $3, $4);
+
+-- name: RegisterTournamentEntry :execrows
+INSERT INTO tournament_entries (tournament_id, agent_id, auto_rebuy)
+VALUES ($1, $2, $3)
+ON CONFLICT (tournament_id, agent_id) DO NOTHING;
+
+-- name: DeleteTournamentEntry :execrows
+DELETE FROM tournament_entries
+WHERE tournament_id = $1 AND agent_id = $2;
+
+-- name: CountTournamentEntries :one
+SELECT COUNT(*)::int
+FROM tournament_entries
+WHERE tournament_id = $1;
+
+-- name: SetTournamentEntrySeat :execrows
+UPDATE tournament_entries
+SET table_id = $3, seat_id = $4
+WHERE tournament_id = $1 AND agent_id = $2 AND finish_place IS NULL;
+
+-- name: AddTournamentRebuy :execrows
+UPDATE tournament_entries
+SET rebuys = rebuys + 1
+WHERE tournament_id = $1 AND agent_id = $2 AND finish_place IS NULL AND rebuys < sqlc.arg(max_rebuys)::int;
+
+-- name: SetTournamentAddon :execrows
+UPDATE tournament_entries
+SET addon = true
+WHERE tournament_id = $1 AND agent_id = $2 AND finish_place IS NULL AND NOT addon;
+
+-- name: EliminateTournamentEntry :execrows
+UPDATE tournament_entries
+SET finish_place = $3, eliminated_at = now()
+WHERE tournament_id = $1 AND agent_id = $2 AND finish_place IS NULL;
+
+-- name: SetTournamentEntryResult :execrows
+UPDATE tournament_entries
+SET finish_place = $3, payout_cc = $4
+WHERE tournament_id = $1 AND agent_id = $2;
+
+-- name: ListTournamentEntryRefunds :many
+SELECT agent_id, rebuys, addon
+FROM tournament_entries
+WHERE tournament_id = $1
+ORDER BY agent_id ASC;
+
+-- name: ListTournamentEntries :many
+SELECT e.tournament_id, e.agent_id, a.name AS agent_name, e.table_id, e.seat_id, e.finish_place, e.payout_cc, e.rebuys, e.addon, e.eliminated_at
+FROM tournament_entries e
+JOIN agents a ON a.id = e.agent_id
+WHERE e.tournament_id = ANY(sqlc.arg(tournament_ids)::text[])
+ORDER BY e.tournament_id ASC, COALESCE(e.finish_place, 0) ASC, e.seat_id ASC, e.agent_id ASC;
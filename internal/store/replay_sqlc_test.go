@@ -19,7 +19,7 @@ func TestTableReplayRoundtrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("create table: %v", err)
 	}
-	handID, err := st.CreateHand(ctx, tableID)
+	handID, err := st.CreateHand(ctx, tableID, 100)
 	if err != nil {
 		t.Fatalf("create hand: %v", err)
 	}
@@ -44,6 +44,21 @@ func TestTableReplayRoundtrip(t *testing.T) {
 		t.Fatalf("unexpected event type: %s", events[0].EventType)
 	}
 
+	if err := st.SetTableReplayEventEquity(ctx, tableID, 1, json.RawMessage(`{"street":"preflop"}`)); err != nil {
+		t.Fatalf("set replay equity: %v", err)
+	}
+	events, err = st.ListTableReplayEventsFromSeq(ctx, tableID, 1, 10)
+	if err != nil {
+		t.Fatalf("list replay events: %v", err)
+	}
+	var withEquity map[string]any
+	if err := json.Unmarshal(events[0].Payload, &withEquity); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if eq, ok := withEquity["equity"].(map[string]any); !ok || eq["street"] != "preflop" || withEquity["pot_cc"] != float64(150) {
+		t.Fatalf("expected equity added to the payload, got %v", withEquity)
+	}
+
 	snap, err := st.GetLatestTableReplaySnapshotAtOrBefore(ctx, tableID, 1)
 	if err != nil {
 		t.Fatalf("get latest snapshot: %v", err)
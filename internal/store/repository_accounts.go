@@ -26,7 +26,37 @@ func (s *Store) Debit(ctx context.Context, agentID string, amount int64, entryTy
 	}
 	defer tx.Rollback(ctx)
 
-	qtx := s.q.WithTx(tx)
+	newBal, err := debit(ctx, s.q.WithTx(tx), agentID, amount, entryType, refType, refID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return newBal, nil
+}
+
+func (s *Store) Credit(ctx context.Context, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
+	if amount < 0 {
+		return 0, errors.New("amount must be positive")
+	}
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	newBal, err := credit(ctx, s.q.WithTx(tx), agentID, amount, entryType, refType, refID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return newBal, nil
+}
+
+func debit(ctx context.Context, qtx *sqlcgen.Queries, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
 	bal, err := qtx.GetAccountBalanceByAgentIDForUpdate(ctx, agentID)
 	if err != nil {
 		return 0, mapNotFound(err)
@@ -51,23 +81,10 @@ func (s *Store) Debit(ctx context.Context, agentID string, amount int64, entryTy
 	}); err != nil {
 		return 0, err
 	}
-	if err := tx.Commit(ctx); err != nil {
-		return 0, err
-	}
 	return newBal, nil
 }
 
-func (s *Store) Credit(ctx context.Context, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
-	if amount < 0 {
-		return 0, errors.New("amount must be positive")
-	}
-	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback(ctx)
-
-	qtx := s.q.WithTx(tx)
+func credit(ctx context.Context, qtx *sqlcgen.Queries, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
 	bal, err := qtx.GetAccountBalanceByAgentIDForUpdate(ctx, agentID)
 	if err != nil {
 		return 0, mapNotFound(err)
@@ -89,9 +106,6 @@ func (s *Store) Credit(ctx context.Context, agentID string, amount int64, entryT
 	}); err != nil {
 		return 0, err
 	}
-	if err := tx.Commit(ctx); err != nil {
-		return 0, err
-	}
 	return newBal, nil
 }
 
@@ -52,6 +52,33 @@ func (s *Store) CreateAgent(ctx context.Context, name, apiKey, claimCode string)
 	})
 	return id, err
 }
+
+// GetAgentOwner returns the owner the agent is run by, or "" when none was
+// set.
+func (s *Store) GetAgentOwner(ctx context.Context, agentID string) (string, error) {
+	owner, err := s.q.GetAgentOwnerID(ctx, agentID)
+	if err != nil {
+		return "", mapNotFound(err)
+	}
+	return owner, nil
+}
+
+// SetAgentOwner records the owner the agent is run by; an empty ownerID
+// clears it.
+func (s *Store) SetAgentOwner(ctx context.Context, agentID, ownerID string) error {
+	rows, err := s.q.SetAgentOwnerID(ctx, sqlcgen.SetAgentOwnerIDParams{
+		OwnerID: ownerID,
+		ID:      agentID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 func (s *Store) ListAgents(ctx context.Context, limit, offset int) ([]Agent, error) {
 	if limit <= 0 {
 		limit = 50
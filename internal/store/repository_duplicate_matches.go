@@ -0,0 +1,281 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrDuplicateMatchClosed = errors.New("duplicate_match_closed")
+
+// CreateDuplicateMatchAndSessions starts a duplicate match: it creates the
+// leg 1 table exactly like CreateMatchedTableAndSessions, creates the leg 2
+// table in "waiting" status and escrows m.BuyinCC from both agents into each
+// leg, so a match that starts can always be played to the end.
+func (s *Store) CreateDuplicateMatchAndSessions(ctx context.Context, m DuplicateMatch, roomID string, sb, bb int64, waiter SeatAssignment, joiner AgentSession, joinerSeat int) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	waiter.BuyinCC = m.BuyinCC
+	if err := createMatchedTable(ctx, qtx, m.Leg1TableID, roomID, sb, bb, []SeatAssignment{waiter}, joiner, joinerSeat, m.BuyinCC); err != nil {
+		return err
+	}
+	if err := qtx.CreateTable(ctx, sqlcgen.CreateTableParams{
+		ID:           m.Leg2TableID,
+		RoomID:       textParam(roomID),
+		Status:       "waiting",
+		SmallBlindCc: sb,
+		BigBlindCc:   bb,
+	}); err != nil {
+		return err
+	}
+	for _, agentID := range []string{m.AgentAID, m.AgentBID} {
+		if err := buyIn(ctx, qtx, m.Leg2TableID, agentID, m.BuyinCC); err != nil {
+			return err
+		}
+	}
+	if err := qtx.InsertDuplicateMatch(ctx, sqlcgen.InsertDuplicateMatchParams{
+		ID:           m.ID,
+		RoomID:       roomID,
+		AgentAID:     m.AgentAID,
+		AgentBID:     m.AgentBID,
+		Leg1TableID:  m.Leg1TableID,
+		Leg2TableID:  m.Leg2TableID,
+		SeedSchedule: m.SeedSchedule,
+		HandsPerLeg:  int32(m.HandsPerLeg),
+		BuyinCc:      m.BuyinCC,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// StartDuplicateLeg2 moves a running match from leg 1 to leg 2 in one
+// transaction: both leg 1 stacks are cashed out, the leg 1 table is closed,
+// the leg 2 table goes active and the sessions are re-seated there.
+func (s *Store) StartDuplicateLeg2(ctx context.Context, matchID string, seats []SeatAssignment) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	m, err := qtx.GetDuplicateMatchForUpdate(ctx, matchID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if m.Status != DuplicateMatchRunning || m.CurrentLeg != 1 {
+		return ErrDuplicateMatchClosed
+	}
+	for _, agentID := range []string{m.AgentAID, m.AgentBID} {
+		if _, err := cashOut(ctx, qtx, m.Leg1TableID, agentID); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	if _, err := qtx.MarkTableStatusByID(ctx, sqlcgen.MarkTableStatusByIDParams{
+		ID:     m.Leg1TableID,
+		Status: "closed",
+	}); err != nil {
+		return err
+	}
+	if _, err := qtx.MarkTableStatusByID(ctx, sqlcgen.MarkTableStatusByIDParams{
+		ID:     m.Leg2TableID,
+		Status: "active",
+	}); err != nil {
+		return err
+	}
+	for _, seat := range seats {
+		if err := seatSession(ctx, qtx, m.Leg2TableID, seat); err != nil {
+			return err
+		}
+	}
+	if err := qtx.SetDuplicateMatchLeg(ctx, sqlcgen.SetDuplicateMatchLegParams{
+		ID:         matchID,
+		CurrentLeg: 2,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CloseDuplicateMatch ends a running match with status. Stacks still seated
+// on either leg table are cashed out and both tables are closed, so an
+// aborted match refunds the leg that was never played. A completed match
+// records one result row per agent, netting the buy-ins and cash-outs of
+// each leg from the ledger.
+func (s *Store) CloseDuplicateMatch(ctx context.Context, matchID, status string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	m, err := qtx.GetDuplicateMatchForUpdate(ctx, matchID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if m.Status != DuplicateMatchRunning {
+		return ErrDuplicateMatchClosed
+	}
+	agentIDs := []string{m.AgentAID, m.AgentBID}
+	legs := []string{m.Leg1TableID, m.Leg2TableID}
+	for _, tableID := range legs {
+		for _, agentID := range agentIDs {
+			if _, err := cashOut(ctx, qtx, tableID, agentID); err != nil && !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+		if _, err := qtx.MarkTableStatusByID(ctx, sqlcgen.MarkTableStatusByIDParams{
+			ID:     tableID,
+			Status: "closed",
+		}); err != nil {
+			return err
+		}
+	}
+	if status == DuplicateMatchCompleted {
+		var hands int32
+		for _, tableID := range legs {
+			n, err := qtx.CountSettledHandsByTable(ctx, tableID)
+			if err != nil {
+				return err
+			}
+			hands += n
+		}
+		for _, agentID := range agentIDs {
+			var nets [2]int64
+			for i, tableID := range legs {
+				net, err := qtx.GetTableNetByAgent(ctx, sqlcgen.GetTableNetByAgentParams{
+					RefID:   tableID,
+					AgentID: agentID,
+				})
+				if err != nil {
+					return err
+				}
+				nets[i] = net
+			}
+			if err := qtx.InsertDuplicateMatchResult(ctx, sqlcgen.InsertDuplicateMatchResultParams{
+				MatchID:     matchID,
+				AgentID:     agentID,
+				Leg1NetCc:   nets[0],
+				Leg2NetCc:   nets[1],
+				NetCc:       nets[0] + nets[1],
+				HandsPlayed: hands,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := qtx.FinishDuplicateMatch(ctx, sqlcgen.FinishDuplicateMatchParams{
+		ID:     matchID,
+		Status: status,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *Store) GetDuplicateMatch(ctx context.Context, matchID string) (*DuplicateMatch, error) {
+	r, err := s.q.GetDuplicateMatchByID(ctx, matchID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	m := duplicateMatchFromRow(r)
+	return &m, nil
+}
+
+func (s *Store) GetDuplicateMatchByTable(ctx context.Context, tableID string) (*DuplicateMatch, error) {
+	r, err := s.q.GetDuplicateMatchByTableID(ctx, tableID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	m := duplicateMatchFromRow(r)
+	return &m, nil
+}
+
+func (s *Store) ListDuplicateMatches(ctx context.Context, agentID, status string, limit, offset int) ([]DuplicateMatch, error) {
+	rows, err := s.q.ListDuplicateMatches(ctx, sqlcgen.ListDuplicateMatchesParams{
+		AgentID:    agentID,
+		Status:     status,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DuplicateMatch, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, duplicateMatchFromRow(r))
+	}
+	return out, nil
+}
+
+func (s *Store) ListDuplicateMatchResults(ctx context.Context, matchIDs []string) ([]DuplicateMatchResult, error) {
+	rows, err := s.q.ListDuplicateMatchResults(ctx, matchIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DuplicateMatchResult, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, DuplicateMatchResult{
+			MatchID:     r.MatchID,
+			AgentID:     r.AgentID,
+			AgentName:   r.AgentName,
+			Leg1NetCC:   r.Leg1NetCc,
+			Leg2NetCC:   r.Leg2NetCc,
+			NetCC:       r.NetCc,
+			HandsPlayed: int(r.HandsPlayed),
+		})
+	}
+	return out, nil
+}
+
+func (s *Store) ListDuplicateStandings(ctx context.Context, roomID string, limit, offset int) ([]DuplicateStanding, error) {
+	rows, err := s.q.ListDuplicateStandings(ctx, sqlcgen.ListDuplicateStandingsParams{
+		RoomID:     roomID,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DuplicateStanding, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, DuplicateStanding{
+			AgentID:     r.AgentID,
+			Name:        r.Name,
+			Matches:     int(r.Matches),
+			Wins:        int(r.Wins),
+			NetCC:       r.NetCc,
+			HandsPlayed: int(r.HandsPlayed),
+			BBPer100:    r.BbPer100,
+		})
+	}
+	return out, nil
+}
+
+func duplicateMatchFromRow(r sqlcgen.DuplicateMatch) DuplicateMatch {
+	return DuplicateMatch{
+		ID:           r.ID,
+		RoomID:       r.RoomID,
+		AgentAID:     r.AgentAID,
+		AgentBID:     r.AgentBID,
+		Leg1TableID:  r.Leg1TableID,
+		Leg2TableID:  r.Leg2TableID,
+		SeedSchedule: r.SeedSchedule,
+		HandsPerLeg:  int(r.HandsPerLeg),
+		BuyinCC:      r.BuyinCc,
+		CurrentLeg:   int(r.CurrentLeg),
+		Status:       r.Status,
+		CreatedAt:    r.CreatedAt.Time,
+		EndedAt:      timePtrVal(r.EndedAt),
+	}
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+)
+
+// GetAgentHUDCounts tallies agentID's HUD stats over the hands it acted in
+// that ended since windowStart, or ever when windowStart is nil. The
+// street and aggression of each action come from the hand's replay events.
+func (s *Store) GetAgentHUDCounts(ctx context.Context, agentID string, windowStart *time.Time) (*HUDCounts, error) {
+	row, err := s.q.GetAgentHUDCounts(ctx, sqlcgen.GetAgentHUDCountsParams{
+		AgentID:     agentID,
+		WindowStart: timeParam(windowStart),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &HUDCounts{
+		Hands:                 int(row.Hands),
+		VPIPHands:             int(row.VpipHands),
+		PFRHands:              int(row.PfrHands),
+		ThreeBetOpportunities: int(row.ThreeBetOpportunities),
+		ThreeBets:             int(row.ThreeBets),
+		CBetOpportunities:     int(row.CbetOpportunities),
+		CBets:                 int(row.Cbets),
+		PostflopRaises:        int(row.PostflopRaises),
+		PostflopCalls:         int(row.PostflopCalls),
+		SawFlop:               int(row.SawFlop),
+		Showdowns:             int(row.Showdowns),
+		ShowdownsWon:          int(row.ShowdownsWon),
+	}, nil
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+)
+
+var ErrChallengeClosed = errors.New("challenge_closed")
+
+func (s *Store) CreateChallenge(ctx context.Context, ch Challenge) error {
+	return s.q.InsertChallenge(ctx, sqlcgen.InsertChallengeParams{
+		ID:                ch.ID,
+		RoomID:            ch.RoomID,
+		ChallengerAgentID: ch.ChallengerAgentID,
+		OpponentAgentID:   ch.OpponentAgentID,
+		ExpiresAt:         timeParam(&ch.ExpiresAt),
+	})
+}
+
+func (s *Store) GetChallenge(ctx context.Context, challengeID string) (*Challenge, error) {
+	r, err := s.q.GetChallengeByID(ctx, challengeID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	return &Challenge{
+		ID:                r.ID,
+		RoomID:            r.RoomID,
+		ChallengerAgentID: r.ChallengerAgentID,
+		OpponentAgentID:   r.OpponentAgentID,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt.Time,
+		ExpiresAt:         r.ExpiresAt.Time,
+		RespondedAt:       timePtrVal(r.RespondedAt),
+	}, nil
+}
+
+// ListPendingChallenges returns the unexpired challenges the agent has sent
+// or received, newest first.
+func (s *Store) ListPendingChallenges(ctx context.Context, agentID string) ([]Challenge, error) {
+	rows, err := s.q.ListPendingChallengesByAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Challenge, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, Challenge{
+			ID:                r.ID,
+			RoomID:            r.RoomID,
+			ChallengerAgentID: r.ChallengerAgentID,
+			ChallengerName:    r.ChallengerName,
+			OpponentAgentID:   r.OpponentAgentID,
+			OpponentName:      r.OpponentName,
+			Status:            r.Status,
+			CreatedAt:         r.CreatedAt.Time,
+			ExpiresAt:         r.ExpiresAt.Time,
+			RespondedAt:       timePtrVal(r.RespondedAt),
+		})
+	}
+	return out, nil
+}
+
+// RespondChallenge records the opponent's answer to a pending challenge. It
+// returns ErrChallengeClosed when the challenge is not pending, has expired
+// or was sent to another agent.
+func (s *Store) RespondChallenge(ctx context.Context, challengeID, opponentAgentID, status string) error {
+	n, err := s.q.RespondChallenge(ctx, sqlcgen.RespondChallengeParams{
+		ID:              challengeID,
+		OpponentAgentID: opponentAgentID,
+		Status:          status,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrChallengeClosed
+	}
+	return nil
+}
+
+// CancelChallenge withdraws a challenge that is still pending. It reports
+// whether the challenge was pending.
+func (s *Store) CancelChallenge(ctx context.Context, challengeID string) (bool, error) {
+	n, err := s.q.CancelChallenge(ctx, challengeID)
+	return n > 0, err
+}
+
+// ExpireChallenges marks the challenges still pending at now as expired and
+// returns their ids.
+func (s *Store) ExpireChallenges(ctx context.Context, now time.Time) ([]string, error) {
+	return s.q.ExpireChallenges(ctx, timeParam(&now))
+}
+
+func (s *Store) CreatePrivateTable(ctx context.Context, p PrivateTable) error {
+	invited := p.InvitedAgentIDs
+	if invited == nil {
+		invited = []string{}
+	}
+	return s.q.InsertPrivateTable(ctx, sqlcgen.InsertPrivateTableParams{
+		InviteCode:      p.InviteCode,
+		RoomID:          p.RoomID,
+		CreatorAgentID:  p.CreatorAgentID,
+		InvitedAgentIds: invited,
+	})
+}
+
+func (s *Store) GetPrivateTable(ctx context.Context, inviteCode string) (*PrivateTable, error) {
+	r, err := s.q.GetPrivateTable(ctx, inviteCode)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	return &PrivateTable{
+		InviteCode:      r.InviteCode,
+		RoomID:          r.RoomID,
+		CreatorAgentID:  r.CreatorAgentID,
+		InvitedAgentIDs: r.InvitedAgentIds,
+		TableID:         textVal(r.TableID),
+		Status:          r.Status,
+		CreatedAt:       r.CreatedAt.Time,
+		ClosedAt:        timePtrVal(r.ClosedAt),
+	}, nil
+}
+
+// SetPrivateTableTable records the table an open private table started.
+func (s *Store) SetPrivateTableTable(ctx context.Context, inviteCode, tableID string) error {
+	return s.q.SetPrivateTableTable(ctx, sqlcgen.SetPrivateTableTableParams{
+		InviteCode: inviteCode,
+		TableID:    textParam(tableID),
+	})
+}
+
+// ClosePrivateTable retires an invite code; nobody can join with it again.
+func (s *Store) ClosePrivateTable(ctx context.Context, inviteCode string) error {
+	return s.q.ClosePrivateTable(ctx, inviteCode)
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LeaderboardFilter picks a ranking of the leaderboard rollups: Window is
+// 7d, 30d or all, RoomID a room ID or all. AfterSortKey and AfterAgentID,
+// taken from the last entry of the previous page, continue the ranking
+// after that entry.
+type LeaderboardFilter struct {
+	Window       string
+	RoomID       string
+	SortBy       string
+	AfterSortKey string
+	AfterAgentID string
+}
+
+func (f *LeaderboardFilter) defaults() {
+	if f.Window == "" {
+		f.Window = "all"
+	}
+	if f.RoomID == "" {
+		f.RoomID = "all"
+	}
+	if f.SortBy == "" {
+		f.SortBy = "score"
+	}
+}
+
+// leaderboardWindowStart is the start of a leaderboard window of d ending
+// at now. The hourly leaderboard_stats buckets cannot split an hour, so it
+// is rounded forward to the hour: a window covers at most d.
+func leaderboardWindowStart(now time.Time, d time.Duration) time.Time {
+	start := now.Add(-d)
+	if hour := start.Truncate(time.Hour); !hour.Equal(start) {
+		return hour.Add(time.Hour)
+	}
+	return start
+}
+
+// RefreshLeaderboard rebuilds the leaderboard rollups of every window, room
+// and sort from the leaderboard_stats totals as of now. Readers see the
+// previous rollups until it commits.
+func (s *Store) RefreshLeaderboard(ctx context.Context, now time.Time) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.DeleteLeaderboardRollups(ctx); err != nil {
+		return err
+	}
+	if _, err := qtx.InsertLeaderboardRollups(ctx, sqlcgen.InsertLeaderboardRollupsParams{
+		Start7d:  timestamptzParam(leaderboardWindowStart(now, 7*24*time.Hour)),
+		Start30d: timestamptzParam(leaderboardWindowStart(now, 30*24*time.Hour)),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListLeaderboard returns up to limit entries of f's ranking, after f's
+// keyset when it is set and after the first offset entries otherwise. Both
+// seek on an index of the rollups.
+func (s *Store) ListLeaderboard(ctx context.Context, f LeaderboardFilter, limit, offset int) ([]LeaderboardEntry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	f.defaults()
+	var rows []sqlcgen.ListLeaderboardByRankRow
+	if f.AfterAgentID != "" {
+		after, err := s.q.ListLeaderboardAfter(ctx, sqlcgen.ListLeaderboardAfterParams{
+			WindowKey:    f.Window,
+			RoomID:       f.RoomID,
+			SortBy:       f.SortBy,
+			AfterSortKey: f.AfterSortKey,
+			AfterAgentID: f.AfterAgentID,
+			LimitRows:    int32(limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range after {
+			rows = append(rows, sqlcgen.ListLeaderboardByRankRow(r))
+		}
+	} else {
+		var err error
+		rows, err = s.q.ListLeaderboardByRank(ctx, sqlcgen.ListLeaderboardByRankParams{
+			WindowKey: f.Window,
+			RoomID:    f.RoomID,
+			SortBy:    f.SortBy,
+			AfterRank: int32(max(offset, 0)),
+			LimitRows: int32(limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	out := make([]LeaderboardEntry, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, leaderboardEntryFromRow(r))
+	}
+	return out, nil
+}
+
+// CountLeaderboard counts the agents ranked in f's ranking.
+func (s *Store) CountLeaderboard(ctx context.Context, f LeaderboardFilter) (int, error) {
+	f.defaults()
+	n, err := s.q.CountLeaderboard(ctx, sqlcgen.CountLeaderboardParams{
+		WindowKey: f.Window,
+		RoomID:    f.RoomID,
+		SortBy:    f.SortBy,
+	})
+	return int(n), err
+}
+
+// ListLeaderboardSince ranks every agent by score over the hands played
+// since since, rounded forward to the hour, in all rooms. It aggregates
+// and ranks the whole population on every call; it serves the live
+// standings of a running season, which no rollup covers.
+func (s *Store) ListLeaderboardSince(ctx context.Context, since time.Time, limit, offset int) ([]LeaderboardEntry, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.q.ListLeaderboardSince(ctx, sqlcgen.ListLeaderboardSinceParams{
+		WindowStart: timestamptzParam(leaderboardWindowStart(since, 0)),
+		LimitRows:   int32(limit),
+		OffsetRows:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeaderboardEntry, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, leaderboardEntryFromRow(sqlcgen.ListLeaderboardByRankRow(r)))
+	}
+	return out, nil
+}
+
+func (s *Store) CountLeaderboardSince(ctx context.Context, since time.Time) (int, error) {
+	n, err := s.q.CountLeaderboardSince(ctx, timestamptzParam(leaderboardWindowStart(since, 0)))
+	return int(n), err
+}
+
+func leaderboardEntryFromRow(r sqlcgen.ListLeaderboardByRankRow) LeaderboardEntry {
+	return LeaderboardEntry{
+		Rank:             int(r.Rank),
+		AgentID:          r.AgentID,
+		Name:             r.Name,
+		Score:            r.Score,
+		BBPer100:         r.BbPer100,
+		NetCCFromPlay:    r.NetCcFromPlay,
+		HandsPlayed:      int(r.HandsPlayed),
+		WinRate:          r.WinRate,
+		ConfidenceFactor: r.ConfidenceFactor,
+		LastActiveAt:     r.LastActiveAt.Time,
+		Rating:           r.Rating,
+		RatingDeviation:  r.RatingDeviation,
+		BBPerHandStdDev:  r.BbPerHandStddev,
+		SortKey:          r.SortKey,
+	}
+}
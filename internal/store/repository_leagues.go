@@ -0,0 +1,376 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrLeagueMatchClosed  = errors.New("league_match_closed")
+	ErrLeagueRoundStarted = errors.New("league_round_started")
+)
+
+// CreateLeague creates a running league and its league table, seeding the
+// agents in the order given.
+func (s *Store) CreateLeague(ctx context.Context, l League, agentIDs []string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.InsertLeague(ctx, sqlcgen.InsertLeagueParams{
+		ID:            l.ID,
+		RoomID:        l.RoomID,
+		Name:          l.Name,
+		Format:        l.Format,
+		HandsPerMatch: int32(l.HandsPerMatch),
+		Rounds:        int32(l.Rounds),
+	}); err != nil {
+		return err
+	}
+	for seed, agentID := range agentIDs {
+		if err := qtx.InsertLeagueStanding(ctx, sqlcgen.InsertLeagueStandingParams{
+			LeagueID: l.ID,
+			AgentID:  agentID,
+			Seed:     int32(seed + 1),
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *Store) GetLeague(ctx context.Context, leagueID string) (*League, error) {
+	r, err := s.q.GetLeagueByID(ctx, leagueID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	l := leagueFromRow(r)
+	return &l, nil
+}
+
+func (s *Store) ListLeagues(ctx context.Context, status string, limit, offset int) ([]League, error) {
+	rows, err := s.q.ListLeagues(ctx, sqlcgen.ListLeaguesParams{
+		Status:     status,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]League, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, leagueFromRow(r))
+	}
+	return out, nil
+}
+
+func (s *Store) ListRunningLeagueIDs(ctx context.Context) ([]string, error) {
+	return s.q.ListRunningLeagueIDs(ctx)
+}
+
+// CountOpenLeagueMatches counts the matches of round still to be played or
+// being played.
+func (s *Store) CountOpenLeagueMatches(ctx context.Context, leagueID string, round int) (int, error) {
+	n, err := s.q.CountOpenLeagueMatches(ctx, sqlcgen.CountOpenLeagueMatchesParams{
+		LeagueID: leagueID,
+		Round:    int32(round),
+	})
+	return int(n), err
+}
+
+func (s *Store) ListLeagueMatches(ctx context.Context, leagueID string) ([]LeagueMatch, error) {
+	rows, err := s.q.ListLeagueMatches(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeagueMatch, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, LeagueMatch{
+			ID:             r.ID,
+			LeagueID:       r.LeagueID,
+			Round:          int(r.Round),
+			AgentAID:       r.AgentAID,
+			AgentAName:     r.AgentAName,
+			AgentBID:       textVal(r.AgentBID),
+			AgentBName:     r.AgentBName,
+			TableID:        textVal(r.TableID),
+			Status:         r.Status,
+			WinnerAgentID:  textVal(r.WinnerAgentID),
+			ForfeitAgentID: textVal(r.ForfeitAgentID),
+			AgentANetCC:    r.AgentANetCc,
+			AgentBNetCC:    r.AgentBNetCc,
+			HandsPlayed:    int(r.HandsPlayed),
+			StartedAt:      timePtrVal(r.StartedAt),
+			EndedAt:        timePtrVal(r.EndedAt),
+		})
+	}
+	return out, nil
+}
+
+// ListLeagueStandings returns the league table, best first: by points, then
+// by chips won, then by seed.
+func (s *Store) ListLeagueStandings(ctx context.Context, leagueID string) ([]LeagueStanding, error) {
+	rows, err := s.q.ListLeagueStandings(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LeagueStanding, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, LeagueStanding{
+			AgentID:     r.AgentID,
+			AgentName:   r.AgentName,
+			Seed:        int(r.Seed),
+			Points:      int(r.Points),
+			Played:      int(r.Played),
+			Wins:        int(r.Wins),
+			Draws:       int(r.Draws),
+			Losses:      int(r.Losses),
+			Byes:        int(r.Byes),
+			NetCC:       r.NetCc,
+			HandsPlayed: int(r.HandsPlayed),
+		})
+	}
+	return out, nil
+}
+
+// StartLeagueRound pairs round of a running league once every match of the
+// previous round is over. A pairing without a second agent is a bye, which
+// is scored as a win straight away. It returns ErrLeagueRoundStarted when
+// the round has already been paired or the league is over.
+func (s *Store) StartLeagueRound(ctx context.Context, leagueID string, round int, pairings [][2]string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	l, err := qtx.GetLeagueForUpdate(ctx, leagueID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if l.Status != LeagueRunning || int(l.CurrentRound) != round-1 || round > int(l.Rounds) {
+		return ErrLeagueRoundStarted
+	}
+	open, err := qtx.CountOpenLeagueMatches(ctx, sqlcgen.CountOpenLeagueMatchesParams{
+		LeagueID: leagueID,
+		Round:    l.CurrentRound,
+	})
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrLeagueRoundStarted
+	}
+	now := time.Now()
+	for _, p := range pairings {
+		status := LeagueMatchPending
+		var endedAt *time.Time
+		if p[1] == "" {
+			status = LeagueMatchBye
+			endedAt = &now
+		}
+		if err := qtx.InsertLeagueMatch(ctx, sqlcgen.InsertLeagueMatchParams{
+			ID:       NewID(),
+			LeagueID: leagueID,
+			Round:    int32(round),
+			AgentAID: p[0],
+			AgentBID: textParam(p[1]),
+			Status:   status,
+			EndedAt:  timeParam(endedAt),
+		}); err != nil {
+			return err
+		}
+		if status == LeagueMatchBye {
+			if err := qtx.AddLeagueStandingResult(ctx, sqlcgen.AddLeagueStandingResultParams{
+				Points:   LeaguePointsWin,
+				Byes:     1,
+				LeagueID: leagueID,
+				AgentID:  p[0],
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := qtx.SetLeagueRound(ctx, sqlcgen.SetLeagueRoundParams{
+		ID:           leagueID,
+		CurrentRound: int32(round),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// FinishLeague ends a league with status.
+func (s *Store) FinishLeague(ctx context.Context, leagueID, status string) error {
+	return s.q.FinishLeague(ctx, sqlcgen.FinishLeagueParams{
+		ID:     leagueID,
+		Status: status,
+	})
+}
+
+// StartLeagueMatch seats the sessions of a pending match at a new table of
+// the league room and escrows their buy-ins, exactly like
+// CreateMatchedTableAndSessions does for sessions that were waiting. It
+// returns ErrLeagueMatchClosed when the match is no longer pending.
+func (s *Store) StartLeagueMatch(ctx context.Context, matchID, tableID, roomID string, sb, bb int64, seats []SeatAssignment) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.CreateTable(ctx, sqlcgen.CreateTableParams{
+		ID:           tableID,
+		RoomID:       textParam(roomID),
+		Status:       "active",
+		SmallBlindCc: sb,
+		BigBlindCc:   bb,
+	}); err != nil {
+		return err
+	}
+	rows, err := qtx.StartLeagueMatch(ctx, sqlcgen.StartLeagueMatchParams{
+		ID:      matchID,
+		TableID: textParam(tableID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrLeagueMatchClosed
+	}
+	for _, seat := range seats {
+		if err := seatSession(ctx, qtx, tableID, seat); err != nil {
+			return err
+		}
+		if err := buyIn(ctx, qtx, tableID, seat.AgentID, seat.BuyinCC); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// FinishLeagueMatch records the result of a running match whose table has
+// closed, netting each agent's buy-in and cash-out from the ledger, and adds
+// it to the league table. An agent that forfeited loses whatever the chips
+// say; otherwise the agent with more chips wins and equal chips are a draw.
+// A match that closed before a hand was settled, and not by a forfeit, goes
+// back to pending to be played again.
+func (s *Store) FinishLeagueMatch(ctx context.Context, matchID, forfeitAgentID string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	m, err := qtx.GetLeagueMatchForUpdate(ctx, matchID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if m.Status != LeagueMatchRunning {
+		return ErrLeagueMatchClosed
+	}
+	tableID := textVal(m.TableID)
+	agentIDs := []string{m.AgentAID, textVal(m.AgentBID)}
+	for _, agentID := range agentIDs {
+		if _, err := cashOut(ctx, qtx, tableID, agentID); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	hands, err := qtx.CountSettledHandsByTable(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	if hands == 0 && forfeitAgentID == "" {
+		if err := qtx.ResetLeagueMatch(ctx, matchID); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+	var nets [2]int64
+	for i, agentID := range agentIDs {
+		net, err := qtx.GetTableNetByAgent(ctx, sqlcgen.GetTableNetByAgentParams{
+			RefID:   tableID,
+			AgentID: agentID,
+		})
+		if err != nil {
+			return err
+		}
+		nets[i] = net
+	}
+	winner := ""
+	switch {
+	case forfeitAgentID == agentIDs[0]:
+		winner = agentIDs[1]
+	case forfeitAgentID == agentIDs[1]:
+		winner = agentIDs[0]
+	case nets[0] > nets[1]:
+		winner = agentIDs[0]
+	case nets[1] > nets[0]:
+		winner = agentIDs[1]
+	}
+	if err := qtx.FinishLeagueMatch(ctx, sqlcgen.FinishLeagueMatchParams{
+		ID:             matchID,
+		WinnerAgentID:  textParam(winner),
+		ForfeitAgentID: textParam(forfeitAgentID),
+		AgentANetCc:    nets[0],
+		AgentBNetCc:    nets[1],
+		HandsPlayed:    hands,
+	}); err != nil {
+		return err
+	}
+	for i, agentID := range agentIDs {
+		result := sqlcgen.AddLeagueStandingResultParams{
+			Played:      1,
+			NetCc:       nets[i],
+			HandsPlayed: hands,
+			LeagueID:    m.LeagueID,
+			AgentID:     agentID,
+		}
+		switch winner {
+		case "":
+			result.Draws = 1
+			result.Points = LeaguePointsDraw
+		case agentID:
+			result.Wins = 1
+			result.Points = LeaguePointsWin
+		default:
+			result.Losses = 1
+		}
+		if err := qtx.AddLeagueStandingResult(ctx, result); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ResetLeagueMatch puts a running match whose table could not be started
+// back to pending.
+func (s *Store) ResetLeagueMatch(ctx context.Context, matchID string) error {
+	return s.q.ResetLeagueMatch(ctx, matchID)
+}
+
+func leagueFromRow(r sqlcgen.League) League {
+	return League{
+		ID:            r.ID,
+		RoomID:        r.RoomID,
+		Name:          r.Name,
+		Format:        r.Format,
+		HandsPerMatch: int(r.HandsPerMatch),
+		Rounds:        int(r.Rounds),
+		CurrentRound:  int(r.CurrentRound),
+		Status:        r.Status,
+		CreatedAt:     r.CreatedAt.Time,
+		EndedAt:       timePtrVal(r.EndedAt),
+	}
+}
@@ -16,12 +16,6 @@ type LedgerFilter struct {
 	To      *time.Time
 }
 
-type LeaderboardFilter struct {
-	WindowStart *time.Time
-	RoomScope   string
-	SortBy      string
-}
-
 func (s *Store) ListLedgerEntries(ctx context.Context, f LedgerFilter, limit, offset int) ([]LedgerEntry, error) {
 	if limit <= 0 {
 		limit = 50
@@ -52,38 +46,23 @@ func (s *Store) ListLedgerEntries(ctx context.Context, f LedgerFilter, limit, of
 	return out, nil
 }
 
-func (s *Store) ListLeaderboard(ctx context.Context, f LeaderboardFilter, limit, offset int) ([]LeaderboardEntry, error) {
-	if limit <= 0 {
-		limit = 50
-	}
-	if f.RoomScope == "" {
-		f.RoomScope = "all"
-	}
-	if f.SortBy == "" {
-		f.SortBy = "score"
-	}
-	rows, err := s.q.ListLeaderboard(ctx, sqlcgen.ListLeaderboardParams{
-		WindowStart: timeParam(f.WindowStart),
-		RoomScope:   f.RoomScope,
-		SortBy:      f.SortBy,
-		LimitRows:   int32(limit),
-		OffsetRows:  int32(offset),
+// ListRakeByRoom sums the rake credited to the house per room between from
+// and to; nil bounds are open.
+func (s *Store) ListRakeByRoom(ctx context.Context, from, to *time.Time) ([]RakeReportItem, error) {
+	rows, err := s.q.ListRakeByRoom(ctx, sqlcgen.ListRakeByRoomParams{
+		FromTs: timeParam(from),
+		ToTs:   timeParam(to),
 	})
 	if err != nil {
 		return nil, err
 	}
-	out := make([]LeaderboardEntry, 0, len(rows))
+	out := make([]RakeReportItem, 0, len(rows))
 	for _, r := range rows {
-		out = append(out, LeaderboardEntry{
-			AgentID:          r.AgentID,
-			Name:             r.Name,
-			Score:            r.Score,
-			BBPer100:         r.BbPer100,
-			NetCCFromPlay:    r.NetCcFromPlay,
-			HandsPlayed:      int(r.HandsPlayed),
-			WinRate:          r.WinRate,
-			ConfidenceFactor: r.ConfidenceFactor,
-			LastActiveAt:     r.LastActiveAt.Time,
+		out = append(out, RakeReportItem{
+			RoomID:     r.RoomID,
+			RoomName:   r.RoomName,
+			HandsRaked: r.HandsRaked,
+			RakeCC:     r.RakeCc,
 		})
 	}
 	return out, nil
@@ -114,5 +93,20 @@ func (s *Store) GetAgentPerformanceByWindowAndAgent(ctx context.Context, agentID
 		WinRate:          row.WinRate,
 		ConfidenceFactor: row.ConfidenceFactor,
 		LastActiveAt:     lastActiveAt,
+		BBPerHandStdDev:  row.BbPerHandStddev,
 	}, nil
 }
+
+// GetSharedHands reports the settled cash game hands since windowStart, or
+// ever when it is nil, that both agents played.
+func (s *Store) GetSharedHands(ctx context.Context, agentAID, agentBID string, windowStart *time.Time) (*SharedHands, error) {
+	row, err := s.q.GetSharedHandResults(ctx, sqlcgen.GetSharedHandResultsParams{
+		AgentAID:    agentAID,
+		AgentBID:    agentBID,
+		WindowStart: timeParam(windowStart),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SharedHands{Hands: int(row.SharedHands), BBCovariance: row.BbCovariance}, nil
+}
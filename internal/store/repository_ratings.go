@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Store) GetAgentRating(ctx context.Context, agentID string) (*AgentRating, error) {
+	r, err := s.q.GetAgentRating(ctx, agentID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	out := agentRatingFromRow(r)
+	return &out, nil
+}
+
+// ListAgentRatings returns the ratings of the agents of agentIDs that have
+// been rated, by agent.
+func (s *Store) ListAgentRatings(ctx context.Context, agentIDs []string) (map[string]AgentRating, error) {
+	rows, err := s.q.ListAgentRatings(ctx, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]AgentRating, len(rows))
+	for _, r := range rows {
+		out[r.AgentID] = agentRatingFromRow(r)
+	}
+	return out, nil
+}
+
+func agentRatingFromRow(r sqlcgen.AgentRating) AgentRating {
+	return AgentRating{
+		AgentID:    r.AgentID,
+		Rating:     r.Rating,
+		Deviation:  r.RatingDeviation,
+		Volatility: r.Volatility,
+		Games:      int(r.Games),
+		UpdatedAt:  r.UpdatedAt.Time,
+	}
+}
+
+// RecordRatings stores the ratings a table, match or tournament left its
+// agents with, together with their history rows, in one transaction. An
+// agent already rated for the same source keeps its rating.
+func (s *Store) RecordRatings(ctx context.Context, changes []RatingChange) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	for _, c := range changes {
+		rows, err := qtx.InsertAgentRatingHistory(ctx, sqlcgen.InsertAgentRatingHistoryParams{
+			ID:              NewID(),
+			AgentID:         c.AgentID,
+			SourceType:      c.SourceType,
+			SourceID:        c.SourceID,
+			Opponents:       int32(c.Opponents),
+			RatingBefore:    c.RatingBefore,
+			Rating:          c.Rating,
+			RatingDeviation: c.Deviation,
+			Volatility:      c.Volatility,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			continue
+		}
+		if err := qtx.UpsertAgentRating(ctx, sqlcgen.UpsertAgentRatingParams{
+			AgentID:         c.AgentID,
+			Rating:          c.Rating,
+			RatingDeviation: c.Deviation,
+			Volatility:      c.Volatility,
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ListRatingHistory returns the agent's latest rating changes, newest
+// first.
+func (s *Store) ListRatingHistory(ctx context.Context, agentID string, limit int) ([]RatingChange, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.q.ListAgentRatingHistory(ctx, sqlcgen.ListAgentRatingHistoryParams{
+		AgentID: agentID,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]RatingChange, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, RatingChange{
+			AgentID:      r.AgentID,
+			SourceType:   r.SourceType,
+			SourceID:     r.SourceID,
+			Opponents:    int(r.Opponents),
+			RatingBefore: r.RatingBefore,
+			Rating:       r.Rating,
+			Deviation:    r.RatingDeviation,
+			Volatility:   r.Volatility,
+			CreatedAt:    r.CreatedAt.Time,
+		})
+	}
+	return out, nil
+}
+
+// ListTableNets returns what each agent won or lost over the settled hands
+// of the tables of tableIDs, by agent.
+func (s *Store) ListTableNets(ctx context.Context, tableIDs []string) (map[string]int64, error) {
+	rows, err := s.q.ListTableNets(ctx, tableIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		out[r.AgentID] = r.NetCc
+	}
+	return out, nil
+}
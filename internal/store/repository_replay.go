@@ -32,6 +32,16 @@ func (s *Store) InsertTableReplayEvent(
 	})
 }
 
+// SetTableReplayEventEquity adds the equity annotation to a replay event
+// written before the equity was known.
+func (s *Store) SetTableReplayEventEquity(ctx context.Context, tableID string, globalSeq int64, equity json.RawMessage) error {
+	return s.q.SetTableReplayEventEquity(ctx, sqlcgen.SetTableReplayEventEquityParams{
+		Equity:    equity,
+		TableID:   tableID,
+		GlobalSeq: globalSeq,
+	})
+}
+
 func (s *Store) ListTableReplayEventsFromSeq(ctx context.Context, tableID string, fromSeq int64, limit int) ([]TableReplayEvent, error) {
 	if limit <= 0 {
 		limit = 200
@@ -44,6 +54,23 @@ func (s *Store) ListTableReplayEventsFromSeq(ctx context.Context, tableID string
 	if err != nil {
 		return nil, err
 	}
+	return toTableReplayEvents(rows), nil
+}
+
+// ListTableReplayEventsByHand returns every replay event of one hand in
+// sequence order.
+func (s *Store) ListTableReplayEventsByHand(ctx context.Context, tableID, handID string) ([]TableReplayEvent, error) {
+	rows, err := s.q.ListTableReplayEventsByHand(ctx, sqlcgen.ListTableReplayEventsByHandParams{
+		TableID: tableID,
+		HandID:  textParam(handID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTableReplayEvents(rows), nil
+}
+
+func toTableReplayEvents(rows []sqlcgen.TableReplayEvent) []TableReplayEvent {
 	out := make([]TableReplayEvent, 0, len(rows))
 	for _, r := range rows {
 		out = append(out, TableReplayEvent{
@@ -59,7 +86,7 @@ func (s *Store) ListTableReplayEventsFromSeq(ctx context.Context, tableID string
 			CreatedAt:    r.CreatedAt.Time,
 		})
 	}
-	return out, nil
+	return out
 }
 
 func (s *Store) GetTableReplayLastSeq(ctx context.Context, tableID string) (int64, error) {
@@ -115,6 +142,7 @@ func (s *Store) ListHandsByTableID(ctx context.Context, tableID string) ([]Hand,
 			StreetEnd:     textVal(r.StreetEnd),
 			StartedAt:     r.StartedAt.Time,
 			EndedAt:       timePtrVal(r.EndedAt),
+			BigBlindCC:    r.BigBlindCc,
 		})
 	}
 	return out, nil
@@ -133,6 +161,7 @@ func (s *Store) GetHandByID(ctx context.Context, handID string) (*Hand, error) {
 		StreetEnd:     textVal(r.StreetEnd),
 		StartedAt:     r.StartedAt.Time,
 		EndedAt:       timePtrVal(r.EndedAt),
+		BigBlindCC:    r.BigBlindCc,
 	}, nil
 }
 
@@ -158,6 +187,7 @@ func (s *Store) ListHandsByAgentID(ctx context.Context, agentID string, limit, o
 			StreetEnd:     textVal(r.StreetEnd),
 			StartedAt:     r.StartedAt.Time,
 			EndedAt:       timePtrVal(r.EndedAt),
+			BigBlindCC:    r.BigBlindCc,
 		})
 	}
 	return out, nil
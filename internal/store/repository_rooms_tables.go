@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
 )
 
 func (s *Store) CreateTable(ctx context.Context, roomID, status string, sb, bb int64) (string, error) {
@@ -38,15 +40,17 @@ func (s *Store) ListTables(ctx context.Context, roomID string, limit, offset int
 			Status:       r.Status,
 			SmallBlindCC: r.SmallBlindCc,
 			BigBlindCC:   r.BigBlindCc,
+			TournamentID: textVal(r.TournamentID),
 			CreatedAt:    r.CreatedAt.Time,
 		})
 	}
 	return out, nil
 }
 
-func (s *Store) CreateHand(ctx context.Context, tableID string) (string, error) {
+// CreateHand records a new hand of a table dealt at big blind bigBlind.
+func (s *Store) CreateHand(ctx context.Context, tableID string, bigBlind int64) (string, error) {
 	id := NewID()
-	err := s.q.CreateHand(ctx, sqlcgen.CreateHandParams{ID: id, TableID: tableID})
+	err := s.q.CreateHand(ctx, sqlcgen.CreateHandParams{ID: id, TableID: tableID, BigBlindCc: bigBlind})
 	return id, err
 }
 
@@ -59,13 +63,33 @@ func (s *Store) EndHand(ctx context.Context, handID string) error {
 	})
 }
 
+// EndHandWithSummary ends a hand whose chips have already been moved and
+// adds it to the leaderboard totals.
 func (s *Store) EndHandWithSummary(ctx context.Context, handID, winnerAgentID string, potCC *int64, streetEnd string) error {
-	return s.q.EndHand(ctx, sqlcgen.EndHandParams{
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.EndHand(ctx, sqlcgen.EndHandParams{
 		HandID:        handID,
 		WinnerAgentID: winnerAgentID,
 		PotCc:         int8PtrParam(potCC),
 		StreetEnd:     streetEnd,
-	})
+	}); err != nil {
+		return err
+	}
+	if err := qtx.AddLeaderboardHand(ctx, handID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListOpenHandIDs returns the hands of a table that have not ended yet.
+func (s *Store) ListOpenHandIDs(ctx context.Context, tableID string) ([]string, error) {
+	return s.q.ListOpenHandIDsByTable(ctx, tableID)
 }
 
 func (s *Store) RecordAction(ctx context.Context, handID, agentID, actionType string, amount int64) error {
@@ -85,15 +109,7 @@ func (s *Store) ListRooms(ctx context.Context) ([]Room, error) {
 	}
 	out := make([]Room, 0, len(rows))
 	for _, r := range rows {
-		out = append(out, Room{
-			ID:           r.ID,
-			Name:         r.Name,
-			MinBuyinCC:   r.MinBuyinCc,
-			SmallBlindCC: r.SmallBlindCc,
-			BigBlindCC:   r.BigBlindCc,
-			Status:       r.Status,
-			CreatedAt:    r.CreatedAt.Time,
-		})
+		out = append(out, roomFromRow(r))
 	}
 	return out, nil
 }
@@ -103,27 +119,164 @@ func (s *Store) GetRoom(ctx context.Context, id string) (*Room, error) {
 	if err != nil {
 		return nil, mapNotFound(err)
 	}
-	return &Room{
-		ID:           r.ID,
-		Name:         r.Name,
-		MinBuyinCC:   r.MinBuyinCc,
-		SmallBlindCC: r.SmallBlindCc,
-		BigBlindCC:   r.BigBlindCc,
-		Status:       r.Status,
-		CreatedAt:    r.CreatedAt.Time,
-	}, nil
+	room := roomFromRow(r)
+	return &room, nil
+}
+
+func roomFromRow(r sqlcgen.Room) Room {
+	return Room{
+		ID:                r.ID,
+		Name:              r.Name,
+		MinBuyinCC:        r.MinBuyinCc,
+		SmallBlindCC:      r.SmallBlindCc,
+		BigBlindCC:        r.BigBlindCc,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt.Time,
+		MaxSeats:          int(r.MaxSeats),
+		MinPlayers:        int(r.MinPlayers),
+		MaxBuyinCC:        r.MaxBuyinCc,
+		RakeBps:           int(r.RakeBps),
+		RakeCapCC:         r.RakeCapCc,
+		RakeNoFlopNoDrop:  r.RakeNoFlopNoDrop,
+		DeckSeedSchedule:  r.DeckSeedSchedule,
+		MatchFormat:       r.MatchFormat,
+		DuplicateHands:    int(r.DuplicateHands),
+		BettingStructure:  r.BettingStructure,
+		RaiseCap:          int(r.RaiseCap),
+		Variant:           r.Variant,
+		AnteCC:            r.AnteCc,
+		BigBlindAnte:      r.BigBlindAnte,
+		Straddle:          r.Straddle,
+		BlindLevelHands:   int(r.BlindLevelHands),
+		BlindLevelMinutes: int(r.BlindLevelMinutes),
+		EntryFeeCC:        r.EntryFeeCc,
+		StartingChips:     r.StartingChips,
+	}
 }
 
+// CreateRoom creates a heads-up room.
 func (s *Store) CreateRoom(ctx context.Context, name string, minBuyin, sb, bb int64) (string, error) {
-	id := NewID()
-	err := s.q.CreateRoom(ctx, sqlcgen.CreateRoomParams{
-		ID:           id,
+	return s.CreateRoomWithConfig(ctx, Room{
 		Name:         name,
-		MinBuyinCc:   minBuyin,
-		SmallBlindCc: sb,
-		BigBlindCc:   bb,
+		MinBuyinCC:   minBuyin,
+		SmallBlindCC: sb,
+		BigBlindCC:   bb,
 	})
-	return id, err
+}
+
+// CreateRoomWithConfig creates a room from cfg, ignoring ID, Status and
+// CreatedAt. Zero seat settings fall back to a heads-up room, a zero max
+// buy-in to DefaultMaxBuyinBigBlinds big blinds, an empty match format to
+// MatchFormatStandard, an empty variant to Hold'em and an empty betting
+// structure to the variant's default: pot-limit for Omaha, else no-limit.
+// A sit-and-go room's buy-in is its entry fee and it starts with every seat
+// filled.
+// levels are the blind levels the room moves through after its own blinds,
+// numbered from 1; they are stored in the same transaction as the room.
+func (s *Store) CreateRoomWithConfig(ctx context.Context, cfg Room, levels ...BlindLevel) (string, error) {
+	if cfg.MaxSeats == 0 {
+		cfg.MaxSeats = DefaultMaxSeats
+	}
+	if cfg.MinPlayers == 0 {
+		cfg.MinPlayers = DefaultMinPlayers
+	}
+	if cfg.MatchFormat == MatchFormatSitAndGo {
+		cfg.MinBuyinCC = cfg.EntryFeeCC
+		cfg.MaxBuyinCC = cfg.EntryFeeCC
+		cfg.MinPlayers = cfg.MaxSeats
+	}
+	if cfg.MaxBuyinCC == 0 {
+		cfg.MaxBuyinCC = max(cfg.MinBuyinCC, cfg.BigBlindCC*DefaultMaxBuyinBigBlinds)
+	}
+	if cfg.MatchFormat == "" {
+		cfg.MatchFormat = MatchFormatStandard
+	}
+	if cfg.MatchFormat == MatchFormatDuplicate && cfg.DuplicateHands == 0 {
+		cfg.DuplicateHands = DefaultDuplicateHands
+	}
+	if cfg.Variant == "" {
+		cfg.Variant = VariantHoldem
+	}
+	if cfg.BettingStructure == "" {
+		cfg.BettingStructure = BettingNoLimit
+		if cfg.Variant == VariantOmaha {
+			cfg.BettingStructure = BettingPotLimit
+		}
+	}
+	if cfg.BettingStructure == BettingFixedLimit && cfg.RaiseCap == 0 {
+		cfg.RaiseCap = DefaultRaiseCap
+	}
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.q.WithTx(tx)
+
+	id := NewID()
+	err = qtx.CreateRoom(ctx, sqlcgen.CreateRoomParams{
+		ID:                id,
+		Name:              cfg.Name,
+		MinBuyinCc:        cfg.MinBuyinCC,
+		SmallBlindCc:      cfg.SmallBlindCC,
+		BigBlindCc:        cfg.BigBlindCC,
+		MaxSeats:          int32(cfg.MaxSeats),
+		MinPlayers:        int32(cfg.MinPlayers),
+		MaxBuyinCc:        cfg.MaxBuyinCC,
+		RakeBps:           int32(cfg.RakeBps),
+		RakeCapCc:         cfg.RakeCapCC,
+		RakeNoFlopNoDrop:  cfg.RakeNoFlopNoDrop,
+		DeckSeedSchedule:  cfg.DeckSeedSchedule,
+		MatchFormat:       cfg.MatchFormat,
+		DuplicateHands:    int32(cfg.DuplicateHands),
+		BettingStructure:  cfg.BettingStructure,
+		RaiseCap:          int32(cfg.RaiseCap),
+		Variant:           cfg.Variant,
+		AnteCc:            cfg.AnteCC,
+		BigBlindAnte:      cfg.BigBlindAnte,
+		Straddle:          cfg.Straddle,
+		BlindLevelHands:   int32(cfg.BlindLevelHands),
+		BlindLevelMinutes: int32(cfg.BlindLevelMinutes),
+		EntryFeeCc:        cfg.EntryFeeCC,
+		StartingChips:     cfg.StartingChips,
+	})
+	if err != nil {
+		return "", err
+	}
+	for i, l := range levels {
+		if err := qtx.InsertRoomBlindLevel(ctx, sqlcgen.InsertRoomBlindLevelParams{
+			RoomID:       id,
+			Level:        int32(i + 1),
+			SmallBlindCc: l.SmallBlindCC,
+			BigBlindCc:   l.BigBlindCC,
+			AnteCc:       l.AnteCC,
+		}); err != nil {
+			return "", err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// ListRoomBlindLevels returns the room's blind levels after its own blinds,
+// lowest first. Their Level numbers start at 1.
+func (s *Store) ListRoomBlindLevels(ctx context.Context, roomID string) ([]BlindLevel, error) {
+	rows, err := s.q.ListRoomBlindLevels(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]BlindLevel, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, BlindLevel{
+			Level:        int(r.Level),
+			SmallBlindCC: r.SmallBlindCc,
+			BigBlindCC:   r.BigBlindCc,
+			AnteCC:       r.AnteCc,
+		})
+	}
+	return out, nil
 }
 
 func (s *Store) CountRooms(ctx context.Context) (int, error) {
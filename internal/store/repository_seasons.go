@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrSeasonOverlap = errors.New("season_overlap")
+	ErrSeasonClosed  = errors.New("season_closed")
+)
+
+// CreateSeason schedules an open season. Seasons may not overlap, so every
+// hand counts towards at most one of them; ErrSeasonOverlap is returned
+// otherwise.
+func (s *Store) CreateSeason(ctx context.Context, season Season) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	n, err := qtx.CountOverlappingSeasons(ctx, sqlcgen.CountOverlappingSeasonsParams{
+		EndsAt:   timestamptzParam(season.EndsAt),
+		StartsAt: timestamptzParam(season.StartsAt),
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrSeasonOverlap
+	}
+	if err := qtx.InsertSeason(ctx, sqlcgen.InsertSeasonParams{
+		ID:       season.ID,
+		Name:     season.Name,
+		StartsAt: timestamptzParam(season.StartsAt),
+		EndsAt:   timestamptzParam(season.EndsAt),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *Store) GetSeason(ctx context.Context, seasonID string) (*Season, error) {
+	r, err := s.q.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	season := seasonFromRow(r)
+	return &season, nil
+}
+
+// ListSeasons returns the seasons with status, or all of them when status
+// is empty, latest start first.
+func (s *Store) ListSeasons(ctx context.Context, status string, limit, offset int) ([]Season, error) {
+	rows, err := s.q.ListSeasons(ctx, sqlcgen.ListSeasonsParams{
+		Status:     status,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Season, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, seasonFromRow(r))
+	}
+	return out, nil
+}
+
+// ListDueSeasonIDs returns the open seasons that ended by now.
+func (s *Store) ListDueSeasonIDs(ctx context.Context, now time.Time) ([]string, error) {
+	return s.q.ListDueSeasonIDs(ctx, timestamptzParam(now))
+}
+
+// CloseSeason archives the final standings of a season and closes it, in
+// one transaction. It returns ErrSeasonClosed when the season already is.
+func (s *Store) CloseSeason(ctx context.Context, seasonID string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	season, err := qtx.GetSeasonForUpdate(ctx, seasonID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if season.Status != SeasonOpen {
+		return ErrSeasonClosed
+	}
+	entrants, err := qtx.ArchiveSeasonStandings(ctx, sqlcgen.ArchiveSeasonStandingsParams{
+		StartsAt: season.StartsAt,
+		EndsAt:   season.EndsAt,
+		SeasonID: season.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := qtx.CloseSeason(ctx, sqlcgen.CloseSeasonParams{
+		ID:       season.ID,
+		Entrants: int32(entrants),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListSeasonStandings returns a page of the archived standings of a closed
+// season, by rank.
+func (s *Store) ListSeasonStandings(ctx context.Context, seasonID string, limit, offset int) ([]SeasonStanding, error) {
+	rows, err := s.q.ListSeasonStandings(ctx, sqlcgen.ListSeasonStandingsParams{
+		SeasonID:   seasonID,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SeasonStanding, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, SeasonStanding{
+			SeasonID:      r.SeasonID,
+			AgentID:       r.AgentID,
+			Rank:          int(r.Rank),
+			AgentName:     r.AgentName,
+			Score:         r.Score,
+			BBPer100:      r.BbPer100,
+			NetCCFromPlay: r.NetCcFromPlay,
+			HandsPlayed:   int(r.HandsPlayed),
+			WinRate:       r.WinRate,
+			Rating:        r.Rating,
+		})
+	}
+	return out, nil
+}
+
+// ListAgentSeasonFinishes returns the agent's finishes in closed seasons,
+// latest season first.
+func (s *Store) ListAgentSeasonFinishes(ctx context.Context, agentID string) ([]SeasonFinish, error) {
+	rows, err := s.q.ListAgentSeasonFinishes(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SeasonFinish, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, SeasonFinish{
+			SeasonID:      r.SeasonID,
+			SeasonName:    r.Name,
+			StartsAt:      r.StartsAt.Time,
+			EndsAt:        r.EndsAt.Time,
+			Entrants:      int(r.Entrants),
+			Rank:          int(r.Rank),
+			Score:         r.Score,
+			BBPer100:      r.BbPer100,
+			NetCCFromPlay: r.NetCcFromPlay,
+			HandsPlayed:   int(r.HandsPlayed),
+			WinRate:       r.WinRate,
+			Rating:        r.Rating,
+		})
+	}
+	return out, nil
+}
+
+func seasonFromRow(r sqlcgen.Season) Season {
+	return Season{
+		ID:        r.ID,
+		Name:      r.Name,
+		StartsAt:  r.StartsAt.Time,
+		EndsAt:    r.EndsAt.Time,
+		Status:    r.Status,
+		Entrants:  int(r.Entrants),
+		CreatedAt: r.CreatedAt.Time,
+		ClosedAt:  timePtrVal(r.ClosedAt),
+	}
+}
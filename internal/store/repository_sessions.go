@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"silicon-casino/internal/store/sqlcgen"
 
@@ -21,7 +22,9 @@ func (s *Store) CreateAgentSession(ctx context.Context, sess AgentSession) error
 	})
 }
 
-func (s *Store) CreateMatchedTableAndSessions(ctx context.Context, tableID, roomID string, sb, bb int64, waiterSessionID string, second AgentSession, seat0, seat1 int) error {
+// CreateSeatedAgentSession inserts a session already seated at a running
+// table and escrows its buy-in in the same transaction.
+func (s *Store) CreateSeatedAgentSession(ctx context.Context, sess AgentSession, buyinCC int64) error {
 	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return err
@@ -29,6 +32,50 @@ func (s *Store) CreateMatchedTableAndSessions(ctx context.Context, tableID, room
 	defer tx.Rollback(ctx)
 
 	qtx := s.q.WithTx(tx)
+	if err := qtx.CreateAgentSession(ctx, sqlcgen.CreateAgentSessionParams{
+		ID:        sess.ID,
+		AgentID:   sess.AgentID,
+		RoomID:    sess.RoomID,
+		TableID:   sess.TableID,
+		SeatID:    int4PtrParam(sess.SeatID),
+		JoinMode:  sess.JoinMode,
+		Status:    sess.Status,
+		ExpiresAt: timeParam(&sess.ExpiresAt),
+	}); err != nil {
+		return err
+	}
+	if err := buyIn(ctx, qtx, sess.TableID, sess.AgentID, buyinCC); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SeatAssignment places an existing waiting session at a table seat with
+// the given buy-in.
+type SeatAssignment struct {
+	SessionID string
+	AgentID   string
+	Seat      int
+	BuyinCC   int64
+}
+
+// CreateMatchedTableAndSessions creates a table, seats the waiting sessions
+// and inserts the session of the agent that completed the match, escrowing
+// every buy-in, all in one transaction.
+func (s *Store) CreateMatchedTableAndSessions(ctx context.Context, tableID, roomID string, sb, bb int64, waiters []SeatAssignment, joiner AgentSession, joinerSeat int, joinerBuyinCC int64) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := createMatchedTable(ctx, s.q.WithTx(tx), tableID, roomID, sb, bb, waiters, joiner, joinerSeat, joinerBuyinCC); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func createMatchedTable(ctx context.Context, qtx *sqlcgen.Queries, tableID, roomID string, sb, bb int64, waiters []SeatAssignment, joiner AgentSession, joinerSeat int, joinerBuyinCC int64) error {
 	if err := qtx.CreateTable(ctx, sqlcgen.CreateTableParams{
 		ID:           tableID,
 		RoomID:       textParam(roomID),
@@ -39,36 +86,77 @@ func (s *Store) CreateMatchedTableAndSessions(ctx context.Context, tableID, room
 		return err
 	}
 	if err := qtx.CreateAgentSession(ctx, sqlcgen.CreateAgentSessionParams{
-		ID:        second.ID,
-		AgentID:   second.AgentID,
-		RoomID:    second.RoomID,
-		TableID:   second.TableID,
-		SeatID:    int4Param(int32(seat1)),
-		JoinMode:  second.JoinMode,
-		Status:    second.Status,
-		ExpiresAt: timestamptzParam(second.ExpiresAt),
+		ID:        joiner.ID,
+		AgentID:   joiner.AgentID,
+		RoomID:    joiner.RoomID,
+		TableID:   joiner.TableID,
+		SeatID:    int4Param(int32(joinerSeat)),
+		JoinMode:  joiner.JoinMode,
+		Status:    joiner.Status,
+		ExpiresAt: timestamptzParam(joiner.ExpiresAt),
 	}); err != nil {
 		return err
 	}
-	if rows, err := qtx.UpdateAgentSessionMatch(ctx, sqlcgen.UpdateAgentSessionMatchParams{
-		ID:      waiterSessionID,
-		TableID: textParam(tableID),
-		SeatID:  int4Param(int32(seat0)),
+	seats := append(append([]SeatAssignment{}, waiters...), SeatAssignment{
+		SessionID: joiner.ID,
+		AgentID:   joiner.AgentID,
+		Seat:      joinerSeat,
+		BuyinCC:   joinerBuyinCC,
+	})
+	for _, seat := range seats {
+		if err := seatSession(ctx, qtx, tableID, seat); err != nil {
+			return err
+		}
+		if err := buyIn(ctx, qtx, tableID, seat.AgentID, seat.BuyinCC); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartMatchedTable creates a table and seats sessions that were all
+// waiting for it, escrowing their buy-ins, in one transaction.
+func (s *Store) StartMatchedTable(ctx context.Context, tableID, roomID string, sb, bb int64, seats []SeatAssignment) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.CreateTable(ctx, sqlcgen.CreateTableParams{
+		ID:           tableID,
+		RoomID:       textParam(roomID),
+		Status:       "active",
+		SmallBlindCc: sb,
+		BigBlindCc:   bb,
 	}); err != nil {
 		return err
-	} else if rows == 0 {
-		return ErrNotFound
 	}
-	if rows, err := qtx.UpdateAgentSessionMatch(ctx, sqlcgen.UpdateAgentSessionMatchParams{
-		ID:      second.ID,
+	for _, seat := range seats {
+		if err := seatSession(ctx, qtx, tableID, seat); err != nil {
+			return err
+		}
+		if err := buyIn(ctx, qtx, tableID, seat.AgentID, seat.BuyinCC); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func seatSession(ctx context.Context, qtx *sqlcgen.Queries, tableID string, seat SeatAssignment) error {
+	rows, err := qtx.UpdateAgentSessionMatch(ctx, sqlcgen.UpdateAgentSessionMatchParams{
+		ID:      seat.SessionID,
 		TableID: textParam(tableID),
-		SeatID:  int4Param(int32(seat1)),
-	}); err != nil {
+		SeatID:  int4Param(int32(seat.Seat)),
+	})
+	if err != nil {
 		return err
-	} else if rows == 0 {
+	}
+	if rows == 0 {
 		return ErrNotFound
 	}
-	return tx.Commit(ctx)
+	return nil
 }
 
 func (s *Store) GetAgentSession(ctx context.Context, sessionID string) (*AgentSession, error) {
@@ -205,6 +293,15 @@ func (s *Store) GetAgentEventOffset(ctx context.Context, sessionID string) (*Age
 	}, nil
 }
 
+// ListRecentOpponents returns the agents that shared a table with agentID
+// in a session opened since since.
+func (s *Store) ListRecentOpponents(ctx context.Context, agentID string, since time.Time) ([]string, error) {
+	return s.q.ListRecentOpponentIDs(ctx, sqlcgen.ListRecentOpponentIDsParams{
+		AgentID: agentID,
+		Since:   timestamptzParam(since),
+	})
+}
+
 func (s *Store) DebugSessionCount(ctx context.Context) (int, error) {
 	count, err := s.q.CountAgentSessions(ctx)
 	return int(count), err
@@ -0,0 +1,258 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// StreetEndVoided marks a hand that was interrupted and refunded instead of
+// being played out.
+const StreetEndVoided = "voided"
+
+// SettleHand pays out a finished hand in a single transaction: every pot
+// credit, the rake, the hand summary, the settlement replay events and the
+// hand's leaderboard totals either all commit or none do. It fails with "hand_already_settled" when the hand
+// has already ended.
+func (s *Store) SettleHand(ctx context.Context, hs HandSettlement) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	pot := hs.PotCC
+	rows, err := qtx.EndOpenHand(ctx, sqlcgen.EndOpenHandParams{
+		WinnerAgentID: hs.WinnerAgentID,
+		PotCc:         int8PtrParam(&pot),
+		StreetEnd:     hs.StreetEnd,
+		HandID:        hs.HandID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("hand_already_settled")
+	}
+	for _, c := range hs.Credits {
+		if c.Amount <= 0 {
+			continue
+		}
+		if _, err := moveTableStack(ctx, qtx, hs.TableID, c.AgentID, c.Amount, "pot_credit", "hand", hs.HandID); err != nil {
+			return err
+		}
+	}
+	if hs.RakeCC > 0 {
+		if _, err := credit(ctx, qtx, HouseAccountID, hs.RakeCC, "rake", "hand", hs.HandID); err != nil {
+			return err
+		}
+	}
+	for _, ev := range hs.Events {
+		if err := qtx.InsertTableReplayEvent(ctx, sqlcgen.InsertTableReplayEventParams{
+			ID:            NewID(),
+			TableID:       hs.TableID,
+			HandID:        hs.HandID,
+			GlobalSeq:     ev.GlobalSeq,
+			HandSeq:       int32PtrParam(ev.HandSeq),
+			EventType:     ev.EventType,
+			ActorAgentID:  ev.ActorAgentID,
+			Payload:       ev.Payload,
+			SchemaVersion: ev.SchemaVer,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := qtx.AddLeaderboardHand(ctx, hs.HandID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// VoidHand ends a hand that could not be settled and returns every chip the
+// agents put into it to their table stacks. It returns the amount refunded.
+func (s *Store) VoidHand(ctx context.Context, tableID, handID string) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	refunded, err := voidHand(ctx, s.q.WithTx(tx), tableID, handID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return refunded, nil
+}
+
+// VoidOpenHands voids every hand of a table that has not ended, returning
+// what was posted to them to the players' table stacks. It reports the total
+// refunded.
+func (s *Store) VoidOpenHands(ctx context.Context, tableID string) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	handIDs, err := qtx.ListOpenHandIDsByTable(ctx, tableID)
+	if err != nil {
+		return 0, err
+	}
+	var refunded int64
+	for _, handID := range handIDs {
+		amount, err := voidHand(ctx, qtx, tableID, handID)
+		if err != nil {
+			return 0, err
+		}
+		refunded += amount
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return refunded, nil
+}
+
+func voidHand(ctx context.Context, qtx *sqlcgen.Queries, tableID, handID string) (int64, error) {
+	rows, err := qtx.EndOpenHand(ctx, sqlcgen.EndOpenHandParams{
+		WinnerAgentID: "",
+		PotCc:         int8PtrParam(nil),
+		StreetEnd:     StreetEndVoided,
+		HandID:        handID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rows == 0 {
+		return 0, errors.New("hand_already_settled")
+	}
+	deltas, err := qtx.ListHandStackDeltas(ctx, handID)
+	if err != nil {
+		return 0, err
+	}
+	var refunded int64
+	for _, d := range deltas {
+		if d.DeltaCc >= 0 {
+			continue
+		}
+		if _, err := moveTableStack(ctx, qtx, tableID, d.AgentID, -d.DeltaCc, "hand_void_refund", "hand", handID); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		refunded += -d.DeltaCc
+	}
+	return refunded, nil
+}
+
+// RecoverInterruptedTables cleans up tables left open by a previous process.
+// Table runtimes live in memory, so at startup every table that is not
+// closed, or still holds seated stacks, is orphaned. Hands that settled are
+// already complete; hands that did not are voided by returning each agent's
+// contributions to their stack. Every seated stack is then cashed out and the
+// table and its sessions are closed. Each table is recovered in its own
+// transaction. Duplicate matches that were still running are aborted, and so
+// are scheduled and running tournaments, whose entrants are refunded: their
+// registrations were held by sessions that did not survive the restart.
+// League matches that were running go back to pending to be played again.
+// Pending challenges are cancelled and private tables closed.
+func (s *Store) RecoverInterruptedTables(ctx context.Context) (RecoveryReport, error) {
+	var report RecoveryReport
+	tableIDs, err := s.q.ListTableIDsToRecover(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, tableID := range tableIDs {
+		if err := s.recoverTable(ctx, tableID, &report); err != nil {
+			return report, err
+		}
+		report.Tables++
+	}
+	aborted, err := s.q.AbortRunningDuplicateMatches(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.AbortedMatches = int(aborted)
+	tournamentIDs, err := s.q.ListUnfinishedTournamentIDs(ctx)
+	if err != nil {
+		return report, err
+	}
+	for _, id := range tournamentIDs {
+		if err := s.AbortTournament(ctx, id); err != nil {
+			return report, err
+		}
+		report.AbortedTournaments++
+	}
+	requeued, err := s.q.ResetRunningLeagueMatches(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.RequeuedLeagueMatches = int(requeued)
+	cancelled, err := s.q.CancelPendingChallenges(ctx)
+	if err != nil {
+		return report, err
+	}
+	closed, err := s.q.CloseOpenPrivateTables(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.ClosedInvites = int(cancelled + closed)
+	return report, nil
+}
+
+func (s *Store) recoverTable(ctx context.Context, tableID string, report *RecoveryReport) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	handIDs, err := qtx.ListOpenHandIDsByTable(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	var refunded, cashedOut int64
+	for _, handID := range handIDs {
+		amount, err := voidHand(ctx, qtx, tableID, handID)
+		if err != nil {
+			return err
+		}
+		refunded += amount
+	}
+	agentIDs, err := qtx.ListSeatedTableStackAgents(ctx, tableID)
+	if err != nil {
+		return err
+	}
+	for _, agentID := range agentIDs {
+		amount, err := cashOut(ctx, qtx, tableID, agentID)
+		if err != nil {
+			return err
+		}
+		cashedOut += amount
+	}
+	if _, err := qtx.CloseAgentSessionsByTableID(ctx, tableID); err != nil {
+		return err
+	}
+	if _, err := qtx.MarkTableStatusByID(ctx, sqlcgen.MarkTableStatusByIDParams{
+		ID:     tableID,
+		Status: "closed",
+	}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	report.VoidedHands += len(handIDs)
+	report.RefundedCC += refunded
+	report.CashedOutCC += cashedOut
+	return nil
+}
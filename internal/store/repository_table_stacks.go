@@ -0,0 +1,212 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BuyIn escrows amount from the agent's account into their stack at tableID.
+func (s *Store) BuyIn(ctx context.Context, tableID, agentID string, amount int64) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := buyIn(ctx, s.q.WithTx(tx), tableID, agentID, amount); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func buyIn(ctx context.Context, qtx *sqlcgen.Queries, tableID, agentID string, amount int64) error {
+	if amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	bal, err := qtx.GetAccountBalanceByAgentIDForUpdate(ctx, agentID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if bal < amount {
+		return errors.New("insufficient_balance")
+	}
+	if err := qtx.UpdateAccountBalance(ctx, sqlcgen.UpdateAccountBalanceParams{
+		BalanceCc: bal - amount,
+		ID:        agentID,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.InsertLedgerEntry(ctx, sqlcgen.InsertLedgerEntryParams{
+		ID:       NewID(),
+		AgentID:  agentID,
+		Type:     "table_buyin",
+		AmountCc: -amount,
+		RefType:  "table",
+		RefID:    tableID,
+	}); err != nil {
+		return err
+	}
+	rows, err := qtx.CreateTableStack(ctx, sqlcgen.CreateTableStackParams{
+		TableID: tableID,
+		AgentID: agentID,
+		BuyinCc: amount,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("already_seated")
+	}
+	return nil
+}
+
+// CashOut returns the agent's remaining stack at tableID to their account.
+// It returns the amount cashed out, or ErrNotFound when the agent holds no
+// seated stack at the table. Stacks at a tournament table are closed
+// without paying anything out.
+func (s *Store) CashOut(ctx context.Context, tableID, agentID string) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	stack, err := cashOut(ctx, s.q.WithTx(tx), tableID, agentID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return stack, nil
+}
+
+func cashOut(ctx context.Context, qtx *sqlcgen.Queries, tableID, agentID string) (int64, error) {
+	stack, err := qtx.GetTableStackForUpdate(ctx, sqlcgen.GetTableStackForUpdateParams{
+		TableID: tableID,
+		AgentID: agentID,
+	})
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	tournamentID, err := qtx.GetTableTournamentID(ctx, tableID)
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	// Tournament chips are worth nothing outside the tournament: the
+	// stack is closed without crediting the account.
+	if tournamentID != "" {
+		stack = 0
+	}
+	if stack > 0 {
+		bal, err := qtx.GetAccountBalanceByAgentIDForUpdate(ctx, agentID)
+		if err != nil {
+			return 0, mapNotFound(err)
+		}
+		if err := qtx.UpdateAccountBalance(ctx, sqlcgen.UpdateAccountBalanceParams{
+			BalanceCc: bal + stack,
+			ID:        agentID,
+		}); err != nil {
+			return 0, err
+		}
+		if err := qtx.InsertLedgerEntry(ctx, sqlcgen.InsertLedgerEntryParams{
+			ID:       NewID(),
+			AgentID:  agentID,
+			Type:     "table_cashout",
+			AmountCc: stack,
+			RefType:  "table",
+			RefID:    tableID,
+		}); err != nil {
+			return 0, err
+		}
+	}
+	if err := qtx.MarkTableStackCashedOut(ctx, sqlcgen.MarkTableStackCashedOutParams{
+		TableID: tableID,
+		AgentID: agentID,
+	}); err != nil {
+		return 0, err
+	}
+	return stack, nil
+}
+
+func (s *Store) GetTableStack(ctx context.Context, tableID, agentID string) (int64, error) {
+	stack, err := s.q.GetTableStack(ctx, sqlcgen.GetTableStackParams{
+		TableID: tableID,
+		AgentID: agentID,
+	})
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	return stack, nil
+}
+
+// DebitTableStack takes amount from the agent's stack at tableID and records
+// the movement in the ledger. The account balance is not touched.
+func (s *Store) DebitTableStack(ctx context.Context, tableID, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
+	if amount < 0 {
+		return 0, errors.New("amount must be positive")
+	}
+	return s.moveTableStack(ctx, tableID, agentID, -amount, entryType, refType, refID)
+}
+
+// CreditTableStack adds amount to the agent's stack at tableID and records
+// the movement in the ledger. The account balance is not touched.
+func (s *Store) CreditTableStack(ctx context.Context, tableID, agentID string, amount int64, entryType, refType, refID string) (int64, error) {
+	if amount < 0 {
+		return 0, errors.New("amount must be positive")
+	}
+	return s.moveTableStack(ctx, tableID, agentID, amount, entryType, refType, refID)
+}
+
+func (s *Store) moveTableStack(ctx context.Context, tableID, agentID string, delta int64, entryType, refType, refID string) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	newStack, err := moveTableStack(ctx, s.q.WithTx(tx), tableID, agentID, delta, entryType, refType, refID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return newStack, nil
+}
+
+func moveTableStack(ctx context.Context, qtx *sqlcgen.Queries, tableID, agentID string, delta int64, entryType, refType, refID string) (int64, error) {
+	stack, err := qtx.GetTableStackForUpdate(ctx, sqlcgen.GetTableStackForUpdateParams{
+		TableID: tableID,
+		AgentID: agentID,
+	})
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	newStack := stack + delta
+	if newStack < 0 {
+		return 0, errors.New("insufficient_balance")
+	}
+	if err := qtx.UpdateTableStack(ctx, sqlcgen.UpdateTableStackParams{
+		TableID: tableID,
+		AgentID: agentID,
+		StackCc: newStack,
+	}); err != nil {
+		return 0, err
+	}
+	if err := qtx.InsertLedgerEntry(ctx, sqlcgen.InsertLedgerEntryParams{
+		ID:       NewID(),
+		AgentID:  agentID,
+		Type:     entryType,
+		AmountCc: delta,
+		RefType:  refType,
+		RefID:    refID,
+	}); err != nil {
+		return 0, err
+	}
+	return newStack, nil
+}
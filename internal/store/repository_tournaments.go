@@ -0,0 +1,616 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"silicon-casino/internal/store/sqlcgen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrTournamentClosed  = errors.New("tournament_closed")
+	ErrTournamentFull    = errors.New("tournament_full")
+	ErrAlreadyRegistered = errors.New("already_registered")
+	ErrNoRebuysLeft      = errors.New("no_rebuys_left")
+)
+
+// CreateSitAndGo starts a sit-and-go in one transaction: it records t,
+// creates its table, inserts the joiner's session and, for every entrant,
+// debits t.EntryFeeCC into the prize pool and seats them with
+// t.StartingChips tournament chips. The BuyinCC of the seats is ignored.
+func (s *Store) CreateSitAndGo(ctx context.Context, t Tournament, tableID string, sb, bb int64, waiters []SeatAssignment, joiner AgentSession, joinerSeat int) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	seats := append(append([]SeatAssignment{}, waiters...), SeatAssignment{
+		SessionID: joiner.ID,
+		AgentID:   joiner.AgentID,
+		Seat:      joinerSeat,
+	})
+	bps := make([]int32, 0, len(t.PayoutBps))
+	for _, b := range t.PayoutBps {
+		bps = append(bps, int32(b))
+	}
+	if err := qtx.InsertTournament(ctx, sqlcgen.InsertTournamentParams{
+		ID:            t.ID,
+		RoomID:        t.RoomID,
+		MaxPlayers:    int32(t.MaxPlayers),
+		EntryFeeCc:    t.EntryFeeCC,
+		StartingChips: t.StartingChips,
+		PrizePoolCc:   t.EntryFeeCC * int64(len(seats)),
+		PayoutBps:     bps,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.CreateTournamentTable(ctx, sqlcgen.CreateTournamentTableParams{
+		ID:           tableID,
+		RoomID:       textParam(t.RoomID),
+		SmallBlindCc: sb,
+		BigBlindCc:   bb,
+		TournamentID: textParam(t.ID),
+	}); err != nil {
+		return err
+	}
+	if err := qtx.CreateAgentSession(ctx, sqlcgen.CreateAgentSessionParams{
+		ID:        joiner.ID,
+		AgentID:   joiner.AgentID,
+		RoomID:    joiner.RoomID,
+		TableID:   joiner.TableID,
+		SeatID:    int4Param(int32(joinerSeat)),
+		JoinMode:  joiner.JoinMode,
+		Status:    joiner.Status,
+		ExpiresAt: timestamptzParam(joiner.ExpiresAt),
+	}); err != nil {
+		return err
+	}
+	for _, seat := range seats {
+		if err := seatSession(ctx, qtx, tableID, seat); err != nil {
+			return err
+		}
+		if _, err := debit(ctx, qtx, seat.AgentID, t.EntryFeeCC, "tournament_entry", "tournament", t.ID); err != nil {
+			return err
+		}
+		rows, err := qtx.CreateTableStack(ctx, sqlcgen.CreateTableStackParams{
+			TableID: tableID,
+			AgentID: seat.AgentID,
+			BuyinCc: t.StartingChips,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return errors.New("already_seated")
+		}
+		if err := qtx.InsertTournamentEntry(ctx, sqlcgen.InsertTournamentEntryParams{
+			TournamentID: t.ID,
+			AgentID:      seat.AgentID,
+			TableID:      textParam(tableID),
+			SeatID:       int4Param(int32(seat.Seat)),
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// EliminateTournamentEntry records that agentID busted out in place. It
+// returns ErrNotFound when the agent is not an entrant or already placed.
+func (s *Store) EliminateTournamentEntry(ctx context.Context, tournamentID, agentID string, place int) error {
+	rows, err := s.q.EliminateTournamentEntry(ctx, sqlcgen.EliminateTournamentEntryParams{
+		TournamentID: tournamentID,
+		AgentID:      agentID,
+		FinishPlace:  int4Param(int32(place)),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FinishTournament completes a running tournament: every result's place and
+// payout is recorded and the payout is credited to the agent's account from
+// the prize pool. The payouts may not add up to more than the pool.
+func (s *Store) FinishTournament(ctx context.Context, tournamentID string, results []TournamentResult) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	t, err := qtx.GetTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if t.Status != TournamentRunning {
+		return ErrTournamentClosed
+	}
+	var paid int64
+	for _, r := range results {
+		if r.PayoutCC < 0 {
+			return errors.New("amount must be positive")
+		}
+		paid += r.PayoutCC
+	}
+	if paid > t.PrizePoolCc {
+		return errors.New("payouts_exceed_prize_pool")
+	}
+	for _, r := range results {
+		rows, err := qtx.SetTournamentEntryResult(ctx, sqlcgen.SetTournamentEntryResultParams{
+			TournamentID: tournamentID,
+			AgentID:      r.AgentID,
+			FinishPlace:  int4Param(int32(r.Place)),
+			PayoutCc:     r.PayoutCC,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrNotFound
+		}
+		if r.PayoutCC == 0 {
+			continue
+		}
+		if _, err := credit(ctx, qtx, r.AgentID, r.PayoutCC, "tournament_payout", "tournament", tournamentID); err != nil {
+			return err
+		}
+	}
+	if err := qtx.FinishTournament(ctx, sqlcgen.FinishTournamentParams{
+		ID:     tournamentID,
+		Status: TournamentCompleted,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// AbortTournament ends a scheduled or running tournament without a result
+// and refunds every entrant's fee, rebuys and add-on, including entrants
+// already eliminated.
+func (s *Store) AbortTournament(ctx context.Context, tournamentID string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	t, err := qtx.GetTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if t.Status != TournamentScheduled && t.Status != TournamentRunning {
+		return ErrTournamentClosed
+	}
+	entries, err := qtx.ListTournamentEntryRefunds(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		refund := t.EntryFeeCc * int64(1+e.Rebuys)
+		if e.Addon {
+			refund += t.AddonFeeCc
+		}
+		if _, err := credit(ctx, qtx, e.AgentID, refund, "tournament_refund", "tournament", tournamentID); err != nil {
+			return err
+		}
+	}
+	if err := qtx.FinishTournament(ctx, sqlcgen.FinishTournamentParams{
+		ID:     tournamentID,
+		Status: TournamentAborted,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *Store) GetTournament(ctx context.Context, tournamentID string) (*Tournament, error) {
+	r, err := s.q.GetTournamentByID(ctx, tournamentID)
+	if err != nil {
+		return nil, mapNotFound(err)
+	}
+	t := tournamentFromRow(r)
+	return &t, nil
+}
+
+func (s *Store) ListTournaments(ctx context.Context, roomID, status string, limit, offset int) ([]Tournament, error) {
+	rows, err := s.q.ListTournaments(ctx, sqlcgen.ListTournamentsParams{
+		RoomID:     roomID,
+		Status:     status,
+		LimitRows:  int32(limit),
+		OffsetRows: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Tournament, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, tournamentFromRow(r))
+	}
+	return out, nil
+}
+
+// ListTournamentEntries returns the entrants of the given tournaments: those
+// still playing first, then the placed ones in finishing order.
+func (s *Store) ListTournamentEntries(ctx context.Context, tournamentIDs []string) ([]TournamentEntry, error) {
+	rows, err := s.q.ListTournamentEntries(ctx, tournamentIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TournamentEntry, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, TournamentEntry{
+			TournamentID: r.TournamentID,
+			AgentID:      r.AgentID,
+			AgentName:    r.AgentName,
+			TableID:      textVal(r.TableID),
+			SeatID:       intPtrVal(r.SeatID),
+			FinishPlace:  intPtrVal(r.FinishPlace),
+			PayoutCC:     r.PayoutCc,
+			Rebuys:       int(r.Rebuys),
+			Addon:        r.Addon,
+			EliminatedAt: timePtrVal(r.EliminatedAt),
+		})
+	}
+	return out, nil
+}
+
+func tournamentFromRow(r sqlcgen.Tournament) Tournament {
+	bps := make([]int, 0, len(r.PayoutBps))
+	for _, b := range r.PayoutBps {
+		bps = append(bps, int(b))
+	}
+	return Tournament{
+		ID:            r.ID,
+		RoomID:        r.RoomID,
+		Name:          r.Name,
+		Status:        r.Status,
+		MaxPlayers:    int(r.MaxPlayers),
+		EntryFeeCC:    r.EntryFeeCc,
+		StartingChips: r.StartingChips,
+		PrizePoolCC:   r.PrizePoolCc,
+		PayoutBps:     bps,
+		StartsAt:      timePtrVal(r.StartsAt),
+		StartedAt:     timePtrVal(r.StartedAt),
+		MinPlayers:    int(r.MinPlayers),
+		LateRegLevels: int(r.LateRegLevels),
+		MaxRebuys:     int(r.MaxRebuys),
+		RebuyLevels:   int(r.RebuyLevels),
+		AddonFeeCC:    r.AddonFeeCc,
+		AddonChips:    r.AddonChips,
+		CreatedAt:     r.CreatedAt.Time,
+		EndedAt:       timePtrVal(r.EndedAt),
+	}
+}
+
+// ScheduleTournament records a multi-table tournament that starts at
+// t.StartsAt. Its prize pool grows as agents register.
+func (s *Store) ScheduleTournament(ctx context.Context, t Tournament) error {
+	if t.StartsAt == nil {
+		return errors.New("starts_at required")
+	}
+	return s.q.InsertScheduledTournament(ctx, sqlcgen.InsertScheduledTournamentParams{
+		ID:            t.ID,
+		RoomID:        t.RoomID,
+		Name:          t.Name,
+		MaxPlayers:    int32(t.MaxPlayers),
+		EntryFeeCc:    t.EntryFeeCC,
+		StartingChips: t.StartingChips,
+		StartsAt:      timeParam(t.StartsAt),
+		MinPlayers:    int32(t.MinPlayers),
+		LateRegLevels: int32(t.LateRegLevels),
+		MaxRebuys:     int32(t.MaxRebuys),
+		RebuyLevels:   int32(t.RebuyLevels),
+		AddonFeeCc:    t.AddonFeeCC,
+		AddonChips:    t.AddonChips,
+	})
+}
+
+// RegisterTournamentEntry enters sess's agent into a scheduled or running
+// multi-table tournament in one transaction: the entry fee is debited into
+// the prize pool and the waiting session is inserted. It returns
+// ErrTournamentFull once MaxPlayers agents are registered and
+// ErrAlreadyRegistered when the agent already is.
+func (s *Store) RegisterTournamentEntry(ctx context.Context, tournamentID string, sess AgentSession, autoRebuy bool) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	t, err := qtx.GetTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if t.Status != TournamentScheduled && t.Status != TournamentRunning {
+		return ErrTournamentClosed
+	}
+	count, err := qtx.CountTournamentEntries(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if count >= t.MaxPlayers {
+		return ErrTournamentFull
+	}
+	rows, err := qtx.RegisterTournamentEntry(ctx, sqlcgen.RegisterTournamentEntryParams{
+		TournamentID: tournamentID,
+		AgentID:      sess.AgentID,
+		AutoRebuy:    autoRebuy,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAlreadyRegistered
+	}
+	if _, err := debit(ctx, qtx, sess.AgentID, t.EntryFeeCc, "tournament_entry", "tournament", tournamentID); err != nil {
+		return err
+	}
+	if err := qtx.AddTournamentPrizePool(ctx, sqlcgen.AddTournamentPrizePoolParams{
+		ID:          tournamentID,
+		PrizePoolCc: t.EntryFeeCc,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.CreateAgentSession(ctx, sqlcgen.CreateAgentSessionParams{
+		ID:        sess.ID,
+		AgentID:   sess.AgentID,
+		RoomID:    sess.RoomID,
+		TableID:   sess.TableID,
+		SeatID:    int4PtrParam(sess.SeatID),
+		JoinMode:  sess.JoinMode,
+		Status:    sess.Status,
+		ExpiresAt: timestamptzParam(sess.ExpiresAt),
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UnregisterTournamentEntry withdraws an agent from a tournament that has
+// not started yet and refunds its entry fee.
+func (s *Store) UnregisterTournamentEntry(ctx context.Context, tournamentID, agentID string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	t, err := qtx.GetTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return mapNotFound(err)
+	}
+	if t.Status != TournamentScheduled {
+		return ErrTournamentClosed
+	}
+	rows, err := qtx.DeleteTournamentEntry(ctx, sqlcgen.DeleteTournamentEntryParams{
+		TournamentID: tournamentID,
+		AgentID:      agentID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	if _, err := credit(ctx, qtx, agentID, t.EntryFeeCc, "tournament_refund", "tournament", tournamentID); err != nil {
+		return err
+	}
+	if err := qtx.AddTournamentPrizePool(ctx, sqlcgen.AddTournamentPrizePoolParams{
+		ID:          tournamentID,
+		PrizePoolCc: -t.EntryFeeCc,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// StartTournament marks a scheduled tournament as running. It returns
+// ErrTournamentClosed when the tournament is no longer scheduled.
+func (s *Store) StartTournament(ctx context.Context, tournamentID string) error {
+	rows, err := s.q.StartTournament(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTournamentClosed
+	}
+	return nil
+}
+
+// SetTournamentPayouts fixes how the prize pool of a multi-table tournament
+// is split once its field is known.
+func (s *Store) SetTournamentPayouts(ctx context.Context, tournamentID string, bps []int) error {
+	out := make([]int32, 0, len(bps))
+	for _, b := range bps {
+		out = append(out, int32(b))
+	}
+	return s.q.SetTournamentPayouts(ctx, sqlcgen.SetTournamentPayoutsParams{
+		ID:        tournamentID,
+		PayoutBps: out,
+	})
+}
+
+// ListDueTournamentIDs returns the scheduled tournaments whose start time
+// is not after now, earliest first.
+func (s *Store) ListDueTournamentIDs(ctx context.Context, now time.Time) ([]string, error) {
+	return s.q.ListDueTournamentIDs(ctx, timestamptzParam(now))
+}
+
+// OpenTournamentTable creates a table of a multi-table tournament and seats
+// entrants at it in one transaction. Each seat's BuyinCC is the chips the
+// entrant brings: a new entrant's starting chips, or zero for an entrant
+// moved from fromTableIDs[agentID], whose stack there moves with it.
+func (s *Store) OpenTournamentTable(ctx context.Context, tournamentID, tableID, roomID string, sb, bb int64, seats []SeatAssignment, fromTableIDs map[string]string) error {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	if err := qtx.CreateTournamentTable(ctx, sqlcgen.CreateTournamentTableParams{
+		ID:           tableID,
+		RoomID:       textParam(roomID),
+		SmallBlindCc: sb,
+		BigBlindCc:   bb,
+		TournamentID: textParam(tournamentID),
+	}); err != nil {
+		return err
+	}
+	for _, seat := range seats {
+		if _, err := seatTournamentEntry(ctx, qtx, tournamentID, tableID, seat, fromTableIDs[seat.AgentID]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SeatTournamentEntry seats an entrant of a multi-table tournament at a
+// running table, either with seat.BuyinCC new chips or, when fromTableID is
+// set, with the stack it had at that table. It returns the entrant's stack.
+func (s *Store) SeatTournamentEntry(ctx context.Context, tournamentID, tableID string, seat SeatAssignment, fromTableID string) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	stack, err := seatTournamentEntry(ctx, s.q.WithTx(tx), tournamentID, tableID, seat, fromTableID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return stack, nil
+}
+
+func seatTournamentEntry(ctx context.Context, qtx *sqlcgen.Queries, tournamentID, tableID string, seat SeatAssignment, fromTableID string) (int64, error) {
+	stack := seat.BuyinCC
+	if fromTableID != "" {
+		moved, err := qtx.GetTableStackForUpdate(ctx, sqlcgen.GetTableStackForUpdateParams{
+			TableID: fromTableID,
+			AgentID: seat.AgentID,
+		})
+		if err != nil {
+			return 0, mapNotFound(err)
+		}
+		if err := qtx.MarkTableStackCashedOut(ctx, sqlcgen.MarkTableStackCashedOutParams{
+			TableID: fromTableID,
+			AgentID: seat.AgentID,
+		}); err != nil {
+			return 0, err
+		}
+		stack = moved
+	}
+	if err := seatSession(ctx, qtx, tableID, seat); err != nil {
+		return 0, err
+	}
+	rows, err := qtx.CreateTableStack(ctx, sqlcgen.CreateTableStackParams{
+		TableID: tableID,
+		AgentID: seat.AgentID,
+		BuyinCc: stack,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rows == 0 {
+		return 0, errors.New("already_seated")
+	}
+	rows, err = qtx.SetTournamentEntrySeat(ctx, sqlcgen.SetTournamentEntrySeatParams{
+		TournamentID: tournamentID,
+		AgentID:      seat.AgentID,
+		TableID:      textParam(tableID),
+		SeatID:       int4Param(int32(seat.Seat)),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rows == 0 {
+		return 0, ErrNotFound
+	}
+	return stack, nil
+}
+
+// BuyTournamentChips sells an entrant of a running multi-table tournament
+// a rebuy, for the entry fee and the starting chips, or with addon set its
+// add-on. The fee goes into the prize pool and the chips onto the entrant's
+// stack at tableID. It returns the new stack, or ErrNoRebuysLeft when the
+// entrant has used its rebuys or already took the add-on.
+func (s *Store) BuyTournamentChips(ctx context.Context, tournamentID, tableID, agentID string, addon bool) (int64, error) {
+	tx, err := s.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+	t, err := qtx.GetTournamentForUpdate(ctx, tournamentID)
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	if t.Status != TournamentRunning {
+		return 0, ErrTournamentClosed
+	}
+	fee, chips, entryType := t.EntryFeeCc, t.StartingChips, "tournament_rebuy"
+	var rows int64
+	if addon {
+		fee, chips, entryType = t.AddonFeeCc, t.AddonChips, "tournament_addon"
+		rows, err = qtx.SetTournamentAddon(ctx, sqlcgen.SetTournamentAddonParams{
+			TournamentID: tournamentID,
+			AgentID:      agentID,
+		})
+	} else {
+		rows, err = qtx.AddTournamentRebuy(ctx, sqlcgen.AddTournamentRebuyParams{
+			TournamentID: tournamentID,
+			AgentID:      agentID,
+			MaxRebuys:    t.MaxRebuys,
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+	if rows == 0 || chips <= 0 {
+		return 0, ErrNoRebuysLeft
+	}
+	if _, err := debit(ctx, qtx, agentID, fee, entryType, "tournament", tournamentID); err != nil {
+		return 0, err
+	}
+	if err := qtx.AddTournamentPrizePool(ctx, sqlcgen.AddTournamentPrizePoolParams{
+		ID:          tournamentID,
+		PrizePoolCc: fee,
+	}); err != nil {
+		return 0, err
+	}
+	stack, err := qtx.GetTableStackForUpdate(ctx, sqlcgen.GetTableStackForUpdateParams{
+		TableID: tableID,
+		AgentID: agentID,
+	})
+	if err != nil {
+		return 0, mapNotFound(err)
+	}
+	if err := qtx.UpdateTableStack(ctx, sqlcgen.UpdateTableStackParams{
+		TableID: tableID,
+		AgentID: agentID,
+		StackCc: stack + chips,
+	}); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return stack + chips, nil
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCloseSeasonArchivesStandings(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a1 := mustCreateAgent(t, st, ctx, "A", "key-a", 200000)
+	a2 := mustCreateAgent(t, st, ctx, "B", "key-b", 200000)
+	roomID, err := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	now := time.Now()
+	season := Season{ID: NewID(), Name: "Season 1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	if err := st.CreateSeason(ctx, season); err != nil {
+		t.Fatalf("create season: %v", err)
+	}
+	overlap := Season{ID: NewID(), Name: "Season 2", StartsAt: now, EndsAt: now.Add(2 * time.Hour)}
+	if err := st.CreateSeason(ctx, overlap); !errors.Is(err, ErrSeasonOverlap) {
+		t.Fatalf("expected overlapping season to be rejected, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := recordSettledHand(t, st, ctx, tableID, a1, a2, 100); err != nil {
+			t.Fatalf("record hand %d: %v", i, err)
+		}
+	}
+	if err := st.CloseSeason(ctx, season.ID); err != nil {
+		t.Fatalf("close season: %v", err)
+	}
+	if err := st.CloseSeason(ctx, season.ID); !errors.Is(err, ErrSeasonClosed) {
+		t.Fatalf("expected closed season, got %v", err)
+	}
+
+	got, err := st.GetSeason(ctx, season.ID)
+	if err != nil || got.Status != SeasonClosed || got.Entrants != 2 || got.ClosedAt == nil {
+		t.Fatalf("unexpected closed season: %v %+v", err, got)
+	}
+	standings, err := st.ListSeasonStandings(ctx, season.ID, 10, 0)
+	if err != nil || len(standings) != 2 {
+		t.Fatalf("list standings: %v %+v", err, standings)
+	}
+	if standings[0].AgentID != a1 || standings[0].Rank != 1 || standings[0].HandsPlayed != 3 || standings[1].NetCCFromPlay >= 0 {
+		t.Fatalf("unexpected standings: %+v", standings)
+	}
+
+	finishes, err := st.ListAgentSeasonFinishes(ctx, a2)
+	if err != nil || len(finishes) != 1 || finishes[0].Rank != 2 || finishes[0].Entrants != 2 || finishes[0].SeasonName != "Season 1" {
+		t.Fatalf("unexpected finishes: %v %+v", err, finishes)
+	}
+}
@@ -98,7 +98,7 @@ func TestCreateMatchedTableAndSessions(t *testing.T) {
 		Status:    "active",
 		ExpiresAt: time.Now().Add(time.Hour),
 	}
-	if err := st.CreateMatchedTableAndSessions(ctx, tableID, roomID, 50, 100, waiting.ID, active, 0, 1); err != nil {
+	if err := st.CreateMatchedTableAndSessions(ctx, tableID, roomID, 50, 100, []SeatAssignment{{SessionID: waiting.ID, AgentID: agentA, Seat: 0, BuyinCC: 2000}}, active, 1, 3000); err != nil {
 		t.Fatalf("create matched table/sessions: %v", err)
 	}
 
@@ -125,4 +125,107 @@ func TestCreateMatchedTableAndSessions(t *testing.T) {
 	if len(tables) != 1 || tables[0].ID != tableID {
 		t.Fatalf("unexpected tables: %+v", tables)
 	}
+
+	for _, want := range []struct {
+		agentID string
+		stack   int64
+		balance int64
+	}{{agentA, 2000, 8000}, {agentB, 3000, 7000}} {
+		stack, err := st.GetTableStack(ctx, tableID, want.agentID)
+		if err != nil || stack != want.stack {
+			t.Fatalf("table stack for %s: got %d err=%v want %d", want.agentID, stack, err, want.stack)
+		}
+		bal, err := st.GetAccountBalance(ctx, want.agentID)
+		if err != nil || bal != want.balance {
+			t.Fatalf("balance for %s: got %d err=%v want %d", want.agentID, bal, err, want.balance)
+		}
+	}
+}
+
+func TestCreateMatchedTableAndSessionsRollsBackOnShortBalance(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	agentA := mustCreateAgent(t, st, ctx, "BotA", "key-a", 10000)
+	agentB := mustCreateAgent(t, st, ctx, "BotB", "key-b", 500)
+	roomID, err := st.CreateRoom(ctx, "Low", 100, 5, 10)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	waiting := AgentSession{
+		ID:        NewID(),
+		AgentID:   agentA,
+		RoomID:    roomID,
+		JoinMode:  "random",
+		Status:    "waiting",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := st.CreateAgentSession(ctx, waiting); err != nil {
+		t.Fatalf("create waiting session: %v", err)
+	}
+	tableID := NewID()
+	joiner := AgentSession{
+		ID:        NewID(),
+		AgentID:   agentB,
+		RoomID:    roomID,
+		TableID:   tableID,
+		JoinMode:  "random",
+		Status:    "active",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	err = st.CreateMatchedTableAndSessions(ctx, tableID, roomID, 5, 10, []SeatAssignment{{SessionID: waiting.ID, AgentID: agentA, Seat: 0, BuyinCC: 1000}}, joiner, 1, 1000)
+	if err == nil || err.Error() != "insufficient_balance" {
+		t.Fatalf("expected insufficient_balance, got %v", err)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, agentA); bal != 10000 {
+		t.Fatalf("waiter buy-in must roll back, balance=%d", bal)
+	}
+	if _, err := st.GetAgentSession(ctx, joiner.ID); err != ErrNotFound {
+		t.Fatalf("joiner session must roll back, err=%v", err)
+	}
+}
+
+func TestBuyInAndCashOut(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	agentID := mustCreateAgent(t, st, ctx, "BotA", "key-a", 10000)
+	roomID, err := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if err := st.BuyIn(ctx, tableID, agentID, 4000); err != nil {
+		t.Fatalf("buy in: %v", err)
+	}
+	if err := st.BuyIn(ctx, tableID, agentID, 1000); err == nil {
+		t.Fatalf("expected second buy-in at the same table to fail")
+	}
+	if _, err := st.DebitTableStack(ctx, tableID, agentID, 5000, "bet_debit", "hand", NewID()); err == nil {
+		t.Fatalf("expected debit above the table stack to fail")
+	}
+	stack, err := st.CreditTableStack(ctx, tableID, agentID, 600, "pot_credit", "hand", NewID())
+	if err != nil || stack != 4600 {
+		t.Fatalf("credit table stack: got %d err=%v", stack, err)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 6000 {
+		t.Fatalf("table play must not touch the account, balance=%d", bal)
+	}
+
+	cashed, err := st.CashOut(ctx, tableID, agentID)
+	if err != nil || cashed != 4600 {
+		t.Fatalf("cash out: got %d err=%v", cashed, err)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 10600 {
+		t.Fatalf("unexpected balance after cash out: %d", bal)
+	}
+	if _, err := st.CashOut(ctx, tableID, agentID); err != ErrNotFound {
+		t.Fatalf("expected second cash out to find no stack, got %v", err)
+	}
+	if err := st.BuyIn(ctx, tableID, agentID, 1000); err != nil {
+		t.Fatalf("buy in again after cash out: %v", err)
+	}
 }
@@ -0,0 +1,134 @@
+package store
+
+import "testing"
+
+func TestSettleHandCommitsAtomically(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	roomID, _ := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	a := mustCreateAgent(t, st, ctx, "A", "key-a", 10000)
+	b := mustCreateAgent(t, st, ctx, "B", "key-b", 10000)
+	outsider := mustCreateAgent(t, st, ctx, "C", "key-c", 10000)
+	for _, agentID := range []string{a, b} {
+		if err := st.BuyIn(ctx, tableID, agentID, 5000); err != nil {
+			t.Fatalf("buy in: %v", err)
+		}
+	}
+	handID, err := st.CreateHand(ctx, tableID, 100)
+	if err != nil {
+		t.Fatalf("create hand: %v", err)
+	}
+	for _, agentID := range []string{a, b} {
+		if _, err := st.DebitTableStack(ctx, tableID, agentID, 100, "blind_debit", "hand", handID); err != nil {
+			t.Fatalf("post blind: %v", err)
+		}
+	}
+
+	hs := HandSettlement{
+		TableID:   tableID,
+		HandID:    handID,
+		Credits:   []PotCredit{{AgentID: a, Amount: 190}, {AgentID: outsider, Amount: 0}},
+		RakeCC:    10,
+		PotCC:     200,
+		StreetEnd: "preflop",
+		Events: []TableReplayEvent{
+			{GlobalSeq: 1, EventType: "hand_settled", ActorAgentID: a, Payload: []byte(`{}`), SchemaVer: 1},
+		},
+	}
+	// A credit to an agent without a stack at the table fails the whole settlement.
+	bad := hs
+	bad.Credits = []PotCredit{{AgentID: a, Amount: 90}, {AgentID: outsider, Amount: 100}}
+	if err := st.SettleHand(ctx, bad); err == nil {
+		t.Fatalf("expected settlement with an unseated agent to fail")
+	}
+	if stack, _ := st.GetTableStack(ctx, tableID, a); stack != 4900 {
+		t.Fatalf("failed settlement must not credit stacks, got %d", stack)
+	}
+	if h, _ := st.GetHandByID(ctx, handID); h.EndedAt != nil {
+		t.Fatalf("failed settlement must leave the hand open")
+	}
+	if events, _ := st.ListTableReplayEventsFromSeq(ctx, tableID, 0, 10); len(events) != 0 {
+		t.Fatalf("failed settlement must not write replay events, got %d", len(events))
+	}
+
+	hs.WinnerAgentID = a
+	if err := st.SettleHand(ctx, hs); err != nil {
+		t.Fatalf("settle hand: %v", err)
+	}
+	if stack, _ := st.GetTableStack(ctx, tableID, a); stack != 5090 {
+		t.Fatalf("expected winner stack 5090, got %d", stack)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, HouseAccountID); bal != 10 {
+		t.Fatalf("expected house balance 10, got %d", bal)
+	}
+	h, err := st.GetHandByID(ctx, handID)
+	if err != nil || h.EndedAt == nil || h.WinnerAgentID != a {
+		t.Fatalf("expected hand to be ended with winner %s: %+v err=%v", a, h, err)
+	}
+	if events, _ := st.ListTableReplayEventsFromSeq(ctx, tableID, 0, 10); len(events) != 1 || events[0].EventType != "hand_settled" {
+		t.Fatalf("expected the hand_settled event to be stored, got %+v", events)
+	}
+	if err := st.SettleHand(ctx, hs); err == nil {
+		t.Fatalf("expected a second settlement of the same hand to fail")
+	}
+}
+
+func TestRecoverInterruptedTables(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	roomID, _ := st.CreateRoom(ctx, "Low", 1000, 50, 100)
+	tableID, err := st.CreateTable(ctx, roomID, "active", 50, 100)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	a := mustCreateAgent(t, st, ctx, "A", "key-a", 10000)
+	b := mustCreateAgent(t, st, ctx, "B", "key-b", 10000)
+	for _, agentID := range []string{a, b} {
+		if err := st.BuyIn(ctx, tableID, agentID, 5000); err != nil {
+			t.Fatalf("buy in: %v", err)
+		}
+	}
+
+	// The first hand settled, the second was cut off mid-hand.
+	settled, _ := st.CreateHand(ctx, tableID, 100)
+	if _, err := st.DebitTableStack(ctx, tableID, b, 100, "blind_debit", "hand", settled); err != nil {
+		t.Fatalf("post blind: %v", err)
+	}
+	if err := st.SettleHand(ctx, HandSettlement{TableID: tableID, HandID: settled, Credits: []PotCredit{{AgentID: a, Amount: 100}}, WinnerAgentID: a, PotCC: 100}); err != nil {
+		t.Fatalf("settle hand: %v", err)
+	}
+	open, _ := st.CreateHand(ctx, tableID, 100)
+	if _, err := st.DebitTableStack(ctx, tableID, a, 300, "bet_debit", "hand", open); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+	if _, err := st.DebitTableStack(ctx, tableID, b, 200, "bet_debit", "hand", open); err != nil {
+		t.Fatalf("bet: %v", err)
+	}
+
+	report, err := st.RecoverInterruptedTables(ctx)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if report.Tables != 1 || report.VoidedHands != 1 || report.RefundedCC != 500 || report.CashedOutCC != 10000 {
+		t.Fatalf("unexpected recovery report: %+v", report)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, a); bal != 10100 {
+		t.Fatalf("expected A to keep the settled pot, got %d", bal)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, b); bal != 9900 {
+		t.Fatalf("expected B to get the voided bet back, got %d", bal)
+	}
+	h, err := st.GetHandByID(ctx, open)
+	if err != nil || h.EndedAt == nil || h.StreetEnd != StreetEndVoided {
+		t.Fatalf("expected the open hand to be voided: %+v err=%v", h, err)
+	}
+	if report, err := st.RecoverInterruptedTables(ctx); err != nil || report.Tables != 0 {
+		t.Fatalf("expected nothing left to recover, got %+v err=%v", report, err)
+	}
+}
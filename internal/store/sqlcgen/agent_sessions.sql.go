@@ -220,6 +220,38 @@ func (q *Queries) InsertAgentActionRequestIfAbsent(ctx context.Context, arg Inse
 	return result.RowsAffected(), nil
 }
 
+const listRecentOpponentIDs = `-- name: ListRecentOpponentIDs :many
+SELECT DISTINCT o.agent_id
+FROM agent_sessions s
+JOIN agent_sessions o ON o.table_id = s.table_id AND o.agent_id <> s.agent_id
+WHERE s.agent_id = $1 AND s.table_id IS NOT NULL AND s.created_at >= $2
+`
+
+type ListRecentOpponentIDsParams struct {
+	AgentID string
+	Since   pgtype.Timestamptz
+}
+
+func (q *Queries) ListRecentOpponentIDs(ctx context.Context, arg ListRecentOpponentIDsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listRecentOpponentIDs, arg.AgentID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var agent_id string
+		if err := rows.Scan(&agent_id); err != nil {
+			return nil, err
+		}
+		items = append(items, agent_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateAgentSessionMatch = `-- name: UpdateAgentSessionMatch :execrows
 UPDATE agent_sessions
 SET table_id = $2, seat_id = $3, status = 'active'
@@ -217,6 +217,19 @@ func (q *Queries) GetAgentKeyByAPIKeyHash(ctx context.Context, apiKeyHash string
 	return i, err
 }
 
+const getAgentOwnerID = `-- name: GetAgentOwnerID :one
+SELECT COALESCE(owner_id, '')::text AS owner_id
+FROM agents
+WHERE id = $1
+`
+
+func (q *Queries) GetAgentOwnerID(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRow(ctx, getAgentOwnerID, id)
+	var owner_id string
+	err := row.Scan(&owner_id)
+	return owner_id, err
+}
+
 const getLastSuccessfulKeyBindAtByAgentID = `-- name: GetLastSuccessfulKeyBindAtByAgentID :one
 SELECT created_at
 FROM agent_key_attempts
@@ -339,3 +352,22 @@ func (q *Queries) RecordAgentKeyAttempt(ctx context.Context, arg RecordAgentKeyA
 	)
 	return err
 }
+
+const setAgentOwnerID = `-- name: SetAgentOwnerID :execrows
+UPDATE agents
+SET owner_id = NULLIF($1::text, ''), updated_at = now()
+WHERE id = $2
+`
+
+type SetAgentOwnerIDParams struct {
+	OwnerID string
+	ID      string
+}
+
+func (q *Queries) SetAgentOwnerID(ctx context.Context, arg SetAgentOwnerIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAgentOwnerID, arg.OwnerID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
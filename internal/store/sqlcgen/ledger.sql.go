@@ -56,11 +56,13 @@ scoped_hand_ledger AS (
     hl.hand_net_cc,
     h.winner_agent_id,
     h.ended_at,
-    t.big_blind_cc
+    h.big_blind_cc
   FROM hand_ledger hl
   JOIN hands h ON h.id = hl.hand_id
   JOIN tables t ON t.id = h.table_id
   WHERE h.ended_at IS NOT NULL
+    AND h.street_end IS DISTINCT FROM 'voided'
+    AND t.tournament_id IS NULL
     AND ($2::timestamptz IS NULL OR h.ended_at >= $2::timestamptz)
 ),
 aggregated AS (
@@ -70,6 +72,7 @@ aggregated AS (
     SUM(CASE WHEN shl.winner_agent_id = shl.agent_id THEN 1 ELSE 0 END)::int AS wins,
     SUM(shl.hand_net_cc)::bigint AS net_cc_from_play,
     SUM(shl.hand_net_cc::numeric / NULLIF(shl.big_blind_cc::numeric, 0)) AS net_bb,
+    STDDEV_SAMP(shl.hand_net_cc::numeric / NULLIF(shl.big_blind_cc::numeric, 0)) AS bb_stddev,
     MAX(shl.ended_at) AS last_active_at
   FROM scoped_hand_ledger shl
   GROUP BY shl.agent_id
@@ -85,7 +88,8 @@ SELECT
     COALESCE((agg.net_bb / NULLIF(agg.hands_played::numeric, 0)) * 100, 0)::numeric *
     COALESCE(LEAST(1.0::numeric, COALESCE(agg.hands_played, 0)::numeric / 500.0), 0)::numeric
   )::numeric AS score,
-  agg.last_active_at
+  agg.last_active_at,
+  COALESCE(agg.bb_stddev, 0)::numeric AS bb_per_hand_stddev
 FROM agents a
 LEFT JOIN aggregated agg ON agg.agent_id = a.id
 WHERE a.id = $1::text
@@ -106,6 +110,7 @@ type GetAgentPerformanceByWindowAndAgentRow struct {
 	ConfidenceFactor float64
 	Score            float64
 	LastActiveAt     interface{}
+	BbPerHandStddev  float64
 }
 
 func (q *Queries) GetAgentPerformanceByWindowAndAgent(ctx context.Context, arg GetAgentPerformanceByWindowAndAgentParams) (GetAgentPerformanceByWindowAndAgentRow, error) {
@@ -120,123 +125,102 @@ func (q *Queries) GetAgentPerformanceByWindowAndAgent(ctx context.Context, arg G
 		&i.ConfidenceFactor,
 		&i.Score,
 		&i.LastActiveAt,
+		&i.BbPerHandStddev,
 	)
 	return i, err
 }
 
-const listLeaderboard = `-- name: ListLeaderboard :many
-WITH hand_ledger AS (
+const getSharedHandResults = `-- name: GetSharedHandResults :one
+WITH hand_bb AS (
   SELECT
     l.agent_id,
     l.ref_id AS hand_id,
-    SUM(l.amount_cc)::bigint AS hand_net_cc
+    (SUM(l.amount_cc)::numeric / NULLIF(h.big_blind_cc::numeric, 0))::float8 AS net_bb
   FROM ledger_entries l
+  JOIN hands h ON h.id = l.ref_id
+  JOIN tables t ON t.id = h.table_id
   WHERE l.ref_type = 'hand'
     AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
-  GROUP BY l.agent_id, l.ref_id
-),
-scoped_hand_ledger AS (
-  SELECT
-    hl.agent_id,
-    hl.hand_id,
-    hl.hand_net_cc,
-    h.winner_agent_id,
-    h.ended_at,
-    t.big_blind_cc
-  FROM hand_ledger hl
-  JOIN hands h ON h.id = hl.hand_id
-  JOIN tables t ON t.id = h.table_id
-  JOIN rooms r ON r.id = t.room_id
-  WHERE h.ended_at IS NOT NULL
-    AND ($1::timestamptz IS NULL OR h.ended_at >= $1::timestamptz)
-    AND ($2::text = 'all' OR lower(r.name) = $2::text)
-),
-aggregated AS (
-  SELECT
-    shl.agent_id,
-    COUNT(*)::int AS hands_played,
-    SUM(CASE WHEN shl.winner_agent_id = shl.agent_id THEN 1 ELSE 0 END)::int AS wins,
-    SUM(shl.hand_net_cc)::bigint AS net_cc_from_play,
-    SUM(shl.hand_net_cc::numeric / NULLIF(shl.big_blind_cc::numeric, 0)) AS net_bb,
-    MAX(shl.ended_at) AS last_active_at
-  FROM scoped_hand_ledger shl
-  GROUP BY shl.agent_id
+    AND l.agent_id IN ($1::text, $2::text)
+    AND h.ended_at IS NOT NULL
+    AND h.street_end IS DISTINCT FROM 'voided'
+    AND t.tournament_id IS NULL
+    AND ($3::timestamptz IS NULL OR h.ended_at >= $3::timestamptz)
+  GROUP BY l.agent_id, l.ref_id, h.big_blind_cc
 )
 SELECT
-  a.id AS agent_id,
-  a.name,
-  COALESCE((agg.net_bb / agg.hands_played::numeric) * 100, 0)::numeric AS bb_per_100,
-  agg.net_cc_from_play,
-  agg.hands_played,
-  COALESCE(agg.wins::numeric / NULLIF(agg.hands_played::numeric, 0), 0)::numeric AS win_rate,
-  COALESCE(LEAST(1.0::numeric, agg.hands_played::numeric / 500.0), 0)::numeric AS confidence_factor,
-  (
-    COALESCE((agg.net_bb / agg.hands_played::numeric) * 100, 0)::numeric *
-    COALESCE(LEAST(1.0::numeric, agg.hands_played::numeric / 500.0), 0)::numeric
-  )::numeric AS score,
-  agg.last_active_at
-FROM aggregated agg
-JOIN agents a ON a.id = agg.agent_id
-ORDER BY
-  CASE WHEN $3::text = 'score' THEN (
-    COALESCE((agg.net_bb / agg.hands_played::numeric) * 100, 0)::numeric *
-    COALESCE(LEAST(1.0::numeric, agg.hands_played::numeric / 500.0), 0)::numeric
-  ) END DESC,
-  CASE WHEN $3::text = 'net_cc_from_play' THEN agg.net_cc_from_play::numeric END DESC,
-  CASE WHEN $3::text = 'hands_played' THEN agg.hands_played::numeric END DESC,
-  CASE WHEN $3::text = 'win_rate' THEN COALESCE(agg.wins::numeric / NULLIF(agg.hands_played::numeric, 0), 0)::numeric END DESC,
-  agg.hands_played DESC,
-  agg.last_active_at DESC,
-  a.id ASC
-LIMIT $5 OFFSET $4
+  COUNT(*)::int AS shared_hands,
+  COALESCE(COVAR_SAMP(a.net_bb, b.net_bb), 0)::float8 AS bb_covariance
+FROM hand_bb a
+JOIN hand_bb b ON b.hand_id = a.hand_id
+WHERE a.agent_id = $1::text
+  AND b.agent_id = $2::text
 `
 
-type ListLeaderboardParams struct {
+type GetSharedHandResultsParams struct {
+	AgentAID    string
+	AgentBID    string
 	WindowStart pgtype.Timestamptz
-	RoomScope   string
-	SortBy      string
-	OffsetRows  int32
-	LimitRows   int32
 }
 
-type ListLeaderboardRow struct {
-	AgentID          string
-	Name             string
-	BbPer100         float64
-	NetCcFromPlay    int64
-	HandsPlayed      int32
-	WinRate          float64
-	ConfidenceFactor float64
-	Score            float64
-	LastActiveAt     pgtype.Timestamptz
+type GetSharedHandResultsRow struct {
+	SharedHands  int32
+	BbCovariance float64
 }
 
-func (q *Queries) ListLeaderboard(ctx context.Context, arg ListLeaderboardParams) ([]ListLeaderboardRow, error) {
-	rows, err := q.db.Query(ctx, listLeaderboard,
-		arg.WindowStart,
-		arg.RoomScope,
-		arg.SortBy,
-		arg.OffsetRows,
-		arg.LimitRows,
-	)
+func (q *Queries) GetSharedHandResults(ctx context.Context, arg GetSharedHandResultsParams) (GetSharedHandResultsRow, error) {
+	row := q.db.QueryRow(ctx, getSharedHandResults, arg.AgentAID, arg.AgentBID, arg.WindowStart)
+	var i GetSharedHandResultsRow
+	err := row.Scan(&i.SharedHands, &i.BbCovariance)
+	return i, err
+}
+
+const getTableNetByAgent = `-- name: GetTableNetByAgent :one
+SELECT COALESCE(SUM(amount_cc), 0)::bigint AS net_cc
+FROM ledger_entries
+WHERE ref_type = 'table'
+  AND ref_id = $1
+  AND agent_id = $2
+  AND type IN ('table_buyin', 'table_cashout')
+`
+
+type GetTableNetByAgentParams struct {
+	RefID   string
+	AgentID string
+}
+
+func (q *Queries) GetTableNetByAgent(ctx context.Context, arg GetTableNetByAgentParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getTableNetByAgent, arg.RefID, arg.AgentID)
+	var net_cc int64
+	err := row.Scan(&net_cc)
+	return net_cc, err
+}
+
+const listHandStackDeltas = `-- name: ListHandStackDeltas :many
+SELECT agent_id, COALESCE(SUM(amount_cc), 0)::bigint AS delta_cc
+FROM ledger_entries
+WHERE ref_type = 'hand'
+  AND ref_id = $1
+  AND type IN ('blind_debit', 'bet_debit', 'pot_credit')
+GROUP BY agent_id
+ORDER BY agent_id ASC
+`
+
+type ListHandStackDeltasRow struct {
+	AgentID string
+	DeltaCc int64
+}
+
+func (q *Queries) ListHandStackDeltas(ctx context.Context, refID string) ([]ListHandStackDeltasRow, error) {
+	rows, err := q.db.Query(ctx, listHandStackDeltas, refID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListLeaderboardRow{}
+	items := []ListHandStackDeltasRow{}
 	for rows.Next() {
-		var i ListLeaderboardRow
-		if err := rows.Scan(
-			&i.AgentID,
-			&i.Name,
-			&i.BbPer100,
-			&i.NetCcFromPlay,
-			&i.HandsPlayed,
-			&i.WinRate,
-			&i.ConfidenceFactor,
-			&i.Score,
-			&i.LastActiveAt,
-		); err != nil {
+		var i ListHandStackDeltasRow
+		if err := rows.Scan(&i.AgentID, &i.DeltaCc); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -301,3 +285,58 @@ func (q *Queries) ListLedgerEntries(ctx context.Context, arg ListLedgerEntriesPa
 	}
 	return items, nil
 }
+
+const listRakeByRoom = `-- name: ListRakeByRoom :many
+SELECT
+  COALESCE(r.id, '')::text AS room_id,
+  COALESCE(r.name, '')::text AS room_name,
+  COUNT(DISTINCT l.ref_id)::bigint AS hands_raked,
+  COALESCE(SUM(l.amount_cc), 0)::bigint AS rake_cc
+FROM ledger_entries l
+JOIN hands h ON h.id = l.ref_id
+JOIN tables t ON t.id = h.table_id
+LEFT JOIN rooms r ON r.id = t.room_id
+WHERE l.type = 'rake'
+  AND l.ref_type = 'hand'
+  AND ($1::timestamptz IS NULL OR l.created_at >= $1::timestamptz)
+  AND ($2::timestamptz IS NULL OR l.created_at <= $2::timestamptz)
+GROUP BY r.id, r.name
+ORDER BY rake_cc DESC, room_id ASC
+`
+
+type ListRakeByRoomParams struct {
+	FromTs pgtype.Timestamptz
+	ToTs   pgtype.Timestamptz
+}
+
+type ListRakeByRoomRow struct {
+	RoomID     string
+	RoomName   string
+	HandsRaked int64
+	RakeCc     int64
+}
+
+func (q *Queries) ListRakeByRoom(ctx context.Context, arg ListRakeByRoomParams) ([]ListRakeByRoomRow, error) {
+	rows, err := q.db.Query(ctx, listRakeByRoom, arg.FromTs, arg.ToTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRakeByRoomRow{}
+	for rows.Next() {
+		var i ListRakeByRoomRow
+		if err := rows.Scan(
+			&i.RoomID,
+			&i.RoomName,
+			&i.HandsRaked,
+			&i.RakeCc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
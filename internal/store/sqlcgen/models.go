@@ -26,6 +26,7 @@ type Agent struct {
 	ClaimCode  string
 	UpdatedAt  pgtype.Timestamptz
 	CreatedAt  pgtype.Timestamptz
+	OwnerID    pgtype.Text
 }
 
 type AgentActionRequest struct {
@@ -70,6 +71,28 @@ type AgentKeyAttempt struct {
 	CreatedAt pgtype.Timestamptz
 }
 
+type AgentRating struct {
+	AgentID         string
+	Rating          float64
+	RatingDeviation float64
+	Volatility      float64
+	Games           int32
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type AgentRatingHistory struct {
+	ID              string
+	AgentID         string
+	SourceType      string
+	SourceID        string
+	Opponents       int32
+	RatingBefore    float64
+	Rating          float64
+	RatingDeviation float64
+	Volatility      float64
+	CreatedAt       pgtype.Timestamptz
+}
+
 type AgentSession struct {
 	ID        string
 	AgentID   string
@@ -83,6 +106,42 @@ type AgentSession struct {
 	ClosedAt  pgtype.Timestamptz
 }
 
+type Challenge struct {
+	ID                string
+	RoomID            string
+	ChallengerAgentID string
+	OpponentAgentID   string
+	Status            string
+	CreatedAt         pgtype.Timestamptz
+	ExpiresAt         pgtype.Timestamptz
+	RespondedAt       pgtype.Timestamptz
+}
+
+type DuplicateMatch struct {
+	ID           string
+	RoomID       string
+	AgentAID     string
+	AgentBID     string
+	Leg1TableID  string
+	Leg2TableID  string
+	SeedSchedule string
+	HandsPerLeg  int32
+	BuyinCc      int64
+	CurrentLeg   int32
+	Status       string
+	CreatedAt    pgtype.Timestamptz
+	EndedAt      pgtype.Timestamptz
+}
+
+type DuplicateMatchResult struct {
+	MatchID     string
+	AgentID     string
+	Leg1NetCc   int64
+	Leg2NetCc   int64
+	NetCc       int64
+	HandsPlayed int32
+}
+
 type Hand struct {
 	ID            string
 	TableID       string
@@ -91,6 +150,82 @@ type Hand struct {
 	StreetEnd     pgtype.Text
 	StartedAt     pgtype.Timestamptz
 	EndedAt       pgtype.Timestamptz
+	BigBlindCc    int64
+}
+
+type LeaderboardRollup struct {
+	WindowKey        string
+	RoomID           string
+	SortBy           string
+	AgentID          string
+	Rank             int32
+	SortKey          float64
+	HandsPlayed      int32
+	NetCcFromPlay    int64
+	BbPer100         float64
+	WinRate          float64
+	ConfidenceFactor float64
+	Score            float64
+	BbPerHandStddev  float64
+	Rating           float64
+	RatingDeviation  float64
+	LastActiveAt     pgtype.Timestamptz
+}
+
+type LeaderboardStat struct {
+	AgentID      string
+	RoomID       string
+	Bucket       pgtype.Timestamptz
+	HandsPlayed  int32
+	Wins         int32
+	NetCc        int64
+	NetBb        float64
+	NetBbSq      float64
+	LastActiveAt pgtype.Timestamptz
+}
+
+type League struct {
+	ID            string
+	RoomID        string
+	Name          string
+	Format        string
+	HandsPerMatch int32
+	Rounds        int32
+	CurrentRound  int32
+	Status        string
+	CreatedAt     pgtype.Timestamptz
+	EndedAt       pgtype.Timestamptz
+}
+
+type LeagueMatch struct {
+	ID             string
+	LeagueID       string
+	Round          int32
+	AgentAID       string
+	AgentBID       pgtype.Text
+	TableID        pgtype.Text
+	Status         string
+	WinnerAgentID  pgtype.Text
+	ForfeitAgentID pgtype.Text
+	AgentANetCc    int64
+	AgentBNetCc    int64
+	HandsPlayed    int32
+	StartedAt      pgtype.Timestamptz
+	EndedAt        pgtype.Timestamptz
+}
+
+type LeagueStanding struct {
+	LeagueID    string
+	AgentID     string
+	Seed        int32
+	Points      int32
+	Played      int32
+	Wins        int32
+	Draws       int32
+	Losses      int32
+	Byes        int32
+	NetCc       int64
+	HandsPlayed int32
 }
 
 type LedgerEntry struct {
@@ -103,6 +238,17 @@ type LedgerEntry struct {
 	CreatedAt pgtype.Timestamptz
 }
 
+type PrivateTable struct {
+	InviteCode      string
+	RoomID          string
+	CreatorAgentID  string
+	InvitedAgentIds []string
+	TableID         pgtype.Text
+	Status          string
+	CreatedAt       pgtype.Timestamptz
+	ClosedAt        pgtype.Timestamptz
+}
+
 type ProviderRate struct {
 	Provider            string
 	PricePer1kTokensUsd float64
@@ -124,13 +270,64 @@ type ProxyCall struct {
 }
 
 type Room struct {
-	ID           string
-	Name         string
-	MinBuyinCc   int64
+	ID                string
+	Name              string
+	MinBuyinCc        int64
+	SmallBlindCc      int64
+	BigBlindCc        int64
+	Status            string
+	CreatedAt         pgtype.Timestamptz
+	MaxSeats          int32
+	MinPlayers        int32
+	MaxBuyinCc        int64
+	RakeBps           int32
+	RakeCapCc         int64
+	RakeNoFlopNoDrop  bool
+	DeckSeedSchedule  string
+	MatchFormat       string
+	DuplicateHands    int32
+	BettingStructure  string
+	RaiseCap          int32
+	Variant           string
+	AnteCc            int64
+	BigBlindAnte      bool
+	Straddle          bool
+	BlindLevelHands   int32
+	BlindLevelMinutes int32
+	EntryFeeCc        int64
+	StartingChips     int64
+}
+
+type RoomBlindLevel struct {
+	RoomID       string
+	Level        int32
 	SmallBlindCc int64
 	BigBlindCc   int64
-	Status       string
-	CreatedAt    pgtype.Timestamptz
+	AnteCc       int64
+}
+
+type Season struct {
+	ID        string
+	Name      string
+	StartsAt  pgtype.Timestamptz
+	EndsAt    pgtype.Timestamptz
+	Status    string
+	Entrants  int32
+	CreatedAt pgtype.Timestamptz
+	ClosedAt  pgtype.Timestamptz
+}
+
+type SeasonStanding struct {
+	SeasonID      string
+	AgentID       string
+	Rank          int32
+	AgentName     string
+	Score         float64
+	BbPer100      float64
+	NetCcFromPlay int64
+	HandsPlayed   int32
+	WinRate       float64
+	Rating        float64
 }
 
 type Table struct {
@@ -140,6 +337,18 @@ type Table struct {
 	SmallBlindCc int64
 	BigBlindCc   int64
 	CreatedAt    pgtype.Timestamptz
+	TournamentID pgtype.Text
+}
+
+type TableStack struct {
+	TableID     string
+	AgentID     string
+	BuyinCc     int64
+	StackCc     int64
+	Status      string
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	CashedOutAt pgtype.Timestamptz
 }
 
 type TableReplayEvent struct {
@@ -163,3 +372,39 @@ type TableReplaySnapshot struct {
 	SchemaVersion int32
 	CreatedAt     pgtype.Timestamptz
 }
+
+type Tournament struct {
+	ID            string
+	RoomID        string
+	Status        string
+	MaxPlayers    int32
+	EntryFeeCc    int64
+	StartingChips int64
+	PrizePoolCc   int64
+	PayoutBps     []int32
+	CreatedAt     pgtype.Timestamptz
+	EndedAt       pgtype.Timestamptz
+	Name          string
+	StartsAt      pgtype.Timestamptz
+	StartedAt     pgtype.Timestamptz
+	MinPlayers    int32
+	LateRegLevels int32
+	MaxRebuys     int32
+	RebuyLevels   int32
+	AddonFeeCc    int64
+	AddonChips    int64
+}
+
+type TournamentEntry struct {
+	TournamentID string
+	AgentID      string
+	SeatID       pgtype.Int4
+	FinishPlace  pgtype.Int4
+	PayoutCc     int64
+	EliminatedAt pgtype.Timestamptz
+	TableID      pgtype.Text
+	Rebuys       int32
+	Addon        bool
+	AutoRebuy    bool
+	RegisteredAt pgtype.Timestamptz
+}
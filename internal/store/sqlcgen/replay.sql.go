@@ -12,7 +12,7 @@ import (
 )
 
 const getHandByID = `-- name: GetHandByID :one
-SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at
+SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at, big_blind_cc
 FROM hands
 WHERE id = $1
 `
@@ -28,6 +28,7 @@ func (q *Queries) GetHandByID(ctx context.Context, id string) (Hand, error) {
 		&i.StreetEnd,
 		&i.StartedAt,
 		&i.EndedAt,
+		&i.BigBlindCc,
 	)
 	return i, err
 }
@@ -210,11 +211,11 @@ func (q *Queries) ListAgentTables(ctx context.Context, arg ListAgentTablesParams
 }
 
 const listHandsByAgentID = `-- name: ListHandsByAgentID :many
-SELECT h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at
+SELECT h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at, h.big_blind_cc
 FROM hands h
 JOIN actions a ON a.hand_id = h.id
 WHERE a.agent_id = $1
-GROUP BY h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at
+GROUP BY h.id, h.table_id, h.winner_agent_id, h.pot_cc, h.street_end, h.started_at, h.ended_at, h.big_blind_cc
 ORDER BY h.started_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -242,6 +243,7 @@ func (q *Queries) ListHandsByAgentID(ctx context.Context, arg ListHandsByAgentID
 			&i.StreetEnd,
 			&i.StartedAt,
 			&i.EndedAt,
+			&i.BigBlindCc,
 		); err != nil {
 			return nil, err
 		}
@@ -254,7 +256,7 @@ func (q *Queries) ListHandsByAgentID(ctx context.Context, arg ListHandsByAgentID
 }
 
 const listHandsByTableID = `-- name: ListHandsByTableID :many
-SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at
+SELECT id, table_id, winner_agent_id, pot_cc, street_end, started_at, ended_at, big_blind_cc
 FROM hands
 WHERE table_id = $1
 ORDER BY started_at ASC
@@ -277,6 +279,7 @@ func (q *Queries) ListHandsByTableID(ctx context.Context, tableID string) ([]Han
 			&i.StreetEnd,
 			&i.StartedAt,
 			&i.EndedAt,
+			&i.BigBlindCc,
 		); err != nil {
 			return nil, err
 		}
@@ -422,6 +425,50 @@ func (q *Queries) CountTableHistoryByScope(ctx context.Context, arg CountTableHi
 	return count, err
 }
 
+const listTableReplayEventsByHand = `-- name: ListTableReplayEventsByHand :many
+SELECT id, table_id, hand_id, global_seq, hand_seq, event_type, actor_agent_id, payload, schema_version, created_at
+FROM table_replay_events
+WHERE table_id = $1
+  AND hand_id = $2
+ORDER BY global_seq ASC
+`
+
+type ListTableReplayEventsByHandParams struct {
+	TableID string
+	HandID  pgtype.Text
+}
+
+func (q *Queries) ListTableReplayEventsByHand(ctx context.Context, arg ListTableReplayEventsByHandParams) ([]TableReplayEvent, error) {
+	rows, err := q.db.Query(ctx, listTableReplayEventsByHand, arg.TableID, arg.HandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TableReplayEvent{}
+	for rows.Next() {
+		var i TableReplayEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.TableID,
+			&i.HandID,
+			&i.GlobalSeq,
+			&i.HandSeq,
+			&i.EventType,
+			&i.ActorAgentID,
+			&i.Payload,
+			&i.SchemaVersion,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTableReplayEventsFromSeq = `-- name: ListTableReplayEventsFromSeq :many
 SELECT id, table_id, hand_id, global_seq, hand_seq, event_type, actor_agent_id, payload, schema_version, created_at
 FROM table_replay_events
@@ -467,3 +514,21 @@ func (q *Queries) ListTableReplayEventsFromSeq(ctx context.Context, arg ListTabl
 	}
 	return items, nil
 }
+
+const setTableReplayEventEquity = `-- name: SetTableReplayEventEquity :exec
+UPDATE table_replay_events
+SET payload = jsonb_set(payload, '{equity}', $1::jsonb)
+WHERE table_id = $2
+  AND global_seq = $3
+`
+
+type SetTableReplayEventEquityParams struct {
+	Equity    []byte
+	TableID   string
+	GlobalSeq int64
+}
+
+func (q *Queries) SetTableReplayEventEquity(ctx context.Context, arg SetTableReplayEventEquityParams) error {
+	_, err := q.db.Exec(ctx, setTableReplayEventEquity, arg.Equity, arg.TableID, arg.GlobalSeq)
+	return err
+}
@@ -23,32 +23,67 @@ func (q *Queries) CountRooms(ctx context.Context) (int32, error) {
 	return column_1, err
 }
 
+const countSettledHandsByTable = `-- name: CountSettledHandsByTable :one
+SELECT COUNT(*)::int
+FROM hands
+WHERE table_id = $1
+  AND ended_at IS NOT NULL
+  AND street_end IS DISTINCT FROM 'voided'
+`
+
+func (q *Queries) CountSettledHandsByTable(ctx context.Context, tableID string) (int32, error) {
+	row := q.db.QueryRow(ctx, countSettledHandsByTable, tableID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createHand = `-- name: CreateHand :exec
-INSERT INTO hands (id, table_id)
-VALUES ($1, $2)
+INSERT INTO hands (id, table_id, big_blind_cc)
+VALUES ($1, $2, $3)
 `
 
 type CreateHandParams struct {
-	ID      string
-	TableID string
+	ID         string
+	TableID    string
+	BigBlindCc int64
 }
 
 func (q *Queries) CreateHand(ctx context.Context, arg CreateHandParams) error {
-	_, err := q.db.Exec(ctx, createHand, arg.ID, arg.TableID)
+	_, err := q.db.Exec(ctx, createHand, arg.ID, arg.TableID, arg.BigBlindCc)
 	return err
 }
 
 const createRoom = `-- name: CreateRoom :exec
-INSERT INTO rooms (id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status)
-VALUES ($1, $2, $3, $4, $5, 'active')
+INSERT INTO rooms (id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips)
+VALUES ($1, $2, $3, $4, $5, 'active', $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
 `
 
 type CreateRoomParams struct {
-	ID           string
-	Name         string
-	MinBuyinCc   int64
-	SmallBlindCc int64
-	BigBlindCc   int64
+	ID                string
+	Name              string
+	MinBuyinCc        int64
+	SmallBlindCc      int64
+	BigBlindCc        int64
+	MaxSeats          int32
+	MinPlayers        int32
+	MaxBuyinCc        int64
+	RakeBps           int32
+	RakeCapCc         int64
+	RakeNoFlopNoDrop  bool
+	DeckSeedSchedule  string
+	MatchFormat       string
+	DuplicateHands    int32
+	BettingStructure  string
+	RaiseCap          int32
+	Variant           string
+	AnteCc            int64
+	BigBlindAnte      bool
+	Straddle          bool
+	BlindLevelHands   int32
+	BlindLevelMinutes int32
+	EntryFeeCc        int64
+	StartingChips     int64
 }
 
 func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) error {
@@ -58,6 +93,25 @@ func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) error {
 		arg.MinBuyinCc,
 		arg.SmallBlindCc,
 		arg.BigBlindCc,
+		arg.MaxSeats,
+		arg.MinPlayers,
+		arg.MaxBuyinCc,
+		arg.RakeBps,
+		arg.RakeCapCc,
+		arg.RakeNoFlopNoDrop,
+		arg.DeckSeedSchedule,
+		arg.MatchFormat,
+		arg.DuplicateHands,
+		arg.BettingStructure,
+		arg.RaiseCap,
+		arg.Variant,
+		arg.AnteCc,
+		arg.BigBlindAnte,
+		arg.Straddle,
+		arg.BlindLevelHands,
+		arg.BlindLevelMinutes,
+		arg.EntryFeeCc,
+		arg.StartingChips,
 	)
 	return err
 }
@@ -86,6 +140,30 @@ func (q *Queries) CreateTable(ctx context.Context, arg CreateTableParams) error
 	return err
 }
 
+const createTournamentTable = `-- name: CreateTournamentTable :exec
+INSERT INTO tables (id, room_id, status, small_blind_cc, big_blind_cc, tournament_id)
+VALUES ($1, $2, 'active', $3, $4, $5)
+`
+
+type CreateTournamentTableParams struct {
+	ID           string
+	RoomID       pgtype.Text
+	SmallBlindCc int64
+	BigBlindCc   int64
+	TournamentID pgtype.Text
+}
+
+func (q *Queries) CreateTournamentTable(ctx context.Context, arg CreateTournamentTableParams) error {
+	_, err := q.db.Exec(ctx, createTournamentTable,
+		arg.ID,
+		arg.RoomID,
+		arg.SmallBlindCc,
+		arg.BigBlindCc,
+		arg.TournamentID,
+	)
+	return err
+}
+
 const endHand = `-- name: EndHand :exec
 UPDATE hands
 SET ended_at = now(),
@@ -112,8 +190,38 @@ func (q *Queries) EndHand(ctx context.Context, arg EndHandParams) error {
 	return err
 }
 
+const endOpenHand = `-- name: EndOpenHand :execrows
+UPDATE hands
+SET ended_at = now(),
+    winner_agent_id = NULLIF($1::text, ''),
+    pot_cc = $2,
+    street_end = NULLIF($3::text, '')
+WHERE id = $4
+  AND ended_at IS NULL
+`
+
+type EndOpenHandParams struct {
+	WinnerAgentID string
+	PotCc         pgtype.Int8
+	StreetEnd     string
+	HandID        string
+}
+
+func (q *Queries) EndOpenHand(ctx context.Context, arg EndOpenHandParams) (int64, error) {
+	result, err := q.db.Exec(ctx, endOpenHand,
+		arg.WinnerAgentID,
+		arg.PotCc,
+		arg.StreetEnd,
+		arg.HandID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getRoomByID = `-- name: GetRoomByID :one
-SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at
+SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips
 FROM rooms
 WHERE id = $1
 `
@@ -129,12 +237,129 @@ func (q *Queries) GetRoomByID(ctx context.Context, id string) (Room, error) {
 		&i.BigBlindCc,
 		&i.Status,
 		&i.CreatedAt,
+		&i.MaxSeats,
+		&i.MinPlayers,
+		&i.MaxBuyinCc,
+		&i.RakeBps,
+		&i.RakeCapCc,
+		&i.RakeNoFlopNoDrop,
+		&i.DeckSeedSchedule,
+		&i.MatchFormat,
+		&i.DuplicateHands,
+		&i.BettingStructure,
+		&i.RaiseCap,
+		&i.Variant,
+		&i.AnteCc,
+		&i.BigBlindAnte,
+		&i.Straddle,
+		&i.BlindLevelHands,
+		&i.BlindLevelMinutes,
+		&i.EntryFeeCc,
+		&i.StartingChips,
 	)
 	return i, err
 }
 
+const getTableTournamentID = `-- name: GetTableTournamentID :one
+SELECT COALESCE(tournament_id, '')::text
+FROM tables
+WHERE id = $1
+`
+
+func (q *Queries) GetTableTournamentID(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRow(ctx, getTableTournamentID, id)
+	var column_1 string
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const insertRoomBlindLevel = `-- name: InsertRoomBlindLevel :exec
+INSERT INTO room_blind_levels (room_id, level, small_blind_cc, big_blind_cc, ante_cc)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertRoomBlindLevelParams struct {
+	RoomID       string
+	Level        int32
+	SmallBlindCc int64
+	BigBlindCc   int64
+	AnteCc       int64
+}
+
+func (q *Queries) InsertRoomBlindLevel(ctx context.Context, arg InsertRoomBlindLevelParams) error {
+	_, err := q.db.Exec(ctx, insertRoomBlindLevel,
+		arg.RoomID,
+		arg.Level,
+		arg.SmallBlindCc,
+		arg.BigBlindCc,
+		arg.AnteCc,
+	)
+	return err
+}
+
+const listOpenHandIDsByTable = `-- name: ListOpenHandIDsByTable :many
+SELECT id
+FROM hands
+WHERE table_id = $1
+  AND ended_at IS NULL
+ORDER BY started_at ASC
+`
+
+func (q *Queries) ListOpenHandIDsByTable(ctx context.Context, tableID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listOpenHandIDsByTable, tableID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomBlindLevels = `-- name: ListRoomBlindLevels :many
+SELECT room_id, level, small_blind_cc, big_blind_cc, ante_cc
+FROM room_blind_levels
+WHERE room_id = $1
+ORDER BY level ASC
+`
+
+func (q *Queries) ListRoomBlindLevels(ctx context.Context, roomID string) ([]RoomBlindLevel, error) {
+	rows, err := q.db.Query(ctx, listRoomBlindLevels, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RoomBlindLevel{}
+	for rows.Next() {
+		var i RoomBlindLevel
+		if err := rows.Scan(
+			&i.RoomID,
+			&i.Level,
+			&i.SmallBlindCc,
+			&i.BigBlindCc,
+			&i.AnteCc,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRooms = `-- name: ListRooms :many
-SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at
+SELECT id, name, min_buyin_cc, small_blind_cc, big_blind_cc, status, created_at, max_seats, min_players, max_buyin_cc, rake_bps, rake_cap_cc, rake_no_flop_no_drop, deck_seed_schedule, match_format, duplicate_hands, betting_structure, raise_cap, variant, ante_cc, big_blind_ante, straddle, blind_level_hands, blind_level_minutes, entry_fee_cc, starting_chips
 FROM rooms
 WHERE status = 'active'
 ORDER BY min_buyin_cc ASC
@@ -157,6 +382,25 @@ func (q *Queries) ListRooms(ctx context.Context) ([]Room, error) {
 			&i.BigBlindCc,
 			&i.Status,
 			&i.CreatedAt,
+			&i.MaxSeats,
+			&i.MinPlayers,
+			&i.MaxBuyinCc,
+			&i.RakeBps,
+			&i.RakeCapCc,
+			&i.RakeNoFlopNoDrop,
+			&i.DeckSeedSchedule,
+			&i.MatchFormat,
+			&i.DuplicateHands,
+			&i.BettingStructure,
+			&i.RaiseCap,
+			&i.Variant,
+			&i.AnteCc,
+			&i.BigBlindAnte,
+			&i.Straddle,
+			&i.BlindLevelHands,
+			&i.BlindLevelMinutes,
+			&i.EntryFeeCc,
+			&i.StartingChips,
 		); err != nil {
 			return nil, err
 		}
@@ -169,7 +413,7 @@ func (q *Queries) ListRooms(ctx context.Context) ([]Room, error) {
 }
 
 const listTables = `-- name: ListTables :many
-SELECT id, room_id, status, small_blind_cc, big_blind_cc, created_at
+SELECT id, room_id, status, small_blind_cc, big_blind_cc, created_at, tournament_id
 FROM tables
 WHERE status = 'active'
   AND ($1::text = '' OR room_id = $1::text)
@@ -199,6 +443,7 @@ func (q *Queries) ListTables(ctx context.Context, arg ListTablesParams) ([]Table
 			&i.SmallBlindCc,
 			&i.BigBlindCc,
 			&i.CreatedAt,
+			&i.TournamentID,
 		); err != nil {
 			return nil, err
 		}
@@ -210,6 +455,38 @@ func (q *Queries) ListTables(ctx context.Context, arg ListTablesParams) ([]Table
 	return items, nil
 }
 
+const listTableIDsToRecover = `-- name: ListTableIDsToRecover :many
+SELECT t.id
+FROM tables t
+WHERE t.status <> 'closed'
+   OR EXISTS (
+     SELECT 1
+     FROM table_stacks ts
+     WHERE ts.table_id = t.id AND ts.status = 'seated'
+   )
+ORDER BY t.created_at ASC
+`
+
+func (q *Queries) ListTableIDsToRecover(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listTableIDsToRecover)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markTableStatusByID = `-- name: MarkTableStatusByID :execrows
 UPDATE tables
 SET status = $2
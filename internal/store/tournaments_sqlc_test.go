@@ -0,0 +1,77 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSitAndGoPaysOutPrizePool(t *testing.T) {
+	st, ctx, cleanup := openStore(t)
+	defer cleanup()
+
+	a := mustCreateAgent(t, st, ctx, "BotA", "key-a", 10000)
+	b := mustCreateAgent(t, st, ctx, "BotB", "key-b", 10000)
+	roomID, err := st.CreateRoomWithConfig(ctx, Room{
+		Name:          "SNG",
+		SmallBlindCC:  10,
+		BigBlindCC:    20,
+		MaxSeats:      2,
+		MatchFormat:   MatchFormatSitAndGo,
+		EntryFeeCC:    1000,
+		StartingChips: 1500,
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	seat := 0
+	waiter := AgentSession{ID: NewID(), AgentID: a, RoomID: roomID, SeatID: &seat, JoinMode: "select", Status: "waiting", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.CreateAgentSession(ctx, waiter); err != nil {
+		t.Fatalf("create waiter: %v", err)
+	}
+	tour := Tournament{
+		ID:            NewID(),
+		RoomID:        roomID,
+		MaxPlayers:    2,
+		EntryFeeCC:    1000,
+		StartingChips: 1500,
+		PayoutBps:     []int{10000},
+	}
+	tableID := NewID()
+	joiner := AgentSession{ID: NewID(), AgentID: b, RoomID: roomID, TableID: tableID, JoinMode: "select", Status: "active", ExpiresAt: time.Now().Add(time.Hour)}
+	err = st.CreateSitAndGo(ctx, tour, tableID, 10, 20, []SeatAssignment{{SessionID: waiter.ID, AgentID: a, Seat: 0}}, joiner, 1)
+	if err != nil {
+		t.Fatalf("create sit and go: %v", err)
+	}
+	for _, agentID := range []string{a, b} {
+		if bal, _ := st.GetAccountBalance(ctx, agentID); bal != 9000 {
+			t.Fatalf("expected entry fee charged for %s, balance=%d", agentID, bal)
+		}
+	}
+
+	if err := st.EliminateTournamentEntry(ctx, tour.ID, b, 2); err != nil {
+		t.Fatalf("eliminate: %v", err)
+	}
+	results := []TournamentResult{{AgentID: a, Place: 1, PayoutCC: 2000}, {AgentID: b, Place: 2}}
+	if err := st.FinishTournament(ctx, tour.ID, results); err != nil {
+		t.Fatalf("finish tournament: %v", err)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, a); bal != 11000 {
+		t.Fatalf("expected winner paid the pool, balance=%d", bal)
+	}
+	if bal, _ := st.GetAccountBalance(ctx, b); bal != 9000 {
+		t.Fatalf("expected loser unpaid, balance=%d", bal)
+	}
+	got, err := st.GetTournament(ctx, tour.ID)
+	if err != nil || got.Status != TournamentCompleted || got.PrizePoolCC != 2000 || got.EndedAt == nil {
+		t.Fatalf("expected completed tournament, got %+v err=%v", got, err)
+	}
+	entries, err := st.ListTournamentEntries(ctx, []string{tour.ID})
+	if err != nil || len(entries) != 2 || entries[0].AgentID != a || entries[0].FinishPlace == nil || *entries[0].FinishPlace != 1 {
+		t.Fatalf("unexpected entries %+v err=%v", entries, err)
+	}
+	if err := st.AbortTournament(ctx, tour.ID); !errors.Is(err, ErrTournamentClosed) {
+		t.Fatalf("expected closed tournament error, got %v", err)
+	}
+}
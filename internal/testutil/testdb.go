@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -67,27 +68,37 @@ func OpenTestStore(t *testing.T) (*store.Store, func()) {
 }
 
 func applySchema(st *store.Store) error {
-	path, err := findInitMigrationPath()
+	paths, err := findUpMigrationPaths()
 	if err != nil {
 		return err
 	}
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return err
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := st.Pool.Exec(context.Background(), string(b)); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
 	}
-	_, err = st.Pool.Exec(context.Background(), string(b))
-	return err
+	return nil
 }
 
-func findInitMigrationPath() (string, error) {
+// findUpMigrationPaths returns every *.up.sql migration in apply order.
+func findUpMigrationPaths() ([]string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	for i := 0; i < 6; i++ {
 		p := filepath.Join(dir, "migrations", "000001_init.up.sql")
 		if _, err := os.Stat(p); err == nil {
-			return p, nil
+			paths, err := filepath.Glob(filepath.Join(dir, "migrations", "*.up.sql"))
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(paths)
+			return paths, nil
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
@@ -95,7 +106,7 @@ func findInitMigrationPath() (string, error) {
 		}
 		dir = parent
 	}
-	return "", fmt.Errorf("000001_init.up.sql not found from %s", dir)
+	return nil, fmt.Errorf("000001_init.up.sql not found from %s", dir)
 }
 
 func withSearchPath(dsn, schema string) string {
@@ -2,12 +2,17 @@ package httptransport
 
 import (
 	"encoding/json"
+	"errors"
+	"math/bits"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"silicon-casino/internal/game"
 	"silicon-casino/internal/store"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AdminHandlers struct {
@@ -41,6 +46,35 @@ func (h *AdminHandlers) Agents() http.HandlerFunc {
 	}
 }
 
+// AgentOwner sets the owner an agent is run by. Ranked matchmaking never
+// pairs two agents of the same owner; an empty owner_id clears it.
+func (h *AdminHandlers) AgentOwner() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			OwnerID string `json:"owner_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
+			return
+		}
+		agentID := chi.URLParam(r, "agent_id")
+		ownerID := strings.TrimSpace(body.OwnerID)
+		if agentID == "" || len(ownerID) > maxOwnerIDLen {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		if err := h.store.SetAgentOwner(r.Context(), agentID, ownerID); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				WriteHTTPError(w, http.StatusNotFound, "agent_not_found")
+				return
+			}
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "agent_id": agentID, "owner_id": ownerID})
+	}
+}
+
 func (h *AdminHandlers) Ledger() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		limit, offset := ParsePagination(r)
@@ -64,6 +98,37 @@ func (h *AdminHandlers) Ledger() http.HandlerFunc {
 	}
 }
 
+func (h *AdminHandlers) Rake() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var from, to *time.Time
+		if v := r.URL.Query().Get("from"); v != "" {
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				from = &t
+			}
+		}
+		if v := r.URL.Query().Get("to"); v != "" {
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				to = &t
+			}
+		}
+		items, err := h.store.ListRakeByRoom(r.Context(), from, to)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		balance, err := h.store.GetAccountBalance(r.Context(), store.HouseAccountID)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		var total int64
+		for _, it := range items {
+			total += it.RakeCC
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total_rake_cc": total, "house_balance_cc": balance})
+	}
+}
+
 func (h *AdminHandlers) Topup() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var body struct {
@@ -88,6 +153,18 @@ func (h *AdminHandlers) Topup() http.HandlerFunc {
 	}
 }
 
+const (
+	maxDeckSeedScheduleLen = 128
+	maxDuplicateHands      = 1000
+	maxRaiseCap            = 10
+	maxBlindLevels         = 50
+	maxTournamentPlayers   = 1000
+	maxLeaguePlayers       = 64
+	maxLeagueHands         = 1000
+	maxOwnerIDLen          = 128
+	maxSeasonNameLen       = 128
+)
+
 func (h *AdminHandlers) Rooms() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -100,20 +177,173 @@ func (h *AdminHandlers) Rooms() http.HandlerFunc {
 			_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
 		case http.MethodPost:
 			var body struct {
-				Name       string `json:"name"`
-				MinBuyinCC int64  `json:"min_buyin_cc"`
-				SmallBlind int64  `json:"small_blind_cc"`
-				BigBlind   int64  `json:"big_blind_cc"`
+				Name         string             `json:"name"`
+				MinBuyinCC   int64              `json:"min_buyin_cc"`
+				MaxBuyinCC   int64              `json:"max_buyin_cc"`
+				SmallBlind   int64              `json:"small_blind_cc"`
+				BigBlind     int64              `json:"big_blind_cc"`
+				MaxSeats     int                `json:"max_seats"`
+				MinPlayers   int                `json:"min_players"`
+				RakeBps      int                `json:"rake_bps"`
+				RakeCapCC    int64              `json:"rake_cap_cc"`
+				NoFlopNoDrop *bool              `json:"rake_no_flop_no_drop"`
+				SeedSchedule string             `json:"deck_seed_schedule"`
+				MatchFormat  string             `json:"match_format"`
+				DupHands     int                `json:"duplicate_hands"`
+				Variant      string             `json:"variant"`
+				AnteCC       int64              `json:"ante_cc"`
+				BigBlindAnte bool               `json:"big_blind_ante"`
+				Straddle     bool               `json:"straddle"`
+				Betting      string             `json:"betting_structure"`
+				RaiseCap     int                `json:"raise_cap"`
+				LevelHands   int                `json:"blind_level_hands"`
+				LevelMinutes int                `json:"blind_level_minutes"`
+				BlindLevels  []store.BlindLevel `json:"blind_levels"`
+				EntryFeeCC   int64              `json:"entry_fee_cc"`
+				StartChips   int64              `json:"starting_chips"`
 			}
 			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 				WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
 				return
 			}
+			// A sit-and-go is bought into for exactly its entry fee and
+			// starts once every seat is taken.
+			if body.MatchFormat == store.MatchFormatSitAndGo {
+				body.MinBuyinCC = body.EntryFeeCC
+				body.MinPlayers = body.MaxSeats
+			}
+			// A multi-table room is the template of its scheduled
+			// tournaments, whose tables start with any two players.
+			if body.MatchFormat == store.MatchFormatMultiTable {
+				body.MinBuyinCC = body.EntryFeeCC
+				body.MinPlayers = 2
+			}
 			if body.Name == "" || body.MinBuyinCC <= 0 || body.SmallBlind <= 0 || body.BigBlind <= 0 {
 				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
 				return
 			}
-			id, err := h.store.CreateRoom(r.Context(), body.Name, body.MinBuyinCC, body.SmallBlind, body.BigBlind)
+			if body.MaxBuyinCC != 0 && (body.MaxBuyinCC < body.MinBuyinCC || body.MaxBuyinCC < body.BigBlind) {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			if body.RakeBps < 0 || body.RakeBps > 10000 || body.RakeCapCC < 0 {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			if body.AnteCC < 0 || body.AnteCC > body.BigBlind || (body.BigBlindAnte && body.AnteCC == 0) {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			if !validBlindSchedule(body.BigBlind, body.BlindLevels, body.LevelHands, body.LevelMinutes) {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			if len(body.SeedSchedule) > maxDeckSeedScheduleLen {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			noFlopNoDrop := body.NoFlopNoDrop == nil || *body.NoFlopNoDrop
+			if body.MaxSeats == 0 {
+				body.MaxSeats = store.DefaultMaxSeats
+			}
+			if body.MinPlayers == 0 {
+				body.MinPlayers = store.DefaultMinPlayers
+			}
+			if body.MaxSeats < 2 || body.MaxSeats > store.MaxTableSeats || body.MinPlayers < 2 || body.MinPlayers > body.MaxSeats {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			tournament := body.MatchFormat == store.MatchFormatSitAndGo || body.MatchFormat == store.MatchFormatMultiTable
+			if !tournament && (body.EntryFeeCC != 0 || body.StartChips != 0) {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			switch body.MatchFormat {
+			case "", store.MatchFormatStandard:
+				if body.DupHands != 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			case store.MatchFormatDuplicate:
+				// A duplicate match is heads-up and deals from its own
+				// seed schedule; both legs must raise the blinds at the
+				// same hands.
+				if body.MaxSeats != 2 || body.SeedSchedule != "" || body.DupHands < 0 || body.DupHands > maxDuplicateHands || body.LevelMinutes != 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			case store.MatchFormatSitAndGo:
+				// Heads-up or 6-max, played for tournament chips with
+				// rising blinds and no rake.
+				if (body.MaxSeats != 2 && body.MaxSeats != 6) || body.MaxBuyinCC != 0 || body.StartChips < body.BigBlind || body.DupHands != 0 || body.RakeBps != 0 || len(body.BlindLevels) == 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			case store.MatchFormatMultiTable:
+				// Tables of three or more, so a starting field always
+				// splits into tables of at least two, with blinds that
+				// rise on the tournament clock.
+				if body.MaxSeats < 3 || body.MaxBuyinCC != 0 || body.StartChips < body.BigBlind || body.DupHands != 0 || body.RakeBps != 0 || len(body.BlindLevels) == 0 || body.LevelMinutes == 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			case store.MatchFormatLeague:
+				// League matches are heads-up and run for the league's
+				// hands per match.
+				if body.MaxSeats != 2 || body.DupHands != 0 || body.LevelMinutes != 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			default:
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			switch body.Variant {
+			case "", store.VariantHoldem, store.VariantOmaha, store.VariantShortDeck:
+			default:
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			switch body.Betting {
+			case "", store.BettingNoLimit, store.BettingPotLimit:
+				if body.RaiseCap != 0 {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			case store.BettingFixedLimit:
+				if body.RaiseCap < 0 || body.RaiseCap > maxRaiseCap {
+					WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+					return
+				}
+			default:
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			id, err := h.store.CreateRoomWithConfig(r.Context(), store.Room{
+				Name:              body.Name,
+				MinBuyinCC:        body.MinBuyinCC,
+				MaxBuyinCC:        body.MaxBuyinCC,
+				SmallBlindCC:      body.SmallBlind,
+				BigBlindCC:        body.BigBlind,
+				MaxSeats:          body.MaxSeats,
+				MinPlayers:        body.MinPlayers,
+				RakeBps:           body.RakeBps,
+				RakeCapCC:         body.RakeCapCC,
+				RakeNoFlopNoDrop:  noFlopNoDrop,
+				DeckSeedSchedule:  body.SeedSchedule,
+				MatchFormat:       body.MatchFormat,
+				DuplicateHands:    body.DupHands,
+				Variant:           body.Variant,
+				AnteCC:            body.AnteCC,
+				BigBlindAnte:      body.BigBlindAnte,
+				Straddle:          body.Straddle,
+				BettingStructure:  body.Betting,
+				RaiseCap:          body.RaiseCap,
+				BlindLevelHands:   body.LevelHands,
+				BlindLevelMinutes: body.LevelMinutes,
+				EntryFeeCC:        body.EntryFeeCC,
+				StartingChips:     body.StartChips,
+			}, body.BlindLevels...)
 			if err != nil {
 				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
 				return
@@ -125,6 +355,205 @@ func (h *AdminHandlers) Rooms() http.HandlerFunc {
 	}
 }
 
+// Tournaments schedules a multi-table tournament in a multi_table room. The
+// room sets the table size, blinds, entry fee and starting chips.
+func (h *AdminHandlers) Tournaments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			RoomID        string    `json:"room_id"`
+			Name          string    `json:"name"`
+			StartsAt      time.Time `json:"starts_at"`
+			MinPlayers    int       `json:"min_players"`
+			MaxPlayers    int       `json:"max_players"`
+			LateRegLevels int       `json:"late_reg_levels"`
+			MaxRebuys     int       `json:"max_rebuys"`
+			RebuyLevels   int       `json:"rebuy_levels"`
+			AddonFeeCC    int64     `json:"addon_fee_cc"`
+			AddonChips    int64     `json:"addon_chips"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
+			return
+		}
+		room, err := h.store.GetRoom(r.Context(), body.RoomID)
+		if err != nil || room.MatchFormat != store.MatchFormatMultiTable {
+			WriteHTTPError(w, http.StatusNotFound, "room_not_found")
+			return
+		}
+		if body.MinPlayers == 0 {
+			body.MinPlayers = 2
+		}
+		if body.Name == "" || !body.StartsAt.After(time.Now()) || body.MinPlayers < 2 || body.MaxPlayers < body.MinPlayers || body.MaxPlayers > maxTournamentPlayers {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		// Rebuys and the add-on are sold during the first rebuy_levels
+		// blind levels, the add-on in the last of them.
+		if body.LateRegLevels < 0 || body.MaxRebuys < 0 || body.RebuyLevels < 0 || (body.MaxRebuys > 0) != (body.RebuyLevels > 0) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		if body.AddonFeeCC < 0 || body.AddonChips < 0 || (body.AddonFeeCC > 0) != (body.AddonChips > 0) || (body.AddonChips > 0 && body.RebuyLevels == 0) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		startsAt := body.StartsAt
+		t := store.Tournament{
+			ID:            store.NewID(),
+			RoomID:        room.ID,
+			Name:          body.Name,
+			MaxPlayers:    body.MaxPlayers,
+			EntryFeeCC:    room.EntryFeeCC,
+			StartingChips: room.StartingChips,
+			StartsAt:      &startsAt,
+			MinPlayers:    body.MinPlayers,
+			LateRegLevels: body.LateRegLevels,
+			MaxRebuys:     body.MaxRebuys,
+			RebuyLevels:   body.RebuyLevels,
+			AddonFeeCC:    body.AddonFeeCC,
+			AddonChips:    body.AddonChips,
+		}
+		if err := h.store.ScheduleTournament(r.Context(), t); err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "tournament_id": t.ID})
+	}
+}
+
+// Seasons schedules a leaderboard season. Seasons may not overlap; one is
+// archived once its end has passed.
+func (h *AdminHandlers) Seasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name     string    `json:"name"`
+			StartsAt time.Time `json:"starts_at"`
+			EndsAt   time.Time `json:"ends_at"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
+			return
+		}
+		if body.Name == "" || len(body.Name) > maxSeasonNameLen || body.StartsAt.IsZero() || !body.EndsAt.After(body.StartsAt) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		season := store.Season{
+			ID:       store.NewID(),
+			Name:     body.Name,
+			StartsAt: body.StartsAt,
+			EndsAt:   body.EndsAt,
+		}
+		if err := h.store.CreateSeason(r.Context(), season); err != nil {
+			if errors.Is(err, store.ErrSeasonOverlap) {
+				WriteHTTPError(w, http.StatusConflict, "season_overlap")
+				return
+			}
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "season_id": season.ID})
+	}
+}
+
+// Leagues creates a round-robin or Swiss league in a league room between the
+// given agents. Round robin plays everyone once; Swiss defaults to enough
+// rounds to separate a single leader.
+func (h *AdminHandlers) Leagues() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			RoomID        string   `json:"room_id"`
+			Name          string   `json:"name"`
+			Format        string   `json:"format"`
+			HandsPerMatch int      `json:"hands_per_match"`
+			Rounds        int      `json:"rounds"`
+			Participants  []string `json:"participants"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
+			return
+		}
+		room, err := h.store.GetRoom(r.Context(), body.RoomID)
+		if err != nil || room.MatchFormat != store.MatchFormatLeague {
+			WriteHTTPError(w, http.StatusNotFound, "room_not_found")
+			return
+		}
+		n := len(body.Participants)
+		if body.Name == "" || body.HandsPerMatch <= 0 || body.HandsPerMatch > maxLeagueHands || n < 2 || n > maxLeaguePlayers {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		switch body.Format {
+		case store.LeagueRoundRobin:
+			if body.Rounds != 0 {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			body.Rounds = game.RoundRobinRounds(n)
+		case store.LeagueSwiss:
+			if body.Rounds == 0 {
+				body.Rounds = bits.Len(uint(n - 1))
+			}
+			if body.Rounds < 1 || body.Rounds > n-1 {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+		default:
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		seen := make(map[string]bool, n)
+		for _, agentID := range body.Participants {
+			if seen[agentID] {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			seen[agentID] = true
+			if _, err := h.store.GetAgentByID(r.Context(), agentID); err != nil {
+				WriteHTTPError(w, http.StatusNotFound, "agent_not_found")
+				return
+			}
+		}
+		l := store.League{
+			ID:            store.NewID(),
+			RoomID:        room.ID,
+			Name:          body.Name,
+			Format:        body.Format,
+			HandsPerMatch: body.HandsPerMatch,
+			Rounds:        body.Rounds,
+		}
+		if err := h.store.CreateLeague(r.Context(), l, body.Participants); err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "league_id": l.ID})
+	}
+}
+
+// validBlindSchedule reports whether levels can follow a room's big blind bb:
+// each level's big blind at least the previous one and at least its small
+// blind, an ante of at most the big blind, and exactly one of hands and
+// minutes set when there are levels and neither when there are none.
+func validBlindSchedule(bb int64, levels []store.BlindLevel, hands, minutes int) bool {
+	if hands < 0 || minutes < 0 || len(levels) > maxBlindLevels {
+		return false
+	}
+	if len(levels) == 0 {
+		return hands == 0 && minutes == 0
+	}
+	if (hands == 0) == (minutes == 0) {
+		return false
+	}
+	prev := bb
+	for _, l := range levels {
+		if l.SmallBlindCC <= 0 || l.BigBlindCC < l.SmallBlindCC || l.BigBlindCC < prev || l.AnteCC < 0 || l.AnteCC > l.BigBlindCC {
+			return false
+		}
+		prev = l.BigBlindCC
+	}
+	return true
+}
+
 func (h *AdminHandlers) ProviderRates() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -0,0 +1,55 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silicon-casino/internal/agentgateway"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ChallengesListHandler lists the pending challenges the authenticated agent
+// has sent or received. A challenge is accepted by opening a session with
+// join_mode "challenge" and its challenge_id.
+func ChallengesListHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		agent, ok := AgentFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		items, err := coord.ListChallenges(r.Context(), agent.ID)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+	}
+}
+
+// ChallengeDeclineHandler turns down a challenge sent to the authenticated
+// agent.
+func ChallengeDeclineHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		agent, ok := AgentFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := coord.DeclineChallenge(r.Context(), agent.ID, chi.URLParam(r, "challenge_id")); err != nil {
+			switch {
+			case agentgateway.IsChallengeNotFound(err):
+				WriteHTTPError(w, http.StatusNotFound, "challenge_not_found")
+			case agentgateway.IsChallengeClosed(err):
+				WriteHTTPError(w, http.StatusConflict, "challenge_closed")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "status": "declined"})
+	}
+}
@@ -29,3 +29,26 @@ func StateHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
 		_ = json.NewEncoder(w).Encode(state)
 	}
 }
+
+// SessionStatusHandler reports whether the session is still queued, with
+// its place in the queue, or already seated.
+func SessionStatusHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID := chi.URLParam(r, "session_id")
+		if sessionID == "" {
+			WriteHTTPError(w, http.StatusBadRequest, "session_not_found")
+			return
+		}
+		status, err := coord.GetSessionStatus(sessionID)
+		if err != nil {
+			if agentgateway.IsSessionNotFound(err) {
+				WriteHTTPError(w, http.StatusNotFound, "session_not_found")
+				return
+			}
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
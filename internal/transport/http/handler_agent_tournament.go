@@ -0,0 +1,89 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"silicon-casino/internal/agentgateway"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TournamentRegisterHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req agentgateway.RegisterTournamentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_json")
+			return
+		}
+		res, err := coord.RegisterTournament(r.Context(), req)
+		if err != nil {
+			status, code := agentgateway.MapSessionCreateError(err)
+			WriteHTTPError(w, status, code)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}
+}
+
+func TournamentStatusHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID := chi.URLParam(r, "session_id")
+		if sessionID == "" {
+			WriteHTTPError(w, http.StatusBadRequest, "session_not_found")
+			return
+		}
+		status, err := coord.GetTournamentStatus(r.Context(), sessionID)
+		if err != nil {
+			switch {
+			case agentgateway.IsSessionNotFound(err):
+				WriteHTTPError(w, http.StatusNotFound, "session_not_found")
+			case agentgateway.IsTournamentNotFound(err):
+				WriteHTTPError(w, http.StatusNotFound, "tournament_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
+
+// TournamentRebuyHandler queues a rebuy for the session's multi-table
+// tournament; it is bought when the current hand ends.
+func TournamentRebuyHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return tournamentChipsHandler(coord, false)
+}
+
+// TournamentAddonHandler queues the add-on for the session's multi-table
+// tournament; it is bought when the current hand ends.
+func TournamentAddonHandler(coord *agentgateway.Coordinator) http.HandlerFunc {
+	return tournamentChipsHandler(coord, true)
+}
+
+func tournamentChipsHandler(coord *agentgateway.Coordinator, addon bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID := chi.URLParam(r, "session_id")
+		if sessionID == "" {
+			WriteHTTPError(w, http.StatusBadRequest, "session_not_found")
+			return
+		}
+		if err := coord.QueueTournamentChips(r.Context(), sessionID, addon); err != nil {
+			switch {
+			case agentgateway.IsSessionNotFound(err):
+				WriteHTTPError(w, http.StatusNotFound, "session_not_found")
+			case agentgateway.IsTournamentNotFound(err):
+				WriteHTTPError(w, http.StatusNotFound, "tournament_not_found")
+			case agentgateway.IsRebuyUnavailable(err):
+				WriteHTTPError(w, http.StatusConflict, "rebuy_not_available")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "queued": true, "addon": addon})
+	}
+}
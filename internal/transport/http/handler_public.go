@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	apppublic "silicon-casino/internal/app/public"
@@ -117,6 +118,247 @@ func (h *PublicHandlers) AgentProfile() http.HandlerFunc {
 	}
 }
 
+func (h *PublicHandlers) VerifyHand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := h.publicSvc.VerifyHand(r.Context(), chi.URLParam(r, "hand_id"))
+		if err != nil {
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "hand_not_found")
+			case errors.Is(err, apppublic.ErrHandInProgress):
+				WriteHTTPError(w, http.StatusConflict, "hand_in_progress")
+			case errors.Is(err, apppublic.ErrNotVerifiable):
+				WriteHTTPError(w, http.StatusNotFound, "hand_not_verifiable")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) Equity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		iterations := 0
+		if v := q.Get("iterations"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			iterations = n
+		}
+		var hands []string
+		if v := q.Get("hands"); v != "" {
+			hands = strings.Split(v, ",")
+		}
+		resp, err := h.publicSvc.Equity(r.Context(), apppublic.EquityQuery{
+			Variant:    q.Get("variant"),
+			Hands:      hands,
+			Board:      q.Get("board"),
+			Dead:       q.Get("dead"),
+			Iterations: iterations,
+		})
+		if err != nil {
+			if errors.Is(err, apppublic.ErrInvalidRequest) {
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+				return
+			}
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) DuplicateMatches() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		status := r.URL.Query().Get("status")
+		if !isAllowedDuplicateMatchStatus(status) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		resp, err := h.publicSvc.DuplicateMatches(r.Context(), r.URL.Query().Get("agent_id"), status, limit, offset)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) DuplicateMatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := h.publicSvc.DuplicateMatch(r.Context(), chi.URLParam(r, "match_id"))
+		if err != nil {
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "match_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) DuplicateStandings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		resp, err := h.publicSvc.DuplicateStandings(r.Context(), r.URL.Query().Get("room_id"), limit, offset)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) Tournaments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		status := r.URL.Query().Get("status")
+		if !isAllowedTournamentStatus(status) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		resp, err := h.publicSvc.Tournaments(r.Context(), r.URL.Query().Get("room_id"), status, limit, offset)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) Tournament() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := h.publicSvc.Tournament(r.Context(), chi.URLParam(r, "tournament_id"))
+		if err != nil {
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "tournament_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) Leagues() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		status := r.URL.Query().Get("status")
+		if !isAllowedLeagueStatus(status) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		resp, err := h.publicSvc.Leagues(r.Context(), status, limit, offset)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) League() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := h.publicSvc.League(r.Context(), chi.URLParam(r, "league_id"))
+		if err != nil {
+			writeLeagueError(w, err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) LeagueStandings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := h.publicSvc.LeagueStandings(r.Context(), chi.URLParam(r, "league_id"))
+		if err != nil {
+			writeLeagueError(w, err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func writeLeagueError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apppublic.ErrInvalidRequest):
+		WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+	case errors.Is(err, apppublic.ErrNotFound):
+		WriteHTTPError(w, http.StatusNotFound, "league_not_found")
+	default:
+		WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+	}
+}
+
+func isAllowedLeagueStatus(v string) bool {
+	return v == "" || v == "running" || v == "completed"
+}
+
+func (h *PublicHandlers) Seasons() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		status := r.URL.Query().Get("status")
+		if !isAllowedSeasonStatus(status) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		resp, err := h.publicSvc.Seasons(r.Context(), status, limit, offset)
+		if err != nil {
+			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *PublicHandlers) SeasonStandings() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, offset := ParsePagination(r)
+		resp, err := h.publicSvc.SeasonStandings(r.Context(), chi.URLParam(r, "season_id"), limit, offset)
+		if err != nil {
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "season_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func isAllowedSeasonStatus(v string) bool {
+	return v == "" || v == "open" || v == "closed"
+}
+
+func isAllowedTournamentStatus(v string) bool {
+	return v == "" || v == "scheduled" || v == "running" || v == "completed" || v == "aborted"
+}
+
+func isAllowedDuplicateMatchStatus(v string) bool {
+	return v == "" || v == "running" || v == "completed" || v == "aborted"
+}
+
 func (h *PublicHandlers) TableReplay() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -261,10 +503,6 @@ func (h *PublicHandlers) Leaderboard() http.HandlerFunc {
 		if roomID == "" {
 			roomID = "all"
 		}
-		if !isAllowedLeaderboardRoom(roomID) {
-			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
-			return
-		}
 		sortBy := r.URL.Query().Get("sort")
 		if sortBy == "" {
 			sortBy = "score"
@@ -277,23 +515,56 @@ func (h *PublicHandlers) Leaderboard() http.HandlerFunc {
 			Window: window,
 			RoomID: roomID,
 			SortBy: sortBy,
+			Cursor: r.URL.Query().Get("cursor"),
 		}, limit, offset)
 		if err != nil {
-			WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "room_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
 			return
 		}
 		_ = json.NewEncoder(w).Encode(resp)
 	}
 }
 
-func isAllowedLeaderboardWindow(v string) bool {
-	return v == "7d" || v == "30d" || v == "all"
+// CompareAgents tells whether agent_a's bb/100 over window is
+// statistically ahead of agent_b's.
+func (h *PublicHandlers) CompareAgents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		window := q.Get("window")
+		if window == "" {
+			window = "30d"
+		}
+		if !isAllowedLeaderboardWindow(window) {
+			WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		resp, err := h.publicSvc.CompareAgents(r.Context(), q.Get("agent_a"), q.Get("agent_b"), window)
+		if err != nil {
+			switch {
+			case errors.Is(err, apppublic.ErrInvalidRequest):
+				WriteHTTPError(w, http.StatusBadRequest, "invalid_request")
+			case errors.Is(err, apppublic.ErrNotFound):
+				WriteHTTPError(w, http.StatusNotFound, "agent_not_found")
+			default:
+				WriteHTTPError(w, http.StatusInternalServerError, "internal_error")
+			}
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
 }
 
-func isAllowedLeaderboardRoom(v string) bool {
-	return v == "all" || v == "low" || v == "mid" || v == "high"
+func isAllowedLeaderboardWindow(v string) bool {
+	return v == "7d" || v == "30d" || v == "all"
 }
 
 func isAllowedLeaderboardSort(v string) bool {
-	return v == "score" || v == "net_cc_from_play" || v == "hands_played" || v == "win_rate"
+	return v == "score" || v == "net_cc_from_play" || v == "hands_played" || v == "win_rate" || v == "rating"
 }
@@ -1,6 +1,14 @@
 package httptransport
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apppublic "silicon-casino/internal/app/public"
+	"silicon-casino/internal/testutil"
+)
 
 func TestLeaderboardParamAllowlists(t *testing.T) {
 	windowTests := []struct {
@@ -19,22 +27,6 @@ func TestLeaderboardParamAllowlists(t *testing.T) {
 		}
 	}
 
-	roomTests := []struct {
-		v    string
-		want bool
-	}{
-		{"all", true},
-		{"low", true},
-		{"mid", true},
-		{"high", true},
-		{"vip", false},
-	}
-	for _, tt := range roomTests {
-		if got := isAllowedLeaderboardRoom(tt.v); got != tt.want {
-			t.Fatalf("room %q = %v, want %v", tt.v, got, tt.want)
-		}
-	}
-
 	sortTests := []struct {
 		v    string
 		want bool
@@ -43,6 +35,7 @@ func TestLeaderboardParamAllowlists(t *testing.T) {
 		{"net_cc_from_play", true},
 		{"hands_played", true},
 		{"win_rate", true},
+		{"rating", true},
 		{"bb_per_100", false},
 	}
 	for _, tt := range sortTests {
@@ -51,3 +44,39 @@ func TestLeaderboardParamAllowlists(t *testing.T) {
 		}
 	}
 }
+
+// TestLeaderboardRoomScopes covers the room_id values the web leaderboard
+// sends: all, or an ID from /api/public/rooms.
+func TestLeaderboardRoomScopes(t *testing.T) {
+	st, cleanup := testutil.OpenTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+	if err := st.EnsureDefaultRooms(ctx); err != nil {
+		t.Fatalf("ensure default rooms: %v", err)
+	}
+	rooms, err := st.ListRooms(ctx)
+	if err != nil || len(rooms) == 0 {
+		t.Fatalf("list rooms: %v", err)
+	}
+	handler := NewPublicHandlers(apppublic.NewService(st), nil).Leaderboard()
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", http.StatusOK},
+		{"room_id=all", http.StatusOK},
+		{"room_id=" + rooms[0].ID, http.StatusOK},
+		{"room_id=" + rooms[0].ID + "&window=7d&sort=rating", http.StatusOK},
+		{"room_id=low", http.StatusNotFound},
+		{"room_id=all&cursor=not-a-cursor", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/public/leaderboard?"+tt.query, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Fatalf("%q: status=%d body=%s, want %d", tt.query, w.Code, w.Body.String(), tt.want)
+		}
+	}
+}
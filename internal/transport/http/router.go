@@ -50,12 +50,27 @@ func NewRouter(st *store.Store, cfg config.ServerConfig, agentCoord *agentgatewa
 	r.Route("/api", func(r chi.Router) {
 		r.Use(APILogMiddleware())
 		r.Get("/public/leaderboard", publicHandlers.Leaderboard())
+		r.Get("/public/leaderboard/compare", publicHandlers.CompareAgents())
 		r.Get("/public/rooms", publicHandlers.Rooms())
 		r.Get("/public/tables", publicHandlers.Tables())
 		r.Get("/public/tables/history", publicHandlers.TableHistory())
 		r.Get("/public/tables/{table_id}/replay", publicHandlers.TableReplay())
 		r.Get("/public/tables/{table_id}/timeline", publicHandlers.TableTimeline())
 		r.Get("/public/tables/{table_id}/snapshot", publicHandlers.TableSnapshot())
+		r.Get("/public/hands/{hand_id}/verify", publicHandlers.VerifyHand())
+		r.Get("/public/equity", publicHandlers.Equity())
+		r.Get("/public/duplicate-matches", publicHandlers.DuplicateMatches())
+		r.Get("/public/duplicate-matches/{match_id}", publicHandlers.DuplicateMatch())
+		r.Get("/public/duplicate-standings", publicHandlers.DuplicateStandings())
+		r.Get("/public/tournaments", publicHandlers.Tournaments())
+		r.Get("/public/tournaments/{tournament_id}", publicHandlers.Tournament())
+		r.Get("/public/tournaments/{tournament_id}/events", spectatorgateway.TournamentEventsHandler(agentCoord))
+		r.Get("/public/tournaments/{tournament_id}/state", spectatorgateway.TournamentStateHandler(agentCoord))
+		r.Get("/public/leagues", publicHandlers.Leagues())
+		r.Get("/public/leagues/{league_id}", publicHandlers.League())
+		r.Get("/public/leagues/{league_id}/standings", publicHandlers.LeagueStandings())
+		r.Get("/public/seasons", publicHandlers.Seasons())
+		r.Get("/public/seasons/{season_id}/standings", publicHandlers.SeasonStandings())
 		r.Get("/public/agent-table", publicHandlers.AgentTable())
 		r.Get("/public/agents/{agent_id}/tables", publicHandlers.AgentTables())
 		r.Get("/public/agents/{agent_id}/profile", publicHandlers.AgentProfile())
@@ -68,20 +83,32 @@ func NewRouter(st *store.Store, cfg config.ServerConfig, agentCoord *agentgatewa
 		r.Delete("/agent/sessions/{session_id}", SessionsDeleteHandler(agentCoord))
 		r.Post("/agent/sessions/{session_id}/actions", ActionsHandler(agentCoord))
 		r.Get("/agent/sessions/{session_id}/state", StateHandler(agentCoord))
+		r.Get("/agent/sessions/{session_id}/status", SessionStatusHandler(agentCoord))
 		r.Get("/agent/sessions/{session_id}/events", EventsSSEHandler(agentCoord))
+		r.Get("/agent/sessions/{session_id}/tournament", TournamentStatusHandler(agentCoord))
+		r.Post("/agent/sessions/{session_id}/tournament/rebuy", TournamentRebuyHandler(agentCoord))
+		r.Post("/agent/sessions/{session_id}/tournament/addon", TournamentAddonHandler(agentCoord))
+		r.Post("/agent/tournaments", TournamentRegisterHandler(agentCoord))
 
 		r.Group(func(r chi.Router) {
 			r.Use(AgentAuthMiddleware(st))
 			r.Get("/agents/me", agentHandlers.Me())
 			r.Post("/agents/bind_key", agentHandlers.BindKey())
+			r.Get("/agent/challenges", ChallengesListHandler(agentCoord))
+			r.Post("/agent/challenges/{challenge_id}/decline", ChallengeDeclineHandler(agentCoord))
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(AdminAuthMiddleware(cfg.AdminAPIKey))
 			r.Get("/agents", adminHandlers.Agents())
+			r.Post("/agents/{agent_id}/owner", adminHandlers.AgentOwner())
 			r.Get("/ledger", adminHandlers.Ledger())
+			r.Get("/rake", adminHandlers.Rake())
 			r.Post("/topup", adminHandlers.Topup())
 			r.Post("/rooms", adminHandlers.Rooms())
+			r.Post("/tournaments", adminHandlers.Tournaments())
+			r.Post("/leagues", adminHandlers.Leagues())
+			r.Post("/seasons", adminHandlers.Seasons())
 			r.MethodFunc(http.MethodGet, "/providers/rates", adminHandlers.ProviderRates())
 			r.MethodFunc(http.MethodPost, "/providers/rates", adminHandlers.ProviderRates())
 
@@ -0,0 +1,5 @@
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_seats_check;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS min_players,
+  DROP COLUMN IF EXISTS max_seats;
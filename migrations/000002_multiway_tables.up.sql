@@ -0,0 +1,6 @@
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS max_seats INT NOT NULL DEFAULT 2,
+  ADD COLUMN IF NOT EXISTS min_players INT NOT NULL DEFAULT 2;
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_seats_check CHECK (max_seats BETWEEN 2 AND 9 AND min_players BETWEEN 2 AND max_seats);
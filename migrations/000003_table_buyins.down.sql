@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS table_stacks;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_buyin_check;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS max_buyin_cc;
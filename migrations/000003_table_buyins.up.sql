@@ -0,0 +1,24 @@
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS max_buyin_cc BIGINT NOT NULL DEFAULT 0;
+
+UPDATE rooms
+SET max_buyin_cc = GREATEST(min_buyin_cc, big_blind_cc * 100)
+WHERE max_buyin_cc = 0;
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_buyin_check CHECK (max_buyin_cc >= min_buyin_cc);
+
+CREATE TABLE IF NOT EXISTS table_stacks (
+  table_id TEXT NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  buyin_cc BIGINT NOT NULL,
+  stack_cc BIGINT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'seated',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cashed_out_at TIMESTAMPTZ,
+  PRIMARY KEY (table_id, agent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_table_stacks_agent_status
+  ON table_stacks (agent_id, status);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_ledger_entries_type_created;
+
+DELETE FROM agents WHERE id = 'house';
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_rake_check;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS rake_no_flop_no_drop,
+  DROP COLUMN IF EXISTS rake_cap_cc,
+  DROP COLUMN IF EXISTS rake_bps;
@@ -0,0 +1,15 @@
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS rake_bps INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rake_cap_cc BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rake_no_flop_no_drop BOOLEAN NOT NULL DEFAULT true;
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_rake_check CHECK (rake_bps BETWEEN 0 AND 10000 AND rake_cap_cc >= 0);
+
+-- House account credited with rake. It has no API key and cannot log in.
+INSERT INTO agents (id, name, api_key_hash, status, claim_code)
+VALUES ('house', 'House', '', 'system', 'house')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_type_created
+  ON ledger_entries (type, created_at);
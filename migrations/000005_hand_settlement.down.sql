@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ledger_entries_ref;
+DROP INDEX IF EXISTS idx_hands_open;
@@ -0,0 +1,8 @@
+-- Startup recovery looks up unfinished hands and the chips each agent put
+-- into them.
+CREATE INDEX IF NOT EXISTS idx_hands_open
+  ON hands (table_id, started_at)
+  WHERE ended_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_ref
+  ON ledger_entries (ref_type, ref_id);
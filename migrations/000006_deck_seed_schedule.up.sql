@@ -0,0 +1,4 @@
+-- Benchmark rooms deal hand N of every table from the same deterministic
+-- seed. Empty means a fresh crypto/rand seed per hand.
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS deck_seed_schedule TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS duplicate_match_results;
+DROP TABLE IF EXISTS duplicate_matches;
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS duplicate_hands,
+  DROP COLUMN IF EXISTS match_format;
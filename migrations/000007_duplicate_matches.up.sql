@@ -0,0 +1,46 @@
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS match_format TEXT NOT NULL DEFAULT 'standard',
+  ADD COLUMN IF NOT EXISTS duplicate_hands INT NOT NULL DEFAULT 0;
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard')
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0)
+  );
+
+-- A duplicate match plays the same deck schedule twice for one pair of
+-- agents: leg 1 on leg1_table_id, then leg 2 on leg2_table_id with the seats
+-- swapped. Both tables are created and bought into when the pair is matched.
+CREATE TABLE IF NOT EXISTS duplicate_matches (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL REFERENCES rooms(id),
+  agent_a_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  agent_b_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  leg1_table_id TEXT NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+  leg2_table_id TEXT NOT NULL REFERENCES tables(id) ON DELETE CASCADE,
+  seed_schedule TEXT NOT NULL,
+  hands_per_leg INT NOT NULL,
+  buyin_cc BIGINT NOT NULL,
+  current_leg INT NOT NULL DEFAULT 1,
+  status TEXT NOT NULL DEFAULT 'running',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_matches_created
+  ON duplicate_matches (created_at DESC);
+
+-- One row per agent of a finished match. net_cc is the combined result of
+-- both legs, which cancels most of the card luck of either leg.
+CREATE TABLE IF NOT EXISTS duplicate_match_results (
+  match_id TEXT NOT NULL REFERENCES duplicate_matches(id) ON DELETE CASCADE,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  leg1_net_cc BIGINT NOT NULL,
+  leg2_net_cc BIGINT NOT NULL,
+  net_cc BIGINT NOT NULL,
+  hands_played INT NOT NULL,
+  PRIMARY KEY (match_id, agent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_match_results_agent
+  ON duplicate_match_results (agent_id);
@@ -0,0 +1,4 @@
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_betting_structure_check;
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS raise_cap,
+  DROP COLUMN IF EXISTS betting_structure;
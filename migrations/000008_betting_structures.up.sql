@@ -0,0 +1,10 @@
+-- raise_cap limits the bets and raises per street of fixed-limit rooms.
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS betting_structure TEXT NOT NULL DEFAULT 'no_limit',
+  ADD COLUMN IF NOT EXISTS raise_cap INT NOT NULL DEFAULT 0;
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_betting_structure_check CHECK (
+    (betting_structure IN ('no_limit', 'pot_limit') AND raise_cap = 0)
+    OR (betting_structure = 'fixed_limit' AND raise_cap > 0)
+  );
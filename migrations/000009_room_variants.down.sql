@@ -0,0 +1,2 @@
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_variant_check;
+ALTER TABLE rooms DROP COLUMN IF EXISTS variant;
@@ -0,0 +1,6 @@
+-- variant is the poker game a room deals.
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT 'holdem';
+
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_variant_check CHECK (variant IN ('holdem', 'omaha'));
@@ -0,0 +1,5 @@
+ALTER TABLE rooms DROP COLUMN IF EXISTS ante_cc;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_variant_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_variant_check CHECK (variant IN ('holdem', 'omaha'));
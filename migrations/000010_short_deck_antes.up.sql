@@ -0,0 +1,8 @@
+-- short_deck rooms deal the 36-card deck. ante_cc is posted by every
+-- dealt-in seat before the blinds.
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_variant_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_variant_check CHECK (variant IN ('holdem', 'omaha', 'short_deck'));
+
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS ante_cc BIGINT NOT NULL DEFAULT 0 CHECK (ante_cc >= 0);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS room_blind_levels;
+
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS blind_level_minutes,
+  DROP COLUMN IF EXISTS blind_level_hands,
+  DROP COLUMN IF EXISTS straddle,
+  DROP COLUMN IF EXISTS big_blind_ante;
@@ -0,0 +1,18 @@
+-- big_blind_ante makes the big blind post the ante for the whole table.
+-- straddle has the seat after the big blind post two big blinds.
+-- A room with blind levels moves to the next one every blind_level_hands
+-- hands or, when that is 0, every blind_level_minutes minutes.
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS big_blind_ante BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS straddle BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS blind_level_hands INT NOT NULL DEFAULT 0 CHECK (blind_level_hands >= 0),
+  ADD COLUMN IF NOT EXISTS blind_level_minutes INT NOT NULL DEFAULT 0 CHECK (blind_level_minutes >= 0);
+
+CREATE TABLE IF NOT EXISTS room_blind_levels (
+  room_id TEXT NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+  level INT NOT NULL CHECK (level > 0),
+  small_blind_cc BIGINT NOT NULL CHECK (small_blind_cc > 0),
+  big_blind_cc BIGINT NOT NULL CHECK (big_blind_cc >= small_blind_cc),
+  ante_cc BIGINT NOT NULL DEFAULT 0 CHECK (ante_cc >= 0),
+  PRIMARY KEY (room_id, level)
+);
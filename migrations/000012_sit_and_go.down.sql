@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS tournament_entries;
+ALTER TABLE tables DROP COLUMN IF EXISTS tournament_id;
+DROP TABLE IF EXISTS tournaments;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  DROP COLUMN IF EXISTS starting_chips,
+  DROP COLUMN IF EXISTS entry_fee_cc;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard')
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0)
+  );
@@ -0,0 +1,52 @@
+-- A sit-and-go room runs a single-table tournament as soon as max_seats
+-- agents have joined: each pays entry_fee_cc into the prize pool and starts
+-- with starting_chips tournament chips.
+ALTER TABLE rooms
+  ADD COLUMN IF NOT EXISTS entry_fee_cc BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS starting_chips BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard' AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'sit_and_go' AND max_seats IN (2, 6) AND min_players = max_seats AND entry_fee_cc > 0 AND starting_chips > 0)
+  );
+
+-- prize_pool_cc holds the entry fees debited from the entrants' accounts
+-- until it is paid out by finishing place, payout_bps[1] to the winner.
+CREATE TABLE IF NOT EXISTS tournaments (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL REFERENCES rooms(id),
+  status TEXT NOT NULL DEFAULT 'running',
+  max_players INT NOT NULL,
+  entry_fee_cc BIGINT NOT NULL,
+  starting_chips BIGINT NOT NULL,
+  prize_pool_cc BIGINT NOT NULL,
+  payout_bps INT[] NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_created
+  ON tournaments (created_at DESC);
+
+-- Table stacks of a tournament table are tournament chips, not CC: they are
+-- never bought in from or cashed out to an account.
+ALTER TABLE tables
+  ADD COLUMN IF NOT EXISTS tournament_id TEXT REFERENCES tournaments(id) ON DELETE CASCADE;
+
+-- finish_place is set when the agent is eliminated, or for the agents still
+-- in when the tournament ends.
+CREATE TABLE IF NOT EXISTS tournament_entries (
+  tournament_id TEXT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  seat_id INT NOT NULL,
+  finish_place INT,
+  payout_cc BIGINT NOT NULL DEFAULT 0,
+  eliminated_at TIMESTAMPTZ,
+  PRIMARY KEY (tournament_id, agent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_entries_agent
+  ON tournament_entries (agent_id);
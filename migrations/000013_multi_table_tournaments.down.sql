@@ -0,0 +1,28 @@
+DELETE FROM tournament_entries WHERE seat_id IS NULL;
+ALTER TABLE tournament_entries
+  DROP COLUMN IF EXISTS registered_at,
+  DROP COLUMN IF EXISTS auto_rebuy,
+  DROP COLUMN IF EXISTS addon,
+  DROP COLUMN IF EXISTS rebuys,
+  DROP COLUMN IF EXISTS table_id,
+  ALTER COLUMN seat_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_tournaments_scheduled;
+ALTER TABLE tournaments
+  DROP COLUMN IF EXISTS addon_chips,
+  DROP COLUMN IF EXISTS addon_fee_cc,
+  DROP COLUMN IF EXISTS rebuy_levels,
+  DROP COLUMN IF EXISTS max_rebuys,
+  DROP COLUMN IF EXISTS late_reg_levels,
+  DROP COLUMN IF EXISTS min_players,
+  DROP COLUMN IF EXISTS started_at,
+  DROP COLUMN IF EXISTS starts_at,
+  DROP COLUMN IF EXISTS name;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard' AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'sit_and_go' AND max_seats IN (2, 6) AND min_players = max_seats AND entry_fee_cc > 0 AND starting_chips > 0)
+  );
@@ -0,0 +1,41 @@
+-- A multi-table room holds the table size, blind schedule, entry fee and
+-- starting chips of the tournaments scheduled in it. Its tables all follow
+-- the tournament clock, so the schedule must be time based.
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard' AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'sit_and_go' AND max_seats IN (2, 6) AND min_players = max_seats AND entry_fee_cc > 0 AND starting_chips > 0)
+    OR (match_format = 'multi_table' AND entry_fee_cc > 0 AND starting_chips > 0 AND blind_level_minutes > 0)
+  );
+
+-- A scheduled tournament takes registrations until starts_at and, once
+-- running, until the blind level late_reg_levels is reached. Rebuys cost
+-- the entry fee and buy the starting chips again, at most max_rebuys times
+-- before level rebuy_levels; the add-on is bought once during the last
+-- rebuy level. Sit-and-gos leave all of these at their defaults.
+ALTER TABLE tournaments
+  ADD COLUMN IF NOT EXISTS name TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS min_players INT NOT NULL DEFAULT 2,
+  ADD COLUMN IF NOT EXISTS late_reg_levels INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS max_rebuys INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS rebuy_levels INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS addon_fee_cc BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS addon_chips BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_scheduled
+  ON tournaments (starts_at)
+  WHERE status = 'scheduled';
+
+-- An entrant of a multi-table tournament is registered before it has a
+-- seat, and table_id and seat_id follow it as it is moved between tables.
+ALTER TABLE tournament_entries
+  ALTER COLUMN seat_id DROP NOT NULL,
+  ADD COLUMN IF NOT EXISTS table_id TEXT REFERENCES tables(id) ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS rebuys INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS addon BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS auto_rebuy BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN IF NOT EXISTS registered_at TIMESTAMPTZ NOT NULL DEFAULT now();
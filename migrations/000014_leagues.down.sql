@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS league_standings;
+DROP TABLE IF EXISTS league_matches;
+DROP TABLE IF EXISTS leagues;
+
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard' AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'sit_and_go' AND max_seats IN (2, 6) AND min_players = max_seats AND entry_fee_cc > 0 AND starting_chips > 0)
+    OR (match_format = 'multi_table' AND entry_fee_cc > 0 AND starting_chips > 0 AND blind_level_minutes > 0)
+  );
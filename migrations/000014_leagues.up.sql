@@ -0,0 +1,74 @@
+-- A league room holds the heads-up table and blinds of the leagues played
+-- in it; agents only reach its tables through their league matches.
+ALTER TABLE rooms DROP CONSTRAINT IF EXISTS rooms_match_format_check;
+ALTER TABLE rooms
+  ADD CONSTRAINT rooms_match_format_check CHECK (
+    (match_format = 'standard' AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'duplicate' AND max_seats = 2 AND duplicate_hands > 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+    OR (match_format = 'sit_and_go' AND max_seats IN (2, 6) AND min_players = max_seats AND entry_fee_cc > 0 AND starting_chips > 0)
+    OR (match_format = 'multi_table' AND entry_fee_cc > 0 AND starting_chips > 0 AND blind_level_minutes > 0)
+    OR (match_format = 'league' AND max_seats = 2 AND duplicate_hands = 0 AND entry_fee_cc = 0 AND starting_chips = 0)
+  );
+
+-- A league plays rounds of heads-up matches of hands_per_match hands
+-- between a fixed set of agents. current_round is 0 until the first round
+-- is paired.
+CREATE TABLE IF NOT EXISTS leagues (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL REFERENCES rooms(id),
+  name TEXT NOT NULL,
+  format TEXT NOT NULL CHECK (format IN ('round_robin', 'swiss')),
+  hands_per_match INT NOT NULL CHECK (hands_per_match > 0),
+  rounds INT NOT NULL CHECK (rounds > 0),
+  current_round INT NOT NULL DEFAULT 0,
+  status TEXT NOT NULL DEFAULT 'running',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_leagues_created
+  ON leagues (created_at DESC);
+
+-- One row per match of a round. A bye has no agent_b_id. The nets are the
+-- chips each agent won or lost at table_id; forfeit_agent_id left the
+-- match early and lost it whatever the chips said.
+CREATE TABLE IF NOT EXISTS league_matches (
+  id TEXT PRIMARY KEY,
+  league_id TEXT NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+  round INT NOT NULL,
+  agent_a_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  agent_b_id TEXT REFERENCES agents(id) ON DELETE CASCADE,
+  table_id TEXT REFERENCES tables(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'pending',
+  winner_agent_id TEXT REFERENCES agents(id) ON DELETE SET NULL,
+  forfeit_agent_id TEXT REFERENCES agents(id) ON DELETE SET NULL,
+  agent_a_net_cc BIGINT NOT NULL DEFAULT 0,
+  agent_b_net_cc BIGINT NOT NULL DEFAULT 0,
+  hands_played INT NOT NULL DEFAULT 0,
+  started_at TIMESTAMPTZ,
+  ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_league_matches_league
+  ON league_matches (league_id, round);
+
+-- The league table: one row per participant, updated with every match
+-- result in the same transaction. seed orders the round robin schedule and
+-- breaks ties.
+CREATE TABLE IF NOT EXISTS league_standings (
+  league_id TEXT NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  seed INT NOT NULL,
+  points INT NOT NULL DEFAULT 0,
+  played INT NOT NULL DEFAULT 0,
+  wins INT NOT NULL DEFAULT 0,
+  draws INT NOT NULL DEFAULT 0,
+  losses INT NOT NULL DEFAULT 0,
+  byes INT NOT NULL DEFAULT 0,
+  net_cc BIGINT NOT NULL DEFAULT 0,
+  hands_played INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (league_id, agent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_league_standings_agent
+  ON league_standings (agent_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS private_tables;
+DROP TABLE IF EXISTS challenges;
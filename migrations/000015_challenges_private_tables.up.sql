@@ -0,0 +1,36 @@
+-- A challenge invites one agent to a heads-up table with the challenger.
+-- It stays pending until the opponent accepts or declines it, the
+-- challenger gives up waiting, or expires_at passes.
+CREATE TABLE IF NOT EXISTS challenges (
+  id TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL REFERENCES rooms(id),
+  challenger_agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  opponent_agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'expired', 'cancelled')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL,
+  responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_opponent
+  ON challenges (opponent_agent_id, status);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_challenger
+  ON challenges (challenger_agent_id, status);
+
+-- A private table is opened by its creator under an invite code. Only the
+-- creator and invited_agent_ids may take its seats, or anyone holding the
+-- code when no agents are listed. The code dies with the table.
+CREATE TABLE IF NOT EXISTS private_tables (
+  invite_code TEXT PRIMARY KEY,
+  room_id TEXT NOT NULL REFERENCES rooms(id),
+  creator_agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  invited_agent_ids TEXT[] NOT NULL DEFAULT '{}',
+  table_id TEXT REFERENCES tables(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  closed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_private_tables_table
+  ON private_tables (table_id);
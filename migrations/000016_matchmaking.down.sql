@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_agent_sessions_agent_created;
+DROP INDEX IF EXISTS idx_agents_owner;
+ALTER TABLE agents DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,10 @@
+-- owner_id groups the agents run by one account. Ranked matchmaking never
+-- seats two agents of the same owner against each other.
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS owner_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_agents_owner
+  ON agents (owner_id)
+  WHERE owner_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_agent_sessions_agent_created
+  ON agent_sessions (agent_id, created_at DESC);
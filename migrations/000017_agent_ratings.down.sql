@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS agent_rating_history;
+DROP TABLE IF EXISTS agent_ratings;
@@ -0,0 +1,32 @@
+-- Glicko-2 ratings, updated after every completed table, duplicate match
+-- and tournament. Agents without a row are unrated: 1500 ± 350.
+CREATE TABLE IF NOT EXISTS agent_ratings (
+  agent_id TEXT PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+  rating DOUBLE PRECISION NOT NULL,
+  rating_deviation DOUBLE PRECISION NOT NULL,
+  volatility DOUBLE PRECISION NOT NULL,
+  games INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_ratings_rating
+  ON agent_ratings (rating DESC);
+
+-- One row per agent and rated table, match or tournament, so a result is
+-- never rated twice.
+CREATE TABLE IF NOT EXISTS agent_rating_history (
+  id TEXT PRIMARY KEY,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  source_type TEXT NOT NULL CHECK (source_type IN ('table', 'duplicate_match', 'tournament')),
+  source_id TEXT NOT NULL,
+  opponents INT NOT NULL,
+  rating_before DOUBLE PRECISION NOT NULL,
+  rating DOUBLE PRECISION NOT NULL,
+  rating_deviation DOUBLE PRECISION NOT NULL,
+  volatility DOUBLE PRECISION NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (agent_id, source_type, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_rating_history_agent
+  ON agent_rating_history (agent_id, created_at DESC);
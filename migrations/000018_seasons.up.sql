@@ -0,0 +1,36 @@
+-- Admin-defined leaderboard seasons. A season is open until its end passes
+-- and its final standings are archived into season_standings; entrants is
+-- the number of ranked agents, set on close.
+CREATE TABLE IF NOT EXISTS seasons (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  starts_at TIMESTAMPTZ NOT NULL,
+  ends_at TIMESTAMPTZ NOT NULL,
+  status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+  entrants INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  closed_at TIMESTAMPTZ,
+  CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_seasons_open_ends
+  ON seasons (ends_at)
+  WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS season_standings (
+  season_id TEXT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  rank INT NOT NULL,
+  agent_name TEXT NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  bb_per_100 DOUBLE PRECISION NOT NULL,
+  net_cc_from_play BIGINT NOT NULL,
+  hands_played INT NOT NULL,
+  win_rate DOUBLE PRECISION NOT NULL,
+  rating DOUBLE PRECISION NOT NULL,
+  PRIMARY KEY (season_id, agent_id),
+  UNIQUE (season_id, rank)
+);
+
+CREATE INDEX IF NOT EXISTS idx_season_standings_agent
+  ON season_standings (agent_id);
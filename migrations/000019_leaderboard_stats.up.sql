@@ -0,0 +1,49 @@
+-- Leaderboard totals per agent, room and hour of play, added to in the
+-- transaction that settles each cash game hand so ranking never scans
+-- ledger_entries. net_bb_sq sums the squared per-hand results in big
+-- blinds for their standard deviation.
+CREATE TABLE IF NOT EXISTS leaderboard_stats (
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  room_id TEXT NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+  bucket TIMESTAMPTZ NOT NULL,
+  hands_played INT NOT NULL DEFAULT 0,
+  wins INT NOT NULL DEFAULT 0,
+  net_cc BIGINT NOT NULL DEFAULT 0,
+  net_bb NUMERIC NOT NULL DEFAULT 0,
+  net_bb_sq NUMERIC NOT NULL DEFAULT 0,
+  last_active_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (agent_id, room_id, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_stats_bucket
+  ON leaderboard_stats (bucket);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_stats_room_bucket
+  ON leaderboard_stats (room_id, bucket);
+
+INSERT INTO leaderboard_stats (agent_id, room_id, bucket, hands_played, wins, net_cc, net_bb, net_bb_sq, last_active_at)
+SELECT
+  hl.agent_id,
+  t.room_id,
+  date_trunc('hour', h.ended_at),
+  COUNT(*)::int,
+  SUM(CASE WHEN h.winner_agent_id = hl.agent_id THEN 1 ELSE 0 END)::int,
+  SUM(hl.hand_net_cc)::bigint,
+  SUM(COALESCE(hl.hand_net_cc::numeric / NULLIF(t.big_blind_cc::numeric, 0), 0)),
+  SUM(COALESCE(hl.hand_net_cc::numeric / NULLIF(t.big_blind_cc::numeric, 0), 0) ^ 2),
+  MAX(h.ended_at)
+FROM (
+  SELECT l.agent_id, l.ref_id AS hand_id, SUM(l.amount_cc)::bigint AS hand_net_cc
+  FROM ledger_entries l
+  WHERE l.ref_type = 'hand'
+    AND l.type IN ('blind_debit', 'bet_debit', 'pot_credit')
+  GROUP BY l.agent_id, l.ref_id
+) hl
+JOIN hands h ON h.id = hl.hand_id
+JOIN tables t ON t.id = h.table_id
+WHERE h.ended_at IS NOT NULL
+  AND h.street_end IS DISTINCT FROM 'voided'
+  AND t.tournament_id IS NULL
+  AND t.room_id IS NOT NULL
+GROUP BY hl.agent_id, t.room_id, date_trunc('hour', h.ended_at)
+ON CONFLICT (agent_id, room_id, bucket) DO NOTHING;
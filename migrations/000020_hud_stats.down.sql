@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_actions_agent_hand;
@@ -0,0 +1,4 @@
+-- HUD stats read an agent's hands through its actions, then replay each
+-- hand's action_applied events in order.
+CREATE INDEX IF NOT EXISTS idx_actions_agent_hand
+  ON actions (agent_id, hand_id);
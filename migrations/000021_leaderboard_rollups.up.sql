@@ -0,0 +1,29 @@
+-- Ranked leaderboard pages, rebuilt from leaderboard_stats every minute:
+-- one row per window (7d, 30d, all), room (a room ID or all), sort and
+-- agent, with the agent's rank under that sort. Pages seek on
+-- (sort_key, agent_id) or on rank instead of ranking every agent per read.
+CREATE TABLE IF NOT EXISTS leaderboard_rollups (
+  window_key TEXT NOT NULL,
+  room_id TEXT NOT NULL,
+  sort_by TEXT NOT NULL,
+  agent_id TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
+  rank INT NOT NULL,
+  sort_key NUMERIC NOT NULL,
+  hands_played INT NOT NULL,
+  net_cc_from_play BIGINT NOT NULL,
+  bb_per_100 NUMERIC NOT NULL,
+  win_rate NUMERIC NOT NULL,
+  confidence_factor NUMERIC NOT NULL,
+  score NUMERIC NOT NULL,
+  bb_per_hand_stddev NUMERIC NOT NULL,
+  rating DOUBLE PRECISION NOT NULL,
+  rating_deviation DOUBLE PRECISION NOT NULL,
+  last_active_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (window_key, room_id, sort_by, agent_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_rollups_rank
+  ON leaderboard_rollups (window_key, room_id, sort_by, rank);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_rollups_seek
+  ON leaderboard_rollups (window_key, room_id, sort_by, sort_key DESC, agent_id);
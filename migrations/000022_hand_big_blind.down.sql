@@ -0,0 +1 @@
+ALTER TABLE hands DROP COLUMN IF EXISTS big_blind_cc;
@@ -0,0 +1,15 @@
+-- The big blind each hand was dealt at. Blind levels raise it above the
+-- table's big_blind_cc, which only records the opening level, so per-hand
+-- bb figures are normalized by this column. Hands dealt before it existed
+-- are backfilled with their table's big blind.
+ALTER TABLE hands
+  ADD COLUMN IF NOT EXISTS big_blind_cc BIGINT;
+
+UPDATE hands h
+SET big_blind_cc = t.big_blind_cc
+FROM tables t
+WHERE t.id = h.table_id
+  AND h.big_blind_cc IS NULL;
+
+ALTER TABLE hands
+  ALTER COLUMN big_blind_cc SET NOT NULL;
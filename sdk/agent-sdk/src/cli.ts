@@ -60,9 +60,9 @@ function printHelp(): void {
   apa-bot claim (--claim-code <code> | --claim-url <url>) [--api-base <url>]
   apa-bot me [--api-base <url>]
   apa-bot bind-key --provider <openrouter|nebius> --vendor-key <key> --budget-usd <num> [--api-base <url>]
-  apa-bot next-decision --join <random|select> [--room-id <id>]
+  apa-bot next-decision --join <random|select> [--room-id <id>] [--buyin <cc>]
                        [--timeout-ms <ms>] [--api-base <url>]
-  apa-bot submit-decision --decision-id <id> --action <fold|check|call|raise|bet>
+  apa-bot submit-decision --decision-id <id> --action <fold|check|call|raise|bet|all_in>
                           [--amount <num>] [--thought-log <text>] [--api-base <url>]
   apa-bot doctor [--api-base <url>]
 
@@ -120,8 +120,10 @@ function readLegalActions(state: Record<string, unknown>): string[] {
 }
 
 type ParsedActionConstraints = {
+  call?: { amount: number; to: number };
   bet?: { min: number; max: number };
   raise?: { min_to: number; max_to: number };
+  all_in?: { amount: number; to: number };
 };
 
 function readActionConstraints(state: Record<string, unknown>): ParsedActionConstraints | undefined {
@@ -131,6 +133,15 @@ function readActionConstraints(state: Record<string, unknown>): ParsedActionCons
   }
   const src = raw as Record<string, unknown>;
   const out: ParsedActionConstraints = {};
+  const call = src["call"];
+  if (call && typeof call === "object") {
+    const c = call as Record<string, unknown>;
+    const amount = Number(c["amount"]);
+    const to = Number(c["to"]);
+    if (Number.isFinite(amount) && Number.isFinite(to)) {
+      out.call = { amount, to };
+    }
+  }
   const bet = src["bet"];
   if (bet && typeof bet === "object") {
     const b = bet as Record<string, unknown>;
@@ -149,14 +160,23 @@ function readActionConstraints(state: Record<string, unknown>): ParsedActionCons
       out.raise = { min_to: minTo, max_to: maxTo };
     }
   }
-  if (!out.bet && !out.raise) {
+  const allIn = src["all_in"];
+  if (allIn && typeof allIn === "object") {
+    const a = allIn as Record<string, unknown>;
+    const amount = Number(a["amount"]);
+    const to = Number(a["to"]);
+    if (Number.isFinite(amount) && Number.isFinite(to)) {
+      out.all_in = { amount, to };
+    }
+  }
+  if (!out.call && !out.bet && !out.raise && !out.all_in) {
     return undefined;
   }
   return out;
 }
 
-function parseAction(raw: string): "fold" | "check" | "call" | "raise" | "bet" {
-  if (raw === "fold" || raw === "check" || raw === "call" || raw === "raise" || raw === "bet") {
+function parseAction(raw: string): "fold" | "check" | "call" | "raise" | "bet" | "all_in" {
+  if (raw === "fold" || raw === "check" || raw === "call" || raw === "raise" || raw === "bet" || raw === "all_in") {
     return raw;
   }
   throw new Error("invalid --action");
@@ -274,7 +294,8 @@ async function ensureSession(
   agentId: string,
   apiKey: string,
   joinMode: "random" | "select",
-  roomId?: string
+  roomId?: string,
+  buyinCC?: number
 ): Promise<{ session_id: string; stream_url: string }> {
   const cachedState = await loadDecisionState();
   if (cachedState.session_id && cachedState.stream_url) {
@@ -304,8 +325,8 @@ async function ensureSession(
   }
   const sessionInput =
     joinMode === "select"
-      ? { agentID: agentId, apiKey, joinMode: "select" as const, roomID: roomId }
-      : { agentID: agentId, apiKey, joinMode: "random" as const };
+      ? { agentID: agentId, apiKey, joinMode: "select" as const, roomID: roomId, buyinCC }
+      : { agentID: agentId, apiKey, joinMode: "random" as const, buyinCC };
   const session = await client.createSession(sessionInput).catch(async (err: unknown) => {
     const recovered = recoverSessionFromConflict(err, apiBase);
     if (!recovered) {
@@ -339,6 +360,7 @@ async function runNextDecision(args: ArgMap): Promise<void> {
   const joinRaw = requireArg("--join", readString(args, "join"));
   const joinMode = joinRaw === "select" ? "select" : "random";
   const roomId = joinMode === "select" ? requireArg("--room-id", readString(args, "room-id")) : undefined;
+  const buyinCC = args["buyin"] === undefined ? undefined : readNumber(args, "buyin");
   const timeoutMs = readNumber(args, "timeout-ms", 5000);
 
   const client = new APAHttpClient({ apiBase });
@@ -355,7 +377,8 @@ async function runNextDecision(args: ArgMap): Promise<void> {
     agentId,
     apiKey,
     joinMode,
-    roomId
+    roomId,
+    buyinCC
   );
 
   const state = await loadDecisionState();
@@ -15,13 +15,14 @@ type CreateSessionInput = {
   apiKey: string;
   joinMode: "random" | "select";
   roomID?: string;
+  buyinCC?: number;
 };
 
 type SubmitActionInput = {
   sessionID: string;
   requestID: string;
   turnID: string;
-  action: "fold" | "check" | "call" | "raise" | "bet";
+  action: "fold" | "check" | "call" | "raise" | "bet" | "all_in";
   amount?: number;
   thoughtLog?: string;
 };
@@ -111,7 +112,8 @@ export class APAHttpClient {
         agent_id: input.agentID,
         api_key: input.apiKey,
         join_mode: input.joinMode,
-        room_id: input.joinMode === "select" ? input.roomID : undefined
+        room_id: input.joinMode === "select" ? input.roomID : undefined,
+        buyin_cc: input.buyinCC
       })
     });
     return parseJson(res);
@@ -2,7 +2,7 @@ import http from "node:http";
 import type { AddressInfo } from "node:net";
 import { URL } from "node:url";
 
-export type DecisionAction = "fold" | "check" | "call" | "raise" | "bet";
+export type DecisionAction = "fold" | "check" | "call" | "raise" | "bet" | "all_in";
 
 export type DecisionPayload = {
   request_id: string;
@@ -1,7 +1,7 @@
 import React from 'react'
 import { useQuery } from '@tanstack/react-query'
 import { Link } from 'react-router-dom'
-import { getLeaderboard } from '../services/api.js'
+import { getLeaderboard, getPublicRooms } from '../services/api.js'
 
 const WINDOW_OPTIONS = [
   { value: '7d', label: '7D' },
@@ -9,13 +9,6 @@ const WINDOW_OPTIONS = [
   { value: 'all', label: 'All' }
 ]
 
-const ROOM_OPTIONS = [
-  { value: 'all', label: 'All' },
-  { value: 'low', label: 'Low' },
-  { value: 'mid', label: 'Mid' },
-  { value: 'high', label: 'High' }
-]
-
 const SORT_OPTIONS = [
   { value: 'score', label: 'Score' },
   { value: 'net_cc_from_play', label: 'Net CC' },
@@ -29,11 +22,9 @@ function formatWindowLabel(scope) {
   return 'All Time'
 }
 
-function formatRoomLabel(scope) {
-  if (scope === 'low') return 'Low'
-  if (scope === 'mid') return 'Mid'
-  if (scope === 'high') return 'High'
-  return 'All Rooms'
+function formatRoomLabel(scope, roomOptions) {
+  if (scope === 'all') return 'All Rooms'
+  return roomOptions.find((opt) => opt.value === scope)?.label || 'All Rooms'
 }
 
 function formatLastActive(value) {
@@ -107,6 +98,16 @@ export default function Leaderboard() {
 
   const offset = (page - 1) * PAGE_SIZE
 
+  const { data: rooms } = useQuery({
+    queryKey: ['rooms'],
+    queryFn: getPublicRooms,
+    staleTime: 60000
+  })
+  const roomOptions = React.useMemo(() => [
+    { value: 'all', label: 'All' },
+    ...(rooms || []).map((room) => ({ value: room.id, label: room.name || room.id }))
+  ], [rooms])
+
   const { data, isLoading, isError } = useQuery({
     queryKey: ['leaderboard', windowScope, roomScope, sortBy, page],
     queryFn: () => getLeaderboard({
@@ -147,7 +148,7 @@ export default function Leaderboard() {
         </p>
         <div className="leaderboard-tags">
           <span className="leaderboard-tag">{formatWindowLabel(windowScope)}</span>
-          <span className="leaderboard-tag">{formatRoomLabel(roomScope)}</span>
+          <span className="leaderboard-tag">{formatRoomLabel(roomScope, roomOptions)}</span>
           <span className="leaderboard-tag leaderboard-tag--accent">{total} Ranked</span>
         </div>
       </div>
@@ -155,7 +156,7 @@ export default function Leaderboard() {
       <div className="leaderboard-filters cyber-border corner-accent">
         <div className="leaderboard-filter-grid">
           <FilterSelect label="Window" value={windowScope} options={WINDOW_OPTIONS} onChange={setWindowScope} />
-          <FilterSelect label="Room" value={roomScope} options={ROOM_OPTIONS} onChange={setRoomScope} />
+          <FilterSelect label="Room" value={roomScope} options={roomOptions} onChange={setRoomScope} />
           <FilterSelect label="Sort" value={sortBy} options={SORT_OPTIONS} onChange={setSortBy} />
         </div>
       </div>